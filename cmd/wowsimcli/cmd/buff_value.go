@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var buffValueCmd = &cobra.Command{
+	Use:   "buff-value",
+	Short: "value each raid buff and debuff",
+	Long:  "sim each enabled buff and debuff removed, and each disabled one added, and print a table of them ranked by raid DPS",
+	Run:   buffValueMain,
+}
+
+func init() {
+	buffValueCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (RaidSimRequest in protojson format)")
+	buffValueCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file for the full results in protojson format")
+	buffValueCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
+	buffValueCmd.MarkFlagRequired("infile")
+}
+
+func buffValueMain(cmd *cobra.Command, args []string) {
+	data, err := os.ReadFile(infile)
+	if err != nil {
+		log.Fatalf("failed to load input json file %q: %v", infile, err)
+	}
+	input := &proto.RaidSimRequest{}
+
+	err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, input)
+	if err != nil {
+		log.Fatalf("failed to load input json file: %s", err)
+	}
+
+	reporter := make(chan *proto.ProgressMetrics, 10)
+	core.BuffValuesAsync(&proto.BuffValueRequest{Request: input}, reporter, "cmd-buff-value")
+
+	var finalResult *proto.BuffValueResult
+	for v := range reporter {
+		if v.FinalBuffValueResult != nil {
+			finalResult = v.FinalBuffValueResult
+			break
+		}
+		if verbose {
+			fmt.Printf("Sim Progress: %d / %d (completed %d / %d)\n", v.CompletedIterations, v.TotalIterations, v.CompletedSims, v.TotalSims)
+		}
+	}
+
+	if finalResult.Error != nil {
+		log.Fatalf("buff value sims failed: %s", finalResult.Error.Message)
+	}
+
+	fmt.Print(formatBuffValues(finalResult))
+
+	if outfile != "" {
+		output, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(finalResult)
+		if err != nil {
+			log.Fatalf("failed to marshal final results: %s", err)
+		}
+		err = os.WriteFile(outfile, output, 0666)
+		if err != nil {
+			log.Fatalf("failed to write output file:: %s", err)
+		}
+		if verbose {
+			fmt.Printf("Wrote output file: `%s` successfully.\n", outfile)
+		}
+	}
+}
+
+func buffValueName(value *proto.BuffValue) string {
+	switch value.Source {
+	case proto.BuffValue_PartyBuff:
+		return fmt.Sprintf("%s (party %d)", value.Name, value.PartyIndex+1)
+	case proto.BuffValue_IndividualBuff:
+		return fmt.Sprintf("%s (player %d)", value.Name, value.Player.Index+1)
+	default:
+		return value.Name
+	}
+}
+
+func formatBuffValues(result *proto.BuffValueResult) string {
+	builder := &strings.Builder{}
+	writer := tabwriter.NewWriter(builder, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(writer, "Rank\tBuff\tSource\tChange\tRaid DPS\tRaid HPS\tExclusive With")
+	for i, value := range result.Values {
+		fmt.Fprintf(writer, "%d\t%s\t%s\t%s\t%0.1f ± %0.1f\t%0.1f ± %0.1f\t%s\n",
+			i+1,
+			buffValueName(value),
+			value.Source,
+			core.Ternary(value.Enabled, "removed", "added"),
+			value.RaidDps, value.RaidDpsStdev,
+			value.RaidHps, value.RaidHpsStdev,
+			strings.Join(value.ExclusiveWith, ", "),
+		)
+	}
+
+	writer.Flush()
+	return builder.String()
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/cata/cmd/wowsimcli/combatlog"
+	"github.com/wowsims/cata/sim/core"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	logBoss        string
+	logPull        int
+	logMovementGap float64
+	logSingleUseCD float64
+	logAddUptime   float64
+)
+
+var encounterFromLogCmd = &cobra.Command{
+	Use:   "encounter-from-log",
+	Short: "build an encounter from a combat log",
+	Long:  "parse a combat log for one boss pull and output a sim encounter along with a timeline of adds, health, abilities and movement",
+	Run:   encounterFromLogMain,
+}
+
+func init() {
+	encounterFromLogCmd.Flags().StringVar(&infile, "infile", "WoWCombatLog.txt", "location of the combat log file")
+	encounterFromLogCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
+	encounterFromLogCmd.Flags().StringVar(&logBoss, "boss", "", "name of the boss, as it appears in the log")
+	encounterFromLogCmd.Flags().IntVar(&logPull, "pull", -1, "which pull of the boss to use, 0-based. Negative values count from the last pull")
+	encounterFromLogCmd.Flags().Float64Var(&logMovementGap, "movement-gap", 2.5, "seconds without casting after which a player is considered to be moving")
+	encounterFromLogCmd.Flags().Float64Var(&logSingleUseCD, "single-use-cooldown", 0, "cooldown in seconds for boss abilities used only once, defaults to the pull's duration")
+	encounterFromLogCmd.Flags().Float64Var(&logAddUptime, "add-min-uptime", 0.5, "fraction of the pull an add has to be alive for to be added as a target")
+	encounterFromLogCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
+	encounterFromLogCmd.MarkFlagRequired("infile")
+	encounterFromLogCmd.MarkFlagRequired("boss")
+}
+
+type encounterFromLogOutput struct {
+	Encounter json.RawMessage     `json:"encounter"`
+	Timeline  *combatlog.Timeline `json:"timeline"`
+}
+
+func encounterFromLogMain(cmd *cobra.Command, args []string) {
+	file, err := os.Open(infile)
+	if err != nil {
+		log.Fatalf("failed to open combat log %q: %v", infile, err)
+	}
+	defer file.Close()
+
+	events, err := combatlog.Parse(file)
+	if err != nil {
+		log.Fatalf("failed to parse combat log: %s", err)
+	}
+	if verbose {
+		fmt.Printf("Parsed %d events\n", len(events))
+	}
+
+	options := combatlog.DefaultOptions(logBoss)
+	options.Pull = logPull
+	options.MovementGap = core.DurationFromSeconds(logMovementGap)
+	options.SingleUseCooldown = core.DurationFromSeconds(logSingleUseCD)
+	options.AddMinUptime = logAddUptime
+
+	encounter, timeline, err := combatlog.BuildEncounter(events, options)
+	if err != nil {
+		log.Fatalf("failed to build encounter: %s", err)
+	}
+
+	encounterJson, err := protojson.Marshal(encounter)
+	if err != nil {
+		log.Fatalf("failed to marshal encounter: %s", err)
+	}
+
+	output, err := json.MarshalIndent(encounterFromLogOutput{
+		Encounter: encounterJson,
+		Timeline:  timeline,
+	}, "", "  ")
+	if err != nil {
+		log.Fatalf("failed to marshal output: %s", err)
+	}
+
+	if outfile == "" {
+		fmt.Println(string(output))
+	} else {
+		err = os.WriteFile(outfile, output, 0666)
+		if err != nil {
+			log.Fatalf("failed to write output file:: %s", err)
+		}
+		if verbose {
+			fmt.Printf("Wrote output file: `%s` successfully.\n", outfile)
+		}
+	}
+}
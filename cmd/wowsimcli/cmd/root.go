@@ -18,6 +18,9 @@ func Execute(version string) {
 	rootCmd.AddCommand(simCmd)
 	rootCmd.AddCommand(bulkCmd)
 	rootCmd.AddCommand(decodeLinkCmd)
+	rootCmd.AddCommand(encounterFromLogCmd)
+	rootCmd.AddCommand(buffValueCmd)
+	rootCmd.AddCommand(sweepCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	sweepDurations    []float64
+	sweepTargetCounts []int32
+)
+
+var sweepCmd = &cobra.Command{
+	Use:   "sweep",
+	Short: "sim across fight durations and target counts",
+	Long:  "rerun a raid sim for each combination of fight duration and target count, and print a table of raid DPS with its standard error",
+	Run:   sweepMain,
+}
+
+func init() {
+	sweepCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (RaidSimRequest in protojson format)")
+	sweepCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file for the full results in protojson format")
+	sweepCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
+	sweepCmd.Flags().Float64SliceVar(&sweepDurations, "durations", nil, "fight durations in seconds, e.g. 60,120,300 (defaults to the encounter's duration)")
+	sweepCmd.Flags().Int32SliceVar(&sweepTargetCounts, "targets", nil, "target counts up to 10, e.g. 1,3,5 (defaults to the encounter's target count)")
+	sweepCmd.MarkFlagRequired("infile")
+}
+
+func sweepMain(cmd *cobra.Command, args []string) {
+	data, err := os.ReadFile(infile)
+	if err != nil {
+		log.Fatalf("failed to load input json file %q: %v", infile, err)
+	}
+	input := &proto.RaidSimRequest{}
+
+	err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, input)
+	if err != nil {
+		log.Fatalf("failed to load input json file: %s", err)
+	}
+
+	reporter := make(chan *proto.ProgressMetrics, 10)
+	core.EncounterSweepAsync(&proto.EncounterSweepRequest{
+		Request:      input,
+		Durations:    sweepDurations,
+		TargetCounts: sweepTargetCounts,
+	}, reporter, "cmd-sweep")
+
+	var finalResult *proto.EncounterSweepResult
+	for v := range reporter {
+		if v.FinalSweepResult != nil {
+			finalResult = v.FinalSweepResult
+			break
+		}
+		if verbose {
+			fmt.Printf("Sim Progress: %d / %d (completed %d / %d)\n", v.CompletedIterations, v.TotalIterations, v.CompletedSims, v.TotalSims)
+		}
+	}
+
+	if finalResult.Error != nil {
+		log.Fatalf("sweep sims failed: %s", finalResult.Error.Message)
+	}
+
+	fmt.Print(formatSweep(finalResult))
+
+	if outfile != "" {
+		output, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(finalResult)
+		if err != nil {
+			log.Fatalf("failed to marshal final results: %s", err)
+		}
+		err = os.WriteFile(outfile, output, 0666)
+		if err != nil {
+			log.Fatalf("failed to write output file:: %s", err)
+		}
+		if verbose {
+			fmt.Printf("Wrote output file: `%s` successfully.\n", outfile)
+		}
+	}
+}
+
+// Formats raid DPS as a table, with a row for each duration and a column for
+// each target count.
+func formatSweep(result *proto.EncounterSweepResult) string {
+	builder := &strings.Builder{}
+	writer := tabwriter.NewWriter(builder, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	fmt.Fprint(writer, "Duration\t")
+	for _, count := range result.TargetCounts {
+		fmt.Fprintf(writer, "%d Target(s)\t", count)
+	}
+	fmt.Fprintln(writer)
+
+	for i, duration := range result.Durations {
+		fmt.Fprintf(writer, "%0.0fs\t", duration)
+		for j := range result.TargetCounts {
+			point := result.Points[i*len(result.TargetCounts)+j]
+			fmt.Fprintf(writer, "%0.1f ± %0.1f\t", point.RaidDps, point.RaidDpsError)
+		}
+		fmt.Fprintln(writer)
+	}
+
+	writer.Flush()
+	return builder.String()
+}
@@ -0,0 +1,215 @@
+package combatlog
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+const (
+	testBoss   = `0xF130000D6A000001,"Test Boss",0xa48,0x0`
+	testAdd    = `0xF130000D6B000002,"Test Add",0xa48,0x0`
+	testTank   = `0x0380000000000001,"Tank",0x514,0x0`
+	testHealer = `0x0380000000000002,"Healer",0x514,0x0`
+)
+
+type testLog struct {
+	sb strings.Builder
+}
+
+func (tl *testLog) add(seconds float64, event string, fields ...string) {
+	ms := int(seconds * 1000)
+	fmt.Fprintf(&tl.sb, "9/14 20:%02d:%02d.%03d  %s,%s\n", ms/60000, ms/1000%60, ms%1000, event, strings.Join(fields, ","))
+}
+
+func buildTestLog() string {
+	tl := &testLog{}
+	tl.add(0, "ENCOUNTER_START", "1205", `"Test Boss"`, "4", "10")
+
+	for t := 0.0; t < 40; t++ {
+		// Boss melee on the tank every 2s, and raid dps on the boss every second.
+		if int(t)%2 == 0 {
+			tl.add(t, "SWING_DAMAGE", testBoss, testTank, "20000", "0", "1", "0", "0", "0", "nil", "nil", "nil")
+		}
+		tl.add(t, "SPELL_DAMAGE", testTank, testBoss, "23881", `"Bloodthirst"`, "0x1", "1000", "0", "1", "0", "0", "0", "nil", "nil", "nil")
+
+		// Both players stop casting from 12s to 16s.
+		if t < 12 || t > 16 {
+			tl.add(t, "SPELL_CAST_SUCCESS", testTank, testBoss, "23881", `"Bloodthirst"`, "0x1")
+			tl.add(t, "SPELL_CAST_SUCCESS", testHealer, testTank, "2061", `"Flash Heal"`, "0x2")
+		}
+	}
+
+	for _, t := range []float64{10, 20, 30} {
+		tl.add(t-2, "SPELL_CAST_START", testBoss, `0x0000000000000000,nil,0x80000000,0x80000000`, "1000", `"Blast"`, "0x4")
+		tl.add(t, "SPELL_CAST_SUCCESS", testBoss, `0x0000000000000000,nil,0x80000000,0x80000000`, "1000", `"Blast"`, "0x4")
+		tl.add(t, "SPELL_DAMAGE", testBoss, testTank, "1000", `"Blast"`, "0x4", "10000", "0", "4", "0", "0", "0", "nil", "nil", "nil")
+		tl.add(t, "SPELL_DAMAGE", testBoss, testHealer, "1000", `"Blast"`, "0x4", "12000", "0", "4", "0", "0", "0", "nil", "nil", "nil")
+	}
+
+	tl.add(15, "SWING_DAMAGE", testAdd, testHealer, "5000", "0", "1", "0", "0", "0", "nil", "nil", "nil")
+	tl.add(25, "UNIT_DIED", `0x0000000000000000,nil,0x80000000,0x80000000`, testAdd)
+
+	tl.add(40, "ENCOUNTER_END", "1205", `"Test Boss"`, "4", "10", "1")
+	return tl.sb.String()
+}
+
+func TestParseLine(t *testing.T) {
+	event, err := ParseLine(`9/14 20:31:45.123  SPELL_DAMAGE,0xF130000D6A000001,"Test Boss",0xa48,0x0,0x0380000000000001,"Tank, the Brave",0x514,0x0,1000,"Blast",0x4,10000,0,4,500,0,2000,1,nil,nil`)
+	if err != nil {
+		t.Fatalf("Failed to parse line: %s", err)
+	}
+
+	if event.Source.Name != "Test Boss" || event.Source.NPCID() != 0x0D6A || !event.Source.IsHostileNPC() {
+		t.Fatalf("Unexpected source: %+v", event.Source)
+	}
+	if event.Dest.Name != "Tank, the Brave" || !event.Dest.IsPlayer() {
+		t.Fatalf("Unexpected dest: %+v", event.Dest)
+	}
+	if event.SpellID != 1000 || event.SpellSchool != 4 {
+		t.Fatalf("Unexpected spell: %d %d", event.SpellID, event.SpellSchool)
+	}
+	if event.Amount != 10000 || event.Resisted != 500 || event.Absorbed != 2000 || !event.Critical {
+		t.Fatalf("Unexpected damage: %+v", event)
+	}
+}
+
+func TestBuildEncounter(t *testing.T) {
+	events, err := Parse(strings.NewReader(buildTestLog()))
+	if err != nil {
+		t.Fatalf("Failed to parse log: %s", err)
+	}
+
+	encounter, timeline, err := BuildEncounter(events, DefaultOptions("Test Boss"))
+	if err != nil {
+		t.Fatalf("Failed to build encounter: %s", err)
+	}
+
+	if encounter.Duration != 40 || !timeline.Killed {
+		t.Fatalf("Expected a 40s kill, got %0.1fs (killed: %t)", encounter.Duration, timeline.Killed)
+	}
+
+	target := encounter.Targets[0]
+	if target.Id != 0x0D6A || target.SwingSpeed != 2 {
+		t.Fatalf("Unexpected boss target: %+v", target)
+	}
+
+	abilities := target.CustomAi.Abilities
+	if len(abilities) != 1 {
+		t.Fatalf("Expected 1 boss ability, got %d", len(abilities))
+	}
+	blast := abilities[0]
+	if blast.Cooldown != 10 || blast.InitialDelay != 10 || blast.CastTime != 2 {
+		t.Fatalf("Unexpected ability timing: %+v", blast)
+	}
+	if blast.MinDamage != 10000 || blast.MaxDamage != 12000 || blast.School != proto.SpellSchool_SpellSchoolFire {
+		t.Fatalf("Unexpected ability damage: %+v", blast)
+	}
+	if blast.TargetSelection != proto.TargetAbility_AllPlayers {
+		t.Fatalf("Expected ability to hit all players, got %s", blast.TargetSelection)
+	}
+
+	if len(timeline.AddSpawns) != 1 || timeline.AddSpawns[0].SpawnSeconds != 15 || timeline.AddSpawns[0].DeathSeconds != 25 {
+		t.Fatalf("Unexpected add spawns: %+v", timeline.AddSpawns)
+	}
+
+	if len(timeline.MovementWindows) != 1 || timeline.MovementWindows[0].StartSeconds != 11 || timeline.MovementWindows[0].EndSeconds != 17 {
+		t.Fatalf("Unexpected movement windows: %+v", timeline.MovementWindows)
+	}
+	if movement := target.CustomAi.RaidMovement; len(movement) != 1 || movement[0].Start != 11 || movement[0].Duration != 6 {
+		t.Fatalf("Expected the movement window to make the raid move, got %v", movement)
+	}
+
+	// The add is only up for a quarter of the fight.
+	if len(encounter.Targets) != 1 {
+		t.Fatalf("Expected only the boss as a target, got %d targets", len(encounter.Targets))
+	}
+
+	// Boss health drops linearly, so it is below 20% for the last ~20% of the fight.
+	if encounter.ExecuteProportion_20 < 0.15 || encounter.ExecuteProportion_20 > 0.25 {
+		t.Fatalf("Unexpected execute proportion: %0.3f", encounter.ExecuteProportion_20)
+	}
+	if encounter.ExecuteProportion_90 < 0.85 || encounter.ExecuteProportion_90 > 0.95 {
+		t.Fatalf("Unexpected 90%% execute proportion: %0.3f", encounter.ExecuteProportion_90)
+	}
+	if len(encounter.HealthCurve) != 9 || encounter.HealthCurve[8].Health != 0.1 {
+		t.Fatalf("Unexpected health curve: %v", encounter.HealthCurve)
+	}
+}
+
+func TestBuildEncounterAdds(t *testing.T) {
+	events, err := Parse(strings.NewReader(buildTestLog()))
+	if err != nil {
+		t.Fatalf("Failed to parse log: %s", err)
+	}
+
+	options := DefaultOptions("Test Boss")
+	options.AddMinUptime = 0.2
+	encounter, _, err := BuildEncounter(events, options)
+	if err != nil {
+		t.Fatalf("Failed to build encounter: %s", err)
+	}
+
+	if len(encounter.Targets) != 2 {
+		t.Fatalf("Expected the boss and the add as targets, got %d targets", len(encounter.Targets))
+	}
+	if add := encounter.Targets[1]; add.Name != "Test Add" || add.Id != 0x0D6B || add.TankIndex != -1 {
+		t.Fatalf("Unexpected add target: %+v", add)
+	}
+}
+
+func TestBuildEncounterSingleUseAbility(t *testing.T) {
+	tl := &testLog{}
+	tl.add(35, "SPELL_DAMAGE", testBoss, testHealer, "2000", `"Doom"`, "0x20", "50000", "0", "32", "0", "0", "0", "nil", "nil", "nil")
+	events, err := Parse(strings.NewReader(buildTestLog() + tl.sb.String()))
+	if err != nil {
+		t.Fatalf("Failed to parse log: %s", err)
+	}
+
+	encounter, _, err := BuildEncounter(events, DefaultOptions("Test Boss"))
+	if err != nil {
+		t.Fatalf("Failed to build encounter: %s", err)
+	}
+	abilities := encounter.Targets[0].CustomAi.Abilities
+	if len(abilities) != 2 || abilities[1].SpellId != 2000 {
+		t.Fatalf("Expected 2 boss abilities, got %v", abilities)
+	}
+
+	// Only used once, so it shouldn't be used again within the fight.
+	if doom := abilities[1]; doom.Cooldown != 40 || doom.InitialDelay != 35 {
+		t.Fatalf("Expected the fight duration as cooldown, got %+v", doom)
+	}
+
+	options := DefaultOptions("Test Boss")
+	options.SingleUseCooldown = time.Second * 90
+	encounter, _, _ = BuildEncounter(events, options)
+	if doom := encounter.Targets[0].CustomAi.Abilities[1]; doom.Cooldown != 90 {
+		t.Fatalf("Expected the configured cooldown, got %+v", doom)
+	}
+}
+
+func TestParseTimestampRollover(t *testing.T) {
+	log := strings.Join([]string{
+		`12/31 23:59:59.000  UNIT_DIED,0x0000000000000000,nil,0x80000000,0x80000000,` + testAdd,
+		`1/1 00:00:01.500  UNIT_DIED,0x0000000000000000,nil,0x80000000,0x80000000,` + testAdd,
+		`1/1 23:59:59.000  UNIT_DIED,0x0000000000000000,nil,0x80000000,0x80000000,` + testAdd,
+		`1/1 00:00:02.000  UNIT_DIED,0x0000000000000000,nil,0x80000000,0x80000000,` + testAdd,
+	}, "\n")
+	events, err := Parse(strings.NewReader(log))
+	if err != nil {
+		t.Fatalf("Failed to parse log: %s", err)
+	}
+	if len(events) != 4 {
+		t.Fatalf("Expected 4 events, got %d", len(events))
+	}
+
+	if gap := events[1].Timestamp.Sub(events[0].Timestamp); gap != time.Millisecond*2500 {
+		t.Fatalf("Expected the log to continue past new year, got a %s gap", gap)
+	}
+	if gap := events[3].Timestamp.Sub(events[2].Timestamp); gap != time.Second*3 {
+		t.Fatalf("Expected the log to continue past midnight, got a %s gap", gap)
+	}
+}
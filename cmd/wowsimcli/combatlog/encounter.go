@@ -0,0 +1,742 @@
+package combatlog
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+type Options struct {
+	// Name of the boss to build the encounter for.
+	Boss string
+
+	// Which pull of the boss to use, 0-based. Negative values count from the
+	// end, so -1 is the last pull in the log.
+	Pull int
+
+	// Boss events further apart than this are treated as separate pulls when
+	// the log has no ENCOUNTER_START/ENCOUNTER_END events.
+	PullGap time.Duration
+
+	// Players not casting anything for at least this long are considered to be
+	// moving.
+	MovementGap time.Duration
+
+	// Fraction of players (0-1) that must be moving at the same time for it to
+	// count as a raid movement window.
+	MovementRaidFraction float64
+
+	// Cooldown for boss abilities used only once in the pull. If 0, the pull's
+	// duration is used, so they're used once in the sim as well.
+	SingleUseCooldown time.Duration
+
+	// Adds alive for at least this fraction (0-1) of the pull are added as
+	// extra targets. Sim targets are up for the whole fight, so short-lived
+	// adds are only kept in the timeline.
+	AddMinUptime float64
+}
+
+func DefaultOptions(boss string) Options {
+	return Options{
+		Boss:                 boss,
+		Pull:                 -1,
+		PullGap:              time.Second * 30,
+		MovementGap:          time.Millisecond * 2500,
+		MovementRaidFraction: 0.5,
+		AddMinUptime:         0.5,
+	}
+}
+
+// Everything extracted from a pull which doesn't fit into the Encounter proto.
+type Timeline struct {
+	Boss            string  `json:"boss"`
+	DurationSeconds float64 `json:"durationSeconds"`
+	Killed          bool    `json:"killed"`
+
+	AddSpawns       []AddSpawn        `json:"addSpawns"`
+	HealthCurves    []HealthCurve     `json:"healthCurves"`
+	Abilities       []AbilityTimeline `json:"abilities"`
+	MovementWindows []Window          `json:"movementWindows"`
+}
+
+type AddSpawn struct {
+	Name         string  `json:"name"`
+	NpcID        int32   `json:"npcId"`
+	SpawnSeconds float64 `json:"spawnSeconds"`
+
+	// Negative if the add was still alive at the end of the pull.
+	DeathSeconds float64 `json:"deathSeconds"`
+
+	guid string
+}
+
+type HealthCurve struct {
+	Name    string         `json:"name"`
+	Samples []HealthSample `json:"samples"`
+}
+
+type HealthSample struct {
+	Seconds float64 `json:"seconds"`
+	Percent float64 `json:"percent"`
+}
+
+type AbilityTimeline struct {
+	SpellID int32  `json:"spellId"`
+	Name    string `json:"name"`
+	Caster  string `json:"caster"`
+
+	CastSeconds []float64 `json:"castSeconds"`
+
+	Hits        int32   `json:"hits"`
+	TotalDamage float64 `json:"totalDamage"`
+	MinHit      float64 `json:"minHit"`
+	MaxHit      float64 `json:"maxHit"`
+	Periodic    bool    `json:"periodic"`
+}
+
+type Window struct {
+	StartSeconds float64 `json:"startSeconds"`
+	EndSeconds   float64 `json:"endSeconds"`
+}
+
+type pull struct {
+	events []*Event
+	start  time.Time
+	end    time.Time
+	killed bool
+}
+
+// Builds an encounter and timeline for one pull of a boss from parsed log events.
+func BuildEncounter(events []*Event, options Options) (*proto.Encounter, *Timeline, error) {
+	if options.Boss == "" {
+		return nil, nil, errors.New("no boss name given")
+	}
+
+	// Logs are written in order, but make sure of it since everything below depends on it.
+	events = slices.Clone(events)
+	slices.SortStableFunc(events, func(a, b *Event) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+
+	pulls := findPulls(events, options)
+	if len(pulls) == 0 {
+		return nil, nil, fmt.Errorf("no pulls of %q found in log", options.Boss)
+	}
+
+	pullIdx := options.Pull
+	if pullIdx < 0 {
+		pullIdx += len(pulls)
+	}
+	if pullIdx < 0 || pullIdx >= len(pulls) {
+		return nil, nil, fmt.Errorf("pull %d out of range, found %d pulls of %q", options.Pull, len(pulls), options.Boss)
+	}
+
+	b := &encounterBuilder{
+		options: options,
+		pull:    pulls[pullIdx],
+	}
+	return b.build()
+}
+
+// Splits events into pulls of the boss, using encounter events when present
+// and gaps between boss events otherwise.
+func findPulls(events []*Event, options Options) []*pull {
+	var pulls []*pull
+	var cur *pull
+
+	hasEncounterEvents := slices.ContainsFunc(events, func(event *Event) bool {
+		return event.Type == "ENCOUNTER_START" && event.EncounterName == options.Boss
+	})
+
+	if hasEncounterEvents {
+		for _, event := range events {
+			switch {
+			case event.Type == "ENCOUNTER_START" && event.EncounterName == options.Boss:
+				cur = &pull{start: event.Timestamp}
+			case event.Type == "ENCOUNTER_END" && cur != nil:
+				cur.end = event.Timestamp
+				cur.killed = event.Success
+				pulls = append(pulls, cur)
+				cur = nil
+			case cur != nil:
+				cur.events = append(cur.events, event)
+			}
+		}
+		return pulls
+	}
+
+	for _, event := range events {
+		involvesBoss := event.Source.Name == options.Boss || event.Dest.Name == options.Boss
+		if involvesBoss && (cur == nil || event.Timestamp.Sub(cur.end) > options.PullGap) {
+			cur = &pull{start: event.Timestamp}
+			pulls = append(pulls, cur)
+		}
+		if cur == nil || (!involvesBoss && event.Timestamp.Sub(cur.end) > options.PullGap) {
+			continue
+		}
+
+		cur.events = append(cur.events, event)
+		if involvesBoss {
+			cur.end = event.Timestamp
+		}
+		if event.Type == "UNIT_DIED" && event.Dest.Name == options.Boss {
+			cur.killed = true
+		}
+	}
+
+	// Drop events after the last boss event of each pull.
+	for _, p := range pulls {
+		last := len(p.events)
+		for last > 0 && p.events[last-1].Timestamp.After(p.end) {
+			last--
+		}
+		p.events = p.events[:last]
+	}
+	return pulls
+}
+
+type encounterBuilder struct {
+	options Options
+	pull    *pull
+
+	bossGUID string
+	bossUnit Unit
+	players  map[string]Unit
+}
+
+func (b *encounterBuilder) seconds(t time.Time) float64 {
+	return t.Sub(b.pull.start).Seconds()
+}
+
+func (b *encounterBuilder) isEnemy(unit Unit) bool {
+	return unit.IsHostileNPC() || unit.Name == b.options.Boss
+}
+
+func (b *encounterBuilder) build() (*proto.Encounter, *Timeline, error) {
+	b.players = make(map[string]Unit)
+	for _, event := range b.pull.events {
+		if b.bossGUID == "" && event.Source.Name == b.options.Boss {
+			b.bossGUID, b.bossUnit = event.Source.GUID, event.Source
+		} else if b.bossGUID == "" && event.Dest.Name == b.options.Boss {
+			b.bossGUID, b.bossUnit = event.Dest.GUID, event.Dest
+		}
+		for _, unit := range []Unit{event.Source, event.Dest} {
+			if unit.IsPlayer() {
+				b.players[unit.GUID] = unit
+			}
+		}
+	}
+	if b.bossGUID == "" {
+		return nil, nil, fmt.Errorf("no events for %q in the selected pull", b.options.Boss)
+	}
+
+	duration := b.pull.end.Sub(b.pull.start)
+	timeline := &Timeline{
+		Boss:            b.options.Boss,
+		DurationSeconds: duration.Seconds(),
+		Killed:          b.pull.killed,
+		AddSpawns:       b.addSpawns(),
+		HealthCurves:    b.healthCurves(),
+		Abilities:       b.abilities(),
+		MovementWindows: b.movementWindows(),
+	}
+
+	target := &proto.Target{
+		Id:        b.bossUnit.NPCID(),
+		Name:      b.options.Boss,
+		Level:     88,
+		MobType:   proto.MobType_MobTypeUnknown,
+		TankIndex: 0,
+		CustomAi:  b.customAI(timeline),
+	}
+	if maxHP := b.maxHealth(b.bossGUID); maxHP > 0 {
+		target.Stats = stats.Stats{stats.Health: maxHP}.ToProtoArray()
+	}
+	b.applyMeleeStats(target)
+
+	encounter := &proto.Encounter{
+		Duration: math.Round(duration.Seconds()),
+		Targets:  append([]*proto.Target{target}, b.addTargets(timeline)...),
+	}
+	if len(timeline.HealthCurves) > 0 && timeline.HealthCurves[0].Name == b.options.Boss {
+		b.applyExecuteProportions(encounter, timeline.HealthCurves[0])
+	}
+
+	return encounter, timeline, nil
+}
+
+func (b *encounterBuilder) addSpawns() []AddSpawn {
+	spawnIdx := make(map[string]int)
+	var spawns []AddSpawn
+
+	for _, event := range b.pull.events {
+		for _, unit := range []Unit{event.Source, event.Dest} {
+			if !unit.IsHostileNPC() || unit.GUID == b.bossGUID || unit.Name == b.options.Boss {
+				continue
+			}
+			if _, ok := spawnIdx[unit.GUID]; !ok {
+				spawnIdx[unit.GUID] = len(spawns)
+				spawns = append(spawns, AddSpawn{
+					Name:         unit.Name,
+					NpcID:        unit.NPCID(),
+					SpawnSeconds: b.seconds(event.Timestamp),
+					DeathSeconds: -1,
+					guid:         unit.GUID,
+				})
+			}
+		}
+
+		if event.Type == "UNIT_DIED" {
+			if idx, ok := spawnIdx[event.Dest.GUID]; ok {
+				spawns[idx].DeathSeconds = b.seconds(event.Timestamp)
+			}
+		}
+	}
+	return spawns
+}
+
+// Untanked targets for the adds which were up for most of the pull.
+func (b *encounterBuilder) addTargets(timeline *Timeline) []*proto.Target {
+	if timeline.DurationSeconds <= 0 {
+		return nil
+	}
+
+	var targets []*proto.Target
+	for _, spawn := range timeline.AddSpawns {
+		despawn := timeline.DurationSeconds
+		if spawn.DeathSeconds >= 0 {
+			despawn = spawn.DeathSeconds
+		}
+		if (despawn-spawn.SpawnSeconds)/timeline.DurationSeconds < b.options.AddMinUptime {
+			continue
+		}
+
+		target := &proto.Target{
+			Id:        spawn.NpcID,
+			Name:      spawn.Name,
+			Level:     88,
+			MobType:   proto.MobType_MobTypeUnknown,
+			TankIndex: -1,
+		}
+		if maxHP := b.maxHealth(spawn.guid); maxHP > 0 {
+			target.Stats = stats.Stats{stats.Health: maxHP}.ToProtoArray()
+		}
+		targets = append(targets, target)
+	}
+	return targets
+}
+
+// Health curves for the boss and adds, sampled at most once per second. Uses
+// advanced logging info when available, and otherwise assumes each unit's
+// total damage taken was its health pool.
+func (b *encounterBuilder) healthCurves() []HealthCurve {
+	type unitHealth struct {
+		name        string
+		samples     []HealthSample
+		damageTimes []float64
+		damage      []float64
+	}
+
+	var order []string
+	units := make(map[string]*unitHealth)
+	getUnit := func(guid string, name string) *unitHealth {
+		if uh, ok := units[guid]; ok {
+			return uh
+		}
+		uh := &unitHealth{name: name}
+		units[guid] = uh
+		order = append(order, guid)
+		return uh
+	}
+
+	for _, event := range b.pull.events {
+		if !b.isEnemy(event.Dest) {
+			continue
+		}
+		uh := getUnit(event.Dest.GUID, event.Dest.Name)
+		t := b.seconds(event.Timestamp)
+
+		if event.Info != nil && event.Info.GUID == event.Dest.GUID && event.Info.MaxHP > 0 {
+			if n := len(uh.samples); n == 0 || t-uh.samples[n-1].Seconds >= 1 {
+				uh.samples = append(uh.samples, HealthSample{Seconds: t, Percent: event.Info.CurrentHP / event.Info.MaxHP * 100})
+			}
+		}
+		if event.IsDamage() {
+			uh.damageTimes = append(uh.damageTimes, t)
+			uh.damage = append(uh.damage, event.Amount)
+		}
+	}
+
+	// Make sure the boss comes first.
+	if idx := slices.Index(order, b.bossGUID); idx > 0 {
+		order = slices.Delete(order, idx, idx+1)
+		order = slices.Insert(order, 0, b.bossGUID)
+	}
+
+	var curves []HealthCurve
+	for _, guid := range order {
+		uh := units[guid]
+		if len(uh.samples) == 0 && len(uh.damage) > 0 {
+			total := 0.0
+			for _, dmg := range uh.damage {
+				total += dmg
+			}
+
+			taken := 0.0
+			for i, dmg := range uh.damage {
+				taken += dmg
+				t := uh.damageTimes[i]
+				if n := len(uh.samples); n == 0 || t-uh.samples[n-1].Seconds >= 1 || i == len(uh.damage)-1 {
+					uh.samples = append(uh.samples, HealthSample{Seconds: t, Percent: (1 - taken/total) * 100})
+				}
+			}
+		}
+
+		if len(uh.samples) > 0 {
+			curves = append(curves, HealthCurve{Name: uh.name, Samples: uh.samples})
+		}
+	}
+	return curves
+}
+
+func (b *encounterBuilder) maxHealth(guid string) float64 {
+	for _, event := range b.pull.events {
+		if event.Info != nil && event.Info.GUID == guid && event.Info.MaxHP > 0 {
+			return event.Info.MaxHP
+		}
+	}
+	return 0
+}
+
+func (b *encounterBuilder) abilities() []AbilityTimeline {
+	type abilityKey struct {
+		caster  string
+		spellID int32
+	}
+
+	var order []abilityKey
+	abilities := make(map[abilityKey]*AbilityTimeline)
+	getAbility := func(event *Event) *AbilityTimeline {
+		key := abilityKey{caster: event.Source.Name, spellID: event.SpellID}
+		if ability, ok := abilities[key]; ok {
+			return ability
+		}
+		ability := &AbilityTimeline{
+			SpellID: event.SpellID,
+			Name:    event.SpellName,
+			Caster:  event.Source.Name,
+			MinHit:  math.MaxFloat64,
+		}
+		abilities[key] = ability
+		order = append(order, key)
+		return ability
+	}
+
+	for _, event := range b.pull.events {
+		if !b.isEnemy(event.Source) || event.IsSwing() {
+			continue
+		}
+
+		if event.Type == "SPELL_CAST_SUCCESS" {
+			ability := getAbility(event)
+			ability.CastSeconds = append(ability.CastSeconds, b.seconds(event.Timestamp))
+		} else if event.IsDamage() && event.Dest.IsPlayer() {
+			ability := getAbility(event)
+			hit := event.Amount + event.Absorbed
+			ability.Hits++
+			ability.TotalDamage += hit
+			ability.MinHit = min(ability.MinHit, hit)
+			ability.MaxHit = max(ability.MaxHit, hit)
+			ability.Periodic = ability.Periodic || event.IsPeriodic()
+		}
+	}
+
+	result := make([]AbilityTimeline, 0, len(order))
+	for _, key := range order {
+		ability := abilities[key]
+		if ability.Hits == 0 {
+			ability.MinHit = 0
+		}
+		result = append(result, *ability)
+	}
+	return result
+}
+
+// Finds windows where a large part of the raid stopped casting at the same
+// time, which usually means they had to move.
+func (b *encounterBuilder) movementWindows() []Window {
+	lastCast := make(map[string]float64)
+	type edge struct {
+		t     float64
+		delta int
+	}
+	var edges []edge
+
+	gap := b.options.MovementGap.Seconds()
+	for _, event := range b.pull.events {
+		if !event.IsCast() || !event.Source.IsPlayer() {
+			continue
+		}
+		t := b.seconds(event.Timestamp)
+		last, ok := lastCast[event.Source.GUID]
+		if ok && t-last >= gap {
+			edges = append(edges, edge{t: last, delta: 1}, edge{t: t, delta: -1})
+		}
+		lastCast[event.Source.GUID] = t
+	}
+
+	if len(b.players) == 0 || len(edges) == 0 {
+		return nil
+	}
+	sort.SliceStable(edges, func(i, j int) bool {
+		return edges[i].t < edges[j].t
+	})
+
+	threshold := int(math.Ceil(b.options.MovementRaidFraction * float64(len(b.players))))
+	var windows []Window
+	idle := 0
+	windowStart := -1.0
+	for _, e := range edges {
+		idle += e.delta
+		if idle >= threshold && windowStart < 0 {
+			windowStart = e.t
+		} else if idle < threshold && windowStart >= 0 {
+			if n := len(windows); n > 0 && windowStart-windows[n-1].EndSeconds < 0.5 {
+				windows[n-1].EndSeconds = e.t
+			} else {
+				windows = append(windows, Window{StartSeconds: windowStart, EndSeconds: e.t})
+			}
+			windowStart = -1
+		}
+	}
+	return windows
+}
+
+// Sets the boss auto attack parameters from its observed melee swings. Damage
+// is after the tank's mitigation, so it's a lower bound on the real values.
+func (b *encounterBuilder) applyMeleeStats(target *proto.Target) {
+	var swingTimes []float64
+	var hits []float64
+	for _, event := range b.pull.events {
+		if event.Source.GUID != b.bossGUID || !event.IsSwing() {
+			continue
+		}
+		swingTimes = append(swingTimes, b.seconds(event.Timestamp))
+		if event.IsDamage() {
+			hits = append(hits, event.Amount+event.Absorbed+event.Blocked)
+		}
+	}
+	if len(swingTimes) < 2 || len(hits) == 0 {
+		return
+	}
+
+	var intervals []float64
+	for i := 1; i < len(swingTimes); i++ {
+		if interval := swingTimes[i] - swingTimes[i-1]; interval > 0.1 {
+			intervals = append(intervals, interval)
+		}
+	}
+	if len(intervals) == 0 {
+		return
+	}
+
+	target.SwingSpeed = math.Round(median(intervals)*10) / 10
+	target.MinBaseDamage = math.Round(percentile(hits, 0.1))
+	target.SpellSchool = proto.SpellSchool_SpellSchoolPhysical
+}
+
+// Builds data-driven abilities for the boss from its observed damage.
+func (b *encounterBuilder) customAI(timeline *Timeline) *proto.CustomTargetAI {
+	customAI := &proto.CustomTargetAI{}
+	numPlayers := len(b.players)
+	mainTank := b.mainTankGUID()
+
+	for _, ability := range timeline.Abilities {
+		if ability.Hits == 0 || ability.Periodic || ability.Caster != b.options.Boss {
+			continue
+		}
+
+		// Group hits landing within a second of each other into one use.
+		var uses []float64
+		targetsPerUse := make(map[float64]map[string]bool)
+		tankHits := 0
+		for _, event := range b.pull.events {
+			if event.Source.GUID != b.bossGUID || event.SpellID != ability.SpellID || !event.IsDamage() || !event.Dest.IsPlayer() {
+				continue
+			}
+			t := b.seconds(event.Timestamp)
+			if n := len(uses); n == 0 || t-uses[n-1] > 1 {
+				uses = append(uses, t)
+				targetsPerUse[t] = make(map[string]bool)
+			}
+			targetsPerUse[uses[len(uses)-1]][event.Dest.GUID] = true
+			if event.Dest.GUID == mainTank {
+				tankHits++
+			}
+		}
+
+		avgTargets := 0.0
+		for _, targets := range targetsPerUse {
+			avgTargets += float64(len(targets))
+		}
+		avgTargets /= float64(len(uses))
+
+		selection := proto.TargetAbility_RandomPlayer
+		if numPlayers > 1 && avgTargets >= 0.75*float64(numPlayers) {
+			selection = proto.TargetAbility_AllPlayers
+		} else if float64(tankHits) >= 0.75*float64(ability.Hits) {
+			selection = proto.TargetAbility_Tank
+		}
+
+		var intervals []float64
+		for i := 1; i < len(uses); i++ {
+			intervals = append(intervals, uses[i]-uses[i-1])
+		}
+		cooldown := median(intervals)
+		if len(intervals) == 0 {
+			cooldown = timeline.DurationSeconds
+			if b.options.SingleUseCooldown > 0 {
+				cooldown = b.options.SingleUseCooldown.Seconds()
+			}
+		}
+
+		customAI.Abilities = append(customAI.Abilities, &proto.TargetAbility{
+			SpellId:         ability.SpellID,
+			Name:            ability.Name,
+			School:          b.spellSchool(ability.SpellID),
+			MinDamage:       math.Round(ability.MinHit),
+			MaxDamage:       math.Round(ability.MaxHit),
+			CastTime:        b.castTime(ability.SpellID),
+			Cooldown:        math.Round(cooldown*10) / 10,
+			InitialDelay:    math.Round(uses[0]*10) / 10,
+			TargetSelection: selection,
+		})
+	}
+
+	for _, window := range timeline.MovementWindows {
+		customAI.RaidMovement = append(customAI.RaidMovement, &proto.TargetTimeWindow{
+			Start:    math.Round(window.StartSeconds*10) / 10,
+			Duration: math.Round((window.EndSeconds-window.StartSeconds)*10) / 10,
+		})
+	}
+	return customAI
+}
+
+// The player the boss meleed the most.
+func (b *encounterBuilder) mainTankGUID() string {
+	counts := make(map[string]int)
+	best := ""
+	for _, event := range b.pull.events {
+		if event.Source.GUID == b.bossGUID && event.IsSwing() && event.Dest.IsPlayer() {
+			counts[event.Dest.GUID]++
+			if counts[event.Dest.GUID] > counts[best] {
+				best = event.Dest.GUID
+			}
+		}
+	}
+	return best
+}
+
+// Median time between a boss spell's cast start and cast success.
+func (b *encounterBuilder) castTime(spellID int32) float64 {
+	var castTimes []float64
+	castStart := -1.0
+	for _, event := range b.pull.events {
+		if event.Source.GUID != b.bossGUID || event.SpellID != spellID {
+			continue
+		}
+		switch event.Type {
+		case "SPELL_CAST_START":
+			castStart = b.seconds(event.Timestamp)
+		case "SPELL_CAST_SUCCESS":
+			if castStart >= 0 {
+				castTimes = append(castTimes, b.seconds(event.Timestamp)-castStart)
+				castStart = -1
+			}
+		}
+	}
+	return math.Round(median(castTimes)*10) / 10
+}
+
+func (b *encounterBuilder) spellSchool(spellID int32) proto.SpellSchool {
+	for _, event := range b.pull.events {
+		if event.SpellID == spellID && event.Source.GUID == b.bossGUID {
+			return spellSchoolFromMask(event.SpellSchool)
+		}
+	}
+	return proto.SpellSchool_SpellSchoolPhysical
+}
+
+// Sets the execute proportions and the encounter health curve from the boss health curve.
+func (b *encounterBuilder) applyExecuteProportions(encounter *proto.Encounter, curve HealthCurve) {
+	duration := b.pull.end.Sub(b.pull.start).Seconds()
+	if duration <= 0 {
+		return
+	}
+
+	firstBelow := func(percent float64) float64 {
+		for _, sample := range curve.Samples {
+			if sample.Percent <= percent {
+				return sample.Seconds
+			}
+		}
+		return duration
+	}
+	proportionBelow := func(percent float64) float64 {
+		return math.Round((1-firstBelow(percent)/duration)*1000) / 1000
+	}
+
+	encounter.ExecuteProportion_20 = proportionBelow(20)
+	encounter.ExecuteProportion_25 = proportionBelow(25)
+	encounter.ExecuteProportion_35 = proportionBelow(35)
+	encounter.ExecuteProportion_90 = proportionBelow(90)
+
+	// Keep one point per 10% of boss health, which is enough to capture burst phases.
+	for percent := 90.0; percent > 0; percent -= 10 {
+		if seconds := firstBelow(percent); seconds < duration {
+			encounter.HealthCurve = append(encounter.HealthCurve, &proto.HealthCurvePoint{
+				Time:   math.Round(seconds/duration*1000) / 1000,
+				Health: percent / 100,
+			})
+		}
+	}
+}
+
+func spellSchoolFromMask(mask int32) proto.SpellSchool {
+	switch {
+	case mask&0x1 != 0:
+		return proto.SpellSchool_SpellSchoolPhysical
+	case mask&0x2 != 0:
+		return proto.SpellSchool_SpellSchoolHoly
+	case mask&0x4 != 0:
+		return proto.SpellSchool_SpellSchoolFire
+	case mask&0x8 != 0:
+		return proto.SpellSchool_SpellSchoolNature
+	case mask&0x10 != 0:
+		return proto.SpellSchool_SpellSchoolFrost
+	case mask&0x20 != 0:
+		return proto.SpellSchool_SpellSchoolShadow
+	case mask&0x40 != 0:
+		return proto.SpellSchool_SpellSchoolArcane
+	}
+	return proto.SpellSchool_SpellSchoolPhysical
+}
+
+func median(values []float64) float64 {
+	return percentile(values, 0.5)
+}
+
+func percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	return sorted[int(p*float64(len(sorted)-1))]
+}
@@ -0,0 +1,303 @@
+// Package combatlog parses WoW combat log files (WoWCombatLog.txt) and turns
+// the events of a boss fight into an encounter the sim can run.
+//
+// Lines are expected in the Cataclysm format, e.g.
+//
+//	9/14 20:31:45.123  SPELL_DAMAGE,0xF130...,"Shannox",0xa48,0x0,0x0380...,"Tank",0x514,0x0,99937,"Jagged Tear",0x1,12000,0,1,0,0,0,nil,nil,nil
+//
+// Logs with and without the raid flag fields are supported, as well as
+// advanced combat logging.
+package combatlog
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Unit flag bits, see COMBATLOG_OBJECT_* in the game's API.
+const (
+	FlagReactionFriendly = 0x10
+	FlagReactionHostile  = 0x40
+	FlagTypePlayer       = 0x400
+	FlagTypeNPC          = 0x800
+	FlagTypePet          = 0x1000
+)
+
+// Number of fields following the spell/swing prefix of a _DAMAGE event:
+// amount, overkill, school, resisted, blocked, absorbed, critical, glancing, crushing.
+const damageSuffixLen = 9
+
+type Unit struct {
+	GUID  string
+	Name  string
+	Flags uint64
+}
+
+func (unit Unit) IsPlayer() bool {
+	return unit.Flags&FlagTypePlayer != 0
+}
+
+func (unit Unit) IsHostileNPC() bool {
+	return unit.Flags&FlagTypeNPC != 0 && unit.Flags&FlagReactionHostile != 0
+}
+
+// Returns the NPC ID encoded in a creature GUID, or 0 if it isn't one.
+func (unit Unit) NPCID() int32 {
+	guid := strings.TrimPrefix(unit.GUID, "0x")
+	if len(guid) != 16 {
+		return 0
+	}
+	id, err := strconv.ParseInt(guid[6:10], 16, 32)
+	if err != nil {
+		return 0
+	}
+	return int32(id)
+}
+
+// Extra unit state included with some events when advanced combat logging is enabled.
+type UnitInfo struct {
+	GUID      string
+	CurrentHP float64
+	MaxHP     float64
+}
+
+type Event struct {
+	Timestamp time.Time
+	Type      string
+
+	Source Unit
+	Dest   Unit
+
+	SpellID     int32
+	SpellName   string
+	SpellSchool int32
+
+	// Only set for _DAMAGE events.
+	Amount   float64
+	Absorbed float64
+	Resisted float64
+	Blocked  float64
+	Critical bool
+
+	// Only set for _MISSED events.
+	MissType string
+
+	// Only set when advanced combat logging is enabled.
+	Info *UnitInfo
+
+	// Only set for ENCOUNTER_START and ENCOUNTER_END.
+	EncounterName string
+	Success       bool
+}
+
+func (event *Event) IsDamage() bool {
+	return strings.HasSuffix(event.Type, "_DAMAGE")
+}
+
+func (event *Event) IsPeriodic() bool {
+	return strings.HasPrefix(event.Type, "SPELL_PERIODIC_")
+}
+
+func (event *Event) IsSwing() bool {
+	return strings.HasPrefix(event.Type, "SWING_")
+}
+
+func (event *Event) IsCast() bool {
+	return event.Type == "SPELL_CAST_START" || event.Type == "SPELL_CAST_SUCCESS"
+}
+
+// Parses all events in a combat log. Lines that aren't recognized are skipped.
+func Parse(r io.Reader) ([]*Event, error) {
+	var events []*Event
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	var last time.Time
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		event, err := ParseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		if event != nil {
+			event.Timestamp = rollForward(event.Timestamp, last)
+			last = event.Timestamp
+			events = append(events, event)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// Log timestamps have no year, so a log running past new year's eve would go
+// back in time. Move such timestamps forward to keep the log in order, and do
+// the same for a time of day wrapping around without the date changing.
+func rollForward(timestamp time.Time, last time.Time) time.Time {
+	if last.IsZero() {
+		return timestamp
+	}
+	for last.Sub(timestamp) > time.Hour*12 {
+		if timestamp.Month() < last.Month() {
+			timestamp = timestamp.AddDate(1, 0, 0)
+		} else {
+			timestamp = timestamp.AddDate(0, 0, 1)
+		}
+	}
+	return timestamp
+}
+
+// Parses a single combat log line. Returns nil without an error for events
+// which aren't needed to build an encounter.
+func ParseLine(line string) (*Event, error) {
+	timestampStr, body, found := strings.Cut(line, "  ")
+	if !found {
+		return nil, fmt.Errorf("missing timestamp separator")
+	}
+
+	timestamp, err := time.Parse("1/2 15:04:05.000", timestampStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timestamp %q: %w", timestampStr, err)
+	}
+
+	fields := splitFields(body)
+	event := &Event{
+		Timestamp: timestamp,
+		Type:      fields[0],
+	}
+
+	switch event.Type {
+	case "ENCOUNTER_START", "ENCOUNTER_END":
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("too few fields for %s", event.Type)
+		}
+		event.EncounterName = fields[2]
+		event.Success = len(fields) > 5 && fields[5] == "1"
+		return event, nil
+	}
+
+	if !isHandledEvent(event.Type) {
+		return nil, nil
+	}
+
+	// Logs from 4.1 onwards have a raid flags field after each unit's flags.
+	unitLen := 3
+	if len(fields) > 5 && strings.HasPrefix(fields[5], "0x") {
+		unitLen = 4
+	}
+	if len(fields) < 1+2*unitLen {
+		return nil, fmt.Errorf("too few fields for %s", event.Type)
+	}
+
+	event.Source = parseUnit(fields[1 : 1+unitLen])
+	event.Dest = parseUnit(fields[1+unitLen : 1+2*unitLen])
+	rest := fields[1+2*unitLen:]
+
+	if !event.IsSwing() && event.Type != "UNIT_DIED" {
+		if len(rest) < 3 {
+			return nil, fmt.Errorf("too few fields for %s", event.Type)
+		}
+		event.SpellID = int32(parseInt(rest[0]))
+		event.SpellName = rest[1]
+		event.SpellSchool = int32(parseInt(rest[2]))
+		rest = rest[3:]
+	}
+
+	suffixLen := 0
+	if event.IsDamage() {
+		suffixLen = damageSuffixLen
+	} else if strings.HasSuffix(event.Type, "_MISSED") {
+		suffixLen = min(len(rest), 2)
+	}
+
+	// Anything between the prefix and the suffix is advanced logging info.
+	if advanced := rest[:max(len(rest)-suffixLen, 0)]; len(advanced) >= 4 && strings.HasPrefix(advanced[0], "0x") {
+		event.Info = &UnitInfo{
+			GUID:      advanced[0],
+			CurrentHP: parseFloat(advanced[2]),
+			MaxHP:     parseFloat(advanced[3]),
+		}
+	}
+	suffix := rest[max(len(rest)-suffixLen, 0):]
+
+	if event.IsDamage() {
+		if len(suffix) < damageSuffixLen {
+			return nil, fmt.Errorf("too few fields for %s", event.Type)
+		}
+		event.Amount = parseFloat(suffix[0])
+		event.Resisted = parseFloat(suffix[3])
+		event.Blocked = parseFloat(suffix[4])
+		event.Absorbed = parseFloat(suffix[5])
+		event.Critical = suffix[6] == "1"
+	} else if strings.HasSuffix(event.Type, "_MISSED") && len(suffix) > 0 {
+		event.MissType = suffix[0]
+	}
+
+	return event, nil
+}
+
+func isHandledEvent(eventType string) bool {
+	switch eventType {
+	case "UNIT_DIED", "SPELL_CAST_START", "SPELL_CAST_SUCCESS", "SPELL_SUMMON":
+		return true
+	}
+	return strings.HasSuffix(eventType, "_DAMAGE") || strings.HasSuffix(eventType, "_MISSED")
+}
+
+func parseUnit(fields []string) Unit {
+	flags, _ := strconv.ParseUint(strings.TrimPrefix(fields[2], "0x"), 16, 64)
+	return Unit{
+		GUID:  fields[0],
+		Name:  fields[1],
+		Flags: flags,
+	}
+}
+
+func parseInt(field string) int64 {
+	if strings.HasPrefix(field, "0x") {
+		val, _ := strconv.ParseInt(field[2:], 16, 64)
+		return val
+	}
+	val, _ := strconv.ParseInt(field, 10, 64)
+	return val
+}
+
+func parseFloat(field string) float64 {
+	val, err := strconv.ParseFloat(field, 64)
+	if err != nil {
+		return 0
+	}
+	return val
+}
+
+// Splits a comma-separated event body, keeping quoted names intact.
+func splitFields(body string) []string {
+	var fields []string
+	var sb strings.Builder
+	inQuotes := false
+
+	for _, c := range body {
+		switch {
+		case c == '"':
+			inQuotes = !inQuotes
+		case c == ',' && !inQuotes:
+			fields = append(fields, sb.String())
+			sb.Reset()
+		default:
+			sb.WriteRune(c)
+		}
+	}
+	return append(fields, sb.String())
+}
@@ -60,6 +60,93 @@ func StatWeightCompute(request *proto.StatWeightsCalcRequest) *proto.StatWeights
 	return computeStatWeights(request)
 }
 
+/**
+ * Returns the DPS lost by removing each equipped item, enchant, gem and set
+ * bonus, or by disabling only their effects.
+ */
+func ItemAttribution(request *proto.ItemAttributionRequest) *proto.ItemAttributionResult {
+	return runItemAttribution(request, nil, simsignals.CreateSignals())
+}
+
+func ItemAttributionAsync(request *proto.ItemAttributionRequest, progress chan *proto.ProgressMetrics, requestId string) {
+	signals, err := simsignals.RegisterWithId(requestId)
+	if err != nil {
+		progress <- &proto.ProgressMetrics{
+			FinalAttributionResult: &proto.ItemAttributionResult{
+				Error: &proto.ErrorOutcome{
+					Message: "Couldn't register for signal API: " + err.Error(),
+				},
+			},
+		}
+		return
+	}
+	go func() {
+		defer simsignals.UnregisterId(requestId)
+		result := runItemAttribution(request, progress, signals)
+		progress <- &proto.ProgressMetrics{
+			FinalAttributionResult: result,
+		}
+	}()
+}
+
+/**
+ * Returns the change in DPS, HPS and TMI from each buff and debuff of a raid,
+ * ranked by raid DPS.
+ */
+func BuffValues(request *proto.BuffValueRequest) *proto.BuffValueResult {
+	return runBuffValue(request, nil, simsignals.CreateSignals())
+}
+
+func BuffValuesAsync(request *proto.BuffValueRequest, progress chan *proto.ProgressMetrics, requestId string) {
+	signals, err := simsignals.RegisterWithId(requestId)
+	if err != nil {
+		progress <- &proto.ProgressMetrics{
+			FinalBuffValueResult: &proto.BuffValueResult{
+				Error: &proto.ErrorOutcome{
+					Message: "Couldn't register for signal API: " + err.Error(),
+				},
+			},
+		}
+		return
+	}
+	go func() {
+		defer simsignals.UnregisterId(requestId)
+		result := runBuffValue(request, progress, signals)
+		progress <- &proto.ProgressMetrics{
+			FinalBuffValueResult: result,
+		}
+	}()
+}
+
+/**
+ * Returns the DPS of a raid for each combination of fight duration and target
+ * count in a grid.
+ */
+func EncounterSweep(request *proto.EncounterSweepRequest) *proto.EncounterSweepResult {
+	return runEncounterSweep(request, nil, simsignals.CreateSignals())
+}
+
+func EncounterSweepAsync(request *proto.EncounterSweepRequest, progress chan *proto.ProgressMetrics, requestId string) {
+	signals, err := simsignals.RegisterWithId(requestId)
+	if err != nil {
+		progress <- &proto.ProgressMetrics{
+			FinalSweepResult: &proto.EncounterSweepResult{
+				Error: &proto.ErrorOutcome{
+					Message: "Couldn't register for signal API: " + err.Error(),
+				},
+			},
+		}
+		return
+	}
+	go func() {
+		defer simsignals.UnregisterId(requestId)
+		result := runEncounterSweep(request, progress, signals)
+		progress <- &proto.ProgressMetrics{
+			FinalSweepResult: result,
+		}
+	}()
+}
+
 /**
  * Runs multiple iterations of the sim with a full raid.
  */
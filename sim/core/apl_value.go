@@ -100,6 +100,8 @@ func (rot *APLRotation) newAPLValue(config *proto.APLValue) APLValue {
 	// Boss
 	case *proto.APLValue_BossSpellIsCasting:
 		value = rot.newValueBossSpellIsCasting(config.GetBossSpellIsCasting(), config.Uuid)
+	case *proto.APLValue_BossIsCastingInterruptible:
+		value = rot.newValueBossIsCastingInterruptible(config.GetBossIsCastingInterruptible(), config.Uuid)
 	case *proto.APLValue_BossSpellTimeToReady:
 		value = rot.newValueBossSpellTimeToReady(config.GetBossSpellTimeToReady(), config.Uuid)
 
@@ -157,6 +159,12 @@ func (rot *APLRotation) newAPLValue(config *proto.APLValue) APLValue {
 	case *proto.APLValue_UnitIsMoving:
 		value = rot.newValueCharacterIsMoving(config.GetUnitIsMoving(), config.Uuid)
 
+	// Raid health
+	case *proto.APLValue_LowestAllyHealthPercent:
+		value = rot.newValueLowestAllyHealthPercent(config.GetLowestAllyHealthPercent(), config.Uuid)
+	case *proto.APLValue_NumInjuredAllies:
+		value = rot.newValueNumInjuredAllies(config.GetNumInjuredAllies(), config.Uuid)
+
 	// GCD
 	case *proto.APLValue_GcdIsReady:
 		value = rot.newValueGCDIsReady(config.GetGcdIsReady(), config.Uuid)
@@ -31,6 +31,30 @@ func (value *APLValueBossSpellIsCasting) String() string {
 	return fmt.Sprintf("Boss is Casting(%s)", value.spell.ActionID)
 }
 
+type APLValueBossIsCastingInterruptible struct {
+	DefaultAPLValueImpl
+	unit UnitReference
+}
+
+func (rot *APLRotation) newValueBossIsCastingInterruptible(config *proto.APLValueBossIsCastingInterruptible, _ *proto.UUID) APLValue {
+	unit := rot.GetTargetUnit(config.TargetUnit)
+	if unit.Get() == nil {
+		return nil
+	}
+	return &APLValueBossIsCastingInterruptible{
+		unit: unit,
+	}
+}
+func (value *APLValueBossIsCastingInterruptible) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeBool
+}
+func (value *APLValueBossIsCastingInterruptible) GetBool(sim *Simulation) bool {
+	return value.unit.Get().IsCastInterruptible(sim)
+}
+func (value *APLValueBossIsCastingInterruptible) String() string {
+	return "Boss is Casting Interruptible"
+}
+
 type APLValueBossSpellTimeToReady struct {
 	DefaultAPLValueImpl
 	spell *Spell
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+// Allies whose health can be tracked, for picking heal targets.
+func (rot *APLRotation) healableAllies() []*Unit {
+	return FilterSlice(rot.unit.Env.Raid.AllUnits, func(unit *Unit) bool {
+		return unit.HasHealthBar() && unit.MaxHealth() > 0
+	})
+}
+
+type APLValueLowestAllyHealthPercent struct {
+	DefaultAPLValueImpl
+	allies []*Unit
+}
+
+func (rot *APLRotation) newValueLowestAllyHealthPercent(_ *proto.APLValueLowestAllyHealthPercent, uuid *proto.UUID) APLValue {
+	allies := rot.healableAllies()
+	if len(allies) == 0 {
+		rot.ValidationMessageByUUID(uuid, proto.LogLevel_Warning, "No allies use Health")
+		return nil
+	}
+	return &APLValueLowestAllyHealthPercent{
+		allies: allies,
+	}
+}
+func (value *APLValueLowestAllyHealthPercent) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeFloat
+}
+func (value *APLValueLowestAllyHealthPercent) GetFloat(sim *Simulation) float64 {
+	lowest := 1.0
+	for _, ally := range value.allies {
+		if ally.IsActive() {
+			lowest = min(lowest, ally.CurrentHealthPercent())
+		}
+	}
+	return lowest
+}
+func (value *APLValueLowestAllyHealthPercent) String() string {
+	return "Lowest Ally Health %"
+}
+
+type APLValueNumInjuredAllies struct {
+	DefaultAPLValueImpl
+	allies    []*Unit
+	threshold APLValue
+}
+
+func (rot *APLRotation) newValueNumInjuredAllies(config *proto.APLValueNumInjuredAllies, uuid *proto.UUID) APLValue {
+	allies := rot.healableAllies()
+	if len(allies) == 0 {
+		rot.ValidationMessageByUUID(uuid, proto.LogLevel_Warning, "No allies use Health")
+		return nil
+	}
+
+	threshold := rot.coerceTo(rot.newAPLValue(config.HealthThreshold), proto.APLValueType_ValueTypeFloat)
+	if threshold == nil {
+		threshold = rot.newValueConst(&proto.APLValueConst{Val: "100%"}, uuid)
+	}
+
+	return &APLValueNumInjuredAllies{
+		allies:    allies,
+		threshold: threshold,
+	}
+}
+func (value *APLValueNumInjuredAllies) GetInnerValues() []APLValue {
+	return []APLValue{value.threshold}
+}
+func (value *APLValueNumInjuredAllies) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeInt
+}
+func (value *APLValueNumInjuredAllies) GetInt(sim *Simulation) int32 {
+	threshold := value.threshold.GetFloat(sim)
+	numInjured := int32(0)
+	for _, ally := range value.allies {
+		if ally.IsActive() && ally.CurrentHealthPercent() < threshold {
+			numInjured++
+		}
+	}
+	return numInjured
+}
+func (value *APLValueNumInjuredAllies) String() string {
+	return fmt.Sprintf("Num Injured Allies(%s)", value.threshold)
+}
@@ -28,6 +28,10 @@ type OnSpellHit func(aura *Aura, sim *Simulation, spell *Spell, result *SpellRes
 // or anything that comes from the final result of a tick.
 type OnPeriodicDamage func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult)
 
+// OnAbsorbConsumed is called when an absorb on this unit soaks damage, after result.Damage has been
+// reduced. absorbAura is the absorb effect that was (partially) used up by the given amount.
+type OnAbsorbConsumed func(aura *Aura, sim *Simulation, absorbAura *Aura, spell *Spell, result *SpellResult, absorbed float64)
+
 const Inactive = -1
 
 // Aura lifecycle:
@@ -71,6 +75,7 @@ type Aura struct {
 	onHealTakenIndex           int32 // Position of this aura's index in the onHealAuras array.
 	onPeriodicHealDealtIndex   int32 // Position of this aura's index in the onPeriodicHealAuras array.
 	onPeriodicHealTakenIndex   int32 // Position of this aura's index in the onPeriodicHealAuras array.
+	onAbsorbConsumedIndex      int32 // Position of this aura's index in the onAbsorbConsumedAuras array.
 
 	// The number of stacks, or charges, of this aura. If this aura doesn't care
 	// about charges, is just 0.
@@ -97,6 +102,7 @@ type Aura struct {
 	OnHealTaken           OnSpellHit       // Invoked when a heal hits and this unit is the target.
 	OnPeriodicHealDealt   OnPeriodicDamage // Invoked when a hot tick occurs and this unit is the caster.
 	OnPeriodicHealTaken   OnPeriodicDamage // Invoked when a hot tick occurs and this unit is the target.
+	OnAbsorbConsumed      OnAbsorbConsumed // Invoked when an absorb effect on this unit absorbs damage.
 
 	// If non-default, stat bonuses from the OnGain callback of this aura will be
 	// included in Character Stats in the UI.
@@ -198,6 +204,10 @@ func (aura *Aura) SetStacks(sim *Simulation, newStacks int32) {
 	if sim.Log != nil {
 		aura.Unit.Log(sim, "%s stacks: %d --> %d", aura.ActionID, oldStacks, newStacks)
 	}
+	// Stacks removed on deactivation are counted up to when the aura expired.
+	if aura.active {
+		aura.metrics.addStackUptime(oldStacks, sim.CurrentTime)
+	}
 	aura.stacks = newStacks
 	if aura.OnStacksChange != nil {
 		aura.OnStacksChange(aura, sim, oldStacks, newStacks)
@@ -315,6 +325,7 @@ type auraTracker struct {
 	onHealTakenAuras           []*Aura
 	onPeriodicHealDealtAuras   []*Aura
 	onPeriodicHealTakenAuras   []*Aura
+	onAbsorbConsumedAuras      []*Aura
 }
 
 func newAuraTracker() auraTracker {
@@ -383,6 +394,7 @@ func (at *auraTracker) registerAura(unit *Unit, aura Aura) *Aura {
 	newAura.Unit = unit
 	newAura.Icd = aura.Icd
 	newAura.metrics.ID = aura.ActionID
+	newAura.metrics.reset()
 	newAura.activeIndex = Inactive
 	newAura.onApplyEffectsIndex = Inactive
 	newAura.onCastCompleteIndex = Inactive
@@ -394,6 +406,7 @@ func (at *auraTracker) registerAura(unit *Unit, aura Aura) *Aura {
 	newAura.onHealTakenIndex = Inactive
 	newAura.onPeriodicHealDealtIndex = Inactive
 	newAura.onPeriodicHealTakenIndex = Inactive
+	newAura.onAbsorbConsumedIndex = Inactive
 
 	at.auras = append(at.auras, newAura)
 	if newAura.Tag != "" {
@@ -421,6 +434,7 @@ func (unit *Unit) GetOrRegisterAura(aura Aura) *Aura {
 		curAura.OnHealTaken = aura.OnHealTaken
 		curAura.OnPeriodicHealDealt = aura.OnPeriodicHealDealt
 		curAura.OnPeriodicHealTaken = aura.OnPeriodicHealTaken
+		curAura.OnAbsorbConsumed = aura.OnAbsorbConsumed
 		return curAura
 	}
 }
@@ -485,6 +499,7 @@ func (at *auraTracker) reset(sim *Simulation) {
 	at.onHealTakenAuras = at.onHealTakenAuras[:0]
 	at.onPeriodicHealDealtAuras = at.onPeriodicHealDealtAuras[:0]
 	at.onPeriodicHealTakenAuras = at.onPeriodicHealTakenAuras[:0]
+	at.onAbsorbConsumedAuras = at.onAbsorbConsumedAuras[:0]
 
 	for _, resetEffect := range at.resetEffects {
 		resetEffect(sim)
@@ -550,7 +565,9 @@ restart:
 // exists it will be replaced with the new one.
 func (aura *Aura) Activate(sim *Simulation) {
 	aura.metrics.Procs++
+	aura.metrics.addProc(sim.CurrentTime)
 	if aura.IsActive() {
+		aura.metrics.Refreshes++
 		if sim.Log != nil && !aura.ActionID.IsEmptyAction() {
 			aura.Unit.Log(sim, "Aura refreshed: %s", aura.ActionID)
 		}
@@ -562,6 +579,9 @@ func (aura *Aura) Activate(sim *Simulation) {
 		panic("Aura with 0 duration")
 	}
 
+	// Damage taken multiplier before this aura's effects, for tank survival metrics.
+	oldDamageTakenMultiplier := aura.Unit.PseudoStats.DamageTakenMultiplier
+
 	// Activate exclusive effects.
 	// If there is already an active aura stronger than this one, then this one
 	// will be blocked.
@@ -579,6 +599,7 @@ func (aura *Aura) Activate(sim *Simulation) {
 
 	aura.active = true
 	aura.startTime = sim.CurrentTime
+	aura.metrics.lastStacksChange = sim.CurrentTime
 	aura.Refresh(sim)
 
 	if aura.Duration != NeverExpires {
@@ -636,6 +657,11 @@ func (aura *Aura) Activate(sim *Simulation) {
 		aura.Unit.onPeriodicHealTakenAuras = append(aura.Unit.onPeriodicHealTakenAuras, aura)
 	}
 
+	if aura.OnAbsorbConsumed != nil {
+		aura.onAbsorbConsumedIndex = int32(len(aura.Unit.onAbsorbConsumedAuras))
+		aura.Unit.onAbsorbConsumedAuras = append(aura.Unit.onAbsorbConsumedAuras, aura)
+	}
+
 	if sim.Log != nil && !aura.ActionID.IsEmptyAction() {
 		aura.Unit.Log(sim, "Aura gained: %s", aura.ActionID)
 	}
@@ -644,6 +670,14 @@ func (aura *Aura) Activate(sim *Simulation) {
 	if aura.OnGain != nil {
 		aura.OnGain(aura, sim)
 	}
+
+	if survival := aura.Unit.Metrics.survival; survival != nil {
+		survival.onAuraGain(aura, oldDamageTakenMultiplier)
+	}
+
+	if cooldowns := aura.Unit.Metrics.cooldowns; cooldowns != nil {
+		cooldowns.onAuraGain(sim, aura)
+	}
 }
 
 // Remove an aura by its ID
@@ -654,10 +688,18 @@ func (aura *Aura) Deactivate(sim *Simulation) {
 	aura.active = false
 
 	if !aura.ActionID.IsEmptyAction() {
-		if sim.CurrentTime > aura.expires {
-			aura.metrics.Uptime += aura.expires - max(aura.startTime, 0)
-		} else {
-			aura.metrics.Uptime += sim.CurrentTime - max(aura.startTime, 0)
+		end := min(sim.CurrentTime, aura.expires)
+		aura.metrics.Uptime += end - max(aura.startTime, 0)
+		if aura.MaxStacks > 0 {
+			aura.metrics.addStackUptime(aura.stacks, end)
+		}
+
+		if timeline := aura.Unit.Metrics.timeline; timeline != nil {
+			timeline.addAuraUptime(aura, aura.startTime, end)
+		}
+
+		if cooldowns := aura.Unit.Metrics.cooldowns; cooldowns != nil {
+			cooldowns.onAuraExpire(aura, end)
 		}
 	}
 
@@ -772,6 +814,15 @@ func (aura *Aura) Deactivate(sim *Simulation) {
 		aura.onPeriodicHealTakenIndex = Inactive
 	}
 
+	if aura.onAbsorbConsumedIndex != Inactive {
+		removeOnAbsorbConsumed := aura.onAbsorbConsumedIndex
+		aura.Unit.onAbsorbConsumedAuras = removeBySwappingToBack(aura.Unit.onAbsorbConsumedAuras, removeOnAbsorbConsumed)
+		if removeOnAbsorbConsumed < int32(len(aura.Unit.onAbsorbConsumedAuras)) {
+			aura.Unit.onAbsorbConsumedAuras[removeOnAbsorbConsumed].onAbsorbConsumedIndex = removeOnAbsorbConsumed
+		}
+		aura.onAbsorbConsumedIndex = Inactive
+	}
+
 	// don't invoke possible callbacks until the internal state is consistent
 	if aura.stacks != 0 {
 		aura.SetStacks(sim, 0)
@@ -785,6 +836,10 @@ func (aura *Aura) Deactivate(sim *Simulation) {
 	if aura.OnExpire != nil {
 		aura.OnExpire(aura, sim)
 	}
+
+	if survival := aura.Unit.Metrics.survival; survival != nil {
+		survival.onAuraExpire(aura)
+	}
 }
 
 // Constant-time removal from slice by swapping with the last element before removing.
@@ -877,12 +932,36 @@ func (at *auraTracker) OnPeriodicHealTaken(sim *Simulation, spell *Spell, result
 	}
 }
 
+// Invokes the OnAbsorbConsumed event for all tracked Auras.
+func (at *auraTracker) OnAbsorbConsumed(sim *Simulation, absorbAura *Aura, spell *Spell, result *SpellResult, absorbed float64) {
+	for _, aura := range at.onAbsorbConsumedAuras {
+		// this check is to handle a case where auras are deactivated during iteration.
+		if !aura.active {
+			continue
+		}
+		aura.OnAbsorbConsumed(aura, sim, absorbAura, spell, result, absorbed)
+	}
+}
+
 func (at *auraTracker) GetMetricsProto() []*proto.AuraMetrics {
 	metrics := make([]*proto.AuraMetrics, 0, len(at.auras))
 
+	// Procs are often triggered by a separate aura, which shares its ICD with
+	// the aura being procced.
+	icdTriggers := make(map[*Cooldown]*AuraMetrics)
+	for _, aura := range at.auras {
+		if aura.Icd != nil && aura.metrics.triggersSum > 0 {
+			icdTriggers[aura.Icd] = &aura.metrics
+		}
+	}
+
 	for _, aura := range at.auras {
 		if !aura.metrics.ID.IsEmptyAction() {
-			metrics = append(metrics, aura.metrics.ToProto())
+			auraMetrics := aura.metrics.ToProto()
+			if trigger, ok := icdTriggers[aura.Icd]; ok && aura.metrics.triggersSum == 0 {
+				trigger.setTriggerProto(auraMetrics)
+			}
+			metrics = append(metrics, auraMetrics)
 		}
 	}
 
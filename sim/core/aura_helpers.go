@@ -94,11 +94,21 @@ func ApplyProcTriggerCallback(unit *Unit, procAura *Aura, config ProcTrigger) {
 			return
 		}
 		if icd.Duration != 0 && !icd.IsReady(sim) {
+			// The extra condition can have side effects, so blocked events are
+			// only counted for triggers without one.
+			if config.ExtraCondition == nil {
+				procChance := config.ProcChance
+				if dpm != nil {
+					procChance *= dpm.Chance(spell.ProcMask)
+				}
+				aura.metrics.addIcdBlocked(procChance)
+			}
 			return
 		}
 		if config.ExtraCondition != nil && !config.ExtraCondition(sim, spell, result) {
 			return
 		}
+		aura.metrics.Triggers++
 		if config.ProcChance != 1 && sim.RandomFloat(config.Name) > config.ProcChance {
 			return
 		} else if dpm != nil && !dpm.Proc(sim, spell.ProcMask, config.Name) {
@@ -145,8 +155,10 @@ func ApplyProcTriggerCallback(unit *Unit, procAura *Aura, config ProcTrigger) {
 				return
 			}
 			if icd.Duration != 0 && !icd.IsReady(sim) {
+				aura.metrics.addIcdBlocked(config.ProcChance)
 				return
 			}
+			aura.metrics.Triggers++
 			if config.ProcChance != 1 && sim.RandomFloat(config.Name) > config.ProcChance {
 				return
 			}
@@ -172,8 +184,10 @@ func ApplyProcTriggerCallback(unit *Unit, procAura *Aura, config ProcTrigger) {
 				return
 			}
 			if icd.Duration != 0 && !icd.IsReady(sim) {
+				aura.metrics.addIcdBlocked(config.ProcChance)
 				return
 			}
+			aura.metrics.Triggers++
 			if config.ProcChance != 1 && sim.RandomFloat(config.Name) > config.ProcChance {
 				return
 			}
@@ -497,6 +511,7 @@ func CreateDamageAbsorptionAura(character *Character, auraLabel string, actionID
 		}),
 		FreshShieldStrengthCalculator: calculator,
 	}
+	character.absorbEffects = append(character.absorbEffects, aura)
 
 	aura.ApplyOnExpire(func(_ *Aura, _ *Simulation) {
 		aura.ShieldStrength = 0
@@ -513,6 +528,7 @@ func CreateDamageAbsorptionAura(character *Character, auraLabel string, actionID
 			}
 
 			aura.Aura.SetStacks(sim, int32(aura.ShieldStrength))
+			character.absorbConsumed(sim, aura.Aura, spell, result, absorbedDamage)
 		}
 	})
 
@@ -0,0 +1,141 @@
+package core
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestAuraProcMetrics(t *testing.T) {
+	sim := &Simulation{}
+
+	unit := Unit{
+		Type:        EnemyUnit,
+		auraTracker: newAuraTracker(),
+	}
+	aura := unit.RegisterAura(Aura{
+		Label:     "Test",
+		ActionID:  ActionID{SpellID: 1},
+		Duration:  time.Second * 10,
+		MaxStacks: 3,
+	})
+	aura.metrics.reset()
+
+	aura.Activate(sim)
+	aura.SetStacks(sim, 1)
+
+	sim.CurrentTime = time.Second * 2
+	aura.AddStack(sim)
+
+	sim.CurrentTime = time.Second * 3
+	aura.Activate(sim)
+
+	sim.CurrentTime = time.Second * 4
+	aura.AddStack(sim)
+
+	sim.CurrentTime = time.Second * 8
+	aura.Deactivate(sim)
+	aura.metrics.doneIteration()
+
+	metrics := aura.metrics.ToProto()
+	if metrics.ProcsAvg != 2 || metrics.RefreshesAvg != 1 {
+		t.Errorf("Expected 2 procs with 1 refresh, got %0.1f procs with %0.1f refreshes", metrics.ProcsAvg, metrics.RefreshesAvg)
+	}
+	if len(metrics.ProcIntervalHist) != 1 || metrics.ProcIntervalHist[3] != 1 || metrics.ProcIntervalAvg != 3 {
+		t.Errorf("Expected a single 3s proc interval, got %v with average %0.2f", metrics.ProcIntervalHist, metrics.ProcIntervalAvg)
+	}
+	if expected := []float64{0, 2, 2, 4}; !slices.Equal(metrics.StackUptimeSecondsAvg, expected) {
+		t.Errorf("Expected stack uptimes %v, got %v", expected, metrics.StackUptimeSecondsAvg)
+	}
+}
+
+func TestAuraTriggerMetricsSharedIcd(t *testing.T) {
+	sim := &Simulation{}
+
+	unit := Unit{
+		Type:        EnemyUnit,
+		auraTracker: newAuraTracker(),
+	}
+	buffAura := unit.RegisterAura(Aura{
+		Label:    "Buff",
+		ActionID: ActionID{SpellID: 1},
+		Duration: time.Second * 5,
+	})
+	triggerAura := MakeProcTriggerAura(&unit, ProcTrigger{
+		Name:       "Trigger",
+		Callback:   CallbackOnCastComplete,
+		ProcChance: 1,
+		ICD:        time.Second * 10,
+		Handler: func(sim *Simulation, _ *Spell, _ *SpellResult) {
+			buffAura.Activate(sim)
+		},
+	})
+	buffAura.Icd = triggerAura.Icd
+	buffAura.metrics.reset()
+	triggerAura.metrics.reset()
+
+	for _, seconds := range []int{0, 5, 12} {
+		sim.CurrentTime = time.Duration(seconds) * time.Second
+		triggerAura.OnCastComplete(triggerAura, sim, &Spell{})
+	}
+	buffAura.metrics.doneIteration()
+	triggerAura.metrics.doneIteration()
+
+	metrics := unit.GetMetricsProto()
+	if len(metrics) != 1 {
+		t.Fatalf("Expected metrics only for the buff aura, got %d", len(metrics))
+	}
+	if metrics[0].ProcsAvg != 2 || metrics[0].TriggersAvg != 3 || math.Abs(metrics[0].IcdBlockedChance-1.0/3) > 1e-9 {
+		t.Errorf("Expected 2 procs from 3 triggers with 1 blocked by the ICD, got %0.1f procs, %0.1f triggers and %0.2f blocked", metrics[0].ProcsAvg, metrics[0].TriggersAvg, metrics[0].IcdBlockedChance)
+	}
+}
+
+func TestAuraTriggerMetricsEligibleEvents(t *testing.T) {
+	sim := &Simulation{}
+
+	unit := Unit{
+		Type:        EnemyUnit,
+		auraTracker: newAuraTracker(),
+	}
+	chanceAura := MakeProcTriggerAura(&unit, ProcTrigger{
+		Name:       "Chance Trigger",
+		Callback:   CallbackOnSpellHitDealt,
+		ProcChance: 0.25,
+		ICD:        time.Second * 10,
+		Handler:    func(_ *Simulation, _ *Spell, _ *SpellResult) {},
+	})
+	conditionAura := MakeProcTriggerAura(&unit, ProcTrigger{
+		Name:     "Condition Trigger",
+		Callback: CallbackOnSpellHitDealt,
+		ExtraCondition: func(_ *Simulation, _ *Spell, _ *SpellResult) bool {
+			return false
+		},
+		Handler: func(_ *Simulation, _ *Spell, _ *SpellResult) {
+			t.Fatalf("Trigger with a failing condition should not proc")
+		},
+	})
+	chanceAura.metrics.reset()
+	conditionAura.metrics.reset()
+
+	// Only events blocked by the ICD are sent, so no proc rolls are needed.
+	chanceAura.Icd.Use(sim)
+	for i := 0; i < 4; i++ {
+		chanceAura.OnSpellHitDealt(chanceAura, sim, &Spell{}, &SpellResult{})
+		conditionAura.OnSpellHitDealt(conditionAura, sim, &Spell{}, &SpellResult{})
+	}
+	chanceAura.metrics.doneIteration()
+	conditionAura.metrics.doneIteration()
+
+	chanceMetrics := chanceAura.metrics.ToProto()
+	chanceAura.metrics.setTriggerProto(chanceMetrics)
+	if chanceMetrics.TriggersAvg != 4 || chanceMetrics.IcdBlockedChance != 0.25 {
+		t.Errorf("Expected 4 triggers with 25%% of them blocked procs, got %0.1f triggers and %0.2f blocked", chanceMetrics.TriggersAvg, chanceMetrics.IcdBlockedChance)
+	}
+
+	conditionMetrics := conditionAura.metrics.ToProto()
+	conditionAura.metrics.setTriggerProto(conditionMetrics)
+	if conditionMetrics.TriggersAvg != 0 {
+		t.Errorf("Expected no triggers for events failing the extra condition, got %0.1f", conditionMetrics.TriggersAvg)
+	}
+}
@@ -0,0 +1,274 @@
+package core
+
+import (
+	"cmp"
+	"slices"
+
+	googleProto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/simsignals"
+)
+
+// A buff or debuff being valued, with the sim needed to value it.
+type buffToggle struct {
+	value *proto.BuffValue
+
+	// Finds the message holding the buff field in a raid.
+	locate       func(raid *proto.Raid) protoreflect.Message
+	field        protoreflect.FieldDescriptor
+	enabledValue protoreflect.Value
+
+	// Sim with the buff toggled from its state in the request.
+	request *proto.RaidSimRequest
+
+	// Party and raid index of the player it applies to, or -1 for all of them.
+	partyIndex int32
+	raidIndex  int32
+}
+
+func (toggle *buffToggle) overlaps(other *buffToggle) bool {
+	return (toggle.partyIndex < 0 || other.partyIndex < 0 || toggle.partyIndex == other.partyIndex) &&
+		(toggle.raidIndex < 0 || other.raidIndex < 0 || toggle.raidIndex == other.raidIndex)
+}
+
+// Makes sure every buff message in the raid is set, so buffs can be toggled in it.
+func fillBuffMessages(raid *proto.Raid) {
+	if raid.Buffs == nil {
+		raid.Buffs = &proto.RaidBuffs{}
+	}
+	if raid.Debuffs == nil {
+		raid.Debuffs = &proto.Debuffs{}
+	}
+	for _, party := range raid.Parties {
+		if party.Buffs == nil {
+			party.Buffs = &proto.PartyBuffs{}
+		}
+		for _, player := range party.Players {
+			if player.Buffs == nil {
+				player.Buffs = &proto.IndividualBuffs{}
+			}
+		}
+	}
+}
+
+// Returns the value which toggles a buff field, and whether it is currently
+// enabled. Counts and effect levels are added as 1. Returns false for ok if the
+// field isn't a buff.
+func toggledBuffValue(msg protoreflect.Message, fd protoreflect.FieldDescriptor) (value protoreflect.Value, enabled bool, ok bool) {
+	if fd.Options().(*descriptorpb.FieldOptions).GetDeprecated() {
+		return protoreflect.Value{}, false, false
+	}
+
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		enabled = msg.Get(fd).Bool()
+		return protoreflect.ValueOfBool(!enabled), enabled, true
+	case protoreflect.Int32Kind:
+		enabled = msg.Get(fd).Int() != 0
+		return protoreflect.ValueOfInt32(int32(Ternary(enabled, 0, 1))), enabled, true
+	case protoreflect.EnumKind:
+		enabled = msg.Get(fd).Enum() != 0
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(Ternary(enabled, 0, 1))), enabled, true
+	default:
+		return protoreflect.Value{}, false, false
+	}
+}
+
+func buildBuffValueToggles(baseRequest *proto.RaidSimRequest) []*buffToggle {
+	var toggles []*buffToggle
+
+	addToggles := func(source proto.BuffValue_Source, locate func(raid *proto.Raid) protoreflect.Message, partyIndex int32, raidIndex int32) {
+		msg := locate(baseRequest.Raid)
+		fields := msg.Descriptor().Fields()
+		for i := 0; i < fields.Len(); i++ {
+			fd := fields.Get(i)
+			toggledValue, enabled, ok := toggledBuffValue(msg, fd)
+			if !ok {
+				continue
+			}
+
+			value := &proto.BuffValue{
+				Source:  source,
+				Name:    string(fd.Name()),
+				Enabled: enabled,
+			}
+			if source == proto.BuffValue_PartyBuff {
+				value.PartyIndex = partyIndex
+			}
+			if source == proto.BuffValue_IndividualBuff {
+				value.Player = &proto.UnitReference{Type: proto.UnitReference_Player, Index: raidIndex}
+			}
+
+			request := googleProto.Clone(baseRequest).(*proto.RaidSimRequest)
+			locate(request.Raid).Set(fd, toggledValue)
+
+			toggles = append(toggles, &buffToggle{
+				value:        value,
+				locate:       locate,
+				field:        fd,
+				enabledValue: Ternary(enabled, msg.Get(fd), toggledValue),
+				request:      request,
+				partyIndex:   partyIndex,
+				raidIndex:    raidIndex,
+			})
+		}
+	}
+
+	addToggles(proto.BuffValue_RaidBuff, func(raid *proto.Raid) protoreflect.Message {
+		return raid.Buffs.ProtoReflect()
+	}, -1, -1)
+	addToggles(proto.BuffValue_Debuff, func(raid *proto.Raid) protoreflect.Message {
+		return raid.Debuffs.ProtoReflect()
+	}, -1, -1)
+
+	numParties := int(baseRequest.Raid.NumActiveParties)
+	if numParties == 0 {
+		numParties = len(baseRequest.Raid.Parties)
+	}
+	for partyIndex, party := range baseRequest.Raid.Parties[:min(numParties, len(baseRequest.Raid.Parties))] {
+		addToggles(proto.BuffValue_PartyBuff, func(raid *proto.Raid) protoreflect.Message {
+			return raid.Parties[partyIndex].Buffs.ProtoReflect()
+		}, int32(partyIndex), -1)
+
+		for playerIndex, player := range party.Players {
+			if player.Class == proto.Class_ClassUnknown {
+				continue
+			}
+			addToggles(proto.BuffValue_IndividualBuff, func(raid *proto.Raid) protoreflect.Message {
+				return raid.Parties[partyIndex].Players[playerIndex].Buffs.ProtoReflect()
+			}, int32(partyIndex), int32(partyIndex*5+playerIndex))
+		}
+	}
+
+	return toggles
+}
+
+// Returns the number of exclusive effects in each category, over all units.
+func exclusiveEffectCounts(raid *proto.Raid, encounter *proto.Encounter) map[string]int {
+	env, _, _ := NewEnvironment(raid, encounter, false)
+
+	counts := make(map[string]int)
+	for _, unit := range env.AllUnits {
+		for _, category := range unit.ExclusiveEffectManager.categories {
+			counts[category.Name] += len(category.effects)
+		}
+	}
+	return counts
+}
+
+// Finds the exclusive categories of each buff, from the exclusive effects it
+// adds to a raid with no other buffs. Individual buffs are the same for each
+// player, so are only checked once.
+func findBuffExclusiveCategories(baseRequest *proto.RaidSimRequest, toggles []*buffToggle) {
+	unbuffedRaid := googleProto.Clone(baseRequest.Raid).(*proto.Raid)
+	unbuffedRaid.Buffs = nil
+	unbuffedRaid.Debuffs = nil
+	for _, party := range unbuffedRaid.Parties {
+		party.Buffs = nil
+		for _, player := range party.Players {
+			player.Buffs = nil
+		}
+	}
+	fillBuffMessages(unbuffedRaid)
+	unbuffedCounts := exclusiveEffectCounts(googleProto.Clone(unbuffedRaid).(*proto.Raid), baseRequest.Encounter)
+
+	individualCategories := make(map[string][]string)
+	for _, toggle := range toggles {
+		if categories, ok := individualCategories[toggle.value.Name]; ok && toggle.value.Source == proto.BuffValue_IndividualBuff {
+			toggle.value.ExclusiveCategories = categories
+			continue
+		}
+
+		buffedRaid := googleProto.Clone(unbuffedRaid).(*proto.Raid)
+		toggle.locate(buffedRaid).Set(toggle.field, toggle.enabledValue)
+		for name, count := range exclusiveEffectCounts(buffedRaid, baseRequest.Encounter) {
+			if count > unbuffedCounts[name] {
+				toggle.value.ExclusiveCategories = append(toggle.value.ExclusiveCategories, name)
+			}
+		}
+		slices.Sort(toggle.value.ExclusiveCategories)
+
+		if toggle.value.Source == proto.BuffValue_IndividualBuff {
+			individualCategories[toggle.value.Name] = toggle.value.ExclusiveCategories
+		}
+	}
+
+	for _, toggle := range toggles {
+		for _, other := range toggles {
+			if other == toggle || !other.value.Enabled || !toggle.overlaps(other) {
+				continue
+			}
+			if slices.ContainsFunc(toggle.value.ExclusiveCategories, func(category string) bool {
+				return slices.Contains(other.value.ExclusiveCategories, category)
+			}) {
+				toggle.value.ExclusiveWith = append(toggle.value.ExclusiveWith, other.value.Name)
+			}
+		}
+	}
+}
+
+// Returns the change in a metric from having a buff enabled, given the values
+// with and without it in the request.
+func buffValueDifference(enabled bool, baselineValues []float64, toggledValues []float64) (float64, float64) {
+	if enabled {
+		return pairedDifference(baselineValues, toggledValues)
+	}
+	return pairedDifference(toggledValues, baselineValues)
+}
+
+func computeBuffValue(toggle *buffToggle, baselineResult *proto.RaidSimResult, toggledResult *proto.RaidSimResult) {
+	value := toggle.value
+	value.RaidDps, value.RaidDpsStdev = buffValueDifference(value.Enabled, baselineResult.RaidMetrics.Dps.AllValues, toggledResult.RaidMetrics.Dps.AllValues)
+	value.RaidHps, value.RaidHpsStdev = buffValueDifference(value.Enabled, baselineResult.RaidMetrics.Hps.AllValues, toggledResult.RaidMetrics.Hps.AllValues)
+
+	for partyIndex, party := range baselineResult.RaidMetrics.Parties {
+		for playerIndex, baselinePlayer := range party.Players {
+			if baselinePlayer.Dps == nil {
+				continue
+			}
+			toggledPlayer := toggledResult.RaidMetrics.Parties[partyIndex].Players[playerIndex]
+
+			unitValue := &proto.UnitBuffValue{
+				Unit: &proto.UnitReference{Type: proto.UnitReference_Player, Index: int32(partyIndex*5 + playerIndex)},
+			}
+			unitValue.Dps, unitValue.DpsStdev = buffValueDifference(value.Enabled, baselinePlayer.Dps.AllValues, toggledPlayer.Dps.AllValues)
+			unitValue.Hps, unitValue.HpsStdev = buffValueDifference(value.Enabled, baselinePlayer.Hps.AllValues, toggledPlayer.Hps.AllValues)
+			unitValue.Tmi, unitValue.TmiStdev = buffValueDifference(value.Enabled, baselinePlayer.Tmi.AllValues, toggledPlayer.Tmi.AllValues)
+			value.Units = append(value.Units, unitValue)
+		}
+	}
+}
+
+// Run buff value sims and rank the buffs by raid DPS.
+func runBuffValue(request *proto.BuffValueRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals) *proto.BuffValueResult {
+	baseRequest := googleProto.Clone(request.Request).(*proto.RaidSimRequest)
+	fillBuffMessages(baseRequest.Raid)
+	preparePairedSimOptions(baseRequest.SimOptions)
+
+	toggles := buildBuffValueToggles(baseRequest)
+	findBuffExclusiveCategories(baseRequest, toggles)
+
+	requests := make([]*proto.RaidSimRequest, len(toggles))
+	for i, toggle := range toggles {
+		requests[i] = toggle.request
+	}
+
+	baselineResult, results, errorOutcome := runPairedSims(baseRequest, requests, progress, signals)
+	if errorOutcome != nil {
+		return &proto.BuffValueResult{Error: errorOutcome}
+	}
+
+	result := &proto.BuffValueResult{}
+	for i, toggle := range toggles {
+		computeBuffValue(toggle, baselineResult, results[i])
+		result.Values = append(result.Values, toggle.value)
+	}
+
+	slices.SortStableFunc(result.Values, func(a, b *proto.BuffValue) int {
+		return cmp.Compare(b.RaidDps, a.RaidDps)
+	})
+	return result
+}
@@ -0,0 +1,72 @@
+package core
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func buffValueTestRequest() *proto.RaidSimRequest {
+	request := newTestRaidSimRequest(newTestPlayer("Player"))
+	request.SimOptions.Iterations = 10
+	request.Raid.Buffs.MarkOfTheWild = true
+	fillBuffMessages(request.Raid)
+	return request
+}
+
+func findBuffToggle(toggles []*buffToggle, source proto.BuffValue_Source, name string) *buffToggle {
+	for _, toggle := range toggles {
+		if toggle.value.Source == source && toggle.value.Name == name {
+			return toggle
+		}
+	}
+	return nil
+}
+
+func TestBuildBuffValueToggles(t *testing.T) {
+	toggles := buildBuffValueToggles(buffValueTestRequest())
+
+	motw := findBuffToggle(toggles, proto.BuffValue_RaidBuff, "mark_of_the_wild")
+	if motw == nil || !motw.value.Enabled || motw.request.Raid.Buffs.MarkOfTheWild {
+		t.Errorf("Expected mark_of_the_wild to be enabled and removed in its request")
+	}
+
+	kings := findBuffToggle(toggles, proto.BuffValue_RaidBuff, "blessing_of_kings")
+	if kings == nil || kings.value.Enabled || !kings.request.Raid.Buffs.BlessingOfKings || !kings.request.Raid.Buffs.MarkOfTheWild {
+		t.Errorf("Expected blessing_of_kings to be disabled and added in its request")
+	}
+
+	// Only the first party and player are in use.
+	for _, toggle := range toggles {
+		if toggle.partyIndex > 0 || toggle.raidIndex > 0 {
+			t.Errorf("Expected no toggles for empty parties or players, got %s for party %d player %d", toggle.value.Name, toggle.partyIndex, toggle.raidIndex)
+		}
+	}
+	if findBuffToggle(toggles, proto.BuffValue_IndividualBuff, "innervate_count") == nil {
+		t.Errorf("Expected individual buff toggles for the player")
+	}
+	if findBuffToggle(toggles, proto.BuffValue_Debuff, "curse_of_elements") == nil {
+		t.Errorf("Expected debuff toggles")
+	}
+}
+
+func TestBuffExclusiveCategories(t *testing.T) {
+	request := buffValueTestRequest()
+	toggles := buildBuffValueToggles(request)
+	findBuffExclusiveCategories(request, toggles)
+
+	kings := findBuffToggle(toggles, proto.BuffValue_RaidBuff, "blessing_of_kings")
+	if len(kings.value.ExclusiveCategories) == 0 {
+		t.Fatalf("Expected blessing_of_kings to have exclusive categories")
+	}
+	if !slices.Equal(kings.value.ExclusiveWith, []string{"mark_of_the_wild"}) {
+		t.Errorf("Expected blessing_of_kings to be exclusive with mark_of_the_wild, got %v", kings.value.ExclusiveWith)
+	}
+
+	// Disabled buffs aren't reported as overlapping.
+	motw := findBuffToggle(toggles, proto.BuffValue_RaidBuff, "mark_of_the_wild")
+	if slices.Contains(motw.value.ExclusiveWith, "blessing_of_kings") {
+		t.Errorf("Expected mark_of_the_wild not to list the disabled blessing_of_kings, got %v", motw.value.ExclusiveWith)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"math"
 	"runtime"
 	"runtime/debug"
+	"slices"
 	"sort"
 	"strings"
 	"sync/atomic"
@@ -180,6 +181,14 @@ func (b *bulkSimRunner) Run(signals simsignals.Signals, progress chan *proto.Pro
 			break
 		}
 
+		// With a target error, combos which stopped before the iteration cap are
+		// already accurate enough, so more iterations won't change the ranking.
+		if b.Request.BaseSettings.SimOptions.GetTargetError() > 0 && !slices.ContainsFunc(rankedResults, func(r *itemSubstitutionSimResult) bool {
+			return r.Result.IterationsDone >= newIters
+		}) {
+			break
+		}
+
 		// Increase accuracy
 		newIters *= 2
 		newNumCombos := len(rankedResults) / 2
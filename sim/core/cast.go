@@ -15,11 +15,20 @@ import (
 type OnCastComplete func(aura *Aura, sim *Simulation, spell *Spell)
 
 type Hardcast struct {
-	Expires    time.Duration
-	ActionID   ActionID
-	OnComplete func(*Simulation, *Unit)
-	Target     *Unit
-	CanMove    bool
+	Expires     time.Duration
+	ActionID    ActionID
+	SpellSchool SpellSchool
+	OnComplete  func(*Simulation, *Unit)
+	Target      *Unit
+	CanMove     bool
+
+	// Whether this cast can be stopped by kick-type abilities and pushed back
+	// by damage taken. Loss of control effects (stuns, silences) interrupt
+	// casts regardless of this.
+	Interruptible bool
+
+	gcdExpires time.Duration // When the GCD triggered by this cast ends, used to restore it on interrupt.
+	pushbacks  int32         // Number of times this cast has been pushed back by damage taken.
 }
 
 // Input for constructing the CastSpell function for a spell.
@@ -102,6 +111,10 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 			return spell.castFailureHelper(sim, "spell attached to an un-equipped item")
 		}
 
+		if reason := spell.lossOfControlReason(sim); reason != "" {
+			return spell.castFailureHelper(sim, reason)
+		}
+
 		if spell.ExtraCastCondition != nil {
 			if !spell.ExtraCastCondition(sim, target) {
 				return spell.castFailureHelper(sim, "extra spell condition")
@@ -165,8 +178,9 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 			}
 
 			spell.Unit.Hardcast = Hardcast{
-				Expires:  sim.CurrentTime + spell.CurCast.CastTime,
-				ActionID: spell.ActionID,
+				Expires:     sim.CurrentTime + spell.CurCast.CastTime,
+				ActionID:    spell.ActionID,
+				SpellSchool: spell.SpellSchool,
 				OnComplete: func(sim *Simulation, target *Unit) {
 					if sim.Log != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
 						spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
@@ -190,8 +204,10 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 						spell.Unit.OnCastComplete(sim, spell)
 					}
 				},
-				Target:  target,
-				CanMove: spell.Flags&SpellFlagCanCastWhileMoving > 0,
+				Target:        target,
+				CanMove:       spell.Flags&SpellFlagCanCastWhileMoving > 0,
+				Interruptible: spell.Unit.Type != EnemyUnit || spell.Flags.Matches(SpellFlagInterruptible),
+				gcdExpires:    sim.CurrentTime + (&Cast{GCD: spell.CurCast.GCD, GCDMin: spell.CurCast.GCDMin}).EffectiveTime(),
 			}
 
 			spell.Unit.newHardcastAction(sim)
@@ -232,6 +248,10 @@ func (spell *Spell) makeCastFuncSimple() CastSuccessFunc {
 			return spell.castFailureHelper(sim, "spell attached to an un-equipped item")
 		}
 
+		if reason := spell.lossOfControlReason(sim); reason != "" {
+			return spell.castFailureHelper(sim, reason)
+		}
+
 		if spell.ExtraCastCondition != nil {
 			if !spell.ExtraCastCondition(sim, target) {
 				return spell.castFailureHelper(sim, "extra spell condition")
@@ -50,6 +50,9 @@ type Character struct {
 	//Item Swap Handler
 	ItemSwap ItemSwap
 
+	// Equipped effects which are not applied, for item attribution.
+	disabledEffects *proto.DisabledEffects
+
 	// Consumables this Character will be using.
 	Consumes *proto.Consumes
 
@@ -117,7 +120,8 @@ func NewCharacter(party *Party, partyIndex int, player *proto.Player) Character
 		Class: player.Class,
 		Spec:  PlayerProtoToSpec(player),
 
-		Equipment: ProtoToEquipment(player.Equipment),
+		Equipment:       ProtoToEquipment(player.Equipment),
+		disabledEffects: player.DisabledEffects,
 
 		professions: [2]proto.Profession{
 			player.Profession1,
@@ -356,10 +360,15 @@ func (character *Character) applyItemEffects(agent Agent) {
 	registeredItemEffects := make(map[int32]bool)
 	registeredItemEnchantEffects := make(map[int32]bool)
 
-	character.Equipment.applyItemEffects(agent, registeredItemEffects, registeredItemEnchantEffects, true)
+	// Disabled effects are treated as already registered, so they are never applied.
+	for _, id := range character.disabledEffects.GetItemIds() {
+		registeredItemEffects[id] = true
+	}
+
+	character.Equipment.applyItemEffects(agent, registeredItemEffects, registeredItemEnchantEffects, true, character.disabledEffects)
 
 	if character.ItemSwap.IsEnabled() {
-		character.ItemSwap.unEquippedItems.applyItemEffects(agent, registeredItemEffects, registeredItemEnchantEffects, false)
+		character.ItemSwap.unEquippedItems.applyItemEffects(agent, registeredItemEffects, registeredItemEnchantEffects, false, nil)
 	}
 }
 
@@ -618,7 +627,7 @@ func (character *Character) getDynamicProcMaskPointer(procMaskFn func() ProcMask
 func (character *Character) getCurrentProcMaskForWeaponEnchant(effectID int32) ProcMask {
 	return character.getCurrentProcMaskFor(func(weapon *Item) bool {
 		return weapon.Enchant.EffectID == effectID
-	})
+	}) &^ character.disabledProcMask((*proto.DisabledEffects).GetEnchantSlots)
 }
 
 func (character *Character) GetDynamicProcMaskForWeaponEffect(itemID int32) *ProcMask {
@@ -630,7 +639,26 @@ func (character *Character) GetDynamicProcMaskForWeaponEffect(itemID int32) *Pro
 func (character *Character) getCurrentProcMaskForWeaponEffect(itemID int32) ProcMask {
 	return character.getCurrentProcMaskFor(func(weapon *Item) bool {
 		return weapon.ID == itemID
-	})
+	}) &^ character.disabledProcMask((*proto.DisabledEffects).GetItemSlots)
+}
+
+// Weapons whose effects are disabled shouldn't proc them, even if the other
+// weapon has the same effect.
+func (character *Character) disabledProcMask(getSlots func(*proto.DisabledEffects) []proto.ItemSlot) ProcMask {
+	mask := ProcMaskUnknown
+
+	if character == nil {
+		return mask
+	}
+
+	disabledSlots := getSlots(character.disabledEffects)
+	if slices.Contains(disabledSlots, proto.ItemSlot_ItemSlotMainHand) {
+		mask |= ProcMaskMeleeMH
+	}
+	if slices.Contains(disabledSlots, proto.ItemSlot_ItemSlotOffHand) {
+		mask |= ProcMaskMeleeOH
+	}
+	return mask
 }
 
 func (character *Character) GetProcMaskForTypes(weaponTypes ...proto.WeaponType) ProcMask {
@@ -0,0 +1,95 @@
+package core
+
+import (
+	"math"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+// Minimum iterations before target_error can stop a sim, so a few similar
+// early iterations can't end it prematurely.
+const MinConvergenceIterations = 100
+
+// Standard error of the mean of the aggregated values.
+func (x *aggregator) standardError() float64 {
+	if x.n < 2 {
+		return math.Inf(1)
+	}
+	_, stdDev := x.meanAndStdDev()
+	return stdDev / math.Sqrt(float64(x.n))
+}
+
+func (x *aggregator) hasConverged(targetError float64) bool {
+	return x.n >= MinConvergenceIterations && x.standardError() <= targetError
+}
+
+// Returns the aggregated values of the metric used for target_error. For TMI
+// this is the first tank, since TMI isn't meaningful summed over the raid.
+func (sim *Simulation) convergenceAggregator() *aggregator {
+	switch sim.Options.TargetErrorMetric {
+	case proto.ConvergenceMetric_ConvergenceMetricHps:
+		return &sim.Raid.hpsMetrics.aggregator
+	case proto.ConvergenceMetric_ConvergenceMetricTmi:
+		for _, unit := range sim.Raid.AllPlayerUnits {
+			if unit.Metrics.IsTanking() {
+				return &unit.Metrics.tmi.aggregator
+			}
+		}
+		return nil
+	default:
+		return &sim.Raid.dpsMetrics.aggregator
+	}
+}
+
+func (sim *Simulation) hasConverged() bool {
+	if sim.Options.TargetError <= 0 {
+		return false
+	}
+	agg := sim.convergenceAggregator()
+	return agg != nil && agg.hasConverged(sim.Options.TargetError)
+}
+
+func (sim *Simulation) convergenceProgress() *proto.ConvergenceProgress {
+	if sim.Options.TargetError <= 0 {
+		return nil
+	}
+	agg := sim.convergenceAggregator()
+	if agg == nil {
+		return nil
+	}
+	return &proto.ConvergenceProgress{
+		N:     int32(agg.n),
+		Sum:   agg.sum,
+		SumSq: agg.sumSq,
+	}
+}
+
+// Combines convergence progress from concurrent sims, to decide when they
+// have reached the target error together.
+type concurrentConvergence struct {
+	targetError float64
+	splits      []aggregator
+}
+
+func newConcurrentConvergence(targetError float64, numSplits int) *concurrentConvergence {
+	return &concurrentConvergence{
+		targetError: targetError,
+		splits:      make([]aggregator, numSplits),
+	}
+}
+
+// Records progress from one of the sims, and returns whether all of them
+// combined have converged.
+func (cc *concurrentConvergence) update(idx int, progress *proto.ConvergenceProgress) bool {
+	if cc.targetError <= 0 || progress == nil {
+		return false
+	}
+
+	cc.splits[idx] = aggregator{n: int(progress.N), sum: progress.Sum, sumSq: progress.SumSq}
+
+	combined := &aggregator{}
+	for i := range cc.splits {
+		combined = combined.merge(&cc.splits[i])
+	}
+	return combined.hasConverged(cc.targetError)
+}
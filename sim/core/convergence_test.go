@@ -0,0 +1,50 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func TestConvergenceStandardError(t *testing.T) {
+	var agg aggregator
+	for i := 0; i < 100; i++ {
+		agg.add(float64(i % 2))
+	}
+
+	// Values alternate between 0 and 1, so the standard deviation is 0.5.
+	if actual := agg.standardError(); math.Abs(actual-0.05) > 1e-9 {
+		t.Errorf("Expected a standard error of 0.05, got %0.4f", actual)
+	}
+	if !agg.hasConverged(0.05) || agg.hasConverged(0.04) {
+		t.Errorf("Expected convergence at exactly the standard error")
+	}
+}
+
+func TestConcurrentConvergence(t *testing.T) {
+	cc := newConcurrentConvergence(0.06, 2)
+
+	// Each split alone has a standard error of 0.5/sqrt(60), but combined it is 0.5/sqrt(120).
+	progress := &proto.ConvergenceProgress{N: 60, Sum: 30, SumSq: 30}
+	if cc.update(0, progress) {
+		t.Errorf("Expected a single split not to have converged")
+	}
+	if !cc.update(1, progress) {
+		t.Errorf("Expected both splits combined to have converged")
+	}
+}
+
+func TestTargetErrorStopsEarly(t *testing.T) {
+	sim := setupSurvivalSim()
+	sim.Options.Iterations = 1000
+	sim.Options.TargetError = math.MaxFloat64
+
+	result := sim.run()
+	if result.IterationsDone != MinConvergenceIterations {
+		t.Errorf("Expected the sim to stop after %d iterations, got %d", MinConvergenceIterations, result.IterationsDone)
+	}
+	if n := result.RaidMetrics.Dps.AggregatorData.N; n != result.IterationsDone {
+		t.Errorf("Expected metrics for %d iterations, got %d", result.IterationsDone, n)
+	}
+}
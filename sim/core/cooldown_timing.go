@@ -0,0 +1,270 @@
+package core
+
+import (
+	"math"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+type cooldownInterval struct {
+	start time.Duration
+	end   time.Duration
+}
+
+// Returns the total time within [0, fightEnd) during which both a and b are active.
+func intervalOverlap(a []cooldownInterval, b []cooldownInterval, fightEnd time.Duration) time.Duration {
+	var total time.Duration
+	for _, x := range a {
+		for _, y := range b {
+			start := max(x.start, y.start, 0)
+			end := min(x.end, y.end, fightEnd)
+			if end > start {
+				total += end - start
+			}
+		}
+	}
+	return total
+}
+
+func intervalDuration(intervals []cooldownInterval, fightEnd time.Duration) time.Duration {
+	var total time.Duration
+	for _, interval := range intervals {
+		if end, start := min(interval.end, fightEnd), max(interval.start, 0); end > start {
+			total += end - start
+		}
+	}
+	return total
+}
+
+// Timing of a single major cooldown.
+type cooldownTiming struct {
+	spell *Spell
+
+	// Buff aura used for window metrics, or nil if the cooldown has none.
+	aura *Aura
+
+	// Values for the current iteration.
+	windows             []cooldownInterval
+	windowDamage        float64
+	windowStartedDamage float64
+
+	// Aggregate values. These are updated after each activation or iteration.
+	activations          int32
+	activationTimeHist   map[int32]int32
+	readyDelaySum        float64
+	bloodlustActivations int32
+	windowSeconds        float64
+	bloodlustSeconds     float64
+	overlapSeconds       []float64
+	windowDamageSum      float64
+	outsideDamageSum     float64
+	outsideSeconds       float64
+}
+
+// Activation timing of a character's major cooldowns, along with the overlap
+// and damage of their buff windows.
+type cooldownTimingMetrics struct {
+	unit *Unit
+
+	// For pets, whose damage also counts towards their owner's DPS.
+	owner *cooldownTimingMetrics
+
+	cooldowns []*cooldownTiming
+	bySpell   map[*Spell]*cooldownTiming
+	byAura    map[*Aura]*cooldownTiming
+
+	bloodlustAuras   map[*Aura]bool
+	bloodlustActive  int
+	bloodlustStart   time.Duration
+	bloodlustWindows []cooldownInterval
+
+	// Damage dealt so far in the current iteration.
+	damage float64
+}
+
+// Finds the aura which is active while a cooldown is in effect.
+func (mcd *MajorCooldown) windowAura(character *Character) *Aura {
+	if mcd.BuffAura != nil {
+		return mcd.BuffAura.Aura
+	}
+	if mcd.Spell.RelatedSelfBuff != nil {
+		return mcd.Spell.RelatedSelfBuff
+	}
+	return character.GetAuraByID(mcd.Spell.ActionID)
+}
+
+func (env *Environment) enableCooldownTimingMetrics() {
+	for _, party := range env.Raid.Parties {
+		for _, player := range party.Players {
+			player.GetCharacter().trackCooldownTimings()
+		}
+	}
+}
+
+func (character *Character) trackCooldownTimings() {
+	if len(character.initialMajorCooldowns) == 0 {
+		return
+	}
+
+	cm := &cooldownTimingMetrics{
+		unit:           &character.Unit,
+		bySpell:        make(map[*Spell]*cooldownTiming),
+		byAura:         make(map[*Aura]*cooldownTiming),
+		bloodlustAuras: make(map[*Aura]bool),
+	}
+
+	for i := range character.initialMajorCooldowns {
+		mcd := &character.initialMajorCooldowns[i]
+		ct := &cooldownTiming{
+			spell:              mcd.Spell,
+			activationTimeHist: make(map[int32]int32),
+		}
+		if aura := mcd.windowAura(character); aura != nil && cm.byAura[aura] == nil {
+			ct.aura = aura
+			cm.byAura[aura] = ct
+		}
+		cm.cooldowns = append(cm.cooldowns, ct)
+		cm.bySpell[mcd.Spell] = ct
+	}
+	for _, ct := range cm.cooldowns {
+		ct.overlapSeconds = make([]float64, len(cm.cooldowns))
+	}
+
+	for _, aura := range character.GetAurasWithTag(BloodlustAuraTag) {
+		cm.bloodlustAuras[aura] = true
+	}
+
+	character.Metrics.cooldowns = cm
+	for _, pet := range character.Pets {
+		pet.Metrics.cooldowns = &cooldownTimingMetrics{
+			unit:  &pet.Unit,
+			owner: cm,
+		}
+	}
+}
+
+func (cm *cooldownTimingMetrics) onCast(sim *Simulation, spell *Spell, readyAt time.Duration) {
+	ct := cm.bySpell[spell]
+	if ct == nil {
+		return
+	}
+
+	ct.activations++
+	ct.activationTimeHist[int32(math.Floor(sim.CurrentTime.Seconds()))]++
+	ct.readyDelaySum += max(sim.CurrentTime-max(readyAt, 0), 0).Seconds()
+	if cm.bloodlustActive > 0 {
+		ct.bloodlustActivations++
+	}
+}
+
+func (cm *cooldownTimingMetrics) addDamage(damage float64) {
+	cm.damage += damage
+	if cm.owner != nil {
+		cm.owner.addDamage(damage)
+	}
+}
+
+func (cm *cooldownTimingMetrics) onAuraGain(sim *Simulation, aura *Aura) {
+	if cm.bloodlustAuras[aura] {
+		if cm.bloodlustActive == 0 {
+			cm.bloodlustStart = sim.CurrentTime
+		}
+		cm.bloodlustActive++
+	}
+
+	if ct := cm.byAura[aura]; ct != nil {
+		ct.windows = append(ct.windows, cooldownInterval{start: sim.CurrentTime, end: NeverExpires})
+		ct.windowStartedDamage = cm.damage
+	}
+}
+
+func (cm *cooldownTimingMetrics) onAuraExpire(aura *Aura, end time.Duration) {
+	if cm.bloodlustAuras[aura] {
+		cm.bloodlustActive--
+		if cm.bloodlustActive == 0 {
+			cm.bloodlustWindows = append(cm.bloodlustWindows, cooldownInterval{start: cm.bloodlustStart, end: end})
+		}
+	}
+
+	if ct := cm.byAura[aura]; ct != nil && len(ct.windows) > 0 {
+		window := &ct.windows[len(ct.windows)-1]
+		window.end = end
+		// Damage in windows without any duration, e.g. buffs consumed by the cast
+		// they empower, can't be turned into DPS, so it counts as outside damage.
+		if end > max(window.start, 0) {
+			ct.windowDamage += cm.damage - ct.windowStartedDamage
+		}
+	}
+}
+
+func (cm *cooldownTimingMetrics) doneIteration(sim *Simulation) {
+	for _, ct := range cm.cooldowns {
+		if ct.aura == nil {
+			continue
+		}
+
+		windowDuration := intervalDuration(ct.windows, sim.Duration)
+		ct.windowSeconds += windowDuration.Seconds()
+		ct.bloodlustSeconds += intervalOverlap(ct.windows, cm.bloodlustWindows, sim.Duration).Seconds()
+		for i, other := range cm.cooldowns {
+			if other != ct {
+				ct.overlapSeconds[i] += intervalOverlap(ct.windows, other.windows, sim.Duration).Seconds()
+			}
+		}
+
+		ct.windowDamageSum += ct.windowDamage
+		ct.outsideDamageSum += cm.damage - ct.windowDamage
+		ct.outsideSeconds += (sim.Duration - windowDuration).Seconds()
+	}
+
+	for _, ct := range cm.cooldowns {
+		ct.windows = ct.windows[:0]
+		ct.windowDamage = 0
+	}
+	cm.bloodlustWindows = cm.bloodlustWindows[:0]
+	cm.bloodlustActive = 0
+	cm.damage = 0
+}
+
+func (cm *cooldownTimingMetrics) ToProto(numIterations float64) []*proto.CooldownTimingMetrics {
+	var metrics []*proto.CooldownTimingMetrics
+	for _, ct := range cm.cooldowns {
+		ctm := &proto.CooldownTimingMetrics{
+			Id:                 ct.spell.ActionID.ToProto(),
+			ActivationsAvg:     float64(ct.activations) / numIterations,
+			ActivationTimeHist: ct.activationTimeHist,
+			HasWindow:          ct.aura != nil,
+			WindowSecondsAvg:   ct.windowSeconds / numIterations,
+		}
+		if ct.activations > 0 {
+			ctm.ReadyDelayAvg = ct.readyDelaySum / float64(ct.activations)
+			ctm.BloodlustActivationChance = float64(ct.bloodlustActivations) / float64(ct.activations)
+		}
+
+		if ct.aura != nil {
+			if ct.windowSeconds > 0 {
+				ctm.BloodlustOverlap = ct.bloodlustSeconds / ct.windowSeconds
+				ctm.WindowDps = ct.windowDamageSum / ct.windowSeconds
+			}
+			ctm.OutsideWindowSecondsAvg = ct.outsideSeconds / numIterations
+			if ct.outsideSeconds > 0 {
+				ctm.OutsideWindowDps = ct.outsideDamageSum / ct.outsideSeconds
+			}
+
+			for i, other := range cm.cooldowns {
+				if other == ct || other.aura == nil {
+					continue
+				}
+				overlap := &proto.CooldownOverlapMetrics{Id: other.spell.ActionID.ToProto()}
+				if ct.windowSeconds > 0 {
+					overlap.Overlap = ct.overlapSeconds[i] / ct.windowSeconds
+				}
+				ctm.Overlaps = append(ctm.Overlaps, overlap)
+			}
+		}
+
+		metrics = append(metrics, ctm)
+	}
+	return metrics
+}
@@ -0,0 +1,109 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIntervalOverlap(t *testing.T) {
+	a := []cooldownInterval{{-time.Second * 2, time.Second * 10}, {time.Second * 50, time.Second * 70}}
+	b := []cooldownInterval{{time.Second * 5, time.Second * 55}, {time.Second * 58, NeverExpires}}
+	fightEnd := time.Second * 60
+
+	if overlap := intervalOverlap(a, b, fightEnd); overlap != time.Second*12 {
+		t.Errorf("Expected 12s of overlap, got %s", overlap)
+	}
+	if duration := intervalDuration(a, fightEnd); duration != time.Second*20 {
+		t.Errorf("Expected 20s within the fight, got %s", duration)
+	}
+}
+
+func TestCooldownTimingMetrics(t *testing.T) {
+	spellA, spellB := &Spell{ActionID: ActionID{SpellID: 1}}, &Spell{ActionID: ActionID{SpellID: 2}}
+	auraA, auraB, bloodlust := &Aura{}, &Aura{}, &Aura{}
+
+	cooldownA := &cooldownTiming{spell: spellA, aura: auraA, activationTimeHist: make(map[int32]int32), overlapSeconds: make([]float64, 2)}
+	cooldownB := &cooldownTiming{spell: spellB, aura: auraB, activationTimeHist: make(map[int32]int32), overlapSeconds: make([]float64, 2)}
+	cm := &cooldownTimingMetrics{
+		cooldowns:      []*cooldownTiming{cooldownA, cooldownB},
+		bySpell:        map[*Spell]*cooldownTiming{spellA: cooldownA, spellB: cooldownB},
+		byAura:         map[*Aura]*cooldownTiming{auraA: cooldownA, auraB: cooldownB},
+		bloodlustAuras: map[*Aura]bool{bloodlust: true},
+	}
+	pet := &cooldownTimingMetrics{owner: cm}
+
+	sim := &Simulation{Duration: time.Second * 100}
+	at := func(seconds int) *Simulation {
+		sim.CurrentTime = time.Duration(seconds) * time.Second
+		return sim
+	}
+
+	cm.onCast(at(0), spellA, 0)
+	cm.onAuraGain(at(0), auraA)
+	cm.onAuraGain(at(5), bloodlust)
+	cm.addDamage(200)
+	cm.onCast(at(10), spellB, time.Second*4)
+	cm.onAuraGain(at(10), auraB)
+	pet.addDamage(300)
+	cm.onAuraExpire(auraA, at(20).CurrentTime)
+	cm.addDamage(500)
+	cm.onAuraExpire(auraB, at(30).CurrentTime)
+	cm.onAuraExpire(bloodlust, at(45).CurrentTime)
+	cm.addDamage(1000)
+	cm.doneIteration(at(100))
+
+	metrics := cm.ToProto(1)
+	if len(metrics) != 2 {
+		t.Fatalf("Expected 2 cooldowns, got %d", len(metrics))
+	}
+
+	expected := []struct {
+		activationSecond int32
+		readyDelay       float64
+		bloodlustChance  float64
+		bloodlustOverlap float64
+		overlap          float64
+		windowDps        float64
+		outsideDps       float64
+	}{
+		{0, 0, 0, 0.75, 0.5, 25, 18.75},
+		{10, 6, 1, 1, 0.5, 40, 15},
+	}
+	for i, exp := range expected {
+		actual := metrics[i]
+		if actual.ActivationsAvg != 1 || actual.ActivationTimeHist[exp.activationSecond] != 1 {
+			t.Errorf("Cooldown %d: expected 1 activation in second %d, got %v", i, exp.activationSecond, actual.ActivationTimeHist)
+		}
+		if actual.ReadyDelayAvg != exp.readyDelay {
+			t.Errorf("Cooldown %d: expected ready delay %0.2f, got %0.2f", i, exp.readyDelay, actual.ReadyDelayAvg)
+		}
+		if actual.BloodlustActivationChance != exp.bloodlustChance || actual.BloodlustOverlap != exp.bloodlustOverlap {
+			t.Errorf("Cooldown %d: expected bloodlust chance %0.2f and overlap %0.2f, got %0.2f and %0.2f", i, exp.bloodlustChance, exp.bloodlustOverlap, actual.BloodlustActivationChance, actual.BloodlustOverlap)
+		}
+		if len(actual.Overlaps) != 1 || actual.Overlaps[0].Overlap != exp.overlap {
+			t.Errorf("Cooldown %d: expected overlap %0.2f with the other cooldown, got %v", i, exp.overlap, actual.Overlaps)
+		}
+		if actual.WindowDps != exp.windowDps || actual.OutsideWindowDps != exp.outsideDps {
+			t.Errorf("Cooldown %d: expected window DPS %0.2f and outside DPS %0.2f, got %0.2f and %0.2f", i, exp.windowDps, exp.outsideDps, actual.WindowDps, actual.OutsideWindowDps)
+		}
+	}
+}
+
+func TestCooldownTimingsOnlyWhenEnabled(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		request := newTestRaidSimRequest(newTestPlayer("Player"))
+		request.SimOptions.CooldownTimings = enabled
+
+		sim := setupHealEventsSim(request, func(fa *FakeAgent) {
+			fa.AddMajorCooldown(MajorCooldown{
+				Spell: fa.RegisterSpell(SpellConfig{ActionID: ActionID{SpellID: 2}}),
+				Type:  CooldownTypeDPS,
+			})
+		})
+
+		player := sim.Raid.AllPlayerUnits[0]
+		if tracked := player.Metrics.cooldowns != nil; tracked != enabled {
+			t.Errorf("Expected cooldown timings to be tracked: %t, got %t", enabled, tracked)
+		}
+	}
+}
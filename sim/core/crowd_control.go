@@ -0,0 +1,216 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+const (
+	// Cast time added to a hardcast each time the caster takes damage.
+	CastPushbackDuration = time.Millisecond * 500
+
+	// Maximum number of times a single hardcast can be pushed back.
+	MaxCastPushbacks = 2
+)
+
+func (unit *Unit) initCrowdControl() {
+	autoAttacksWereEnabled := false
+
+	unit.stunAura = unit.GetOrRegisterAura(Aura{
+		Label:    "Stunned",
+		ActionID: ActionID{OtherID: proto.OtherAction_OtherActionStun},
+		Duration: time.Second,
+
+		OnGain: func(aura *Aura, sim *Simulation) {
+			unit.PseudoStats.Stunned = true
+			unit.InterruptCast(sim)
+
+			autoAttacksWereEnabled = unit.AutoAttacks.anyEnabled()
+			unit.AutoAttacks.CancelAutoSwing(sim)
+		},
+		OnExpire: func(aura *Aura, sim *Simulation) {
+			unit.PseudoStats.Stunned = false
+
+			if autoAttacksWereEnabled {
+				unit.AutoAttacks.EnableAutoSwing(sim)
+			}
+		},
+	})
+
+	unit.silenceAura = unit.GetOrRegisterAura(Aura{
+		Label:    "Silenced",
+		ActionID: ActionID{OtherID: proto.OtherAction_OtherActionSilence},
+		Duration: time.Second,
+
+		OnGain: func(aura *Aura, sim *Simulation) {
+			if unit.Hardcast.Expires > sim.CurrentTime && !unit.Hardcast.SpellSchool.Matches(SpellSchoolPhysical) {
+				unit.InterruptCast(sim)
+			} else if unit.ChanneledDot != nil && !unit.ChanneledDot.Spell.SpellSchool.Matches(SpellSchoolPhysical) {
+				unit.InterruptCast(sim)
+			}
+		},
+	})
+}
+
+// Stuns the unit for the given duration. Stuns interrupt any cast in progress,
+// stop auto attacks and prevent the unit from using any abilities.
+func (unit *Unit) Stun(sim *Simulation, duration time.Duration) {
+	if !unit.applyCrowdControl(sim, unit.stunAura, duration) {
+		return
+	}
+
+	if unit.rotationAction != nil {
+		unit.SetGCDTimer(sim, max(unit.NextGCDAt(), unit.stunAura.ExpiresAt()))
+	}
+}
+
+// Silences the unit for the given duration. Silences interrupt any non-physical
+// cast in progress and prevent the unit from casting non-physical spells.
+func (unit *Unit) Silence(sim *Simulation, duration time.Duration) {
+	unit.applyCrowdControl(sim, unit.silenceAura, duration)
+}
+
+func (unit *Unit) IsStunned() bool {
+	return unit.stunAura.IsActive()
+}
+
+func (unit *Unit) IsSilenced() bool {
+	return unit.silenceAura.IsActive()
+}
+
+func (unit *Unit) applyCrowdControl(sim *Simulation, aura *Aura, duration time.Duration) bool {
+	if duration <= 0 || (aura.IsActive() && aura.RemainingDuration(sim) >= duration) {
+		return false
+	}
+
+	aura.Duration = duration
+	aura.Activate(sim)
+	return true
+}
+
+// Stops the unit's current cast or channel without applying its effects.
+// Returns whether anything was interrupted.
+func (unit *Unit) InterruptCast(sim *Simulation) bool {
+	if dot := unit.ChanneledDot; dot != nil {
+		if sim.Log != nil {
+			unit.Log(sim, "Channel interrupted: %s", dot.Spell.ActionID)
+		}
+
+		dot.tickAction.NextActionAt = NeverExpires // don't tick again in ApplyOnExpire
+		dot.Deactivate(sim)
+		if unit.rotationAction != nil && unit.GCD.IsReady(sim) {
+			unit.WaitUntil(sim, sim.CurrentTime+unit.ChannelClipDelay)
+		}
+		return true
+	}
+
+	hc := &unit.Hardcast
+	if hc.Expires <= sim.CurrentTime {
+		return false
+	}
+
+	if sim.Log != nil {
+		unit.Log(sim, "Cast interrupted: %s", hc.ActionID)
+	}
+
+	if unit.hardcastAction != nil && !unit.hardcastAction.consumed {
+		unit.hardcastAction.Cancel(sim)
+	}
+
+	gcdExpires := hc.gcdExpires
+	*hc = Hardcast{Expires: startingCDTime}
+
+	if unit.rotationAction != nil {
+		unit.SetGCDTimer(sim, max(sim.CurrentTime, gcdExpires))
+	}
+	return true
+}
+
+// Returns whether the unit is currently casting or channeling something that
+// can be stopped by a kick-type ability.
+func (unit *Unit) IsCastInterruptible(sim *Simulation) bool {
+	if unit.ChanneledDot != nil {
+		return unit.ChanneledDot.Spell.Flags.Matches(SpellFlagInterruptible)
+	}
+	return unit.Hardcast.Interruptible && unit.Hardcast.Expires > sim.CurrentTime
+}
+
+// Interrupts the target's current cast if it is interruptible, and locks the
+// school of the interrupted spell for lockoutDuration. Meant to be called from
+// the ApplyEffects of kick-type abilities. Returns whether the interrupt succeeded.
+func (spell *Spell) Interrupt(sim *Simulation, target *Unit, lockoutDuration time.Duration) bool {
+	if !target.IsCastInterruptible(sim) {
+		return false
+	}
+
+	school := target.Hardcast.SpellSchool
+	if target.ChanneledDot != nil {
+		school = target.ChanneledDot.Spell.SpellSchool
+	}
+
+	target.InterruptCast(sim)
+	if lockoutDuration > 0 {
+		target.lockedOutSchool = school
+		target.schoolLockoutExpires = sim.CurrentTime + lockoutDuration
+	}
+
+	if sim.CurrentTime >= 0 {
+		spell.SpellMetrics[target.UnitIndex].Interrupts++
+	}
+	return true
+}
+
+// Applies cast pushback to the unit's current hardcast, e.g. after taking damage.
+// Only interruptible casts are pushed back, and only if the encounter enables
+// pushback. Channels are not shortened by damage taken.
+func (unit *Unit) applyCastPushback(sim *Simulation) {
+	hc := &unit.Hardcast
+	if !sim.Encounter.CastPushback || hc.Expires <= sim.CurrentTime || hc.OnComplete == nil || !hc.Interruptible || hc.pushbacks >= MaxCastPushbacks {
+		return
+	}
+
+	pushback := time.Duration(float64(CastPushbackDuration) * unit.PseudoStats.CastPushbackMultiplier)
+	if pushback <= 0 {
+		return
+	}
+
+	hc.pushbacks++
+	hc.Expires += pushback
+	if sim.Log != nil {
+		unit.Log(sim, "Cast pushed back by %s: %s", pushback, hc.ActionID)
+	}
+
+	unit.newHardcastAction(sim)
+	if unit.rotationAction != nil && unit.NextGCDAt() < hc.Expires {
+		unit.SetGCDTimer(sim, hc.Expires)
+	}
+}
+
+// Returns why this spell can't currently be cast because of a loss of control
+// effect on its caster, or an empty string if it can. Only actively used
+// abilities are affected, procs and triggered effects still go through.
+func (spell *Spell) lossOfControlReason(sim *Simulation) string {
+	unit := spell.Unit
+	if spell.Flags.Matches(SpellFlagPassiveSpell) {
+		return ""
+	}
+	if unit.Type != EnemyUnit && !spell.Flags.Matches(SpellFlagAPL|SpellFlagMCD) {
+		return ""
+	}
+
+	if unit.stunAura.IsActive() {
+		return "unit is stunned"
+	}
+
+	isPhysical := spell.SpellSchool == SpellSchoolPhysical || spell.ProcMask.Matches(ProcMaskMeleeOrRanged)
+	if !isPhysical && unit.silenceAura.IsActive() {
+		return "unit is silenced"
+	}
+
+	if spell.SpellSchool.Matches(unit.lockedOutSchool) && unit.schoolLockoutExpires > sim.CurrentTime {
+		return "spell school is locked out"
+	}
+
+	return ""
+}
@@ -0,0 +1,106 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStunInterruptsCast(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	unit := &fa.Unit
+
+	unit.Hardcast = Hardcast{
+		Expires:    sim.CurrentTime + time.Second*2,
+		OnComplete: func(_ *Simulation, _ *Unit) { t.Fatalf("Interrupted cast should not complete") },
+	}
+
+	unit.Stun(sim, time.Second*3)
+	if unit.Hardcast.Expires > sim.CurrentTime {
+		t.Fatalf("Stun should interrupt the current cast")
+	}
+	if !unit.IsStunned() || !unit.PseudoStats.Stunned {
+		t.Fatalf("Unit should be stunned")
+	}
+
+	fa.Spell.Flags |= SpellFlagAPL
+	if fa.Spell.CanCast(sim, unit.CurrentTarget) {
+		t.Fatalf("Stunned unit should not be able to cast")
+	}
+
+	unit.stunAura.Deactivate(sim)
+	if unit.PseudoStats.Stunned || !fa.Spell.CanCast(sim, unit.CurrentTarget) {
+		t.Fatalf("Unit should be able to cast after the stun ends")
+	}
+}
+
+func TestInterruptLocksOutSchool(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	target := sim.Encounter.TargetUnits[0]
+
+	target.Hardcast = Hardcast{
+		Expires:     sim.CurrentTime + time.Second*2,
+		SpellSchool: SpellSchoolShadow,
+		OnComplete:  func(_ *Simulation, _ *Unit) {},
+	}
+	if fa.Spell.Interrupt(sim, target, time.Second*4) {
+		t.Fatalf("Non-interruptible casts should not be interrupted")
+	}
+
+	target.Hardcast.Interruptible = true
+	if !fa.Spell.Interrupt(sim, target, time.Second*4) {
+		t.Fatalf("Interruptible cast should be interrupted")
+	}
+	if target.Hardcast.Expires > sim.CurrentTime {
+		t.Fatalf("Interrupted cast should be cleared")
+	}
+	if interrupts := fa.Spell.SpellMetrics[target.UnitIndex].Interrupts; interrupts != 1 {
+		t.Fatalf("Expected 1 interrupt, got %d", interrupts)
+	}
+
+	// The interrupted cast was shadow, so only shadow spells are locked out.
+	targetSpell := &Spell{Unit: target, SpellSchool: SpellSchoolShadow}
+	if targetSpell.lossOfControlReason(sim) == "" {
+		t.Fatalf("Interrupted school should be locked out")
+	}
+	targetSpell.SpellSchool = SpellSchoolFire
+	if reason := targetSpell.lossOfControlReason(sim); reason != "" {
+		t.Fatalf("Other schools should not be locked out: %s", reason)
+	}
+}
+
+func TestCastPushback(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	unit := &fa.Unit
+
+	castEnd := sim.CurrentTime + time.Second*2
+	unit.Hardcast = Hardcast{
+		Expires:    castEnd,
+		OnComplete: func(_ *Simulation, _ *Unit) {},
+	}
+
+	unit.Hardcast.Interruptible = true
+	unit.applyCastPushback(sim)
+	if unit.Hardcast.Expires != castEnd {
+		t.Fatalf("Casts should not be pushed back unless the encounter enables it")
+	}
+
+	sim.Encounter.CastPushback = true
+	unit.Hardcast.Interruptible = false
+	unit.applyCastPushback(sim)
+	if unit.Hardcast.Expires != castEnd {
+		t.Fatalf("Non-interruptible casts should not be pushed back")
+	}
+
+	unit.Hardcast.Interruptible = true
+	for i := 0; i < MaxCastPushbacks+1; i++ {
+		unit.applyCastPushback(sim)
+	}
+
+	expected := castEnd + CastPushbackDuration*MaxCastPushbacks
+	if unit.Hardcast.Expires != expected {
+		t.Fatalf("Expected cast to end at %s, got %s", expected, unit.Hardcast.Expires)
+	}
+}
@@ -24,6 +24,10 @@ func init() {
 	)
 }
 
+// Extra setup run when fake agents initialize, for tests that need to
+// register effects before the environment is finalized.
+var fakeAgentInit func(fa *FakeAgent)
+
 type FakeAgent struct {
 	Spell *Spell
 	Dot   *Dot
@@ -88,6 +92,10 @@ func NewFakeElementalShaman(char *Character, _ *proto.Player) Agent {
 			},
 		})
 		fa.Dot = fa.Spell.CurDot()
+
+		if fakeAgentInit != nil {
+			fakeAgentInit(fa)
+		}
 	}
 
 	return fa
@@ -0,0 +1,199 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+
+	googleProto "google.golang.org/protobuf/proto"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/simsignals"
+)
+
+// Most targets a sweep may use, to keep the sims of each grid point fast.
+const maxEncounterSweepTargets = 10
+
+// Returns a copy of the encounter with the given number of targets. Targets
+// beyond those already in the encounter are copies of its first target.
+func encounterWithTargetCount(encounter *proto.Encounter, count int32) *proto.Encounter {
+	sweepEncounter := googleProto.Clone(encounter).(*proto.Encounter)
+	targets := sweepEncounter.Targets[:min(int(count), len(sweepEncounter.Targets))]
+	for len(targets) < int(count) {
+		targets = append(targets, googleProto.Clone(encounter.Targets[0]).(*proto.Target))
+	}
+	sweepEncounter.Targets = targets
+	return sweepEncounter
+}
+
+// Returns the durations and target counts to sweep, filling in defaults from
+// the encounter.
+func encounterSweepGrid(request *proto.EncounterSweepRequest) ([]float64, []int32, error) {
+	if request.Request == nil || request.Request.Encounter == nil || len(request.Request.Encounter.Targets) == 0 {
+		return nil, nil, fmt.Errorf("encounter sweeps need an encounter with at least one target")
+	}
+	encounter := request.Request.Encounter
+
+	durations := request.Durations
+	if len(durations) == 0 {
+		durations = []float64{encounter.Duration}
+	} else if encounter.UseHealth {
+		return nil, nil, fmt.Errorf("can't sweep durations of a health based encounter")
+	}
+	for _, duration := range durations {
+		if duration <= 0 {
+			return nil, nil, fmt.Errorf("invalid duration %0.1f, must be positive", duration)
+		}
+	}
+
+	targetCounts := request.TargetCounts
+	if len(targetCounts) == 0 {
+		targetCounts = []int32{int32(len(encounter.Targets))}
+	}
+	for _, count := range targetCounts {
+		if count < 1 || count > maxEncounterSweepTargets {
+			return nil, nil, fmt.Errorf("invalid target count %d, must be between 1 and %d", count, maxEncounterSweepTargets)
+		}
+	}
+
+	return durations, targetCounts, nil
+}
+
+func buildEncounterSweepRequests(request *proto.EncounterSweepRequest, durations []float64, targetCounts []int32) []*proto.RaidSimRequest {
+	var requests []*proto.RaidSimRequest
+	for _, duration := range durations {
+		for _, count := range targetCounts {
+			sweepRequest := googleProto.Clone(request.Request).(*proto.RaidSimRequest)
+			sweepRequest.Encounter = encounterWithTargetCount(request.Request.Encounter, count)
+			sweepRequest.Encounter.Duration = duration
+			requests = append(requests, sweepRequest)
+		}
+	}
+	return requests
+}
+
+// Returns the mean, standard deviation and standard error of the mean.
+func sweepDpsStats(dps *proto.DistributionMetrics, iterations int32) (float64, float64, float64) {
+	return dps.Avg, dps.Stdev, dps.Stdev / math.Sqrt(float64(max(iterations, 1)))
+}
+
+func computeEncounterSweepPoint(duration float64, targetCount int32, result *proto.RaidSimResult) *proto.EncounterSweepPoint {
+	point := &proto.EncounterSweepPoint{
+		Duration:    duration,
+		TargetCount: targetCount,
+		Iterations:  result.IterationsDone,
+	}
+	point.RaidDps, point.RaidDpsStdev, point.RaidDpsError = sweepDpsStats(result.RaidMetrics.Dps, result.IterationsDone)
+
+	for partyIndex, party := range result.RaidMetrics.Parties {
+		for playerIndex, player := range party.Players {
+			if player.Dps == nil {
+				continue
+			}
+			unitDps := &proto.UnitSweepDps{
+				Unit: &proto.UnitReference{Type: proto.UnitReference_Player, Index: int32(partyIndex*5 + playerIndex)},
+			}
+			unitDps.Dps, unitDps.DpsStdev, unitDps.DpsError = sweepDpsStats(player.Dps, result.IterationsDone)
+			point.Players = append(point.Players, unitDps)
+		}
+	}
+
+	return point
+}
+
+// Runs the sims of each grid point at the same time, each on a single thread,
+// and returns their results in the same order as the requests. With fewer
+// points than threads, splitting each sim between threads is faster, so they
+// are run one after another instead.
+func runEncounterSweepSims(requests []*proto.RaidSimRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals) []*proto.RaidSimResult {
+	results := make([]*proto.RaidSimResult, len(requests))
+
+	if IsRunningInWasm() || len(requests) < runtime.NumCPU() {
+		seq := newSimSequence(requests, progress, signals)
+		for i, request := range requests {
+			results[i] = seq.run(request)
+			if results[i].Error != nil {
+				break
+			}
+		}
+		return results
+	}
+
+	type simUpdate struct {
+		index   int
+		metrics *proto.ProgressMetrics
+	}
+	updates := make(chan simUpdate, 100)
+	threads := make(chan struct{}, runtime.NumCPU())
+
+	var iterationsTotal int32
+	for i, request := range requests {
+		iterationsTotal += request.SimOptions.Iterations
+
+		go func() {
+			threads <- struct{}{}
+			defer func() { <-threads }()
+
+			simProgress := make(chan *proto.ProgressMetrics, 100)
+			go RunSim(request, simProgress, signals)
+			for metrics := range simProgress {
+				updates <- simUpdate{index: i, metrics: metrics}
+				if metrics.FinalRaidResult != nil {
+					return
+				}
+			}
+		}()
+	}
+
+	completedIterations := make([]int32, len(requests))
+	var iterationsDone, simsCompleted int32
+	for simsCompleted < int32(len(requests)) {
+		update := <-updates
+		iterationsDone += update.metrics.CompletedIterations - completedIterations[update.index]
+		completedIterations[update.index] = update.metrics.CompletedIterations
+
+		if update.metrics.FinalRaidResult != nil {
+			results[update.index] = update.metrics.FinalRaidResult
+			simsCompleted++
+		}
+
+		if progress != nil {
+			progress <- &proto.ProgressMetrics{
+				TotalIterations:     iterationsTotal,
+				CompletedIterations: iterationsDone,
+				CompletedSims:       simsCompleted,
+				TotalSims:           int32(len(requests)),
+			}
+		}
+	}
+
+	return results
+}
+
+// Run a raid sim for each combination of duration and target count.
+func runEncounterSweep(request *proto.EncounterSweepRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals) *proto.EncounterSweepResult {
+	durations, targetCounts, err := encounterSweepGrid(request)
+	if err != nil {
+		return &proto.EncounterSweepResult{
+			Error: &proto.ErrorOutcome{Message: err.Error()},
+		}
+	}
+
+	requests := buildEncounterSweepRequests(request, durations, targetCounts)
+	simResults := runEncounterSweepSims(requests, progress, signals)
+
+	result := &proto.EncounterSweepResult{
+		Durations:    durations,
+		TargetCounts: targetCounts,
+	}
+	for i, simResult := range simResults {
+		if simResult.Error != nil {
+			return &proto.EncounterSweepResult{Error: simResult.Error}
+		}
+
+		duration, targetCount := durations[i/len(targetCounts)], targetCounts[i%len(targetCounts)]
+		result.Points = append(result.Points, computeEncounterSweepPoint(duration, targetCount, simResult))
+	}
+
+	return result
+}
@@ -0,0 +1,97 @@
+package core
+
+import (
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/simsignals"
+)
+
+func encounterSweepTestRequest() *proto.EncounterSweepRequest {
+	request := newTestRaidSimRequest(newTestPlayer("Player"))
+	request.Encounter.Duration = 180
+	request.Encounter.Targets[0].Name = "Boss"
+	request.Encounter.Targets = append(request.Encounter.Targets, &proto.Target{Name: "Add", Level: 87})
+	return &proto.EncounterSweepRequest{Request: request}
+}
+
+func TestEncounterWithTargetCount(t *testing.T) {
+	encounter := encounterSweepTestRequest().Request.Encounter
+
+	single := encounterWithTargetCount(encounter, 1)
+	if len(single.Targets) != 1 || single.Targets[0].Name != "Boss" {
+		t.Errorf("Expected only the boss, got %v", single.Targets)
+	}
+
+	extra := encounterWithTargetCount(encounter, 4)
+	var names []string
+	for _, target := range extra.Targets {
+		names = append(names, target.Name)
+	}
+	if expected := []string{"Boss", "Add", "Boss", "Boss"}; !slices.Equal(names, expected) {
+		t.Errorf("Expected targets %v, got %v", expected, names)
+	}
+
+	extra.Targets[2].Name = "Changed"
+	if len(encounter.Targets) != 2 || encounter.Targets[0].Name != "Boss" {
+		t.Errorf("Expected the original encounter to be unchanged, got %v", encounter.Targets)
+	}
+}
+
+func TestEncounterSweepGrid(t *testing.T) {
+	request := encounterSweepTestRequest()
+	durations, targetCounts, err := encounterSweepGrid(request)
+	if err != nil || !slices.Equal(durations, []float64{180}) || !slices.Equal(targetCounts, []int32{2}) {
+		t.Errorf("Expected the encounter's duration and target count by default, got %v, %v, %v", durations, targetCounts, err)
+	}
+
+	request.Durations = []float64{60, 300}
+	request.TargetCounts = []int32{1, 3}
+	requests := buildEncounterSweepRequests(request, request.Durations, request.TargetCounts)
+	if len(requests) != 4 {
+		t.Fatalf("Expected 4 sims, got %d", len(requests))
+	}
+	for i, sweepRequest := range requests {
+		duration, count := request.Durations[i/2], request.TargetCounts[i%2]
+		if sweepRequest.Encounter.Duration != duration || len(sweepRequest.Encounter.Targets) != int(count) {
+			t.Errorf("Sim %d: expected %0.0fs with %d targets, got %0.0fs with %d", i, duration, count, sweepRequest.Encounter.Duration, len(sweepRequest.Encounter.Targets))
+		}
+	}
+
+	invalid := []func(*proto.EncounterSweepRequest){
+		func(r *proto.EncounterSweepRequest) { r.Request.Encounter.Targets = nil },
+		func(r *proto.EncounterSweepRequest) { r.Request.Encounter.UseHealth = true },
+		func(r *proto.EncounterSweepRequest) { r.Durations = []float64{60, 0} },
+		func(r *proto.EncounterSweepRequest) { r.TargetCounts = []int32{0} },
+		func(r *proto.EncounterSweepRequest) { r.TargetCounts = []int32{1, maxEncounterSweepTargets + 1} },
+	}
+	for i, modify := range invalid {
+		request := encounterSweepTestRequest()
+		request.Durations = []float64{60}
+		modify(request)
+		if _, _, err := encounterSweepGrid(request); err == nil {
+			t.Errorf("Case %d: expected an error", i)
+		}
+	}
+}
+
+func TestEncounterSweepSimsInOrder(t *testing.T) {
+	request := encounterSweepTestRequest()
+	request.Request.SimOptions.Iterations = 5
+	for i := 0; i <= runtime.NumCPU(); i++ {
+		request.Durations = append(request.Durations, float64(30+10*i))
+	}
+	request.TargetCounts = []int32{1}
+
+	results := runEncounterSweepSims(buildEncounterSweepRequests(request, request.Durations, request.TargetCounts), nil, simsignals.CreateSignals())
+	for i, result := range results {
+		if result.Error != nil {
+			t.Fatalf("Sim %d failed: %s", i, result.Error.Message)
+		}
+		if result.AvgIterationDuration != request.Durations[i] {
+			t.Errorf("Sim %d: expected a %0.0fs fight, got %0.0fs", i, request.Durations[i], result.AvgIterationDuration)
+		}
+	}
+}
@@ -133,6 +133,8 @@ func (env *Environment) initialize(raidProto *proto.Raid, encounterProto *proto.
 		}
 	}
 
+	env.setupIncomingDamage(encounterProto.IncomingDamage)
+
 	env.State = Initialized
 	return raidStats
 }
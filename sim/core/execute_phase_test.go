@@ -0,0 +1,72 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func TestExecuteProportionsFromHealthCurve(t *testing.T) {
+	// Boss drops to 30% in the first half of the fight, then slowly to 0.
+	encounter := NewEncounter(&proto.Encounter{
+		Duration:             300,
+		ExecuteProportion_20: 0.2,
+		HealthCurve: []*proto.HealthCurvePoint{
+			{Time: 0.5, Health: 0.3},
+		},
+	})
+
+	expected := map[string][2]float64{
+		"20": {encounter.ExecuteProportion_20, 1 - (0.5 + 0.5*0.1/0.3)},
+		"25": {encounter.ExecuteProportion_25, 1 - (0.5 + 0.5*0.05/0.3)},
+		"35": {encounter.ExecuteProportion_35, 1 - 0.5*0.65/0.7},
+		"90": {encounter.ExecuteProportion_90, 1 - 0.5*0.1/0.7},
+	}
+	for phase, values := range expected {
+		if math.Abs(values[0]-values[1]) > 1e-9 {
+			t.Fatalf("Expected %s%% execute proportion %0.4f, got %0.4f", phase, values[1], values[0])
+		}
+	}
+}
+
+func TestExecutePhaseFromTargetHealth(t *testing.T) {
+	request := newTestRaidSimRequest(newTestPlayer("Player"))
+	request.Raid.Tanks = nil
+	request.Encounter = &proto.Encounter{
+		UseHealth: true,
+		Targets: []*proto.Target{
+			{Name: "Boss", Level: 88, Stats: stats.Stats{stats.Health: 1000}.ToProtoArray()},
+			{Name: "Add", Level: 88, Stats: stats.Stats{stats.Health: 9000}.ToProtoArray()},
+		},
+	}
+	sim := newTestSim(request)
+
+	sim.reset()
+
+	var phases []int32
+	sim.RegisterExecutePhaseCallback(func(_ *Simulation, phase int32) {
+		phases = append(phases, phase)
+	})
+
+	boss := sim.Encounter.TargetUnits[0]
+
+	// 70% of the boss' health is only 7% of the encounter's total health.
+	boss.RemoveHealth(sim, 700)
+	sim.advance(DurationFromSeconds(10))
+
+	if !sim.IsExecutePhase35() || sim.IsExecutePhase25() {
+		t.Fatalf("Expected boss at 30%% health to be in the 35%% execute phase, got phase %d", sim.executePhase)
+	}
+	if len(phases) != 2 || phases[0] != 90 || phases[1] != 35 {
+		t.Fatalf("Unexpected execute phase callbacks: %v", phases)
+	}
+
+	boss.RemoveHealth(sim, 150)
+	sim.advance(DurationFromSeconds(20))
+
+	if !sim.IsExecutePhase20() {
+		t.Fatalf("Expected boss at 15%% health to be in the 20%% execute phase, got phase %d", sim.executePhase)
+	}
+}
@@ -206,6 +206,7 @@ const (
 	SpellFlagPassiveSpell                                   // Indicates this spell is applied/cast as a result of another spell
 	SpellFlagSupressDoTApply                                // If present this spell will not apply dots (Used for DTR dot supression)
 	SpellFlagSwapped                                        // Indicates that this spell is not useable because it is from a currently swapped item
+	SpellFlagInterruptible                                  // Allows enemy casts of this spell to be interrupted by kick-type abilities (Kick, Pummel, Counterspell, etc.). Player and pet casts always are.
 
 	// Used to let agents categorize their spells.
 	SpellFlagAgentReserved1
@@ -0,0 +1,182 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+// Sets up a sim whose player runs the given setup before finalization.
+func setupHealEventsSim(request *proto.RaidSimRequest, setup func(fa *FakeAgent)) *Simulation {
+	fakeAgentInit = setup
+	defer func() { fakeAgentInit = nil }()
+
+	return newTestSim(request)
+}
+
+func TestOnHealTakenFromHealingModel(t *testing.T) {
+	player := newTestPlayer("Tank")
+	player.HealingModel = &proto.HealingModel{Hps: 1000, CadenceSeconds: 2}
+
+	heals := 0
+	healed := 0.0
+	sim := setupHealEventsSim(newTestRaidSimRequest(player), func(fa *FakeAgent) {
+		MakePermanent(fa.RegisterAura(Aura{
+			Label: "Heal Listener",
+			OnHealTaken: func(_ *Aura, _ *Simulation, _ *Spell, result *SpellResult) {
+				heals++
+				healed += result.Damage
+			},
+		}))
+	})
+	sim.runOnce()
+
+	if heals < 25 || heals > 35 {
+		t.Errorf("Expected a modeled heal about every 2s, got %d heals", heals)
+	}
+	if !WithinToleranceFloat64(float64(heals)*2000, healed, 0.001) {
+		t.Errorf("Expected each modeled heal to pass 2000 healing, got %0.1f over %d heals", healed, heals)
+	}
+}
+
+func TestOnHealTakenFromGainHealth(t *testing.T) {
+	var healed []float64
+	sim := setupHealEventsSim(newTestRaidSimRequest(newTestPlayer("Tank")), func(fa *FakeAgent) {
+		healthMetrics := fa.NewHealthMetrics(ActionID{SpellID: 2})
+
+		MakePermanent(fa.RegisterAura(Aura{
+			Label: "Heal Listener",
+			OnHealTaken: func(_ *Aura, _ *Simulation, spell *Spell, result *SpellResult) {
+				if spell.ActionID.OtherID == proto.OtherAction_OtherActionHealthGain {
+					healed = append(healed, result.Damage)
+				}
+			},
+		}))
+
+		fa.RegisterResetEffect(func(sim *Simulation) {
+			healed = nil
+			StartDelayedAction(sim, DelayedActionOptions{
+				DoAt: time.Second * 5,
+				OnAction: func(sim *Simulation) {
+					fa.GainHealth(sim, 500, healthMetrics)
+				},
+			})
+		})
+	})
+	sim.runOnce()
+
+	if len(healed) != 1 || healed[0] != 500 {
+		t.Errorf("Expected a single 500 heal from gaining health, got %v", healed)
+	}
+}
+
+// Runs a sim where a 600 strength shield is put on the player, who then hits
+// itself for 1000 damage twice. Returns the damage absorbed and the damage
+// taken by each hit.
+func runAbsorbSim(t *testing.T, useDamageAbsorptionAura bool) ([]float64, []float64) {
+	var absorbed, damageTaken []float64
+
+	request := newTestRaidSimRequest(newTestPlayer("Tank"))
+	request.Encounter.Targets[0].SwingSpeed = 0
+
+	sim := setupHealEventsSim(request, func(fa *FakeAgent) {
+		var applyShield func(sim *Simulation)
+		if useDamageAbsorptionAura {
+			absorbAura := fa.NewDamageAbsorptionAura("Test Absorb", ActionID{SpellID: 2}, time.Second*10, func(_ *Unit) float64 {
+				return 600
+			})
+			applyShield = func(sim *Simulation) { absorbAura.Activate(sim) }
+		} else {
+			shieldSpell := fa.RegisterSpell(SpellConfig{
+				ActionID:    ActionID{SpellID: 2},
+				SpellSchool: SpellSchoolHoly,
+				ProcMask:    ProcMaskSpellHealing,
+				Flags:       SpellFlagHelpful,
+
+				DamageMultiplier: 1,
+
+				Shield: ShieldConfig{
+					SelfOnly: true,
+					Aura: Aura{
+						Label:    "Test Shield",
+						Duration: time.Second * 10,
+					},
+				},
+			})
+			applyShield = func(sim *Simulation) { shieldSpell.SelfShield().Apply(sim, 600) }
+		}
+
+		hitSpell := fa.RegisterSpell(SpellConfig{
+			ActionID:         ActionID{SpellID: 3},
+			SpellSchool:      SpellSchoolPhysical,
+			ProcMask:         ProcMaskEmpty,
+			Flags:            SpellFlagIgnoreModifiers,
+			DamageMultiplier: 1,
+			ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+				spell.CalcAndDealDamage(sim, target, 1000, spell.OutcomeAlwaysHit)
+			},
+		})
+
+		MakePermanent(fa.RegisterAura(Aura{
+			Label: "Absorb Listener",
+			OnAbsorbConsumed: func(_ *Aura, _ *Simulation, _ *Aura, spell *Spell, result *SpellResult, amount float64) {
+				if spell != hitSpell {
+					t.Errorf("Expected the absorb to be consumed by the hit spell")
+				}
+				if result.Damage != 1000-amount {
+					t.Errorf("Expected the absorbed amount to be removed before handlers run, got %0.1f damage", result.Damage)
+				}
+				absorbed[len(absorbed)-1] += amount
+			},
+			OnSpellHitTaken: func(_ *Aura, _ *Simulation, spell *Spell, result *SpellResult) {
+				if spell == hitSpell {
+					damageTaken[len(damageTaken)-1] += result.Damage
+				}
+			},
+		}))
+
+		fa.RegisterResetEffect(func(sim *Simulation) {
+			absorbed, damageTaken = nil, nil
+			for _, hitAt := range []time.Duration{time.Millisecond * 2500, time.Millisecond * 4500} {
+				StartDelayedAction(sim, DelayedActionOptions{
+					DoAt: hitAt,
+					OnAction: func(sim *Simulation) {
+						if len(absorbed) == 0 {
+							applyShield(sim)
+						}
+						absorbed = append(absorbed, 0)
+						damageTaken = append(damageTaken, 0)
+						hitSpell.Cast(sim, &fa.Unit)
+					},
+				})
+			}
+		})
+	})
+	sim.runOnce()
+
+	return absorbed, damageTaken
+}
+
+func TestOnAbsorbConsumed(t *testing.T) {
+	for _, useDamageAbsorptionAura := range []bool{false, true} {
+		absorbed, damageTaken := runAbsorbSim(t, useDamageAbsorptionAura)
+		if len(absorbed) != 2 {
+			t.Fatalf("Expected 2 hits, got %d", len(absorbed))
+		}
+
+		if absorbed[0] != 600 {
+			t.Errorf("Expected 600 damage to be absorbed, got %0.1f", absorbed[0])
+		}
+		// Once the absorb is used up, nothing else is passed to the handlers.
+		if absorbed[1] != 0 {
+			t.Errorf("Expected no damage to be absorbed by a used up shield, got %0.1f", absorbed[1])
+		}
+		// Handlers of the hit itself see the damage left after absorbs.
+		for i, expected := range []float64{400, 1000} {
+			if damageTaken[i] != expected {
+				t.Errorf("Expected hit %d to deal %0.1f damage, got %0.1f", i+1, expected, damageTaken[i])
+			}
+		}
+	}
+}
@@ -13,6 +13,10 @@ type healthBar struct {
 	currentHealth float64
 
 	DamageTakenHealthMetrics *ResourceMetrics
+
+	// Only used to pass health gained outside of healing spells to OnHealTaken
+	// handlers, the health itself is tracked through the metrics of each gain.
+	healthGainSpell *Spell
 }
 
 func (unit *Unit) EnableHealthBar() {
@@ -22,6 +26,17 @@ func (unit *Unit) EnableHealthBar() {
 	}
 }
 
+// Registered when the unit is finalized, after all other spells, so their
+// order in the spellbook doesn't depend on whether the unit has a health bar.
+func (hb *healthBar) registerHealthGainSpell() {
+	hb.healthGainSpell = hb.unit.RegisterSpell(SpellConfig{
+		ActionID:    ActionID{OtherID: proto.OtherAction_OtherActionHealthGain},
+		SpellSchool: SpellSchoolHoly,
+		ProcMask:    ProcMaskEmpty,
+		Flags:       SpellFlagHelpful | SpellFlagNoMetrics | SpellFlagNoLogs | SpellFlagNoOnCastComplete,
+	})
+}
+
 func (unit *Unit) HasHealthBar() bool {
 	return unit.healthBar.unit != nil
 }
@@ -45,7 +60,27 @@ func (hb *healthBar) CurrentHealthPercent() float64 {
 	return hb.currentHealth / hb.unit.stats[stats.Health]
 }
 
+// Health percent used to pick heal targets, treating units without a health bar as full.
+func (unit *Unit) healthPercentForHealing() float64 {
+	if !unit.HasHealthBar() || unit.MaxHealth() <= 0 {
+		return 1
+	}
+	return unit.CurrentHealthPercent()
+}
+
+// Gains health outside of a healing spell, which is passed to OnHealTaken
+// handlers like any other heal.
 func (hb *healthBar) GainHealth(sim *Simulation, amount float64, metrics *ResourceMetrics) {
+	hb.gainHealth(sim, amount, metrics)
+
+	result := hb.healthGainSpell.NewResult(hb.unit)
+	result.Outcome = OutcomeHit
+	result.Damage = amount
+	hb.unit.OnHealTaken(sim, hb.healthGainSpell, result)
+	hb.healthGainSpell.DisposeResult(result)
+}
+
+func (hb *healthBar) gainHealth(sim *Simulation, amount float64, metrics *ResourceMetrics) {
 	if amount < 0 {
 		panic("Trying to gain negative health!")
 	}
@@ -72,7 +107,7 @@ func (hb *healthBar) RemoveHealth(sim *Simulation, amount float64) {
 	metrics.AddEvent(-amount, newHealth-oldHealth)
 
 	// TMI calculations need timestamps and Max HP information for each damage taken event
-	if hb.unit.Metrics.isTanking {
+	if hb.unit.Metrics.isTanking || hb.unit.Metrics.survival != nil {
 		entry := tmiListItem{
 			Timestamp:      sim.CurrentTime,
 			WeightedDamage: amount / hb.MaxHealth(),
@@ -80,6 +115,10 @@ func (hb *healthBar) RemoveHealth(sim *Simulation, amount float64) {
 		hb.unit.Metrics.tmiList = append(hb.unit.Metrics.tmiList, entry)
 	}
 
+	if hb.unit.Metrics.survival != nil {
+		hb.unit.Metrics.survival.addDamageTaken(sim, amount)
+	}
+
 	if sim.Log != nil {
 		hb.unit.Log(sim, "Spent %0.3f health from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, oldHealth, newHealth, hb.MaxHealth())
 	}
@@ -92,7 +131,7 @@ func (hb *healthBar) UpdateMaxHealth(sim *Simulation, bonusHealth float64, metri
 	hb.unit.AddStatsDynamic(sim, stats.Stats{stats.Health: bonusHealth})
 
 	if bonusHealth >= 0 {
-		hb.GainHealth(sim, bonusHealth, metrics)
+		hb.gainHealth(sim, bonusHealth, metrics)
 	} else {
 		hb.RemoveHealth(sim, max(0, min(-bonusHealth, hb.currentHealth-1))) // Last Stand effects always leave the player with at least 1 HP when they expire
 	}
@@ -125,6 +164,7 @@ func (character *Character) trackChanceOfDeath(healingModel *proto.HealingModel)
 						OnAction: func(s *Simulation) {
 							if aura.Unit.CurrentHealth() <= 0 && !aura.Unit.Metrics.Died {
 								aura.Unit.Metrics.Died = true
+								aura.Unit.Metrics.TimeOfDeath = sim.CurrentTime
 								if sim.Log != nil {
 									character.Log(sim, "Dead")
 								}
@@ -145,6 +185,7 @@ func (character *Character) trackChanceOfDeath(healingModel *proto.HealingModel)
 						OnAction: func(s *Simulation) {
 							if aura.Unit.CurrentHealth() <= 0 && !aura.Unit.Metrics.Died {
 								aura.Unit.Metrics.Died = true
+								aura.Unit.Metrics.TimeOfDeath = sim.CurrentTime
 								if sim.Log != nil {
 									character.Log(sim, "Dead")
 								}
@@ -156,9 +197,9 @@ func (character *Character) trackChanceOfDeath(healingModel *proto.HealingModel)
 		},
 	})
 
-	if !character.Unit.Metrics.isTanking {
-		return
-	}
+	// Anyone can end up being hit by an enemy, e.g. an off tank after a tank
+	// swap, so survival is tracked for everyone and reported for whoever was hit.
+	character.trackSurvival()
 
 	if healingModel == nil {
 		return
@@ -166,7 +207,7 @@ func (character *Character) trackChanceOfDeath(healingModel *proto.HealingModel)
 
 	character.Unit.Metrics.tmiBin = healingModel.BurstWindow
 
-	if healingModel.Hps != 0 {
+	if character.Unit.Metrics.isTanking && healingModel.Hps != 0 {
 		character.applyHealingModel(healingModel)
 	}
 }
@@ -185,6 +226,15 @@ func (character *Character) applyHealingModel(healingModel *proto.HealingModel)
 	healingModelActionID := ActionID{OtherID: proto.OtherAction_OtherActionHealingModel}
 	healthMetrics := character.NewHealthMetrics(healingModelActionID)
 
+	// Only used to pass the modeled heals to OnHealTaken handlers, the healing
+	// itself is tracked through the health metrics.
+	healingModelSpell := character.RegisterSpell(SpellConfig{
+		ActionID:    healingModelActionID,
+		SpellSchool: SpellSchoolHoly,
+		ProcMask:    ProcMaskEmpty,
+		Flags:       SpellFlagHelpful | SpellFlagNoMetrics | SpellFlagNoLogs | SpellFlagNoOnCastComplete,
+	})
+
 	// Register a shield aura on the tank to model the aggregate impact of
 	// shield spells that contribute towards the total modeled HPS.
 	var healPerTick float64
@@ -216,7 +266,12 @@ func (character *Character) applyHealingModel(healingModel *proto.HealingModel)
 
 			if healPerTick > 0 {
 				// Execute the direct portion of the heal
-				character.GainHealth(sim, healPerTick * (1.0 - absorbFrac), healthMetrics)
+				result := healingModelSpell.NewResult(&character.Unit)
+				result.Outcome = OutcomeHit
+				result.Damage = healPerTick * (1.0 - absorbFrac)
+				character.gainHealth(sim, result.Damage, healthMetrics)
+				character.OnHealTaken(sim, healingModelSpell, result)
+				healingModelSpell.DisposeResult(result)
 
 				// Turn the remainder into an absorb shield
 				if absorbShield != nil {
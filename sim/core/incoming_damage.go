@@ -0,0 +1,99 @@
+package core
+
+import (
+	"slices"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+// Applies the damage taken by the raid in healing sims, so heals land on real
+// health deficits and overhealing can be measured. The damage is dealt by the
+// primary target, so it goes through the usual damage taken modifiers and absorbs.
+func (env *Environment) setupIncomingDamage(profile *proto.IncomingDamageProfile) {
+	if profile == nil {
+		return
+	}
+
+	// Give the target dummies health, so they can be damaged and healed like players.
+	if profile.TargetDummyHealth > 0 {
+		for _, party := range env.Raid.Parties {
+			for _, player := range party.Players {
+				if dummy, ok := player.(*TargetDummy); ok {
+					dummy.AddStat(stats.Health, profile.TargetDummyHealth)
+					dummy.EnableHealthBar()
+					dummy.trackChanceOfDeath(nil)
+				}
+			}
+		}
+	}
+
+	boss := env.Encounter.TargetUnits[0]
+	variation := Clamp(profile.DamageVariation, 0, 1)
+
+	registerDamageSpell := func(tag int32, school SpellSchool, damage float64) *Spell {
+		return boss.RegisterSpell(SpellConfig{
+			ActionID:    ActionID{OtherID: proto.OtherAction_OtherActionDamageTaken, Tag: tag},
+			SpellSchool: school,
+			ProcMask:    ProcMaskEmpty,
+			Flags:       SpellFlagIgnoreResists | SpellFlagIgnoreAttackerModifiers | SpellFlagNoOnCastComplete,
+
+			DamageMultiplier: 1,
+
+			ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+				baseDamage := damage * (1 + variation*(2*sim.RandomFloat("Incoming Damage")-1))
+				spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeAlwaysHit)
+			},
+		})
+	}
+
+	// Periodically hits every unit returned by getTargets.
+	schedule := func(spell *Spell, period time.Duration, getTargets func() []*Unit) {
+		boss.RegisterResetEffect(func(sim *Simulation) {
+			StartPeriodicAction(sim, PeriodicActionOptions{
+				Period: period,
+				OnAction: func(sim *Simulation) {
+					for _, target := range getTargets() {
+						spell.SkipCastAndApplyEffects(sim, target)
+					}
+				},
+			})
+		})
+	}
+
+	raidMembers := func() []*Unit {
+		return env.Raid.AllPlayerUnits
+	}
+
+	if profile.RaidDps > 0 {
+		env.Encounter.HasIncomingDamage = true
+		schedule(registerDamageSpell(1, SpellSchoolFire, profile.RaidDps), time.Second, raidMembers)
+	}
+
+	if profile.AoeBurstDamage > 0 && profile.AoeBurstInterval > 0 {
+		env.Encounter.HasIncomingDamage = true
+		schedule(registerDamageSpell(2, SpellSchoolFire, profile.AoeBurstDamage), DurationFromSeconds(profile.AoeBurstInterval), raidMembers)
+	}
+
+	if profile.TankSpikeDamage > 0 && profile.TankSpikeInterval > 0 {
+		var tanks []*Unit
+		for _, target := range env.Encounter.Targets {
+			for _, tank := range []*Unit{target.CurrentTarget, target.SecondaryTarget} {
+				if tank != nil && tank.Type != EnemyUnit && !slices.Contains(tanks, tank) {
+					tanks = append(tanks, tank)
+				}
+			}
+		}
+		if len(tanks) == 0 {
+			if dummy := env.Raid.GetFirstTargetDummy(); dummy != nil {
+				tanks = append(tanks, &dummy.Unit)
+			}
+		}
+
+		env.Encounter.HasIncomingDamage = true
+		schedule(registerDamageSpell(3, SpellSchoolPhysical, profile.TankSpikeDamage), DurationFromSeconds(profile.TankSpikeInterval), func() []*Unit {
+			return tanks
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package core
+
+import (
+	googleProto "google.golang.org/protobuf/proto"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/simsignals"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+// An equipped item, enchant, gem or set bonus being valued, with the sims
+// needed to value it.
+type itemAttributionSim struct {
+	attribution *proto.ItemAttribution
+
+	// Sim with it removed entirely. Set bonuses have no stats, so for them
+	// this is the same as the disabled request.
+	removedRequest *proto.RaidSimRequest
+
+	// Sim with only its effect disabled, or nil if it has no effect.
+	disabledRequest *proto.RaidSimRequest
+
+	// For items, the enchant and gems on it. They are removed along with the
+	// item, but valued on their own, so their value is subtracted from the item's.
+	parts []itemAttributionPart
+}
+
+// An enchant or gem on an item, and the share of its value which belongs to
+// the item. Identical gems are valued together, so each has an equal share.
+type itemAttributionPart struct {
+	sim   *itemAttributionSim
+	share float64
+}
+
+func equipmentSpecStats(spec *proto.EquipmentSpec) stats.Stats {
+	equipment := ProtoToEquipment(spec)
+	return equipment.Stats()
+}
+
+func buildItemAttributionRequests(request *proto.ItemAttributionRequest) (*proto.RaidSimRequest, []*itemAttributionSim) {
+	preparePairedSimOptions(request.SimOptions)
+
+	raidProto := SinglePlayerRaidProto(request.Player, request.PartyBuffs, request.RaidBuffs, request.Debuffs)
+	raidProto.Tanks = request.Tanks
+
+	baseRequest := &proto.RaidSimRequest{
+		Raid:       raidProto,
+		Encounter:  request.Encounter,
+		SimOptions: request.SimOptions,
+	}
+	basePlayer := baseRequest.Raid.Parties[0].Players[0]
+	baseStats := equipmentSpecStats(basePlayer.Equipment)
+
+	// Returns a request with the equipment modified, and the stats lost by doing so.
+	withEquipment := func(modify func(spec *proto.EquipmentSpec)) (*proto.RaidSimRequest, *proto.UnitStats) {
+		modifiedRequest := googleProto.Clone(baseRequest).(*proto.RaidSimRequest)
+		spec := modifiedRequest.Raid.Parties[0].Players[0].Equipment
+		modify(spec)
+		return modifiedRequest, &proto.UnitStats{
+			Stats: baseStats.Subtract(equipmentSpecStats(spec)).ToProtoArray(),
+		}
+	}
+	withDisabledEffects := func(disabled *proto.DisabledEffects) *proto.RaidSimRequest {
+		modifiedRequest := googleProto.Clone(baseRequest).(*proto.RaidSimRequest)
+		modifiedRequest.Raid.Parties[0].Players[0].DisabledEffects = disabled
+		return modifiedRequest
+	}
+
+	var sims []*itemAttributionSim
+	itemSims := make([]*itemAttributionSim, len(basePlayer.Equipment.Items))
+
+	for i, item := range basePlayer.Equipment.Items {
+		if item.Id == 0 {
+			continue
+		}
+		slot := proto.ItemSlot(i)
+
+		itemSim := &itemAttributionSim{
+			attribution: &proto.ItemAttribution{
+				Source: proto.ItemAttribution_Item,
+				Slot:   slot,
+				Id:     item.Id,
+			},
+		}
+		itemSim.removedRequest, itemSim.attribution.Stats = withEquipment(func(spec *proto.EquipmentSpec) {
+			spec.Items[i] = &proto.ItemSpec{}
+		})
+		if HasItemEffect(item.Id) {
+			itemSim.disabledRequest = withDisabledEffects(&proto.DisabledEffects{ItemSlots: []proto.ItemSlot{slot}})
+		}
+		sims = append(sims, itemSim)
+		itemSims[i] = itemSim
+
+		if item.Enchant != 0 {
+			enchantSim := &itemAttributionSim{
+				attribution: &proto.ItemAttribution{
+					Source: proto.ItemAttribution_Enchant,
+					Slot:   slot,
+					Id:     item.Enchant,
+				},
+			}
+			enchantSim.removedRequest, enchantSim.attribution.Stats = withEquipment(func(spec *proto.EquipmentSpec) {
+				spec.Items[i].Enchant = 0
+			})
+			if HasEnchantEffect(item.Enchant) || HasWeaponEffect(item.Enchant) {
+				enchantSim.disabledRequest = withDisabledEffects(&proto.DisabledEffects{EnchantSlots: []proto.ItemSlot{slot}})
+			}
+			sims = append(sims, enchantSim)
+			itemSim.parts = append(itemSim.parts, itemAttributionPart{sim: enchantSim, share: 1})
+		}
+	}
+
+	// Identical gems are valued together, to avoid a sim for every socket.
+	var gemIDs []int32
+	gemCounts := make(map[int32]int32)
+	for _, item := range basePlayer.Equipment.Items {
+		for _, gemID := range item.Gems {
+			if gemID == 0 {
+				continue
+			}
+			if gemCounts[gemID] == 0 {
+				gemIDs = append(gemIDs, gemID)
+			}
+			gemCounts[gemID]++
+		}
+	}
+	gemSims := make(map[int32]*itemAttributionSim, len(gemIDs))
+	for _, gemID := range gemIDs {
+		gemSim := &itemAttributionSim{
+			attribution: &proto.ItemAttribution{
+				Source: proto.ItemAttribution_Gem,
+				Id:     gemID,
+				Count:  gemCounts[gemID],
+			},
+		}
+		gemSim.removedRequest, gemSim.attribution.Stats = withEquipment(func(spec *proto.EquipmentSpec) {
+			for _, item := range spec.Items {
+				for socket, socketGemID := range item.Gems {
+					if socketGemID == gemID {
+						item.Gems[socket] = 0
+					}
+				}
+			}
+		})
+		if HasItemEffect(gemID) {
+			gemSim.disabledRequest = withDisabledEffects(&proto.DisabledEffects{ItemIds: []int32{gemID}})
+		}
+		sims = append(sims, gemSim)
+		gemSims[gemID] = gemSim
+	}
+
+	// Items are valued net of their enchant and gems, so the stats of those are
+	// taken off too. Socket bonuses are lost along with the gems, so they count
+	// towards the gems.
+	for i, item := range basePlayer.Equipment.Items {
+		itemSim := itemSims[i]
+		if itemSim == nil {
+			continue
+		}
+		for _, gemID := range item.Gems {
+			if gemID != 0 {
+				itemSim.parts = append(itemSim.parts, itemAttributionPart{sim: gemSims[gemID], share: 1 / float64(gemCounts[gemID])})
+			}
+		}
+
+		itemStats := stats.FromProtoArray(itemSim.attribution.Stats.Stats)
+		for _, part := range itemSim.parts {
+			itemStats = itemStats.Subtract(stats.FromProtoArray(part.sim.attribution.Stats.Stats).Multiply(part.share))
+		}
+		itemSim.attribution.Stats.Stats = itemStats.ToProtoArray()
+	}
+
+	equipment := ProtoToEquipment(basePlayer.Equipment)
+	for _, setBonus := range equipment.getSetBonuses() {
+		disabledRequest := withDisabledEffects(&proto.DisabledEffects{SetBonuses: []string{setBonus.String()}})
+		sims = append(sims, &itemAttributionSim{
+			attribution: &proto.ItemAttribution{
+				Source:   proto.ItemAttribution_SetBonus,
+				SetBonus: setBonus.String(),
+				Stats:    &proto.UnitStats{Stats: stats.Stats{}.ToProtoArray()},
+			},
+			removedRequest:  disabledRequest,
+			disabledRequest: disabledRequest,
+		})
+	}
+
+	return baseRequest, sims
+}
+
+func playerDpsValues(result *proto.RaidSimResult) []float64 {
+	return result.RaidMetrics.Parties[0].Players[0].Dps.AllValues
+}
+
+// Returns the values of the sim with an item removed, with the DPS lost from
+// each of its parts added back, so the difference to the baseline is the
+// value of the item alone.
+func withPartsAddedBack(baselineValues []float64, removedValues []float64, parts []itemAttributionPart, partValues map[*itemAttributionSim][]float64) []float64 {
+	n := min(len(baselineValues), len(removedValues))
+	for _, part := range parts {
+		n = min(n, len(partValues[part.sim]))
+	}
+
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = removedValues[i]
+		for _, part := range parts {
+			values[i] += part.share * (baselineValues[i] - partValues[part.sim][i])
+		}
+	}
+	return values
+}
+
+// Run item attribution sims and compute the DPS of each.
+func runItemAttribution(request *proto.ItemAttributionRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals) *proto.ItemAttributionResult {
+	if request.Player.GetDatabase() != nil {
+		addToDatabase(request.Player.GetDatabase())
+	}
+
+	baseRequest, sims := buildItemAttributionRequests(request)
+
+	// Set bonuses use the same request for both, so only need one sim.
+	var requests []*proto.RaidSimRequest
+	for _, attributionSim := range sims {
+		requests = append(requests, attributionSim.removedRequest)
+		if attributionSim.disabledRequest != nil && attributionSim.disabledRequest != attributionSim.removedRequest {
+			requests = append(requests, attributionSim.disabledRequest)
+		}
+	}
+
+	baselineResult, results, errorOutcome := runPairedSims(baseRequest, requests, progress, signals)
+	if errorOutcome != nil {
+		return &proto.ItemAttributionResult{Error: errorOutcome}
+	}
+
+	result := &proto.ItemAttributionResult{
+		Dps: baselineResult.RaidMetrics.Parties[0].Players[0].Dps.Avg,
+	}
+
+	// Results are in the same order as the requests were added.
+	nextValues := func() []float64 {
+		values := playerDpsValues(results[0])
+		results = results[1:]
+		return values
+	}
+
+	baselineValues := playerDpsValues(baselineResult)
+	removedValues := make(map[*itemAttributionSim][]float64, len(sims))
+	for _, attributionSim := range sims {
+		attribution := attributionSim.attribution
+		removedValues[attributionSim] = nextValues()
+		attribution.Dps, attribution.DpsStdev = pairedDifference(baselineValues, removedValues[attributionSim])
+
+		if attributionSim.disabledRequest == attributionSim.removedRequest {
+			attribution.HasEffect = true
+			attribution.EffectDps, attribution.EffectDpsStdev = attribution.Dps, attribution.DpsStdev
+		} else if attributionSim.disabledRequest != nil {
+			attribution.HasEffect = true
+			attribution.EffectDps, attribution.EffectDpsStdev = pairedDifference(baselineValues, nextValues())
+		}
+
+		result.Attributions = append(result.Attributions, attribution)
+	}
+
+	for _, attributionSim := range sims {
+		if len(attributionSim.parts) > 0 {
+			itemValues := withPartsAddedBack(baselineValues, removedValues[attributionSim], attributionSim.parts, removedValues)
+			attributionSim.attribution.Dps, attributionSim.attribution.DpsStdev = pairedDifference(baselineValues, itemValues)
+		}
+	}
+
+	return result
+}
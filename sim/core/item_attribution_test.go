@@ -0,0 +1,181 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+const (
+	attributionHelm          = 900001
+	attributionShoulders     = 900002
+	attributionGem           = 900003
+	attributionEnchant       = 900004
+	attributionEffectEnchant = 900005
+	attributionWeapon        = 900006
+)
+
+var attributionDatabase = &proto.SimDatabase{
+	Items: []*proto.SimItem{
+		{
+			Id:          attributionHelm,
+			Type:        proto.ItemType_ItemTypeHead,
+			Stats:       stats.Stats{stats.Stamina: 100}.ToProtoArray(),
+			GemSockets:  []proto.GemColor{proto.GemColor_GemColorRed},
+			SocketBonus: stats.Stats{stats.Strength: 10}.ToProtoArray(),
+		},
+		{
+			Id:          attributionShoulders,
+			Type:        proto.ItemType_ItemTypeShoulder,
+			Stats:       stats.Stats{stats.Stamina: 50}.ToProtoArray(),
+			GemSockets:  []proto.GemColor{proto.GemColor_GemColorRed},
+			SocketBonus: stats.Stats{stats.Strength: 10}.ToProtoArray(),
+		},
+		{
+			Id:              attributionWeapon,
+			Type:            proto.ItemType_ItemTypeWeapon,
+			WeaponType:      proto.WeaponType_WeaponTypeAxe,
+			HandType:        proto.HandType_HandTypeOneHand,
+			WeaponDamageMin: 100,
+			WeaponDamageMax: 200,
+			WeaponSpeed:     2.6,
+		},
+	},
+	Gems: []*proto.SimGem{
+		{Id: attributionGem, Color: proto.GemColor_GemColorRed, Stats: stats.Stats{stats.Strength: 20}.ToProtoArray()},
+	},
+	Enchants: []*proto.SimEnchant{
+		{EffectId: attributionEnchant, Stats: stats.Stats{stats.Agility: 30}.ToProtoArray()},
+		{EffectId: attributionEffectEnchant},
+	},
+}
+
+func attributionEquipment() *proto.EquipmentSpec {
+	spec := &proto.EquipmentSpec{
+		Items: make([]*proto.ItemSpec, proto.ItemSlot_ItemSlotRanged+1),
+	}
+	for i := range spec.Items {
+		spec.Items[i] = &proto.ItemSpec{}
+	}
+	spec.Items[proto.ItemSlot_ItemSlotHead] = &proto.ItemSpec{Id: attributionHelm, Enchant: attributionEnchant, Gems: []int32{attributionGem}}
+	spec.Items[proto.ItemSlot_ItemSlotShoulder] = &proto.ItemSpec{Id: attributionShoulders, Gems: []int32{attributionGem}}
+	return spec
+}
+
+func TestItemAttributionRequests(t *testing.T) {
+	addToDatabase(attributionDatabase)
+
+	_, sims := buildItemAttributionRequests(&proto.ItemAttributionRequest{
+		Player: &proto.Player{
+			Class:     proto.Class_ClassShaman,
+			Equipment: attributionEquipment(),
+		},
+		Encounter:  &proto.Encounter{},
+		SimOptions: &proto.SimOptions{Iterations: 10},
+	})
+
+	expected := []struct {
+		source proto.ItemAttribution_Source
+		id     int32
+		count  int32
+		stats  stats.Stats
+	}{
+		// Items don't include the stats of their enchant and gems.
+		{proto.ItemAttribution_Item, attributionHelm, 0, stats.Stats{stats.Stamina: 100}},
+		{proto.ItemAttribution_Enchant, attributionEnchant, 0, stats.Stats{stats.Agility: 30}},
+		{proto.ItemAttribution_Item, attributionShoulders, 0, stats.Stats{stats.Stamina: 50}},
+		{proto.ItemAttribution_Gem, attributionGem, 2, stats.Stats{stats.Strength: 60}},
+	}
+	if len(sims) != len(expected) {
+		t.Fatalf("Expected %d attributions, got %d", len(expected), len(sims))
+	}
+
+	for i, exp := range expected {
+		attribution := sims[i].attribution
+		if attribution.Source != exp.source || attribution.Id != exp.id || attribution.Count != exp.count {
+			t.Errorf("Attribution %d: expected %s %d (x%d), got %s %d (x%d)", i, exp.source, exp.id, exp.count, attribution.Source, attribution.Id, attribution.Count)
+		}
+		if actual := stats.FromProtoArray(attribution.Stats.Stats); !actual.Equals(exp.stats) {
+			t.Errorf("Attribution %d: expected stats %s, got %s", i, exp.stats, actual)
+		}
+		if sims[i].disabledRequest != nil {
+			t.Errorf("Attribution %d: expected no effect to be disabled", i)
+		}
+	}
+
+	// The helm has the enchant and one of the two gems, the shoulders the other gem.
+	helm, enchant, shoulders, gem := sims[0], sims[1], sims[2], sims[3]
+	if len(helm.parts) != 2 || helm.parts[0] != (itemAttributionPart{sim: enchant, share: 1}) || helm.parts[1] != (itemAttributionPart{sim: gem, share: 0.5}) {
+		t.Errorf("Expected the helm to be valued net of its enchant and half the gems, got %v", helm.parts)
+	}
+	if len(shoulders.parts) != 1 || shoulders.parts[0] != (itemAttributionPart{sim: gem, share: 0.5}) {
+		t.Errorf("Expected the shoulders to be valued net of half the gems, got %v", shoulders.parts)
+	}
+}
+
+func TestItemValuesWithoutParts(t *testing.T) {
+	enchant, gem := &itemAttributionSim{}, &itemAttributionSim{}
+	parts := []itemAttributionPart{{sim: enchant, share: 1}, {sim: gem, share: 0.5}}
+	partValues := map[*itemAttributionSim][]float64{
+		enchant: {90, 95},
+		gem:     {96, 98},
+	}
+
+	baselineValues := []float64{100, 100}
+	itemValues := withPartsAddedBack(baselineValues, []float64{70, 60}, parts, partValues)
+	if dps, _ := pairedDifference(baselineValues, itemValues); dps != 26 {
+		t.Errorf("Expected the item alone to be worth 26 DPS, got %0.3f", dps)
+	}
+}
+
+func TestDisabledEffects(t *testing.T) {
+	addToDatabase(attributionDatabase)
+
+	applied := 0
+	if !HasEnchantEffect(attributionEffectEnchant) {
+		NewEnchantEffect(attributionEffectEnchant, func(agent Agent) {
+			applied++
+		})
+	}
+
+	for _, disabledSlots := range [][]proto.ItemSlot{
+		nil,
+		{proto.ItemSlot_ItemSlotHead},
+		{proto.ItemSlot_ItemSlotHead, proto.ItemSlot_ItemSlotShoulder},
+	} {
+		applied = 0
+
+		equipment := attributionEquipment()
+		equipment.Items[proto.ItemSlot_ItemSlotHead].Enchant = attributionEffectEnchant
+		equipment.Items[proto.ItemSlot_ItemSlotShoulder].Enchant = attributionEffectEnchant
+		player := newTestPlayer("Player")
+		player.Equipment = equipment
+		player.DisabledEffects = &proto.DisabledEffects{EnchantSlots: disabledSlots}
+
+		newTestSim(newTestRaidSimRequest(player))
+
+		// The copy of the enchant in the other slot still applies the effect.
+		if expected := Ternary(len(disabledSlots) == 2, 0, 1); applied != expected {
+			t.Errorf("Expected the enchant effect to be applied %d times with %v disabled, got %d", expected, disabledSlots, applied)
+		}
+	}
+}
+
+func TestDisabledWeaponEnchantSlot(t *testing.T) {
+	addToDatabase(attributionDatabase)
+
+	equipment := attributionEquipment()
+	equipment.Items[proto.ItemSlot_ItemSlotMainHand] = &proto.ItemSpec{Id: attributionWeapon, Enchant: attributionEffectEnchant}
+	equipment.Items[proto.ItemSlot_ItemSlotOffHand] = &proto.ItemSpec{Id: attributionWeapon, Enchant: attributionEffectEnchant}
+	player := newTestPlayer("Player")
+	player.Equipment = equipment
+	player.DisabledEffects = &proto.DisabledEffects{EnchantSlots: []proto.ItemSlot{proto.ItemSlot_ItemSlotMainHand}}
+
+	sim := newTestSim(newTestRaidSimRequest(player))
+	character := sim.Raid.Parties[0].Players[0].GetCharacter()
+
+	if procMask := character.getCurrentProcMaskForWeaponEnchant(attributionEffectEnchant); procMask != ProcMaskMeleeOH {
+		t.Errorf("Expected only the off hand to proc the enchant, got proc mask %d", procMask)
+	}
+}
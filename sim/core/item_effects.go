@@ -94,27 +94,30 @@ func AddWeaponEffect(id int32, weaponEffect ApplyWeaponEffect) {
 	weaponEffects[id] = weaponEffect
 }
 
-func (equipment *Equipment) applyItemEffects(agent Agent, registeredItemEffects map[int32]bool, registeredItemEnchantEffects map[int32]bool, includeGemEffects bool) {
+func (equipment *Equipment) applyItemEffects(agent Agent, registeredItemEffects map[int32]bool, registeredItemEnchantEffects map[int32]bool, includeGemEffects bool, disabledEffects *proto.DisabledEffects) {
 	for slot, eq := range equipment {
-		if applyItemEffect, ok := itemEffects[eq.ID]; ok && !registeredItemEffects[eq.ID] {
+		itemDisabled := slices.Contains(disabledEffects.GetItemSlots(), proto.ItemSlot(slot))
+		enchantDisabled := slices.Contains(disabledEffects.GetEnchantSlots(), proto.ItemSlot(slot))
+
+		if applyItemEffect, ok := itemEffects[eq.ID]; ok && !registeredItemEffects[eq.ID] && !itemDisabled {
 			applyItemEffect(agent)
 			registeredItemEffects[eq.ID] = true
 		}
 
 		if includeGemEffects {
 			for _, g := range eq.Gems {
-				if applyGemEffect, ok := itemEffects[g.ID]; ok {
+				if applyGemEffect, ok := itemEffects[g.ID]; ok && !registeredItemEffects[g.ID] {
 					applyGemEffect(agent)
 				}
 			}
 		}
 
-		if applyEnchantEffect, ok := enchantEffects[eq.Enchant.EffectID]; ok && !registeredItemEnchantEffects[eq.Enchant.EffectID] {
+		if applyEnchantEffect, ok := enchantEffects[eq.Enchant.EffectID]; ok && !registeredItemEnchantEffects[eq.Enchant.EffectID] && !enchantDisabled {
 			applyEnchantEffect(agent)
 			registeredItemEnchantEffects[eq.Enchant.EffectID] = true
 		}
 
-		if applyWeaponEffect, ok := weaponEffects[eq.Enchant.EffectID]; ok && !registeredItemEnchantEffects[eq.Enchant.EffectID] {
+		if applyWeaponEffect, ok := weaponEffects[eq.Enchant.EffectID]; ok && !registeredItemEnchantEffects[eq.Enchant.EffectID] && !enchantDisabled {
 			applyWeaponEffect(agent, proto.ItemSlot(slot))
 			registeredItemEnchantEffects[eq.Enchant.EffectID] = true
 		}
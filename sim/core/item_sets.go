@@ -107,6 +107,10 @@ type SetBonus struct {
 	Slots []proto.ItemSlot
 }
 
+func (setBonus SetBonus) String() string {
+	return fmt.Sprintf("%s (%dpc)", setBonus.Name, setBonus.NumPieces)
+}
+
 type SetBonusCollection []SetBonus
 
 // Returns a list describing all active set bonuses.
@@ -209,6 +213,9 @@ func (character *Character) applyItemSetBonusEffects(agent Agent) {
 
 	for _, activeSetBonus := range activeSetBonuses {
 		setBonusAura := character.makeSetBonusStatusAura(activeSetBonus.Name, activeSetBonus.NumPieces, activeSetBonus.Slots, true)
+		if slices.Contains(character.disabledEffects.GetSetBonuses(), activeSetBonus.String()) {
+			continue
+		}
 		activeSetBonus.BonusEffect(agent, setBonusAura)
 	}
 
@@ -253,7 +260,7 @@ func (character *Character) GetActiveSetBonusNames() []string {
 
 	names := make([]string, len(activeSetBonuses))
 	for i, activeSetBonus := range activeSetBonuses {
-		names[i] = fmt.Sprintf("%s (%dpc)", activeSetBonus.Name, activeSetBonus.NumPieces)
+		names[i] = activeSetBonus.String()
 	}
 	return names
 }
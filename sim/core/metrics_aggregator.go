@@ -24,6 +24,11 @@ type DistributionMetrics struct {
 	minSeed int64
 	hist    map[int32]int32 // rounded DPS to count
 	sample  []float64
+
+	// Kept for percentiles, but only included in results when they still need
+	// to be combined with other split sims.
+	sketch     quantileSketch
+	saveSketch bool
 }
 
 func (distMetrics *DistributionMetrics) reset() {
@@ -32,32 +37,44 @@ func (distMetrics *DistributionMetrics) reset() {
 
 // This should be called when a Sim iteration is complete.
 func (distMetrics *DistributionMetrics) doneIteration(sim *Simulation) {
-	dps := distMetrics.Total / sim.Duration.Seconds()
-	distMetrics.add(dps)
+	distMetrics.addValue(sim, distMetrics.Total/sim.Duration.Seconds())
+}
+
+// Records the value for a completed iteration, for distributions which aren't
+// a total over the fight duration.
+func (distMetrics *DistributionMetrics) addValue(sim *Simulation, value float64) {
+	distMetrics.add(value)
+	distMetrics.sketch.add(value)
+	distMetrics.saveSketch = sim.Options.SaveQuantileSketches
 
 	if sim.Options.SaveAllValues {
 		if cap(distMetrics.sample) < int(sim.Options.Iterations) {
 			distMetrics.sample = make([]float64, 0, sim.Options.Iterations)
 		}
-		distMetrics.sample = append(distMetrics.sample, dps)
+		distMetrics.sample = append(distMetrics.sample, value)
 	}
 
-	if dps > distMetrics.max {
-		distMetrics.max = dps
+	if value > distMetrics.max {
+		distMetrics.max = value
 		distMetrics.maxSeed = sim.rand.GetSeed()
 	}
-	if dps <= distMetrics.min || distMetrics.min < 0 {
-		distMetrics.min = dps
+	if value <= distMetrics.min || distMetrics.min < 0 {
+		distMetrics.min = value
 		distMetrics.minSeed = sim.rand.GetSeed()
 	}
 
-	dpsRounded := int32(math.Round(dps/25) * 25)
-	distMetrics.hist[dpsRounded]++
+	valueRounded := int32(math.Round(value/25) * 25)
+	distMetrics.hist[valueRounded]++
 }
 
 func (distMetrics *DistributionMetrics) ToProto() *proto.DistributionMetrics {
 	mean, stdev := distMetrics.meanAndStdDev()
 
+	var sketch *proto.QuantileSketch
+	if distMetrics.saveSketch {
+		sketch = distMetrics.sketch.ToProto()
+	}
+
 	return &proto.DistributionMetrics{
 		Avg:       mean,
 		Stdev:     stdev,
@@ -69,9 +86,11 @@ func (distMetrics *DistributionMetrics) ToProto() *proto.DistributionMetrics {
 		AllValues: distMetrics.sample,
 
 		AggregatorData: &proto.AggregatorData{
-			N:     int32(distMetrics.n),
-			SumSq: distMetrics.sumSq,
+			N:      int32(distMetrics.n),
+			SumSq:  distMetrics.sumSq,
+			Sketch: sketch,
 		},
+		Percentiles: distMetrics.sketch.PercentilesProto(),
 	}
 }
 
@@ -101,13 +120,27 @@ type UnitMetrics struct {
 	oomTimeSum   float64
 	actions      map[ActionID]*ActionMetrics
 	resources    []*ResourceMetrics
+
+	tankingWindows []*TankingWindowMetrics
+	aggro          []*AggroMetrics
+
+	// Only set for players, and only reported for those hit by an enemy.
+	survival *survivalMetrics
+
+	// Only set when timeline metrics are enabled in the sim options.
+	timeline *timelineMetrics
+
+	// Only set when cooldown timings are enabled, for characters with major
+	// cooldowns and their pets.
+	cooldowns *cooldownTimingMetrics
 }
 
 // Metrics for the current iteration, for 1 agent. Keep this as a separate
 // struct, so it's easy to clear.
 type CharacterIterationMetrics struct {
-	Died    bool // Whether this unit died in the current iteration.
-	WentOOM bool // Whether the agent has hit OOM at least once in this iteration.
+	Died        bool          // Whether this unit died in the current iteration.
+	TimeOfDeath time.Duration // Timestamp at which the unit died, if it did.
+	WentOOM     bool          // Whether the agent has hit OOM at least once in this iteration.
 
 	ManaSpent  float64
 	ManaGained float64
@@ -126,6 +159,47 @@ type ActionMetrics struct {
 	Targets []TargetedActionMetrics
 }
 
+type TankingWindowMetrics struct {
+	TargetIndex int32
+	Window      int32
+
+	// Aggregate values, summed over all iterations in which this window occurred.
+	iterations  int32
+	startSum    float64
+	durationSum float64
+	dtpsSum     float64
+	tmiSum      float64
+}
+
+func (twm *TankingWindowMetrics) ToProto() *proto.TankingWindowMetrics {
+	n := float64(twm.iterations)
+	return &proto.TankingWindowMetrics{
+		TargetIndex:        twm.TargetIndex,
+		Window:             twm.Window,
+		Iterations:         twm.iterations,
+		StartSecondsAvg:    twm.startSum / n,
+		DurationSecondsAvg: twm.durationSum / n,
+		DtpsAvg:            twm.dtpsSum / n,
+		TmiAvg:             twm.tmiSum / n,
+	}
+}
+
+type AggroMetrics struct {
+	TargetIndex int32
+
+	// Aggregate values, summed over all iterations.
+	pullsSum       int32
+	timeTankingSum time.Duration
+}
+
+func (am *AggroMetrics) ToProto(numIterations float64) *proto.AggroMetrics {
+	return &proto.AggroMetrics{
+		TargetIndex:       am.TargetIndex,
+		PullsAvg:          float64(am.pullsSum) / numIterations,
+		SecondsTankingAvg: am.timeTankingSum.Seconds() / numIterations,
+	}
+}
+
 type tmiListItem struct {
 	Timestamp      time.Duration
 	WeightedDamage float64
@@ -172,7 +246,10 @@ type SpellMetrics struct {
 	TotalHealing         float64 // Healing done by all casts of this spell.
 	TotalCritHealing     float64 // Healing done by all critical casts of this spell.
 	TotalShielding       float64 // Shielding done by all casts of this spell.
+	TotalOverhealing     float64 // Healing done by all casts of this spell beyond the target's missing health.
+	TotalShieldsApplied  float64 // Absorb shields placed by all casts of this spell.
 	TotalCastTime        time.Duration
+	Interrupts           int32 // Number of casts of this spell that interrupted a target's cast.
 }
 
 type TargetedActionMetrics struct {
@@ -201,7 +278,10 @@ type TargetedActionMetrics struct {
 	Healing         float64
 	CritHealing     float64
 	Shielding       float64
+	Overhealing     float64
+	ShieldsApplied  float64
 	CastTime        time.Duration
+	Interrupts      int32
 }
 
 func (tam *TargetedActionMetrics) ToProto() *proto.TargetedActionMetrics {
@@ -230,7 +310,10 @@ func (tam *TargetedActionMetrics) ToProto() *proto.TargetedActionMetrics {
 		Healing:         tam.Healing,
 		CritHealing:     tam.CritHealing,
 		Shielding:       tam.Shielding,
+		Overhealing:     tam.Overhealing,
+		ShieldsApplied:  tam.ShieldsApplied,
 		CastTimeMs:      float64(tam.CastTime.Milliseconds()),
+		Interrupts:      tam.Interrupts,
 	}
 }
 
@@ -254,8 +337,12 @@ type ResourceMetrics struct {
 	Gain       float64
 	ActualGain float64
 
-	EventsFromPreviousIterations     int32
-	ActualGainFromPreviousIterations float64
+	EventsFromPreviousIterations int32
+	// Gains are summed per iteration and only added to the totals when the
+	// next iteration starts, so neither depends on the iterations before it
+	// or on how iterations are split between threads.
+	GainForIteration       float64
+	ActualGainForIteration float64
 }
 
 func (resourceMetrics *ResourceMetrics) ToProto() *proto.ResourceMetrics {
@@ -264,26 +351,29 @@ func (resourceMetrics *ResourceMetrics) ToProto() *proto.ResourceMetrics {
 		Type: resourceMetrics.Type,
 
 		Events:     resourceMetrics.Events,
-		Gain:       resourceMetrics.Gain,
-		ActualGain: resourceMetrics.ActualGain,
+		Gain:       resourceMetrics.Gain + resourceMetrics.GainForIteration,
+		ActualGain: resourceMetrics.ActualGain + resourceMetrics.ActualGainForIteration,
 	}
 }
 
 func (resourceMetrics *ResourceMetrics) reset() {
 	resourceMetrics.EventsFromPreviousIterations = resourceMetrics.Events
-	resourceMetrics.ActualGainFromPreviousIterations = resourceMetrics.ActualGain
+	resourceMetrics.Gain += resourceMetrics.GainForIteration
+	resourceMetrics.ActualGain += resourceMetrics.ActualGainForIteration
+	resourceMetrics.GainForIteration = 0
+	resourceMetrics.ActualGainForIteration = 0
 }
 func (resourceMetrics *ResourceMetrics) EventsForCurrentIteration() int32 {
 	return resourceMetrics.Events - resourceMetrics.EventsFromPreviousIterations
 }
 func (resourceMetrics *ResourceMetrics) ActualGainForCurrentIteration() float64 {
-	return resourceMetrics.ActualGain - resourceMetrics.ActualGainFromPreviousIterations
+	return resourceMetrics.ActualGainForIteration
 }
 
 func (resourceMetrics *ResourceMetrics) AddEvent(gain float64, actualGain float64) {
 	resourceMetrics.Events++
-	resourceMetrics.Gain += gain
-	resourceMetrics.ActualGain += actualGain
+	resourceMetrics.GainForIteration += gain
+	resourceMetrics.ActualGainForIteration += actualGain
 }
 
 func (unitMetrics *UnitMetrics) NewResourceMetrics(actionID ActionID, resourceType proto.ResourceType) *ResourceMetrics {
@@ -382,6 +472,9 @@ func (unitMetrics *UnitMetrics) addSpellMetrics(spell *Spell, actionID ActionID,
 		tam.Healing += spellTargetMetrics.TotalHealing
 		tam.CritHealing += spellTargetMetrics.TotalCritHealing
 		tam.Shielding += spellTargetMetrics.TotalShielding
+		tam.Overhealing += spellTargetMetrics.TotalOverhealing
+		tam.ShieldsApplied += spellTargetMetrics.TotalShieldsApplied
+		tam.Interrupts += spellTargetMetrics.Interrupts
 		if !spell.Flags.Matches(SpellFlagPassiveSpell) {
 			tam.CastTime += spellTargetMetrics.TotalCastTime
 		}
@@ -459,7 +552,7 @@ func (unitMetrics *UnitMetrics) doneIteration(unit *Unit, sim *Simulation) {
 		unitMetrics.tto.Total *= encounterDurationSeconds
 	}
 
-	if unitMetrics.isTanking {
+	if unitMetrics.isTanking || (unitMetrics.survival != nil && unitMetrics.survival.hitByEnemy) {
 		unitMetrics.tmi.Total = unitMetrics.calculateTMI(unit, sim)
 
 		// Hack because of the way DistributionMetrics does its calculations.
@@ -477,28 +570,45 @@ func (unitMetrics *UnitMetrics) doneIteration(unit *Unit, sim *Simulation) {
 	if unitMetrics.Died {
 		unitMetrics.numItersDead++
 	}
+
+	if unitMetrics.survival != nil {
+		unitMetrics.survival.doneIteration(sim)
+	}
+
+	if unitMetrics.timeline != nil {
+		unitMetrics.timeline.doneIteration(sim)
+	}
+
+	if unitMetrics.cooldowns != nil {
+		unitMetrics.cooldowns.doneIteration(sim)
+	}
 }
 
 func (unitMetrics *UnitMetrics) calculateTMI(unit *Unit, sim *Simulation) float64 {
-	if unit.Metrics.tmiList == nil || unitMetrics.tmiBin == 0 {
+	return unitMetrics.calculateWindowTMI(0, sim.Duration)
+}
+
+// Calculates TMI from the damage taken between start and end.
+func (unitMetrics *UnitMetrics) calculateWindowTMI(start time.Duration, end time.Duration) float64 {
+	if unitMetrics.tmiList == nil || unitMetrics.tmiBin == 0 {
 		return 0
 	}
 
 	bin := int(unitMetrics.tmiBin) // Seconds width for bin, default = 6
 	firstEvent := 0                // Marks event at start of current bin
 	ev := 0                        // Marks event at end of current bin
-	lastEvent := len(unit.Metrics.tmiList)
+	lastEvent := len(unitMetrics.tmiList)
 	var buckets []float64
 
 	// Traverse event array via marching time bins
-	for tStep := 0; float64(tStep) < sim.Duration.Seconds()-float64(bin); tStep++ {
+	for tStep := int(start.Seconds()); float64(tStep) < end.Seconds()-float64(bin); tStep++ {
 
 		// Increment event counter until we exceed the bin start
-		for ; firstEvent < lastEvent && unit.Metrics.tmiList[firstEvent].Timestamp.Seconds() < float64(tStep); firstEvent++ {
+		for ; firstEvent < lastEvent && unitMetrics.tmiList[firstEvent].Timestamp.Seconds() < float64(tStep); firstEvent++ {
 		}
 
 		// Increment event counter until we exceed the bin end
-		for ; ev < lastEvent && unit.Metrics.tmiList[ev].Timestamp.Seconds() < float64(tStep+bin); ev++ {
+		for ; ev < lastEvent && unitMetrics.tmiList[ev].Timestamp.Seconds() < float64(tStep+bin); ev++ {
 		}
 
 		if ev-firstEvent > 0 {
@@ -506,7 +616,7 @@ func (unitMetrics *UnitMetrics) calculateTMI(unit *Unit, sim *Simulation) float6
 
 			// Add up everything in the bin
 			for j := firstEvent; j < ev; j++ {
-				sum += unit.Metrics.tmiList[j].WeightedDamage
+				sum += unitMetrics.tmiList[j].WeightedDamage
 			}
 
 			//if sim.Log != nil {
@@ -551,6 +661,56 @@ func (unitMetrics *UnitMetrics) calculateTMI(unit *Unit, sim *Simulation) float6
 
 }
 
+// Records a finished tanking window for the current iteration.
+func (unitMetrics *UnitMetrics) addTankingWindow(targetIndex int32, window int32, start time.Duration, end time.Duration, damage float64) {
+	var twm *TankingWindowMetrics
+	for _, existing := range unitMetrics.tankingWindows {
+		if existing.TargetIndex == targetIndex && existing.Window == window {
+			twm = existing
+			break
+		}
+	}
+
+	if twm == nil {
+		twm = &TankingWindowMetrics{
+			TargetIndex: targetIndex,
+			Window:      window,
+		}
+		unitMetrics.tankingWindows = append(unitMetrics.tankingWindows, twm)
+	}
+
+	duration := end - start
+	twm.iterations++
+	twm.startSum += start.Seconds()
+	twm.durationSum += duration.Seconds()
+	if duration > 0 {
+		twm.dtpsSum += damage / duration.Seconds()
+	}
+	twm.tmiSum += unitMetrics.calculateWindowTMI(start, end)
+}
+
+func (unitMetrics *UnitMetrics) getAggroMetrics(targetIndex int32) *AggroMetrics {
+	for _, am := range unitMetrics.aggro {
+		if am.TargetIndex == targetIndex {
+			return am
+		}
+	}
+
+	am := &AggroMetrics{
+		TargetIndex: targetIndex,
+	}
+	unitMetrics.aggro = append(unitMetrics.aggro, am)
+	return am
+}
+
+func (unitMetrics *UnitMetrics) addAggroPull(targetIndex int32) {
+	unitMetrics.getAggroMetrics(targetIndex).pullsSum++
+}
+
+func (unitMetrics *UnitMetrics) addTimeTanking(targetIndex int32, duration time.Duration) {
+	unitMetrics.getAggroMetrics(targetIndex).timeTankingSum += duration
+}
+
 func (unitMetrics *UnitMetrics) ToProto() *proto.UnitMetrics {
 	n := float64(unitMetrics.dps.n)
 	protoMetrics := &proto.UnitMetrics{
@@ -576,6 +736,28 @@ func (unitMetrics *UnitMetrics) ToProto() *proto.UnitMetrics {
 		}
 	}
 
+	protoMetrics.TankingWindows = make([]*proto.TankingWindowMetrics, 0, len(unitMetrics.tankingWindows))
+	for _, window := range unitMetrics.tankingWindows {
+		protoMetrics.TankingWindows = append(protoMetrics.TankingWindows, window.ToProto())
+	}
+
+	protoMetrics.Aggro = make([]*proto.AggroMetrics, 0, len(unitMetrics.aggro))
+	for _, am := range unitMetrics.aggro {
+		protoMetrics.Aggro = append(protoMetrics.Aggro, am.ToProto(n))
+	}
+
+	if unitMetrics.survival != nil && unitMetrics.survival.rawDamageSum > 0 {
+		protoMetrics.Survival = unitMetrics.survival.ToProto(n)
+	}
+
+	if unitMetrics.timeline != nil {
+		protoMetrics.Timeline = unitMetrics.timeline.ToProto(n)
+	}
+
+	if unitMetrics.cooldowns != nil {
+		protoMetrics.Cooldowns = unitMetrics.cooldowns.ToProto(n)
+	}
+
 	return protoMetrics
 }
 
@@ -583,29 +765,99 @@ type AuraMetrics struct {
 	ID ActionID
 
 	// Metrics for the current iteration.
-	Uptime time.Duration
-	Procs  int32
+	Uptime     time.Duration
+	Procs      int32
+	Refreshes  int32
+	Triggers   int32
+	IcdBlocked float64 // Procs blocked by the ICD, weighted by their chance to have happened.
+
+	lastProcAt       time.Duration
+	lastStacksChange time.Duration
+	procIntervals    []time.Duration
+	stackUptimes     []float64
 
 	// Aggregate values. These are updated after each iteration.
 	aggregator
-	procsSum int32
+	procsSum      int32
+	refreshesSum  int32
+	triggersSum   int32
+	icdBlockedSum float64
+
+	procIntervalHist []int32
+	procIntervalSum  float64
+	stackUptime      []float64
 }
 
 func (auraMetrics *AuraMetrics) reset() {
 	auraMetrics.Uptime = 0
 	auraMetrics.Procs = 0
+	auraMetrics.Refreshes = 0
+	auraMetrics.Triggers = 0
+	auraMetrics.IcdBlocked = 0
+	auraMetrics.lastProcAt = NeverExpires
+	auraMetrics.procIntervals = auraMetrics.procIntervals[:0]
+	clear(auraMetrics.stackUptimes)
+}
+
+// Records the interval since the previous proc in this iteration, if any.
+// Prepull actions can activate auras out of order, so those are skipped.
+func (auraMetrics *AuraMetrics) addProc(procAt time.Duration) {
+	if auraMetrics.lastProcAt != NeverExpires && procAt >= auraMetrics.lastProcAt {
+		auraMetrics.procIntervals = append(auraMetrics.procIntervals, procAt-auraMetrics.lastProcAt)
+	}
+	auraMetrics.lastProcAt = procAt
+}
+
+// Records a proc trigger event which was blocked by the ICD, and which would
+// otherwise have proc'd with the given chance.
+func (auraMetrics *AuraMetrics) addIcdBlocked(procChance float64) {
+	auraMetrics.Triggers++
+	auraMetrics.IcdBlocked += procChance
+}
+
+// Adds the time since the last change in stacks to the uptime of the given
+// stack count. Time before the start of the fight isn't counted.
+func (auraMetrics *AuraMetrics) addStackUptime(stacks int32, end time.Duration) {
+	if start := max(auraMetrics.lastStacksChange, 0); end > start {
+		auraMetrics.stackUptimes = growBuckets(auraMetrics.stackUptimes, int(stacks))
+		auraMetrics.stackUptimes[stacks] += (end - start).Seconds()
+	}
+	auraMetrics.lastStacksChange = end
 }
 
 // This should be called when a Sim iteration is complete.
 func (auraMetrics *AuraMetrics) doneIteration() {
 	auraMetrics.add(auraMetrics.Uptime.Seconds())
 	auraMetrics.procsSum += auraMetrics.Procs
+	auraMetrics.refreshesSum += auraMetrics.Refreshes
+	auraMetrics.triggersSum += auraMetrics.Triggers
+	auraMetrics.icdBlockedSum += auraMetrics.IcdBlocked
+
+	for _, interval := range auraMetrics.procIntervals {
+		idx := int(interval / time.Second)
+		auraMetrics.procIntervalHist = growBuckets(auraMetrics.procIntervalHist, idx)
+		auraMetrics.procIntervalHist[idx]++
+		auraMetrics.procIntervalSum += interval.Seconds()
+	}
+	for stacks, uptime := range auraMetrics.stackUptimes {
+		auraMetrics.stackUptime = growBuckets(auraMetrics.stackUptime, stacks)
+		auraMetrics.stackUptime[stacks] += uptime
+	}
+}
+
+// Sets the proc trigger metrics in an aura's results, which may come from a
+// separate trigger aura sharing its ICD.
+func (auraMetrics *AuraMetrics) setTriggerProto(protoMetrics *proto.AuraMetrics) {
+	protoMetrics.TriggersAvg = float64(auraMetrics.triggersSum) / float64(auraMetrics.n)
+	if auraMetrics.triggersSum > 0 {
+		protoMetrics.IcdBlockedChance = auraMetrics.icdBlockedSum / float64(auraMetrics.triggersSum)
+	}
 }
 
 func (auraMetrics *AuraMetrics) ToProto() *proto.AuraMetrics {
 	mean, stdev := auraMetrics.meanAndStdDev()
 
-	return &proto.AuraMetrics{
+	protoMetrics := &proto.AuraMetrics{
 		Id: auraMetrics.ID.ToProto(),
 
 		UptimeSecondsAvg:   mean,
@@ -616,5 +868,27 @@ func (auraMetrics *AuraMetrics) ToProto() *proto.AuraMetrics {
 			N:     int32(auraMetrics.n),
 			SumSq: auraMetrics.sumSq,
 		},
+
+		RefreshesAvg:     float64(auraMetrics.refreshesSum) / float64(auraMetrics.n),
+		ProcIntervalHist: make(map[int32]int32),
 	}
+
+	numIntervals := int32(0)
+	for idx, count := range auraMetrics.procIntervalHist {
+		if count > 0 {
+			protoMetrics.ProcIntervalHist[int32(idx)] = count
+			numIntervals += count
+		}
+	}
+	if numIntervals > 0 {
+		protoMetrics.ProcIntervalAvg = auraMetrics.procIntervalSum / float64(numIntervals)
+	}
+
+	auraMetrics.setTriggerProto(protoMetrics)
+
+	for _, uptime := range auraMetrics.stackUptime {
+		protoMetrics.StackUptimeSecondsAvg = append(protoMetrics.StackUptimeSecondsAvg, uptime/float64(auraMetrics.n))
+	}
+
+	return protoMetrics
 }
@@ -0,0 +1,161 @@
+package core
+
+import (
+	"time"
+
+	googleProto "google.golang.org/protobuf/proto"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/simsignals"
+)
+
+// Sets up options for sims which are compared to a baseline iteration by
+// iteration, so that RNG lines up between them.
+func preparePairedSimOptions(options *proto.SimOptions) {
+	options.SaveAllValues = true
+
+	// Make sure an RNG seed is always set because it gives more consistent results.
+	// When there is no user-supplied seed it needs to be a randomly-selected seed
+	// though, so that run-run differences still exist.
+	if options.RandomSeed == 0 {
+		options.RandomSeed = time.Now().UnixNano()
+	}
+
+	// Reduce variance even more by using test-level RNG controls.
+	options.UseLabeledRands = true
+}
+
+// Returns the mean and standard deviation of the difference between values
+// and baselineValues in each iteration.
+func pairedDifference(values []float64, baselineValues []float64) (float64, float64) {
+	// With a target error, sims may stop after different numbers of
+	// iterations, so only compare the iterations they all did.
+	var diff aggregator
+	for i := 0; i < min(len(values), len(baselineValues)); i++ {
+		diff.add(values[i] - baselineValues[i])
+	}
+	if diff.n == 0 {
+		return 0, 0
+	}
+	return diff.meanAndStdDev()
+}
+
+// Sims compared iteration by iteration need identical seeds. With a target
+// error the baseline decides how many iterations are done, so the other
+// requests are fixed to that many. Returns a request to redo the baseline with,
+// if its seeds no longer line up with theirs.
+func alignIterationsToBaseline(baseRequest *proto.RaidSimRequest, baselineResult *proto.RaidSimResult, requests []*proto.RaidSimRequest) *proto.RaidSimRequest {
+	baseOptions := baseRequest.SimOptions
+	if baseOptions.TargetError <= 0 || baselineResult.IterationsDone >= baseOptions.Iterations {
+		return nil
+	}
+
+	for _, request := range requests {
+		request.SimOptions.Iterations = baselineResult.IterationsDone
+		request.SimOptions.TargetError = 0
+	}
+
+	// A single sim stops at the end of a contiguous range of seeds, but
+	// concurrent splits stop partway through each of their ranges.
+	if IsRunningInWasm() {
+		return nil
+	}
+
+	rerunRequest := googleProto.Clone(baseRequest).(*proto.RaidSimRequest)
+	rerunRequest.SimOptions.Iterations = baselineResult.IterationsDone
+	rerunRequest.SimOptions.TargetError = 0
+	return rerunRequest
+}
+
+// Runs sims one after another, reporting their combined progress.
+type simSequence struct {
+	progress chan *proto.ProgressMetrics
+	signals  simsignals.Signals
+
+	iterationsTotal int32
+	iterationsDone  int32
+	simsTotal       int32
+	simsCompleted   int32
+}
+
+func newSimSequence(requests []*proto.RaidSimRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals) *simSequence {
+	seq := &simSequence{
+		progress:  progress,
+		signals:   signals,
+		simsTotal: int32(len(requests)),
+	}
+	for _, request := range requests {
+		seq.iterationsTotal += request.SimOptions.Iterations
+	}
+	return seq
+}
+
+func (seq *simSequence) run(request *proto.RaidSimRequest) *proto.RaidSimResult {
+	simFunc := runSimConcurrent
+	// Don't use go threads in wasm, it just adds more overhead and makes the worker more unresponsive.
+	if IsRunningInWasm() {
+		simFunc = RunSim
+	}
+
+	simProgress := make(chan *proto.ProgressMetrics, 100)
+	go simFunc(request, simProgress, seq.signals)
+
+	var lastCompleted int32 = 0
+	for metrics := range simProgress {
+		seq.iterationsDone += metrics.CompletedIterations - lastCompleted
+		lastCompleted = metrics.CompletedIterations
+
+		if seq.progress != nil {
+			seq.progress <- &proto.ProgressMetrics{
+				TotalIterations:     seq.iterationsTotal,
+				CompletedIterations: seq.iterationsDone,
+				CompletedSims:       seq.simsCompleted,
+				TotalSims:           seq.simsTotal,
+			}
+		}
+
+		if metrics.FinalRaidResult != nil {
+			seq.simsCompleted++
+			return metrics.FinalRaidResult
+		}
+	}
+	return nil
+}
+
+// Runs a baseline sim followed by each of the requests, which are compared to
+// it iteration by iteration, and reports their combined progress. Results are
+// returned in the same order as the requests.
+func runPairedSims(baseRequest *proto.RaidSimRequest, requests []*proto.RaidSimRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals) (*proto.RaidSimResult, []*proto.RaidSimResult, *proto.ErrorOutcome) {
+	seq := newSimSequence(append([]*proto.RaidSimRequest{baseRequest}, requests...), progress, signals)
+
+	baselineResult := seq.run(baseRequest)
+	if baselineResult.Error != nil {
+		return nil, nil, baselineResult.Error
+	}
+
+	rerunRequest := alignIterationsToBaseline(baseRequest, baselineResult, requests)
+
+	seq.iterationsTotal = baselineResult.IterationsDone
+	for _, request := range requests {
+		seq.iterationsTotal += request.SimOptions.Iterations
+	}
+	if rerunRequest != nil {
+		seq.iterationsTotal += rerunRequest.SimOptions.Iterations
+		seq.simsTotal++
+
+		baselineResult = seq.run(rerunRequest)
+		if baselineResult.Error != nil {
+			return nil, nil, baselineResult.Error
+		}
+	}
+
+	results := make([]*proto.RaidSimResult, len(requests))
+	for i, request := range requests {
+		results[i] = seq.run(request)
+		if results[i].Error != nil {
+			return nil, nil, results[i].Error
+		}
+	}
+
+	return baselineResult, results, nil
+}
@@ -0,0 +1,179 @@
+package core
+
+import (
+	"maps"
+	"math"
+	"slices"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+// Estimated quantiles are within this fraction of the true value, i.e. 1%.
+const quantileSketchRelativeAccuracy = 0.01
+
+// Values closer to zero than this are counted as zero.
+const quantileSketchMinValue = 1e-9
+
+var quantileSketchGamma = (1 + quantileSketchRelativeAccuracy) / (1 - quantileSketchRelativeAccuracy)
+var quantileSketchLogGamma = math.Log(quantileSketchGamma)
+
+// A mergeable sketch for estimating quantiles of a distribution without
+// keeping every value. Values are counted in buckets whose size grows with
+// their distance from zero, so merging sketches only adds up counts and gives
+// the same quantiles no matter how the values were split between them.
+// Quantiles are estimated within quantileSketchRelativeAccuracy of the true
+// value, except near zero where values below quantileSketchMinValue are
+// counted as zero.
+type quantileSketch struct {
+	positive  map[int32]int32
+	negative  map[int32]int32
+	zeroCount int32
+	count     int32
+	min       float64
+	max       float64
+}
+
+func quantileSketchBucket(value float64) int32 {
+	return int32(math.Ceil(math.Log(value) / quantileSketchLogGamma))
+}
+
+// Returns the value with the same relative error to both ends of a bucket.
+func quantileSketchBucketValue(bucket int32) float64 {
+	return 2 * math.Pow(quantileSketchGamma, float64(bucket)) / (quantileSketchGamma + 1)
+}
+
+func (qs *quantileSketch) add(value float64) {
+	if qs.count == 0 {
+		qs.min, qs.max = value, value
+	} else {
+		qs.min = min(qs.min, value)
+		qs.max = max(qs.max, value)
+	}
+	qs.count++
+
+	if value > quantileSketchMinValue {
+		if qs.positive == nil {
+			qs.positive = make(map[int32]int32)
+		}
+		qs.positive[quantileSketchBucket(value)]++
+	} else if value < -quantileSketchMinValue {
+		if qs.negative == nil {
+			qs.negative = make(map[int32]int32)
+		}
+		qs.negative[quantileSketchBucket(-value)]++
+	} else {
+		qs.zeroCount++
+	}
+}
+
+func mergeQuantileSketchBuckets(base map[int32]int32, add map[int32]int32) map[int32]int32 {
+	if len(add) == 0 {
+		return base
+	}
+	if base == nil {
+		base = make(map[int32]int32, len(add))
+	}
+	for bucket, count := range add {
+		base[bucket] += count
+	}
+	return base
+}
+
+func (qs *quantileSketch) merge(other *quantileSketch) {
+	if other.count == 0 {
+		return
+	}
+	if qs.count == 0 {
+		qs.min, qs.max = other.min, other.max
+	} else {
+		qs.min = min(qs.min, other.min)
+		qs.max = max(qs.max, other.max)
+	}
+
+	qs.positive = mergeQuantileSketchBuckets(qs.positive, other.positive)
+	qs.negative = mergeQuantileSketchBuckets(qs.negative, other.negative)
+	qs.zeroCount += other.zeroCount
+	qs.count += other.count
+}
+
+// Returns the estimated value at quantile q, from 0 to 1.
+func (qs *quantileSketch) quantile(q float64) float64 {
+	if qs.count == 0 {
+		return 0
+	}
+	if q <= 0 {
+		return qs.min
+	}
+	if q >= 1 {
+		return qs.max
+	}
+
+	rank := q * float64(qs.count-1)
+	seen := 0.0
+
+	// Negative buckets go from the most negative value up, so in order of decreasing bucket.
+	negativeBuckets := slices.Sorted(maps.Keys(qs.negative))
+	for i := len(negativeBuckets) - 1; i >= 0; i-- {
+		seen += float64(qs.negative[negativeBuckets[i]])
+		if seen > rank {
+			return Clamp(-quantileSketchBucketValue(negativeBuckets[i]), qs.min, qs.max)
+		}
+	}
+
+	seen += float64(qs.zeroCount)
+	if seen > rank {
+		return Clamp(0, qs.min, qs.max)
+	}
+
+	for _, bucket := range slices.Sorted(maps.Keys(qs.positive)) {
+		seen += float64(qs.positive[bucket])
+		if seen > rank {
+			return Clamp(quantileSketchBucketValue(bucket), qs.min, qs.max)
+		}
+	}
+	return qs.max
+}
+
+func (qs *quantileSketch) PercentilesProto() *proto.Percentiles {
+	return &proto.Percentiles{
+		P1:  qs.quantile(0.01),
+		P5:  qs.quantile(0.05),
+		P25: qs.quantile(0.25),
+		P50: qs.quantile(0.50),
+		P75: qs.quantile(0.75),
+		P95: qs.quantile(0.95),
+		P99: qs.quantile(0.99),
+	}
+}
+
+func (qs *quantileSketch) ToProto() *proto.QuantileSketch {
+	return &proto.QuantileSketch{
+		PositiveCounts: maps.Clone(qs.positive),
+		NegativeCounts: maps.Clone(qs.negative),
+		ZeroCount:      qs.zeroCount,
+		Min:            qs.min,
+		Max:            qs.max,
+	}
+}
+
+func quantileSketchFromProto(sketch *proto.QuantileSketch) *quantileSketch {
+	qs := &quantileSketch{}
+	if sketch == nil {
+		return qs
+	}
+
+	qs.positive = mergeQuantileSketchBuckets(nil, sketch.PositiveCounts)
+	qs.negative = mergeQuantileSketchBuckets(nil, sketch.NegativeCounts)
+	qs.zeroCount = sketch.ZeroCount
+	qs.count = qs.zeroCount
+	for _, count := range sketch.PositiveCounts {
+		qs.count += count
+	}
+	for _, count := range sketch.NegativeCounts {
+		qs.count += count
+	}
+	if qs.count > 0 {
+		qs.min, qs.max = sketch.Min, sketch.Max
+	}
+	return qs
+}
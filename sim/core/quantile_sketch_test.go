@@ -0,0 +1,97 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func TestQuantileSketchSmall(t *testing.T) {
+	qs := &quantileSketch{}
+	for _, value := range []float64{5, 1, 4, 2, 3} {
+		qs.add(value)
+	}
+
+	if median := qs.quantile(0.5); math.Abs(median-3) > 3*quantileSketchRelativeAccuracy {
+		t.Errorf("Expected median close to 3, got %0.3f", median)
+	}
+	if low, high := qs.quantile(0), qs.quantile(1); low != 1 || high != 5 {
+		t.Errorf("Expected quantiles 0 and 1 to be the min and max, got %0.3f and %0.3f", low, high)
+	}
+}
+
+func TestQuantileSketchMostlyZero(t *testing.T) {
+	qs := &quantileSketch{}
+	for i := 0; i < 1000; i++ {
+		qs.add(TernaryFloat64(i%200 == 0, 500, 0))
+	}
+
+	if p99 := qs.quantile(0.99); p99 != 0 {
+		t.Errorf("Expected quantile 0.99 to be 0, got %0.3f", p99)
+	}
+	if high := qs.quantile(0.999); math.Abs(high-500) > 500*quantileSketchRelativeAccuracy {
+		t.Errorf("Expected quantile 0.999 to be close to 500, got %0.3f", high)
+	}
+}
+
+func TestQuantileSketchMerge(t *testing.T) {
+	const numValues = 20000
+
+	// Spread values out evenly between 0 and 1 but add them out of order, alternating between two sketches.
+	whole, first, second := &quantileSketch{}, &quantileSketch{}, &quantileSketch{}
+	for i := 0; i < numValues; i++ {
+		value := float64((i*7919)%numValues) / (numValues - 1)
+		whole.add(value)
+		if i%2 == 0 {
+			first.add(value)
+		} else {
+			second.add(value)
+		}
+	}
+
+	merged := quantileSketchFromProto(first.ToProto())
+	merged.merge(quantileSketchFromProto(second.ToProto()))
+
+	for _, q := range []float64{0.01, 0.05, 0.25, 0.5, 0.75, 0.95, 0.99} {
+		estimate := whole.quantile(q)
+		if math.Abs(estimate-q) > q*quantileSketchRelativeAccuracy+1.0/(numValues-1) {
+			t.Errorf("Expected quantile %0.2f to be close to %0.2f, got %0.4f", q, q, estimate)
+		}
+		// Merging only adds up bucket counts, so it doesn't matter how values were split.
+		if mergedEstimate := merged.quantile(q); mergedEstimate != estimate {
+			t.Errorf("Expected merged quantile %0.2f to be %0.4f, got %0.4f", q, estimate, mergedEstimate)
+		}
+	}
+}
+
+func TestQuantileSketchOnlyInSplitResults(t *testing.T) {
+	request := newTestRaidSimRequest(newTestPlayer("Player"))
+	request.SimOptions.Iterations = 10
+
+	result := RunRaidSim(request)
+	dtps := result.RaidMetrics.Parties[0].Players[0].Dtps
+	if dtps.AggregatorData.Sketch != nil {
+		t.Errorf("Expected no sketch in the result of an unsplit sim")
+	}
+	if dtps.Percentiles == nil {
+		t.Errorf("Expected percentiles in the result of an unsplit sim")
+	}
+
+	split := SplitSimRequestForConcurrency(request, 2)
+	splitResults := make([]*proto.RaidSimResult, len(split.Requests))
+	for i, splitRequest := range split.Requests {
+		splitResults[i] = RunRaidSim(splitRequest)
+		if splitResults[i].RaidMetrics.Parties[0].Players[0].Dtps.AggregatorData.Sketch == nil {
+			t.Fatalf("Expected a sketch in the result of split sim %d", i)
+		}
+	}
+
+	combined := CombineConcurrentSimResults(splitResults, false).RaidMetrics.Parties[0].Players[0].Dtps
+	if combined.AggregatorData.Sketch != nil {
+		t.Errorf("Expected no sketch in the combined result")
+	}
+	if combined.Percentiles == nil || combined.Percentiles.P50 <= 0 {
+		t.Errorf("Expected percentiles in the combined result, got %v", combined.Percentiles)
+	}
+}
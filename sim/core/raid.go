@@ -1,7 +1,9 @@
 package core
 
 import (
+	"cmp"
 	"slices"
+	"time"
 
 	"github.com/wowsims/cata/sim/core/proto"
 	"github.com/wowsims/cata/sim/core/stats"
@@ -349,6 +351,39 @@ func (raid *Raid) GetFirstNPlayersOrPets(n int32) []*Unit {
 	return raid.AllUnits[:min(n, int32(len(raid.AllUnits)))]
 }
 
+// Returns up to n active raid units, lowest health percent first. Used by smart heals.
+func (raid *Raid) GetLowestHealthUnits(n int32) []*Unit {
+	return LowestHealthUnits(raid.GetActiveAllyUnits(), n)
+}
+
+// Returns up to n of the given units, lowest health percent first. Units
+// without a health bar are treated as being at full health.
+func LowestHealthUnits(units []*Unit, n int32) []*Unit {
+	sorted := slices.Clone(units)
+	slices.SortStableFunc(sorted, func(u1, u2 *Unit) int {
+		return cmp.Compare(u1.healthPercentForHealing(), u2.healthPercentForHealing())
+	})
+	return sorted[:min(n, int32(len(sorted)))]
+}
+
+// Makes every player in the raid move for the given duration, e.g. to dodge a boss ability.
+// Players finish their current hard cast before moving.
+func (raid *Raid) MoveAllPlayers(sim *Simulation, duration time.Duration) {
+	for _, player := range raid.AllPlayerUnits {
+		if player.Hardcast.Expires > sim.CurrentTime && !player.Hardcast.CanMove {
+			StartDelayedAction(sim, DelayedActionOptions{
+				DoAt:     player.Hardcast.Expires,
+				Priority: ActionPriorityPrePull + 1,
+				OnAction: func(sim *Simulation) {
+					player.MoveDuration(duration, sim)
+				},
+			})
+		} else {
+			player.MoveDuration(duration, sim)
+		}
+	}
+}
+
 func (raid *Raid) GetPlayerFromUnitIndex(unitIndex int32) Agent {
 	for _, party := range raid.Parties {
 		for _, agent := range party.PlayersAndPets {
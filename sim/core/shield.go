@@ -5,6 +5,10 @@ import "strconv"
 type ShieldConfig struct {
 	SelfOnly bool // Set to true to only create the self-shield.
 
+	// Set to true if the spell consumes the shield with its own damage taken handling.
+	// These shields are credited with their full amount when applied.
+	AbsorbManually bool
+
 	Spell *Spell
 
 	Aura
@@ -14,6 +18,11 @@ type ShieldConfig struct {
 type Shield struct {
 	Spell *Spell
 
+	// Remaining amount of damage this shield can absorb.
+	ShieldStrength float64
+
+	absorbManually bool
+
 	// Embed Aura so we can use IsActive/Refresh/etc directly.
 	*Aura
 }
@@ -27,12 +36,25 @@ func (shield *Shield) Apply(sim *Simulation, shieldAmount float64) {
 	// So we only apply the spell-specific multiplier.
 	shieldAmount *= shield.Spell.DamageMultiplier
 
+	// Only the increase over a remaining shield counts as newly applied.
+	oldStrength := 0.0
+	if shield.Aura.IsActive() {
+		oldStrength = shield.ShieldStrength
+	}
+
 	shield.Aura.Deactivate(sim)
 	shield.Aura.Activate(sim)
 
+	shield.ShieldStrength = shieldAmount
+	if sim.CurrentTime >= 0 {
+		shield.Spell.SpellMetrics[target.UnitIndex].TotalShieldsApplied += max(0, shieldAmount-oldStrength)
+	}
+
 	threat := 0.0 // TODO
 	shield.Spell.SpellMetrics[target.UnitIndex].TotalThreat += threat
-	shield.Spell.SpellMetrics[target.UnitIndex].TotalShielding += shieldAmount
+	if shield.absorbManually && sim.CurrentTime >= 0 {
+		shield.Spell.SpellMetrics[target.UnitIndex].TotalShielding += shieldAmount
+	}
 	shield.Spell.SpellMetrics[target.UnitIndex].Hits++
 
 	if sim.Log != nil {
@@ -40,10 +62,44 @@ func (shield *Shield) Apply(sim *Simulation, shieldAmount float64) {
 	}
 }
 
+// Absorbs as much of the result's damage as the shield allows, returning how much was absorbed.
+// Only the absorbed amount is credited as shielding.
+func (shield *Shield) Absorb(sim *Simulation, spell *Spell, result *SpellResult) float64 {
+	if !shield.Aura.IsActive() || result.Damage <= 0 || shield.ShieldStrength <= 0 {
+		return 0
+	}
+
+	absorbed := min(shield.ShieldStrength, result.Damage)
+	result.Damage -= absorbed
+	shield.ShieldStrength -= absorbed
+	if sim.CurrentTime >= 0 {
+		shield.Spell.SpellMetrics[shield.Aura.Unit.UnitIndex].TotalShielding += absorbed
+	}
+
+	if sim.Log != nil {
+		shield.Aura.Unit.Log(sim, "%s absorbed %0.3f damage, new shield strength: %0.3f", shield.Spell.ActionID, absorbed, shield.ShieldStrength)
+	}
+
+	shield.Aura.Unit.absorbConsumed(sim, shield.Aura, spell, result, absorbed)
+
+	if shield.ShieldStrength <= 0 {
+		shield.Aura.Deactivate(sim)
+	}
+	return absorbed
+}
+
 func newShield(config Shield) *Shield {
 	shield := &Shield{}
 	*shield = config
 
+	shield.Aura.Unit.absorbEffects = append(shield.Aura.Unit.absorbEffects, shield)
+
+	if !shield.absorbManually {
+		shield.Aura.Unit.AddDynamicDamageTakenModifier(func(sim *Simulation, spell *Spell, result *SpellResult) {
+			shield.Absorb(sim, spell, result)
+		})
+	}
+
 	return shield
 }
 
@@ -62,7 +118,8 @@ func (spell *Spell) createShields(config ShieldConfig) {
 		config.Spell = spell
 	}
 	shield := Shield{
-		Spell: config.Spell,
+		Spell:          config.Spell,
+		absorbManually: config.AbsorbManually,
 	}
 
 	auraConfig := config.Aura
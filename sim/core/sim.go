@@ -49,6 +49,10 @@ type Simulation struct {
 
 	nextExecuteDuration time.Duration
 	nextExecuteDamage   float64
+	nextExecuteHealth   float64
+
+	// Primary target whose health drives the execute phases, if its health is tracked.
+	executeTarget *Unit
 
 	endOfCombatDuration time.Duration
 	endOfCombatDamage   float64
@@ -61,6 +65,9 @@ type Simulation struct {
 
 	minTaskTime time.Duration
 	tasks       []Task
+
+	// Fight duration of each iteration, in seconds.
+	durationMetrics DistributionMetrics
 }
 
 func (sim *Simulation) rescheduleTracker(trackerTime time.Duration) {
@@ -184,6 +191,12 @@ func runSim(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, ski
 
 func NewSim(rsr *proto.RaidSimRequest, signals simsignals.Signals) *Simulation {
 	env, _, _ := NewEnvironment(rsr.Raid, rsr.Encounter, false)
+	if rsr.SimOptions.Timeline {
+		env.enableTimelineMetrics()
+	}
+	if rsr.SimOptions.CooldownTimings {
+		env.enableCooldownTimingMetrics()
+	}
 	return newSimWithEnv(env, rsr.SimOptions, signals)
 }
 
@@ -204,6 +217,8 @@ func newSimWithEnv(env *Environment, simOptions *proto.SimOptions, signals simsi
 		testRands: make(map[string]Rand),
 
 		Signals: signals,
+
+		durationMetrics: NewDistributionMetrics(),
 	}
 }
 
@@ -302,12 +317,14 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 		firstIterationDuration = sim.CurrentTime
 	}
 	totalDuration := firstIterationDuration
+	sim.durationMetrics.addValue(sim, firstIterationDuration.Seconds())
 
 	if !sim.Options.Debug {
 		sim.Log = nil
 	}
 
 	var st time.Time
+	iterationsDone := int32(1)
 	for i := int32(1); i < sim.Options.Iterations; i++ {
 		if sim.Signals.Abort.IsTriggered() {
 			quitResult := &proto.RaidSimResult{Error: &proto.ErrorOutcome{Type: proto.ErrorOutcomeType_ErrorOutcomeAborted}}
@@ -316,11 +333,14 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 			}
 			return quitResult
 		}
+		if sim.Signals.Finish.IsTriggered() || sim.hasConverged() {
+			break
+		}
 
 		// fmt.Printf("Iteration: %d\n", i)
 		if sim.ProgressReport != nil && time.Since(st) > time.Millisecond*100 {
 			metrics := sim.Raid.GetMetrics()
-			sim.ProgressReport(&proto.ProgressMetrics{TotalIterations: sim.Options.Iterations, CompletedIterations: i, Dps: metrics.Dps.Avg, Hps: metrics.Hps.Avg})
+			sim.ProgressReport(&proto.ProgressMetrics{TotalIterations: sim.Options.Iterations, CompletedIterations: i, Dps: metrics.Dps.Avg, Hps: metrics.Hps.Avg, Convergence: sim.convergenceProgress()})
 			if IsRunningInWasm() {
 				time.Sleep(time.Microsecond) // Need to sleep to escape the go scheduler in wasm to give the JS event loop a chance to process requests to the worker.
 			} else {
@@ -338,6 +358,8 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 			iterDuration = sim.CurrentTime
 		}
 		totalDuration += iterDuration
+		sim.durationMetrics.addValue(sim, iterDuration.Seconds())
+		iterationsDone++
 	}
 	result := &proto.RaidSimResult{
 		RaidMetrics:      sim.Raid.GetMetrics(),
@@ -345,16 +367,17 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 
 		Logs:                   logsBuffer.String(),
 		FirstIterationDuration: firstIterationDuration.Seconds(),
-		AvgIterationDuration:   totalDuration.Seconds() / float64(sim.Options.Iterations),
-		IterationsDone:         sim.Options.Iterations,
+		AvgIterationDuration:   totalDuration.Seconds() / float64(iterationsDone),
+		IterationsDone:         iterationsDone,
+		Duration:               sim.durationMetrics.ToProto(),
 	}
 
 	// Final progress report
 	if sim.ProgressReport != nil {
-		sim.ProgressReport(&proto.ProgressMetrics{TotalIterations: sim.Options.Iterations, CompletedIterations: sim.Options.Iterations, Dps: result.RaidMetrics.Dps.Avg, FinalRaidResult: result})
+		sim.ProgressReport(&proto.ProgressMetrics{TotalIterations: sim.Options.Iterations, CompletedIterations: iterationsDone, Dps: result.RaidMetrics.Dps.Avg, FinalRaidResult: result, Convergence: sim.convergenceProgress()})
 	}
 
-	if d := sim.Options.Iterations; d > 3000 {
+	if d := iterationsDone; d > 3000 {
 		log.Printf("running %d iterations took %s", d, time.Since(t0))
 	}
 
@@ -394,6 +417,10 @@ func (sim *Simulation) reset() {
 	sim.pendingActions = sim.pendingActions[:0]
 	sim.pendingActions = append(sim.pendingActions, sentinelPendingAction)
 
+	sim.executeTarget = nil
+	if primaryTarget := sim.Encounter.TargetUnits[0]; primaryTarget.HasHealthBar() && primaryTarget.MaxHealth() > 0 {
+		sim.executeTarget = primaryTarget
+	}
 	sim.executePhase = 0
 	sim.nextExecutePhase()
 	sim.executePhaseCallbacks = nil
@@ -553,7 +580,8 @@ func (sim *Simulation) advance(nextTime time.Duration) {
 
 	// this is a loop to handle duplicate ExecuteProportions, e.g. if they're all set to 100%, you reach
 	// execute phases 35%, 25%, and 20% in the first advance() call.
-	for sim.CurrentTime >= sim.nextExecuteDuration || sim.Encounter.DamageTaken >= sim.nextExecuteDamage {
+	for sim.CurrentTime >= sim.nextExecuteDuration || sim.Encounter.DamageTaken >= sim.nextExecuteDamage ||
+		(sim.executeTarget != nil && sim.executeTarget.CurrentHealthPercent() <= sim.nextExecuteHealth) {
 		sim.nextExecutePhase()
 		for _, callback := range sim.executePhaseCallbacks {
 			callback(sim, sim.executePhase)
@@ -573,11 +601,13 @@ func (sim *Simulation) setupReverseExecute(phase int32, activeProportion float64
 	sim.nextExecuteDuration = time.Duration(activeProportion * float64(sim.Duration))
 }
 
-// nextExecutePhase updates nextExecuteDuration and nextExecuteDamage based on executePhase.
+// nextExecutePhase updates nextExecuteDuration, nextExecuteDamage and nextExecuteHealth based on executePhase.
 func (sim *Simulation) nextExecutePhase() {
 	setup := func(phase int32, damage float64, health float64) {
 		sim.executePhase = phase
-		if sim.Encounter.EndFightAtHealth > 0 {
+		if sim.executeTarget != nil {
+			sim.nextExecuteHealth = damage
+		} else if sim.Encounter.EndFightAtHealth > 0 {
 			sim.nextExecuteDamage = (1 - damage) * sim.Encounter.EndFightAtHealth
 		} else {
 			sim.nextExecuteDuration = time.Duration((1 - health) * float64(sim.Duration))
@@ -586,6 +616,7 @@ func (sim *Simulation) nextExecutePhase() {
 
 	sim.nextExecuteDuration = NeverExpires
 	sim.nextExecuteDamage = math.MaxFloat64
+	sim.nextExecuteHealth = -1
 
 	switch sim.executePhase {
 	case 0: // initially waiting for 90%
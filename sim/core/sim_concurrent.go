@@ -34,6 +34,7 @@ func SplitSimRequestForConcurrency(request *proto.RaidSimRequest, splitCount int
 
 	split[0] = googleProto.Clone(request).(*proto.RaidSimRequest)
 	split[0].SimOptions.Iterations = iterPerSplit + request.SimOptions.Iterations%splitCount
+	split[0].SimOptions.SaveQuantileSketches = splitCount > 1
 
 	// Sims increment their seed each iteration. Offset starting seed of each split to emulate that.
 	nextStartSeed := split[0].SimOptions.RandomSeed + int64(split[0].SimOptions.Iterations)
@@ -43,6 +44,7 @@ func SplitSimRequestForConcurrency(request *proto.RaidSimRequest, splitCount int
 		split[i].SimOptions.Iterations = iterPerSplit
 		split[i].SimOptions.DebugFirstIteration = false // No logs
 		split[i].SimOptions.RandomSeed = nextStartSeed
+		split[i].SimOptions.SaveQuantileSketches = true
 		nextStartSeed += int64(split[i].SimOptions.Iterations)
 	}
 
@@ -80,12 +82,34 @@ func (rsrc *raidSimResultCombiner) newUnitMetrics(baseUnit *proto.UnitMetrics) *
 		Auras:     make([]*proto.AuraMetrics, len(baseUnit.Auras)),
 		Resources: make([]*proto.ResourceMetrics, 0, len(baseUnit.Resources)),
 		Pets:      make([]*proto.UnitMetrics, len(baseUnit.Pets)),
+
+		TankingWindows: make([]*proto.TankingWindowMetrics, 0, len(baseUnit.TankingWindows)),
+		Aggro:          make([]*proto.AggroMetrics, 0, len(baseUnit.Aggro)),
 	}
 
 	for i, aura := range baseUnit.Auras {
 		newUm.Auras[i] = &proto.AuraMetrics{
-			Id:             aura.Id,
-			AggregatorData: &proto.AggregatorData{},
+			Id:               aura.Id,
+			AggregatorData:   &proto.AggregatorData{},
+			ProcIntervalHist: make(map[int32]int32),
+		}
+	}
+
+	if baseUnit.Survival != nil {
+		newUm.Survival = &proto.SurvivalMetrics{
+			DeathTimeHist: make(map[int32]int32),
+		}
+		for _, spike := range baseUnit.Survival.SpikeDamage {
+			newUm.Survival.SpikeDamage = append(newUm.Survival.SpikeDamage, &proto.SpikeDamageMetrics{
+				WindowSeconds: spike.WindowSeconds,
+				Damage:        rsrc.newDistMetrics(),
+			})
+		}
+	}
+
+	if baseUnit.Timeline != nil {
+		newUm.Timeline = &proto.TimelineMetrics{
+			BucketSeconds: baseUnit.Timeline.BucketSeconds,
 		}
 	}
 
@@ -133,8 +157,15 @@ func (rsrc *raidSimResultCombiner) combineDistMetrics(base *proto.DistributionMe
 
 	base.AggregatorData.N += add.AggregatorData.N
 	base.AggregatorData.SumSq += add.AggregatorData.SumSq
+
+	sketch := quantileSketchFromProto(base.AggregatorData.Sketch)
+	sketch.merge(quantileSketchFromProto(add.AggregatorData.Sketch))
+	base.AggregatorData.Sketch = sketch.ToProto()
+
 	if isLast {
 		base.Stdev = math.Sqrt(base.AggregatorData.SumSq/float64(base.AggregatorData.N) - base.Avg*base.Avg)
+		base.Percentiles = sketch.PercentilesProto()
+		base.AggregatorData.Sketch = nil
 	}
 }
 
@@ -192,13 +223,36 @@ func (rsrc *raidSimResultCombiner) addActionMetrics(unit *proto.UnitMetrics, add
 		baseTgt.Healing += addTgt.Healing
 		baseTgt.CritHealing += addTgt.CritHealing
 		baseTgt.Shielding += addTgt.Shielding
+		baseTgt.Overhealing += addTgt.Overhealing
+		baseTgt.ShieldsApplied += addTgt.ShieldsApplied
 		baseTgt.CastTimeMs += addTgt.CastTimeMs
+		baseTgt.Interrupts += addTgt.Interrupts
 	}
 }
 
 func (rsrc *raidSimResultCombiner) combineAuraMetrics(base *proto.AuraMetrics, add *proto.AuraMetrics, weight float64, isLast bool) {
 	base.UptimeSecondsAvg += add.UptimeSecondsAvg * weight
 	base.ProcsAvg += add.ProcsAvg * weight
+	base.RefreshesAvg += add.RefreshesAvg * weight
+
+	baseIntervals, addIntervals := int32(0), int32(0)
+	for _, count := range base.ProcIntervalHist {
+		baseIntervals += count
+	}
+	for idx, count := range add.ProcIntervalHist {
+		base.ProcIntervalHist[idx] += count
+		addIntervals += count
+	}
+	base.ProcIntervalAvg = weightedAverage(base.ProcIntervalAvg, float64(baseIntervals), add.ProcIntervalAvg, float64(addIntervals))
+
+	addTriggers := add.TriggersAvg * weight
+	base.IcdBlockedChance = weightedAverage(base.IcdBlockedChance, base.TriggersAvg, add.IcdBlockedChance, addTriggers)
+	base.TriggersAvg += addTriggers
+
+	base.StackUptimeSecondsAvg = growBuckets(base.StackUptimeSecondsAvg, len(add.StackUptimeSecondsAvg)-1)
+	for i, uptime := range add.StackUptimeSecondsAvg {
+		base.StackUptimeSecondsAvg[i] += uptime * weight
+	}
 
 	base.AggregatorData.N += add.AggregatorData.N
 	base.AggregatorData.SumSq += add.AggregatorData.SumSq
@@ -234,6 +288,251 @@ func (rsrc *raidSimResultCombiner) addResourceMetrics(unit *proto.UnitMetrics, a
 	rm.ActualGain += add.ActualGain
 }
 
+func (rsrc *raidSimResultCombiner) addTankingWindowMetrics(unit *proto.UnitMetrics, add *proto.TankingWindowMetrics) {
+	var twm *proto.TankingWindowMetrics
+
+	for _, baseWindow := range unit.TankingWindows {
+		if baseWindow.TargetIndex == add.TargetIndex && baseWindow.Window == add.Window {
+			twm = baseWindow
+			break
+		}
+	}
+
+	if twm == nil {
+		twm = &proto.TankingWindowMetrics{
+			TargetIndex: add.TargetIndex,
+			Window:      add.Window,
+		}
+		unit.TankingWindows = append(unit.TankingWindows, twm)
+	}
+
+	// Windows don't occur in every iteration, so weight by iterations instead of result weight.
+	total := float64(twm.Iterations + add.Iterations)
+	if total == 0 {
+		return
+	}
+	baseWeight := float64(twm.Iterations) / total
+	addWeight := float64(add.Iterations) / total
+
+	twm.StartSecondsAvg = twm.StartSecondsAvg*baseWeight + add.StartSecondsAvg*addWeight
+	twm.DurationSecondsAvg = twm.DurationSecondsAvg*baseWeight + add.DurationSecondsAvg*addWeight
+	twm.DtpsAvg = twm.DtpsAvg*baseWeight + add.DtpsAvg*addWeight
+	twm.TmiAvg = twm.TmiAvg*baseWeight + add.TmiAvg*addWeight
+	twm.Iterations += add.Iterations
+}
+
+func (rsrc *raidSimResultCombiner) addAggroMetrics(unit *proto.UnitMetrics, add *proto.AggroMetrics, weight float64) {
+	var am *proto.AggroMetrics
+
+	for _, baseAggro := range unit.Aggro {
+		if baseAggro.TargetIndex == add.TargetIndex {
+			am = baseAggro
+			break
+		}
+	}
+
+	if am == nil {
+		am = &proto.AggroMetrics{
+			TargetIndex: add.TargetIndex,
+		}
+		unit.Aggro = append(unit.Aggro, am)
+	}
+
+	am.PullsAvg += add.PullsAvg * weight
+	am.SecondsTankingAvg += add.SecondsTankingAvg * weight
+}
+
+func (rsrc *raidSimResultCombiner) combineSurvivalMetrics(base *proto.SurvivalMetrics, add *proto.SurvivalMetrics, isLast bool, weight float64) {
+	// Deaths don't occur in every iteration, so weight the average by deaths instead of result weight.
+	baseDeaths, addDeaths := int32(0), int32(0)
+	for _, count := range base.DeathTimeHist {
+		baseDeaths += count
+	}
+	for idx, count := range add.DeathTimeHist {
+		base.DeathTimeHist[idx] += count
+		addDeaths += count
+	}
+	if baseDeaths+addDeaths > 0 {
+		base.DeathTimeAvg = (base.DeathTimeAvg*float64(baseDeaths) + add.DeathTimeAvg*float64(addDeaths)) / float64(baseDeaths+addDeaths)
+	}
+
+	for i, spike := range add.SpikeDamage {
+		rsrc.combineDistMetrics(base.SpikeDamage[i].Damage, spike.Damage, isLast, weight)
+	}
+
+	// Likewise, later snapshots are only taken in iterations that last long enough.
+	for i, addEh := range add.EffectiveHealth {
+		if i == len(base.EffectiveHealth) {
+			base.EffectiveHealth = append(base.EffectiveHealth, &proto.EffectiveHealthMetrics{
+				TimeSeconds: addEh.TimeSeconds,
+			})
+		}
+		eh := base.EffectiveHealth[i]
+
+		total := float64(eh.Iterations + addEh.Iterations)
+		if total == 0 {
+			continue
+		}
+		baseWeight := float64(eh.Iterations) / total
+		addWeight := float64(addEh.Iterations) / total
+
+		eh.HealthAvg = eh.HealthAvg*baseWeight + addEh.HealthAvg*addWeight
+		eh.AbsorbAvg = eh.AbsorbAvg*baseWeight + addEh.AbsorbAvg*addWeight
+		eh.EffectiveHealthAvg = eh.EffectiveHealthAvg*baseWeight + addEh.EffectiveHealthAvg*addWeight
+		eh.Iterations += addEh.Iterations
+	}
+
+	base.RawDamageAvg += add.RawDamageAvg * weight
+	for _, addMitigation := range add.Mitigation {
+		var mm *proto.MitigationMetrics
+		for _, baseMitigation := range base.Mitigation {
+			if baseMitigation.Source == addMitigation.Source && googleProto.Equal(baseMitigation.Id, addMitigation.Id) {
+				mm = baseMitigation
+				break
+			}
+		}
+
+		if mm == nil {
+			mm = &proto.MitigationMetrics{
+				Source: addMitigation.Source,
+				Id:     addMitigation.Id,
+			}
+			base.Mitigation = append(base.Mitigation, mm)
+		}
+
+		mm.DamageMitigatedAvg += addMitigation.DamageMitigatedAvg * weight
+	}
+
+	for _, mm := range base.Mitigation {
+		if base.RawDamageAvg > 0 {
+			mm.Share = mm.DamageMitigatedAvg / base.RawDamageAvg
+		}
+	}
+	sortMitigationMetrics(base.Mitigation)
+}
+
+// Averages per-bucket values, weighted by how much of each bucket was covered
+// in each result. Must be called before the covered seconds are combined.
+func combineTimelineSeries(base []float64, baseCovered []float64, add []float64, addCovered []float64, weight float64) []float64 {
+	base = growBuckets(base, len(add)-1)
+	for i, value := range add {
+		baseWeight := 0.0
+		if i < len(baseCovered) {
+			baseWeight = baseCovered[i]
+		}
+		addWeight := addCovered[i] * weight
+		if total := baseWeight + addWeight; total > 0 {
+			base[i] = (base[i]*baseWeight + value*addWeight) / total
+		}
+	}
+	return base
+}
+
+func (rsrc *raidSimResultCombiner) combineTimelineMetrics(base *proto.TimelineMetrics, add *proto.TimelineMetrics, weight float64) {
+	base.Dps = combineTimelineSeries(base.Dps, base.CoveredSecondsAvg, add.Dps, add.CoveredSecondsAvg, weight)
+
+	for _, addResource := range add.Resources {
+		var rt *proto.ResourceTimelineMetrics
+		for _, baseResource := range base.Resources {
+			if baseResource.Type == addResource.Type {
+				rt = baseResource
+				break
+			}
+		}
+
+		if rt == nil {
+			rt = &proto.ResourceTimelineMetrics{
+				Type: addResource.Type,
+			}
+			base.Resources = append(base.Resources, rt)
+		}
+
+		rt.LevelAvg = combineTimelineSeries(rt.LevelAvg, base.CoveredSecondsAvg, addResource.LevelAvg, add.CoveredSecondsAvg, weight)
+	}
+
+	for _, addAura := range add.Auras {
+		var at *proto.AuraTimelineMetrics
+		for _, baseAura := range base.Auras {
+			if googleProto.Equal(baseAura.Id, addAura.Id) {
+				at = baseAura
+				break
+			}
+		}
+
+		if at == nil {
+			at = &proto.AuraTimelineMetrics{
+				Id: addAura.Id,
+			}
+			base.Auras = append(base.Auras, at)
+		}
+
+		at.ActiveChance = combineTimelineSeries(at.ActiveChance, base.CoveredSecondsAvg, addAura.ActiveChance, add.CoveredSecondsAvg, weight)
+	}
+
+	// Auras which never occurred in this result were inactive for all of it.
+	for _, baseAura := range base.Auras {
+		found := false
+		for _, addAura := range add.Auras {
+			if googleProto.Equal(baseAura.Id, addAura.Id) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			baseAura.ActiveChance = combineTimelineSeries(baseAura.ActiveChance, base.CoveredSecondsAvg, make([]float64, len(add.CoveredSecondsAvg)), add.CoveredSecondsAvg, weight)
+		}
+	}
+
+	base.CoveredSecondsAvg = growBuckets(base.CoveredSecondsAvg, len(add.CoveredSecondsAvg)-1)
+	for i, covered := range add.CoveredSecondsAvg {
+		base.CoveredSecondsAvg[i] += covered * weight
+	}
+}
+
+// Averages values weighted by how often they occurred in each result.
+func weightedAverage(baseValue float64, baseWeight float64, addValue float64, addWeight float64) float64 {
+	if total := baseWeight + addWeight; total > 0 {
+		return (baseValue*baseWeight + addValue*addWeight) / total
+	}
+	return 0
+}
+
+func (rsrc *raidSimResultCombiner) combineCooldownTimingMetrics(base *proto.CooldownTimingMetrics, add *proto.CooldownTimingMetrics, weight float64) {
+	baseActivations, addActivations := base.ActivationsAvg, add.ActivationsAvg*weight
+	base.ReadyDelayAvg = weightedAverage(base.ReadyDelayAvg, baseActivations, add.ReadyDelayAvg, addActivations)
+	base.BloodlustActivationChance = weightedAverage(base.BloodlustActivationChance, baseActivations, add.BloodlustActivationChance, addActivations)
+	base.ActivationsAvg += addActivations
+	for idx, count := range add.ActivationTimeHist {
+		base.ActivationTimeHist[idx] += count
+	}
+
+	baseSeconds, addSeconds := base.WindowSecondsAvg, add.WindowSecondsAvg*weight
+	base.BloodlustOverlap = weightedAverage(base.BloodlustOverlap, baseSeconds, add.BloodlustOverlap, addSeconds)
+	base.WindowDps = weightedAverage(base.WindowDps, baseSeconds, add.WindowDps, addSeconds)
+	baseOutsideSeconds, addOutsideSeconds := base.OutsideWindowSecondsAvg, add.OutsideWindowSecondsAvg*weight
+	base.OutsideWindowDps = weightedAverage(base.OutsideWindowDps, baseOutsideSeconds, add.OutsideWindowDps, addOutsideSeconds)
+	base.OutsideWindowSecondsAvg += addOutsideSeconds
+	for _, addOverlap := range add.Overlaps {
+		var om *proto.CooldownOverlapMetrics
+		for _, baseOverlap := range base.Overlaps {
+			if googleProto.Equal(baseOverlap.Id, addOverlap.Id) {
+				om = baseOverlap
+				break
+			}
+		}
+
+		if om == nil {
+			om = &proto.CooldownOverlapMetrics{
+				Id: addOverlap.Id,
+			}
+			base.Overlaps = append(base.Overlaps, om)
+		}
+
+		om.Overlap = weightedAverage(om.Overlap, baseSeconds, addOverlap.Overlap, addSeconds)
+	}
+	base.WindowSecondsAvg += addSeconds
+}
+
 func (rsrc *raidSimResultCombiner) combineUnitMetrics(base *proto.UnitMetrics, add *proto.UnitMetrics, isLast bool, weight float64) {
 	rsrc.combineDistMetrics(base.Dps, add.Dps, isLast, weight)
 	rsrc.combineDistMetrics(base.Threat, add.Threat, isLast, weight)
@@ -257,6 +556,43 @@ func (rsrc *raidSimResultCombiner) combineUnitMetrics(base *proto.UnitMetrics, a
 		rsrc.addResourceMetrics(base, addResource)
 	}
 
+	for _, addWindow := range add.TankingWindows {
+		rsrc.addTankingWindowMetrics(base, addWindow)
+	}
+
+	for _, addAggro := range add.Aggro {
+		rsrc.addAggroMetrics(base, addAggro, weight)
+	}
+
+	if add.Survival != nil {
+		rsrc.combineSurvivalMetrics(base.Survival, add.Survival, isLast, weight)
+	}
+
+	if add.Timeline != nil {
+		rsrc.combineTimelineMetrics(base.Timeline, add.Timeline, weight)
+	}
+
+	for _, addCooldown := range add.Cooldowns {
+		var ctm *proto.CooldownTimingMetrics
+		for _, baseCooldown := range base.Cooldowns {
+			if googleProto.Equal(baseCooldown.Id, addCooldown.Id) {
+				ctm = baseCooldown
+				break
+			}
+		}
+
+		if ctm == nil {
+			ctm = &proto.CooldownTimingMetrics{
+				Id:                 addCooldown.Id,
+				ActivationTimeHist: make(map[int32]int32),
+				HasWindow:          addCooldown.HasWindow,
+			}
+			base.Cooldowns = append(base.Cooldowns, ctm)
+		}
+
+		rsrc.combineCooldownTimingMetrics(ctm, addCooldown, weight)
+	}
+
 	for i, addPet := range add.Pets {
 		rsrc.combineUnitMetrics(base.Pets[i], addPet, isLast, weight)
 	}
@@ -265,6 +601,7 @@ func (rsrc *raidSimResultCombiner) combineUnitMetrics(base *proto.UnitMetrics, a
 func (rsrc *raidSimResultCombiner) AddResult(result *proto.RaidSimResult, isLast bool, weight float64) {
 	rsrc.combineDistMetrics(rsrc.Combined.RaidMetrics.Dps, result.RaidMetrics.Dps, isLast, weight)
 	rsrc.combineDistMetrics(rsrc.Combined.RaidMetrics.Hps, result.RaidMetrics.Hps, isLast, weight)
+	rsrc.combineDistMetrics(rsrc.Combined.Duration, result.Duration, isLast, weight)
 
 	for partyIdx, party := range result.RaidMetrics.Parties {
 		baseParty := rsrc.Combined.RaidMetrics.Parties[partyIdx]
@@ -298,6 +635,7 @@ func (rsrc *raidSimResultCombiner) SetBaseResult(baseRsr *proto.RaidSimResult) {
 			Targets: make([]*proto.UnitMetrics, len(baseRsr.EncounterMetrics.Targets)),
 		},
 		FirstIterationDuration: baseRsr.FirstIterationDuration,
+		Duration:               rsrc.newDistMetrics(),
 	}
 
 	if !rsrc.Debug {
@@ -456,8 +794,13 @@ func runSimConcurrent(request *proto.RaidSimRequest, progress chan *proto.Progre
 		log.Printf("Running %d iterations on %d concurrent sims.", csd.IterationsTotal, csd.Concurrency)
 	}
 
+	// Splits get their own finish signal, so they can all be stopped once their
+	// combined results reach the target error.
+	splitSignals := signals.WithSeparateFinish()
+	convergence := newConcurrentConvergence(request.SimOptions.TargetError, int(threads))
+
 	for i, req := range splitRes.Requests {
-		go RunSim(req, substituteChannels[i], signals)
+		go RunSim(req, substituteChannels[i], splitSignals)
 	}
 
 	progressCounter := 0
@@ -480,6 +823,10 @@ func runSimConcurrent(request *proto.RaidSimRequest, progress chan *proto.Progre
 		}
 
 		msg := val.Interface().(*proto.ProgressMetrics)
+		if convergence.update(i, msg.Convergence) || signals.Finish.IsTriggered() {
+			splitSignals.Finish.Trigger()
+		}
+
 		if csd.UpdateProgress(i, msg) {
 			if msg.FinalRaidResult != nil && msg.FinalRaidResult.Error != nil {
 				if progress != nil {
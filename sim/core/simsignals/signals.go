@@ -23,10 +23,21 @@ func (s *triggerSignal) IsTriggered() bool {
 
 type Signals struct {
 	Abort triggerSignal
+
+	// Stops a sim early, but still returns results for the iterations done so far.
+	Finish triggerSignal
 }
 
 func CreateSignals() Signals {
 	return Signals{
-		Abort: triggerSignal{channel: make(chan struct{})},
+		Abort:  triggerSignal{channel: make(chan struct{})},
+		Finish: triggerSignal{channel: make(chan struct{})},
 	}
 }
+
+// Returns signals sharing the same abort signal, but with a separate finish
+// signal, so a group of sims can be finished without affecting later ones.
+func (s Signals) WithSeparateFinish() Signals {
+	s.Finish = triggerSignal{channel: make(chan struct{})}
+	return s
+}
@@ -518,6 +518,13 @@ func (spell *Spell) CanCast(sim *Simulation, target *Unit) bool {
 		return false
 	}
 
+	if spell.lossOfControlReason(sim) != "" {
+		//if sim.Log != nil {
+		//	sim.Log("Cant cast because of loss of control")
+		//}
+		return false
+	}
+
 	if spell.ExtraCastCondition != nil && !spell.ExtraCastCondition(sim, target) {
 		//if sim.Log != nil {
 		//	sim.Log("Cant cast because of extra condition")
@@ -574,6 +581,16 @@ func (spell *Spell) Cast(sim *Simulation, target *Unit) bool {
 	if target == nil {
 		target = spell.Unit.CurrentTarget
 	}
+
+	if cooldowns := spell.Unit.Metrics.cooldowns; cooldowns != nil && spell.Flags.Matches(SpellFlagMCD) {
+		readyAt := spell.ReadyAt()
+		if !spell.castFn(sim, target) {
+			return false
+		}
+		cooldowns.onCast(sim, spell, readyAt)
+		return true
+	}
+
 	return spell.castFn(sim, target)
 }
 
@@ -183,7 +183,13 @@ func (spell *Spell) calcDamageInternal(sim *Simulation, target *Unit, baseDamage
 	result := spell.NewResult(target)
 	result.Damage = baseDamage
 
-	if sim.Log == nil {
+	// Tanks tracking survival metrics need the damage after each step, to split up their mitigation.
+	survival := target.Metrics.survival
+	if survival != nil && spell.Unit.Type != EnemyUnit {
+		survival = nil
+	}
+
+	if sim.Log == nil && survival == nil {
 		result.Damage *= attackerMultiplier
 		result.applyResistances(sim, spell, isPeriodic, attackTable)
 		result.applyTargetModifiers(sim, spell, attackTable, isPeriodic)
@@ -224,13 +230,21 @@ func (spell *Spell) calcDamageInternal(sim *Simulation, target *Unit, baseDamage
 			result.Outcome |= partialOutcome
 		}
 
+		if survival != nil {
+			survival.startHit()
+		}
 		spell.ApplyPostOutcomeDamageModifiers(sim, result)
 		afterPostOutcome := result.Damage
+		if survival != nil {
+			survival.recordHit(spell, result, afterAttackMods, afterResistances, afterTargetMods, afterOutcome)
+		}
 
-		spell.Unit.Log(
-			sim,
-			"%s %s [DEBUG] MAP: %0.01f, RAP: %0.01f, SP: %0.01f, BaseDamage:%0.01f, AfterAttackerMods:%0.01f, AfterResistances:%0.01f, AfterTargetMods:%0.01f, AfterOutcome:%0.01f, AfterPostOutcome:%0.01f",
-			target.LogLabel(), spell.ActionID, spell.Unit.GetStat(stats.AttackPower), spell.Unit.GetStat(stats.RangedAttackPower), spell.SpellPower(), baseDamage, afterAttackMods, afterResistances, afterTargetMods, afterOutcome, afterPostOutcome)
+		if sim.Log != nil {
+			spell.Unit.Log(
+				sim,
+				"%s %s [DEBUG] MAP: %0.01f, RAP: %0.01f, SP: %0.01f, BaseDamage:%0.01f, AfterAttackerMods:%0.01f, AfterResistances:%0.01f, AfterTargetMods:%0.01f, AfterOutcome:%0.01f, AfterPostOutcome:%0.01f",
+				target.LogLabel(), spell.ActionID, spell.Unit.GetStat(stats.AttackPower), spell.Unit.GetStat(stats.RangedAttackPower), spell.SpellPower(), baseDamage, afterAttackMods, afterResistances, afterTargetMods, afterOutcome, afterPostOutcome)
+		}
 	}
 
 	result.Threat = spell.ThreatFromDamage(result.Outcome, result.Damage)
@@ -275,6 +289,12 @@ func (spell *Spell) CalcAndDealOutcome(sim *Simulation, target *Unit, outcomeApp
 func (spell *Spell) dealDamageInternal(sim *Simulation, isPeriodic bool, result *SpellResult) {
 	if sim.CurrentTime >= 0 {
 		spell.SpellMetrics[result.Target.UnitIndex].TotalDamage += result.Damage
+		if timeline := spell.Unit.Metrics.timeline; timeline != nil && spell.Unit.IsOpponent(result.Target) {
+			timeline.addDamage(sim, result.Damage)
+		}
+		if cooldowns := spell.Unit.Metrics.cooldowns; cooldowns != nil && spell.Unit.IsOpponent(result.Target) {
+			cooldowns.addDamage(result.Damage)
+		}
 		if isPeriodic {
 			spell.SpellMetrics[result.Target.UnitIndex].TotalTickDamage += result.Damage
 		}
@@ -294,12 +314,22 @@ func (spell *Spell) dealDamageInternal(sim *Simulation, isPeriodic bool, result
 			spell.SpellMetrics[result.Target.UnitIndex].TotalBlockDamage += result.Damage
 		}
 		spell.SpellMetrics[result.Target.UnitIndex].TotalThreat += result.Threat
+		spell.Unit.generateThreat(sim, result.Target, result.Threat)
 	}
 
 	// Mark total damage done in raid so far for health based fights.
 	// Don't include damage done by EnemyUnits to Players
 	if result.Target.Type == EnemyUnit {
 		sim.Encounter.DamageTaken += result.Damage
+		if result.Damage > 0 && result.Target.HasHealthBar() {
+			result.Target.RemoveHealth(sim, result.Damage)
+		}
+	}
+
+	// Damage taken from enemies pushes back any interruptible cast in progress, if
+	// the encounter enables pushback.
+	if result.Damage > 0 && spell.Unit.Type == EnemyUnit && result.Target.Type != EnemyUnit {
+		result.Target.applyCastPushback(sim)
 	}
 
 	if sim.Log != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
@@ -417,8 +447,11 @@ func (spell *Spell) dealHealingInternal(sim *Simulation, isPeriodic bool, result
 	}
 	spell.SpellMetrics[result.Target.UnitIndex].TotalHealing += result.Damage
 	spell.SpellMetrics[result.Target.UnitIndex].TotalThreat += result.Threat
+	spell.Unit.generateHealingThreat(sim, result.Threat)
 	if result.Target.HasHealthBar() {
-		result.Target.GainHealth(sim, result.Damage, spell.HealthMetrics(result.Target))
+		missingHealth := result.Target.MaxHealth() - result.Target.CurrentHealth()
+		spell.SpellMetrics[result.Target.UnitIndex].TotalOverhealing += max(0, result.Damage-missingHealth)
+		result.Target.gainHealth(sim, result.Damage, spell.HealthMetrics(result.Target))
 	}
 
 	if sim.Log != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
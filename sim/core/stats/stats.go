@@ -448,6 +448,7 @@ type PseudoStats struct {
 	HealingTakenMultiplier         float64 // All healing sources including self-healing
 	ExternalHealingTakenMultiplier float64 // Modulates the output of the individual tank sim healing model
 	MovementSpeedMultiplier        float64 // Multiplier for movement speed, default to 1. Player base movement 7 yards/s. All effects affecting movements are multipliers.
+	CastPushbackMultiplier         float64 // Multiplier for cast pushback from damage taken while hardcasting.
 }
 
 func NewPseudoStats() PseudoStats {
@@ -482,6 +483,7 @@ func NewPseudoStats() PseudoStats {
 		HealingTakenMultiplier:         1,
 		ExternalHealingTakenMultiplier: 1,
 		MovementSpeedMultiplier:        1,
+		CastPushbackMultiplier:         1,
 	}
 }
 
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"math"
 	"strings"
-	"time"
 
 	"github.com/wowsims/cata/sim/core/proto"
 	"github.com/wowsims/cata/sim/core/simsignals"
@@ -131,21 +130,10 @@ func buildStatWeightRequests(swr *proto.StatWeightsRequest) *proto.StatWeightReq
 	raidProto := SinglePlayerRaidProto(swr.Player, swr.PartyBuffs, swr.RaidBuffs, swr.Debuffs)
 	raidProto.Tanks = swr.Tanks
 
-	swr.SimOptions.SaveAllValues = true
-
 	// Cut in half since we're doing above and below separately.
 	// This number needs to be the same for the baseline sim too, so that RNG lines up perfectly.
 	swr.SimOptions.Iterations /= 2
-
-	// Make sure an RNG seed is always set because it gives more consistent results.
-	// When there is no user-supplied seed it needs to be a randomly-selected seed
-	// though, so that run-run differences still exist.
-	if swr.SimOptions.RandomSeed == 0 {
-		swr.SimOptions.RandomSeed = time.Now().UnixNano()
-	}
-
-	// Reduce variance even more by using test-level RNG controls.
-	swr.SimOptions.UseLabeledRands = true
+	preparePairedSimOptions(swr.SimOptions)
 
 	swBaseResponse := &proto.StatWeightRequestsData{
 		BaseRequest: &proto.RaidSimRequest{
@@ -266,12 +254,14 @@ func computeStatWeights(swcr *proto.StatWeightsCalcRequest) *proto.StatWeightsRe
 		}
 
 		calcWeightResults := func(baselineMetrics *proto.DistributionMetrics, modLowMetrics *proto.DistributionMetrics, modHighMetrics *proto.DistributionMetrics, weightResults *StatWeightValues) {
+			// With a target error, sims may stop after different numbers of
+			// iterations, so only compare the iterations they all did.
 			var lo, hi aggregator
-			for i := 0; i < len(baselineMetrics.AllValues); i++ {
+			for i := 0; i < min(len(baselineMetrics.AllValues), len(modLowMetrics.AllValues)); i++ {
 				lo.add(modLowMetrics.AllValues[i] - baselineMetrics.AllValues[i])
 			}
 			lo.scale(1 / statResult.StatData.ModLow)
-			for i := 0; i < len(baselineMetrics.AllValues); i++ {
+			for i := 0; i < min(len(baselineMetrics.AllValues), len(modHighMetrics.AllValues)); i++ {
 				hi.add(modHighMetrics.AllValues[i] - baselineMetrics.AllValues[i])
 			}
 			hi.scale(1 / statResult.StatData.ModHigh)
@@ -323,74 +313,22 @@ func computeStatWeights(swcr *proto.StatWeightsCalcRequest) *proto.StatWeightsRe
 func runStatWeights(request *proto.StatWeightsRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals) *proto.StatWeightsResult {
 	requestData := buildStatWeightRequests(request)
 
-	var iterationsTotal int32 = requestData.BaseRequest.SimOptions.Iterations
-	var iterationsDone int32 = 0
-	var simsTotal int32 = 1
-	var simsCompleted int32 = 0
-
+	var statRequests []*proto.RaidSimRequest
 	for _, reqData := range requestData.StatSimRequests {
-		iterationsTotal += reqData.RequestLow.SimOptions.Iterations
-		iterationsTotal += reqData.RequestHigh.SimOptions.Iterations
-		simsTotal += 2
+		statRequests = append(statRequests, reqData.RequestLow, reqData.RequestHigh)
 	}
 
-	waitForResult := func(srcProgressChannel chan *proto.ProgressMetrics) *proto.RaidSimResult {
-		var lastCompleted int32 = 0
-		for metrics := range srcProgressChannel {
-			iterationsDone += metrics.CompletedIterations - lastCompleted
-			lastCompleted = metrics.CompletedIterations
-
-			if progress != nil {
-				progress <- &proto.ProgressMetrics{
-					TotalIterations:     iterationsTotal,
-					CompletedIterations: iterationsDone,
-					CompletedSims:       simsCompleted,
-					TotalSims:           simsTotal,
-				}
-			}
-
-			if metrics.FinalRaidResult != nil {
-				simsCompleted++
-				return metrics.FinalRaidResult
-			}
-		}
-		return nil
-	}
-
-	simFunc := runSimConcurrent
-	// Don't use go threads in wasm, it just adds more overhead and makes the worker more unresponsive.
-	if IsRunningInWasm() {
-		simFunc = RunSim
-	}
-
-	baseProgress := make(chan *proto.ProgressMetrics, 100)
-	go simFunc(requestData.BaseRequest, baseProgress, signals)
-	baselineResult := waitForResult(baseProgress)
-	if baselineResult.Error != nil {
-		return &proto.StatWeightsResult{Error: baselineResult.Error}
+	baselineResult, results, errorOutcome := runPairedSims(requestData.BaseRequest, statRequests, progress, signals)
+	if errorOutcome != nil {
+		return &proto.StatWeightsResult{Error: errorOutcome}
 	}
 
 	statResults := []*proto.StatWeightsStatResultData{}
-
-	for _, reqData := range requestData.StatSimRequests {
-		lowProgress := make(chan *proto.ProgressMetrics, 100)
-		go simFunc(reqData.RequestLow, lowProgress, signals)
-		lowRes := waitForResult(lowProgress)
-		if lowRes.Error != nil {
-			return &proto.StatWeightsResult{Error: lowRes.Error}
-		}
-
-		highProgress := make(chan *proto.ProgressMetrics, 100)
-		go simFunc(reqData.RequestHigh, highProgress, signals)
-		highRes := waitForResult(highProgress)
-		if highRes.Error != nil {
-			return &proto.StatWeightsResult{Error: highRes.Error}
-		}
-
+	for i, reqData := range requestData.StatSimRequests {
 		statResults = append(statResults, &proto.StatWeightsStatResultData{
 			StatData:   reqData.StatData,
-			ResultLow:  lowRes,
-			ResultHigh: highRes,
+			ResultLow:  results[i*2],
+			ResultHigh: results[i*2+1],
 		})
 	}
 
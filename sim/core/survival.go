@@ -0,0 +1,335 @@
+package core
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+// Rolling windows for which the largest damage taken is reported.
+var SpikeDamageWindows = []time.Duration{time.Second * 2, time.Second * 4, time.Second * 6}
+
+// Time between effective health snapshots.
+const EffectiveHealthInterval = time.Second * 5
+
+// Anything which absorbs damage, so its remaining strength can be counted
+// towards effective health.
+type absorbEffect interface {
+	remainingAbsorb() float64
+}
+
+func (aura *DamageAbsorptionAura) remainingAbsorb() float64 {
+	if !aura.IsActive() {
+		return 0
+	}
+	return aura.ShieldStrength
+}
+
+func (shield *Shield) remainingAbsorb() float64 {
+	if !shield.IsActive() {
+		return 0
+	}
+	return shield.ShieldStrength
+}
+
+// Credits absorbed damage to survival metrics, if tracked, before invoking
+// OnAbsorbConsumed handlers.
+func (unit *Unit) absorbConsumed(sim *Simulation, absorbAura *Aura, spell *Spell, result *SpellResult, absorbed float64) {
+	if unit.Metrics.survival != nil {
+		unit.Metrics.survival.onAbsorb(absorbAura, absorbed)
+	}
+	unit.OnAbsorbConsumed(sim, absorbAura, spell, result, absorbed)
+}
+
+type mitigationKey struct {
+	source   proto.MitigationMetrics_Source
+	actionID ActionID
+}
+
+// An active aura which lowered the damage taken multiplier.
+type damageReduction struct {
+	aura          *Aura
+	logMultiplier float64
+}
+
+type damageTakenEvent struct {
+	timestamp time.Duration
+	amount    float64
+}
+
+type effectiveHealthBucket struct {
+	iterations         int32
+	healthSum          float64
+	absorbSum          float64
+	effectiveHealthSum float64
+}
+
+// Survival metrics for anyone hit by an enemy: when they die, their worst
+// damage spikes, their effective health over time and where their mitigation
+// comes from.
+type survivalMetrics struct {
+	unit *Unit
+
+	// Values for the current iteration.
+	damageEvents     []damageTakenEvent
+	activeReductions []damageReduction
+	trackingHit      bool
+	hitAbsorbed      float64
+	hitByEnemy       bool
+	snapshotting     bool
+
+	// Aggregate values. These are updated after each iteration.
+	deathTimeHist   map[int32]int32
+	deathTimeSum    float64
+	deaths          int32
+	spikeDamage     []DistributionMetrics
+	effectiveHealth []effectiveHealthBucket
+	rawDamageSum    float64
+	mitigation      map[mitigationKey]float64
+}
+
+func (unit *Unit) trackSurvival() {
+	sm := &survivalMetrics{
+		unit:          unit,
+		deathTimeHist: make(map[int32]int32),
+		spikeDamage:   make([]DistributionMetrics, len(SpikeDamageWindows)),
+		mitigation:    make(map[mitigationKey]float64),
+	}
+	for i := range sm.spikeDamage {
+		sm.spikeDamage[i] = NewDistributionMetrics()
+	}
+	unit.Metrics.survival = sm
+
+	unit.RegisterResetEffect(func(sim *Simulation) {
+		sm.damageEvents = sm.damageEvents[:0]
+		sm.activeReductions = sm.activeReductions[:0]
+		sm.hitByEnemy = false
+		sm.snapshotting = false
+		for i := range sm.spikeDamage {
+			sm.spikeDamage[i].reset()
+		}
+
+		if unit.Metrics.isTanking {
+			sm.startSnapshots(sim)
+		}
+	})
+}
+
+// Snapshots effective health from now on. Starts at the pull for tanks, and on
+// the first hit from an enemy for everyone else.
+func (sm *survivalMetrics) startSnapshots(sim *Simulation) {
+	sm.snapshotting = true
+	StartPeriodicAction(sim, PeriodicActionOptions{
+		Period:          EffectiveHealthInterval,
+		TickImmediately: true,
+		OnAction:        sm.snapshotEffectiveHealth,
+	})
+}
+
+// Called when an aura on the unit is gained, with the unit's damage taken
+// multiplier from before the aura's effects were applied.
+func (sm *survivalMetrics) onAuraGain(aura *Aura, oldMultiplier float64) {
+	newMultiplier := sm.unit.PseudoStats.DamageTakenMultiplier
+	if newMultiplier < oldMultiplier && oldMultiplier > 0 {
+		sm.activeReductions = append(sm.activeReductions, damageReduction{
+			aura:          aura,
+			logMultiplier: math.Log(max(newMultiplier/oldMultiplier, 1e-6)),
+		})
+	}
+}
+
+func (sm *survivalMetrics) onAuraExpire(aura *Aura) {
+	for i, reduction := range sm.activeReductions {
+		if reduction.aura == aura {
+			sm.activeReductions = append(sm.activeReductions[:i], sm.activeReductions[i+1:]...)
+			return
+		}
+	}
+}
+
+func (sm *survivalMetrics) onAbsorb(absorbAura *Aura, absorbed float64) {
+	if !sm.trackingHit {
+		return
+	}
+	sm.hitAbsorbed += absorbed
+	sm.mitigation[mitigationKey{source: proto.MitigationMetrics_Absorb, actionID: absorbAura.ActionID}] += absorbed
+}
+
+func (sm *survivalMetrics) addMitigation(source proto.MitigationMetrics_Source, amount float64) {
+	if amount != 0 {
+		sm.mitigation[mitigationKey{source: source}] += amount
+	}
+}
+
+// Called before post-outcome modifiers are applied to a hit from an enemy, so
+// absorbs can be credited to it.
+func (sm *survivalMetrics) startHit() {
+	sm.trackingHit = true
+	sm.hitAbsorbed = 0
+}
+
+// Splits the damage prevented on a hit from an enemy between armor or
+// resistances, damage taken multipliers, avoidance, block and absorbs, given
+// the damage after each step of the calculation.
+func (sm *survivalMetrics) recordHit(spell *Spell, result *SpellResult, raw float64, afterResistances float64, afterTargetMods float64, afterOutcome float64) {
+	sm.trackingHit = false
+	if raw <= 0 || spell.Flags.Matches(SpellFlagNoMetrics) {
+		return
+	}
+
+	sm.hitByEnemy = true
+	sm.rawDamageSum += raw
+	sm.addMitigation(Ternary(spell.SpellSchool.Matches(SpellSchoolPhysical), proto.MitigationMetrics_Armor, proto.MitigationMetrics_Resistance), raw-afterResistances)
+
+	// Damage taken multipliers are split between the active cooldowns by their
+	// share of the total multiplier, with the rest coming from passive effects.
+	if reduced := afterResistances - afterTargetMods; reduced != 0 && afterResistances > 0 {
+		totalLog := math.Log(max(afterTargetMods/afterResistances, 1e-6))
+		attributedLog := 0.0
+		for _, reduction := range sm.activeReductions {
+			sm.mitigation[mitigationKey{source: proto.MitigationMetrics_Cooldown, actionID: reduction.aura.ActionID}] += reduced * reduction.logMultiplier / totalLog
+			attributedLog += reduction.logMultiplier
+		}
+		sm.addMitigation(proto.MitigationMetrics_Other, reduced*(totalLog-attributedLog)/totalLog)
+	}
+
+	if result.Outcome.Matches(OutcomeMiss | OutcomeDodge | OutcomeParry) {
+		sm.addMitigation(proto.MitigationMetrics_Avoidance, afterTargetMods)
+	} else if result.DidBlock() {
+		if blockReduction := result.Target.BlockDamageReduction(); blockReduction < 1 {
+			sm.addMitigation(proto.MitigationMetrics_Block, afterOutcome*blockReduction/(1-blockReduction))
+		} else {
+			sm.addMitigation(proto.MitigationMetrics_Block, afterTargetMods)
+		}
+	}
+
+	if other := afterOutcome - result.Damage - sm.hitAbsorbed; other > 0 {
+		sm.addMitigation(proto.MitigationMetrics_Other, other)
+	}
+}
+
+func (sm *survivalMetrics) addDamageTaken(sim *Simulation, amount float64) {
+	if sm.hitByEnemy && !sm.snapshotting {
+		sm.startSnapshots(sim)
+	}
+	sm.damageEvents = append(sm.damageEvents, damageTakenEvent{
+		timestamp: sim.CurrentTime,
+		amount:    amount,
+	})
+}
+
+func (sm *survivalMetrics) snapshotEffectiveHealth(sim *Simulation) {
+	unit := sm.unit
+
+	absorbs := 0.0
+	for _, effect := range unit.absorbEffects {
+		absorbs += effect.remainingAbsorb()
+	}
+
+	multiplier := unit.PseudoStats.DamageTakenMultiplier
+	if attacker := unit.CurrentTarget; attacker != nil && attacker.Type == EnemyUnit {
+		multiplier *= attacker.AttackTables[unit.UnitIndex].GetArmorDamageModifier(nil)
+	}
+
+	idx := int(sim.CurrentTime / EffectiveHealthInterval)
+	if idx < 0 {
+		return
+	}
+	for len(sm.effectiveHealth) <= idx {
+		sm.effectiveHealth = append(sm.effectiveHealth, effectiveHealthBucket{})
+	}
+
+	bucket := &sm.effectiveHealth[idx]
+	bucket.iterations++
+	bucket.healthSum += unit.CurrentHealth()
+	bucket.absorbSum += absorbs
+	bucket.effectiveHealthSum += (unit.CurrentHealth() + absorbs) / max(multiplier, 1e-6)
+}
+
+// Returns the largest total damage taken within any window of the given length.
+func (sm *survivalMetrics) maxDamageInWindow(window time.Duration) float64 {
+	maxDamage, damage := 0.0, 0.0
+	start := 0
+	for _, event := range sm.damageEvents {
+		damage += event.amount
+		for event.timestamp-sm.damageEvents[start].timestamp >= window {
+			damage -= sm.damageEvents[start].amount
+			start++
+		}
+		maxDamage = max(maxDamage, damage)
+	}
+	return maxDamage
+}
+
+func (sm *survivalMetrics) doneIteration(sim *Simulation) {
+	if sm.unit.Metrics.Died {
+		deathTime := sm.unit.Metrics.TimeOfDeath.Seconds()
+		sm.deathTimeHist[int32(deathTime)]++
+		sm.deathTimeSum += deathTime
+		sm.deaths++
+	}
+
+	for i, window := range SpikeDamageWindows {
+		// Hack because of the way DistributionMetrics does its calculations.
+		sm.spikeDamage[i].Total = sm.maxDamageInWindow(window) * sim.Duration.Seconds()
+		sm.spikeDamage[i].doneIteration(sim)
+	}
+}
+
+func (sm *survivalMetrics) ToProto(numIterations float64) *proto.SurvivalMetrics {
+	metrics := &proto.SurvivalMetrics{
+		DeathTimeHist: sm.deathTimeHist,
+		RawDamageAvg:  sm.rawDamageSum / numIterations,
+	}
+
+	if sm.deaths > 0 {
+		metrics.DeathTimeAvg = sm.deathTimeSum / float64(sm.deaths)
+	}
+
+	for i, window := range SpikeDamageWindows {
+		metrics.SpikeDamage = append(metrics.SpikeDamage, &proto.SpikeDamageMetrics{
+			WindowSeconds: window.Seconds(),
+			Damage:        sm.spikeDamage[i].ToProto(),
+		})
+	}
+
+	for i, bucket := range sm.effectiveHealth {
+		n := float64(bucket.iterations)
+		metrics.EffectiveHealth = append(metrics.EffectiveHealth, &proto.EffectiveHealthMetrics{
+			TimeSeconds:        (time.Duration(i) * EffectiveHealthInterval).Seconds(),
+			Iterations:         bucket.iterations,
+			HealthAvg:          bucket.healthSum / n,
+			AbsorbAvg:          bucket.absorbSum / n,
+			EffectiveHealthAvg: bucket.effectiveHealthSum / n,
+		})
+	}
+
+	for key, mitigated := range sm.mitigation {
+		mm := &proto.MitigationMetrics{
+			Source:             key.source,
+			DamageMitigatedAvg: mitigated / numIterations,
+		}
+		if !key.actionID.IsEmptyAction() {
+			mm.Id = key.actionID.ToProto()
+		}
+		if sm.rawDamageSum > 0 {
+			mm.Share = mitigated / sm.rawDamageSum
+		}
+		metrics.Mitigation = append(metrics.Mitigation, mm)
+	}
+	sortMitigationMetrics(metrics.Mitigation)
+
+	return metrics
+}
+
+func sortMitigationMetrics(mitigation []*proto.MitigationMetrics) {
+	slices.SortFunc(mitigation, func(a, b *proto.MitigationMetrics) int {
+		if a.Source != b.Source {
+			return int(a.Source - b.Source)
+		}
+		return strings.Compare(a.Id.String(), b.Id.String())
+	})
+}
@@ -0,0 +1,139 @@
+package core
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func setupSurvivalSim() *Simulation {
+	return newTestSim(newTestRaidSimRequest(newTestPlayer("Tank")))
+}
+
+func TestSurvivalMaxDamageInWindow(t *testing.T) {
+	sm := &survivalMetrics{
+		damageEvents: []damageTakenEvent{
+			{timestamp: time.Second * 0, amount: 100},
+			{timestamp: time.Second * 1, amount: 300},
+			{timestamp: time.Second * 3, amount: 200},
+			{timestamp: time.Second * 4, amount: 250},
+			{timestamp: time.Second * 10, amount: 400},
+		},
+	}
+
+	for _, tc := range []struct {
+		window   time.Duration
+		expected float64
+	}{
+		{time.Second, 400},
+		{time.Second * 2, 450},
+		{time.Second * 4, 750},
+		{time.Second * 5, 850},
+	} {
+		if actual := sm.maxDamageInWindow(tc.window); actual != tc.expected {
+			t.Errorf("Expected %0.1f damage in a %s window, got %0.1f", tc.expected, tc.window, actual)
+		}
+	}
+}
+
+func TestSurvivalMetrics(t *testing.T) {
+	sim := setupSurvivalSim()
+	tank := sim.Encounter.Targets[0].CurrentTarget
+
+	sm := tank.Metrics.survival
+	if sm == nil {
+		t.Fatalf("Expected survival metrics to be tracked for the tank")
+	}
+
+	sim.runOnce()
+	metrics := sm.ToProto(1)
+
+	if len(metrics.EffectiveHealth) != 13 {
+		t.Fatalf("Expected 13 effective health snapshots, got %d", len(metrics.EffectiveHealth))
+	}
+	if metrics.EffectiveHealth[0].EffectiveHealthAvg < metrics.EffectiveHealth[0].HealthAvg {
+		t.Errorf("Expected effective health to include mitigation")
+	}
+
+	for i := 1; i < len(metrics.SpikeDamage); i++ {
+		if metrics.SpikeDamage[i].Damage.Avg < metrics.SpikeDamage[i-1].Damage.Avg {
+			t.Errorf("Expected longer windows to have larger spikes")
+		}
+	}
+	if metrics.SpikeDamage[0].Damage.Avg <= 0 {
+		t.Errorf("Expected the tank to take damage")
+	}
+
+	mitigated := map[proto.MitigationMetrics_Source]float64{}
+	totalShare := 0.0
+	for _, mm := range metrics.Mitigation {
+		mitigated[mm.Source] += mm.DamageMitigatedAvg
+		totalShare += mm.Share
+	}
+	if mitigated[proto.MitigationMetrics_Avoidance] <= 0 {
+		t.Errorf("Expected some damage to be avoided")
+	}
+	if metrics.RawDamageAvg <= 0 || totalShare > 1 {
+		t.Errorf("Expected mitigation to be a share of raw damage %0.1f, got %0.3f", metrics.RawDamageAvg, totalShare)
+	}
+}
+
+func TestSurvivalMetricsAfterTankSwap(t *testing.T) {
+	request := newTestRaidSimRequest(newTestPlayer("Tank"), newTestPlayer("Off Tank"), newTestPlayer("Dps"))
+	for _, player := range request.Raid.Parties[0].Players {
+		player.HealingModel = &proto.HealingModel{BurstWindow: 5}
+	}
+	sim := newTestSim(request)
+
+	target := sim.Encounter.Targets[0]
+	players := sim.Raid.AllPlayerUnits
+	offTank, dps := players[1], players[2]
+	if offTank.Metrics.IsTanking() {
+		t.Fatalf("Expected the off tank to not be tanking at the start of the fight")
+	}
+
+	target.RegisterResetEffect(func(sim *Simulation) {
+		StartDelayedAction(sim, DelayedActionOptions{
+			DoAt: time.Second * 30,
+			OnAction: func(sim *Simulation) {
+				target.SwapTank(sim, offTank)
+			},
+		})
+	})
+	sim.runOnce()
+
+	offTankMetrics := offTank.Metrics.ToProto()
+	if offTankMetrics.Survival == nil || offTankMetrics.Survival.RawDamageAvg <= 0 {
+		t.Fatalf("Expected survival metrics for the off tank after picking up the target")
+	}
+	if offTankMetrics.Tmi.Avg == 0 {
+		t.Fatalf("Expected TMI for the off tank after picking up the target")
+	}
+
+	if dpsMetrics := dps.Metrics.ToProto(); dpsMetrics.Survival != nil || dpsMetrics.Tmi.Avg != 0 {
+		t.Fatalf("Expected no survival metrics for a player never hit by an enemy")
+	}
+}
+
+func TestAggregatorSumSqDoesNotDependOnSplits(t *testing.T) {
+	const numValues = 10000
+
+	// Large values, like spike damage, whose squares are summed into totals where a naive sum loses precision.
+	var whole aggregator
+	splits := make([]aggregator, 3)
+	for i := 0; i < numValues; i++ {
+		value := 150000 + math.Sqrt(float64((i*7919)%numValues))*313.37
+		whole.add(value)
+		splits[i*len(splits)/numValues].add(value)
+	}
+
+	combined := splits[0].merge(&splits[1]).merge(&splits[2])
+	if diff := math.Abs(whole.sum - combined.sum); diff > 1e-6 {
+		t.Errorf("Expected split sums to add up to %0.6f, got %0.6f", whole.sum, combined.sum)
+	}
+	if diff := math.Abs(whole.sumSq - combined.sumSq); diff > 0.1 {
+		t.Errorf("Expected split sums of squares to add up to %0.3f, got %0.3f", whole.sumSq, combined.sumSq)
+	}
+}
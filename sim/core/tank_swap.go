@@ -0,0 +1,360 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+// Handles a stacking debuff applied by a target to its current tank, and
+// swapping between the target's two tanks based on stacks or a timer. Lets
+// targets declare tank swap mechanics without a custom AI, and preset AIs
+// share the same swap handling for their own tank debuffs.
+type tankSwapManager struct {
+	target *Target
+	config *proto.TankSwapConfig
+
+	// Main tank and off tank. The off tank is nil in individual sims, in which
+	// case the target leaves the main tank alone until the debuff expires.
+	tanks [2]*Unit
+
+	// Nil if the config has no debuff.
+	debuffAuras  [2]*Aura
+	tankingAuras [2]*Aura
+
+	// Nil if damage taken is increased for all schools.
+	damageTakenSchools []stats.SchoolIndex
+
+	swapAtStacks int32
+	swapInterval time.Duration
+
+	// Per-iteration state for the tanking window in progress.
+	nextWindow     int32
+	windowIndex    [2]int32
+	windowStart    [2]time.Duration
+	windowDamage   [2]float64
+	pendingSwapFor *Unit
+}
+
+// Sets up tank swaps for the target. Preset AIs can call this from Initialize,
+// to build the config from their target inputs.
+func (target *Target) EnableTankSwap(config *proto.TankSwapConfig) {
+	if target.CurrentTarget == nil {
+		return
+	}
+
+	tsm := &tankSwapManager{
+		target:       target,
+		config:       config,
+		tanks:        [2]*Unit{target.CurrentTarget, target.SecondaryTarget},
+		swapAtStacks: config.SwapAtStacks,
+		swapInterval: DurationFromSeconds(config.SwapInterval),
+	}
+
+	schools := SpellSchoolNone
+	for _, school := range config.DamageTakenSchools {
+		schools |= SpellSchoolFromProto(school)
+	}
+	for schoolIndex := stats.SchoolIndexPhysical; schoolIndex < stats.SchoolLen; schoolIndex++ {
+		if schools.Matches(SpellSchool(1 << schoolIndex)) {
+			tsm.damageTakenSchools = append(tsm.damageTakenSchools, schoolIndex)
+		}
+	}
+
+	for i, tank := range tsm.tanks {
+		if tank != nil {
+			tsm.registerTankAuras(i, tank)
+		}
+	}
+
+	target.tankSwap = tsm
+}
+
+func (tsm *tankSwapManager) registerTankAuras(tankIndex int, tank *Unit) {
+	config := tsm.config
+	target := tsm.target
+
+	if config.DebuffSpellId != 0 || config.DebuffName != "" {
+		tsm.registerDebuffAura(tankIndex, tank)
+	}
+
+	tsm.tankingAuras[tankIndex] = tank.GetOrRegisterAura(Aura{
+		Label:    "Tanking " + target.Label,
+		Duration: NeverExpires,
+
+		OnReset: func(aura *Aura, sim *Simulation) {
+			if target.CurrentTarget == aura.Unit {
+				aura.Activate(sim)
+			}
+		},
+		OnGain: func(aura *Aura, sim *Simulation) {
+			tsm.windowIndex[tankIndex] = tsm.nextWindow
+			tsm.windowStart[tankIndex] = sim.CurrentTime
+			tsm.windowDamage[tankIndex] = 0
+			tsm.nextWindow++
+		},
+		OnExpire: func(aura *Aura, sim *Simulation) {
+			aura.Unit.Metrics.addTankingWindow(target.Index, tsm.windowIndex[tankIndex], tsm.windowStart[tankIndex], sim.CurrentTime, tsm.windowDamage[tankIndex])
+		},
+		OnSpellHitTaken: func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult) {
+			tsm.windowDamage[tankIndex] += result.Damage
+
+			if !config.StacksFromAi && config.StackInterval == 0 && spell.Unit == &target.Unit && spell.ProcMask.Matches(ProcMaskMelee) && result.Landed() {
+				tsm.addStack(sim, aura.Unit)
+			}
+		},
+		OnPeriodicDamageTaken: func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult) {
+			tsm.windowDamage[tankIndex] += result.Damage
+		},
+	})
+}
+
+func (tsm *tankSwapManager) registerDebuffAura(tankIndex int, tank *Unit) {
+	config := tsm.config
+	target := tsm.target
+
+	label := config.DebuffName
+	if label == "" {
+		label = "Tank Swap Debuff"
+	}
+	duration := NeverExpires
+	if config.DebuffDuration > 0 {
+		duration = DurationFromSeconds(config.DebuffDuration)
+	}
+	maxStacks := max(config.MaxStacks, 1)
+
+	tsm.debuffAuras[tankIndex] = tank.GetOrRegisterAura(Aura{
+		Label:     label + "-" + target.Label,
+		ActionID:  ActionID{SpellID: config.DebuffSpellId},
+		Duration:  duration,
+		MaxStacks: maxStacks,
+
+		OnStacksChange: func(aura *Aura, sim *Simulation, oldStacks int32, newStacks int32) {
+			if perStack := config.DamageTakenPerStack; perStack != 0 {
+				multiplier := (1 + perStack*float64(newStacks)) / (1 + perStack*float64(oldStacks))
+				if tsm.damageTakenSchools == nil {
+					aura.Unit.PseudoStats.DamageTakenMultiplier *= multiplier
+				}
+				for _, schoolIndex := range tsm.damageTakenSchools {
+					aura.Unit.PseudoStats.SchoolDamageTakenMultiplier[schoolIndex] *= multiplier
+				}
+			}
+			if perStack := config.HealingTakenPerStack; perStack != 0 {
+				aura.Unit.PseudoStats.HealingTakenMultiplier *= (1 + perStack*float64(newStacks)) / (1 + perStack*float64(oldStacks))
+			}
+
+			if tsm.swapAtStacks > 0 && newStacks >= tsm.swapAtStacks && target.CurrentTarget == aura.Unit {
+				tsm.queueSwapAwayFrom(sim, aura.Unit)
+			}
+		},
+		OnExpire: func(aura *Aura, sim *Simulation) {
+			// Without an off tank, the target comes back once the debuff has dropped.
+			if target.CurrentTarget == nil && tsm.otherTank(aura.Unit) == nil && sim.CurrentTime < sim.Duration {
+				target.SwapTank(sim, aura.Unit)
+			}
+		},
+	})
+}
+
+func (tsm *tankSwapManager) reset(sim *Simulation) {
+	tsm.nextWindow = 0
+	tsm.pendingSwapFor = nil
+
+	if tsm.config.StackInterval > 0 {
+		StartPeriodicAction(sim, PeriodicActionOptions{
+			Period:   DurationFromSeconds(tsm.config.StackInterval),
+			Priority: ActionPriorityDOT,
+
+			OnAction: func(sim *Simulation) {
+				if tank := tsm.target.CurrentTarget; tank != nil {
+					tsm.addStack(sim, tank)
+				}
+			},
+		})
+	}
+
+	if tsm.swapInterval > 0 {
+		StartPeriodicAction(sim, PeriodicActionOptions{
+			Period:   tsm.swapInterval,
+			Priority: ActionPriorityDOT,
+
+			OnAction: func(sim *Simulation) {
+				if sim.CurrentTime >= sim.Duration {
+					return
+				}
+				newTank := Ternary((sim.CurrentTime/tsm.swapInterval)%2 == 0, tsm.tanks[0], tsm.tanks[1])
+				tsm.swapTo(sim, newTank)
+			},
+		})
+	}
+}
+
+func (tsm *tankSwapManager) tankIndex(tank *Unit) int {
+	for i, t := range tsm.tanks {
+		if t != nil && t == tank {
+			return i
+		}
+	}
+	return -1
+}
+
+func (tsm *tankSwapManager) otherTank(tank *Unit) *Unit {
+	switch tsm.tankIndex(tank) {
+	case 0:
+		return tsm.tanks[1]
+	case 1:
+		return tsm.tanks[0]
+	}
+	return nil
+}
+
+func (tsm *tankSwapManager) addStack(sim *Simulation, tank *Unit) {
+	idx := tsm.tankIndex(tank)
+	if idx == -1 {
+		return
+	}
+
+	debuff := tsm.debuffAuras[idx]
+	if debuff == nil {
+		return
+	}
+	debuff.Activate(sim)
+	debuff.AddStack(sim)
+}
+
+// Moves the target onto the new tank. With exchange_targets, the old tank
+// also picks up whichever other target the new tank was tanking.
+func (tsm *tankSwapManager) swapTo(sim *Simulation, newTank *Unit) {
+	target := tsm.target
+	oldTank := target.CurrentTarget
+	if newTank == oldTank {
+		return
+	}
+
+	var exchanged *Target
+	if tsm.config.ExchangeTargets && oldTank != nil && newTank != nil {
+		for _, other := range sim.Encounter.ActiveTargets {
+			if other != target && other.CurrentTarget == newTank {
+				exchanged = other
+				break
+			}
+		}
+	}
+
+	target.SwapTank(sim, newTank)
+	if exchanged != nil {
+		exchanged.SwapTank(sim, oldTank)
+	}
+}
+
+// Swaps are queued rather than done inline, since stacks are usually added
+// in the middle of processing the target's melee swing.
+func (tsm *tankSwapManager) queueSwapAwayFrom(sim *Simulation, tank *Unit) {
+	if tsm.pendingSwapFor == tank {
+		return
+	}
+	// Without an off tank, the target only leaves if the debuff can drop off.
+	if tsm.otherTank(tank) == nil && tsm.debuffAuras[tsm.tankIndex(tank)].Duration == NeverExpires {
+		return
+	}
+	tsm.pendingSwapFor = tank
+
+	StartDelayedAction(sim, DelayedActionOptions{
+		DoAt:     sim.CurrentTime,
+		Priority: ActionPriorityDOT,
+
+		OnAction: func(sim *Simulation) {
+			tsm.pendingSwapFor = nil
+			if tsm.target.CurrentTarget == tank {
+				tsm.swapTo(sim, tsm.otherTank(tank))
+			}
+		},
+	})
+}
+
+// Adds a stack of the tank swap debuff to one of the target's tanks, for
+// debuffs applied by the target's AI.
+func (target *Target) AddTankSwapStack(sim *Simulation, tank *Unit) {
+	if tsm := target.tankSwap; tsm != nil {
+		tsm.addStack(sim, tank)
+	}
+}
+
+// Returns the tank swap debuff on the given tank, or nil if there is none.
+func (target *Target) TankSwapDebuff(tank *Unit) *Aura {
+	if tsm := target.tankSwap; tsm != nil {
+		if idx := tsm.tankIndex(tank); idx != -1 {
+			return tsm.debuffAuras[idx]
+		}
+	}
+	return nil
+}
+
+// Hands the target over to the other one of its two tanks, e.g. on a phase
+// change. Does nothing without an off tank.
+func (target *Target) SwapTanks(sim *Simulation) {
+	tsm := target.tankSwap
+	if tsm == nil {
+		return
+	}
+	if newTank := tsm.otherTank(target.CurrentTarget); newTank != nil {
+		tsm.swapTo(sim, newTank)
+	}
+}
+
+// Moves the target onto a new tank, which also becomes the new tank's target.
+// Passing nil leaves the target without anyone to melee. For targets which
+// model threat, this acts as a taunt by the new tank.
+func (target *Target) SwapTank(sim *Simulation, newTank *Unit) {
+	if tt := target.threatTable; tt != nil && newTank != nil {
+		tt.taunt(sim, newTank)
+	}
+
+	oldTank := target.CurrentTarget
+	if newTank == oldTank {
+		return
+	}
+
+	if sim.Log != nil {
+		target.Log(sim, "Swapping tanks: %s -> %s", unitLabel(oldTank), unitLabel(newTank))
+	}
+
+	if newTank != nil {
+		newTank.CurrentTarget = &target.Unit
+	}
+	target.changeCurrentTarget(sim, newTank)
+}
+
+// Moves the target's attacks onto a new unit, without changing who that unit is targeting.
+func (target *Target) changeCurrentTarget(sim *Simulation, newTarget *Unit) {
+	oldTarget := target.CurrentTarget
+
+	target.AutoAttacks.CancelAutoSwing(sim)
+	target.CurrentTarget = newTarget
+
+	if newTarget != nil && target.AutoAttacks.AutoSwingMelee {
+		target.AutoAttacks.EnableAutoSwing(sim)
+		target.AutoAttacks.RandomizeMeleeTiming(sim)
+	}
+
+	if tsm := target.tankSwap; tsm != nil {
+		if idx := tsm.tankIndex(oldTarget); idx != -1 {
+			tsm.tankingAuras[idx].Deactivate(sim)
+		}
+		if idx := tsm.tankIndex(newTarget); idx != -1 {
+			tsm.tankingAuras[idx].Activate(sim)
+		}
+	}
+
+	if tt := target.threatTable; tt != nil {
+		tt.setHolder(sim, newTarget)
+	}
+}
+
+func unitLabel(unit *Unit) string {
+	if unit == nil {
+		return "none"
+	}
+	return unit.Label
+}
@@ -0,0 +1,178 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func setupTankSwapSim(tankSwap *proto.TankSwapConfig) *Simulation {
+	request := newTestRaidSimRequest(newTestPlayer("Tank 1"), newTestPlayer("Tank 2"))
+	request.Raid.Tanks = append(request.Raid.Tanks, &proto.UnitReference{Type: proto.UnitReference_Player, Index: 1})
+	request.Encounter.Targets[0].SecondTankIndex = 1
+	request.Encounter.Targets[0].TankSwap = tankSwap
+	return newTestSim(request)
+}
+
+func TestTankSwapOnTimer(t *testing.T) {
+	sim := setupTankSwapSim(&proto.TankSwapConfig{
+		DebuffName:   "Test Debuff",
+		MaxStacks:    10,
+		SwapInterval: 10,
+	})
+	target := sim.Encounter.Targets[0]
+	tank1 := target.CurrentTarget
+	tank2 := target.SecondaryTarget
+
+	sim.runOnce()
+
+	if len(tank1.Metrics.tankingWindows) != 3 || len(tank2.Metrics.tankingWindows) != 3 {
+		t.Fatalf("Expected 3 tanking windows per tank, got %d and %d", len(tank1.Metrics.tankingWindows), len(tank2.Metrics.tankingWindows))
+	}
+
+	for i, window := range tank1.Metrics.tankingWindows {
+		if window.Window != int32(i*2) {
+			t.Fatalf("Expected main tank to have window %d, got %d", i*2, window.Window)
+		}
+		if window.durationSum != 10 || window.startSum != float64(i*20) {
+			t.Fatalf("Unexpected window timing: start %0.1f, duration %0.1f", window.startSum, window.durationSum)
+		}
+		if window.dtpsSum <= 0 {
+			t.Fatalf("Expected damage taken during window %d", window.Window)
+		}
+	}
+}
+
+func TestTankSwapAtStacks(t *testing.T) {
+	sim := setupTankSwapSim(&proto.TankSwapConfig{
+		DebuffName:          "Test Debuff",
+		DebuffDuration:      20,
+		MaxStacks:           10,
+		DamageTakenPerStack: 0.1,
+		SwapAtStacks:        3,
+	})
+	target := sim.Encounter.Targets[0]
+	tank1 := target.CurrentTarget
+	tank2 := target.SecondaryTarget
+	debuff := target.tankSwap.debuffAuras[0]
+
+	sim.reset()
+	sim.PrePull()
+	for target.CurrentTarget == tank1 && !sim.Step() {
+	}
+
+	if target.CurrentTarget != tank2 || tank2.CurrentTarget != &target.Unit {
+		t.Fatalf("Expected target to swap to the off tank")
+	}
+	if debuff.GetStacks() != 3 {
+		t.Fatalf("Expected main tank to be swapped at 3 stacks, got %d", debuff.GetStacks())
+	}
+	if !WithinToleranceFloat64(tank1.PseudoStats.DamageTakenMultiplier, 1.3, 0.0001) {
+		t.Fatalf("Expected 30%% increased damage taken, got %0.3f", tank1.PseudoStats.DamageTakenMultiplier)
+	}
+}
+
+func TestTankSwapStacksFromAI(t *testing.T) {
+	sim := setupTankSwapSim(&proto.TankSwapConfig{
+		DebuffName:          "Test Debuff",
+		MaxStacks:           10,
+		DamageTakenPerStack: 0.2,
+		DamageTakenSchools:  []proto.SpellSchool{proto.SpellSchool_SpellSchoolShadow},
+		StacksFromAi:        true,
+		SwapAtStacks:        2,
+	})
+	target := sim.Encounter.Targets[0]
+	tank1 := target.CurrentTarget
+	tank2 := target.SecondaryTarget
+	debuff := target.TankSwapDebuff(tank1)
+
+	sim.reset()
+	sim.PrePull()
+	for sim.CurrentTime < time.Second*10 && !sim.Step() {
+	}
+
+	if debuff.GetStacks() != 0 {
+		t.Fatalf("Expected no stacks from melee hits, got %d", debuff.GetStacks())
+	}
+
+	target.AddTankSwapStack(sim, tank1)
+	target.AddTankSwapStack(sim, tank1)
+
+	if !WithinToleranceFloat64(tank1.PseudoStats.SchoolDamageTakenMultiplier[stats.SchoolIndexShadow], 1.4, 0.0001) {
+		t.Fatalf("Expected 40%% increased shadow damage taken, got %0.3f", tank1.PseudoStats.SchoolDamageTakenMultiplier[stats.SchoolIndexShadow])
+	}
+	if tank1.PseudoStats.SchoolDamageTakenMultiplier[stats.SchoolIndexPhysical] != 1 || tank1.PseudoStats.DamageTakenMultiplier != 1 {
+		t.Fatalf("Expected physical damage taken to be unchanged")
+	}
+
+	for target.CurrentTarget == tank1 && !sim.Step() {
+	}
+	if target.CurrentTarget != tank2 {
+		t.Fatalf("Expected target to swap to the off tank")
+	}
+}
+
+func TestTankSwapExchangeTargets(t *testing.T) {
+	request := newTestRaidSimRequest(newTestPlayer("Tank 1"), newTestPlayer("Tank 2"))
+	request.Raid.Tanks = append(request.Raid.Tanks, &proto.UnitReference{Type: proto.UnitReference_Player, Index: 1})
+	request.Encounter.Targets[0].SecondTankIndex = 1
+	request.Encounter.Targets[0].TankSwap = &proto.TankSwapConfig{
+		DebuffName:      "Test Debuff",
+		DebuffDuration:  20,
+		MaxStacks:       10,
+		SwapAtStacks:    3,
+		ExchangeTargets: true,
+	}
+	request.Encounter.Targets = append(request.Encounter.Targets, &proto.Target{
+		Name:            "add",
+		Level:           88,
+		MobType:         proto.MobType_MobTypeDemon,
+		MinBaseDamage:   1000,
+		SwingSpeed:      1.5,
+		TankIndex:       1,
+		SecondTankIndex: 0,
+	})
+
+	sim := newTestSim(request)
+	boss := sim.Encounter.Targets[0]
+	tank1 := boss.CurrentTarget
+	tank2 := boss.SecondaryTarget
+
+	sim.reset()
+	sim.PrePull()
+	for boss.CurrentTarget == tank1 && !sim.Step() {
+	}
+
+	if boss.CurrentTarget != tank2 {
+		t.Fatalf("Expected boss to swap to the off tank")
+	}
+	if sim.Encounter.Targets[1].CurrentTarget != tank1 {
+		t.Fatalf("Expected the main tank to pick up the add")
+	}
+}
+
+func TestSwapTanksWithoutDebuff(t *testing.T) {
+	sim := setupTankSwapSim(&proto.TankSwapConfig{})
+	target := sim.Encounter.Targets[0]
+	tank1 := target.CurrentTarget
+	tank2 := target.SecondaryTarget
+
+	if target.TankSwapDebuff(tank1) != nil {
+		t.Fatalf("Expected no tank swap debuff")
+	}
+
+	sim.reset()
+	sim.PrePull()
+	sim.Step()
+
+	target.SwapTanks(sim)
+	if target.CurrentTarget != tank2 {
+		t.Fatalf("Expected target to swap to the off tank")
+	}
+	target.SwapTanks(sim)
+	if target.CurrentTarget != tank1 {
+		t.Fatalf("Expected target to swap back to the main tank")
+	}
+}
@@ -27,11 +27,24 @@ type Encounter struct {
 	// In health fight: set to true until we get something to base on
 	DurationIsEstimate bool
 
+	// Whether the raid takes damage from an incoming damage profile.
+	HasIncomingDamage bool
+
+	// Whether damage taken from enemies pushes back hardcasts.
+	CastPushback bool
+
 	// Value to multiply by, for damage spells which are subject to the aoe cap.
 	aoeCapMultiplier float64
 }
 
 func NewEncounter(options *proto.Encounter) Encounter {
+	if len(options.HealthCurve) > 0 && !options.UseHealth {
+		options.ExecuteProportion_20 = healthCurveExecuteProportion(options.HealthCurve, 0.20)
+		options.ExecuteProportion_25 = healthCurveExecuteProportion(options.HealthCurve, 0.25)
+		options.ExecuteProportion_35 = healthCurveExecuteProportion(options.HealthCurve, 0.35)
+		options.ExecuteProportion_90 = healthCurveExecuteProportion(options.HealthCurve, 0.90)
+	}
+
 	options.ExecuteProportion_25 = max(options.ExecuteProportion_25, options.ExecuteProportion_20)
 	options.ExecuteProportion_35 = max(options.ExecuteProportion_35, options.ExecuteProportion_25)
 
@@ -42,6 +55,7 @@ func NewEncounter(options *proto.Encounter) Encounter {
 		ExecuteProportion_25: max(options.ExecuteProportion_25, 0),
 		ExecuteProportion_35: max(options.ExecuteProportion_35, 0),
 		ExecuteProportion_90: max(options.ExecuteProportion_90, 0),
+		CastPushback:         options.CastPushback,
 		Targets:              []*Target{},
 		ActiveTargets:        []*Target{},
 	}
@@ -65,6 +79,12 @@ func NewEncounter(options *proto.Encounter) Encounter {
 		for _, t := range options.Targets {
 			encounter.EndFightAtHealth += t.Stats[stats.Health]
 		}
+		// Track each target's health, so execute phases follow the primary target's actual health.
+		for _, target := range encounter.Targets {
+			if target.stats[stats.Health] > 0 {
+				target.EnableHealthBar()
+			}
+		}
 		if encounter.EndFightAtHealth == 0 {
 			encounter.EndFightAtHealth = 1 // default to something so we don't instantly end without anything.
 		}
@@ -81,6 +101,26 @@ func NewEncounter(options *proto.Encounter) Encounter {
 	return encounter
 }
 
+// Returns the proportion of the fight for which the boss is at or below the
+// given health, based on a curve of (time, health) points. The curve starts
+// at full health and ends at 0, with health interpolated linearly in between.
+func healthCurveExecuteProportion(curve []*proto.HealthCurvePoint, health float64) float64 {
+	prevTime, prevHealth := 0.0, 1.0
+	for _, point := range append(curve[:len(curve):len(curve)], &proto.HealthCurvePoint{Time: 1, Health: 0}) {
+		pointTime := Clamp(point.Time, prevTime, 1)
+		pointHealth := Clamp(point.Health, 0, 1)
+		if pointHealth <= health {
+			crossTime := pointTime
+			if prevHealth > pointHealth {
+				crossTime = prevTime + (pointTime-prevTime)*(prevHealth-health)/(prevHealth-pointHealth)
+			}
+			return 1 - crossTime
+		}
+		prevTime, prevHealth = pointTime, pointHealth
+	}
+	return 0
+}
+
 func (encounter *Encounter) AOECapMultiplier() float64 {
 	return encounter.aoeCapMultiplier
 }
@@ -91,6 +131,9 @@ func (encounter *Encounter) updateAOECapMultiplier() {
 func (encounter *Encounter) doneIteration(sim *Simulation) {
 	for i := range encounter.Targets {
 		target := encounter.Targets[i]
+		if target.threatTable != nil {
+			target.threatTable.doneIteration(sim)
+		}
 		target.doneIteration(sim)
 	}
 }
@@ -116,6 +159,9 @@ type Target struct {
 	IsActive bool
 
 	AI TargetAI
+
+	tankSwap    *tankSwapManager
+	threatTable *threatTable
 }
 
 func NewTarget(options *proto.Target, targetIndex int32) *Target {
@@ -165,6 +211,8 @@ func NewTarget(options *proto.Target, targetIndex int32) *Target {
 	preset := GetPresetTargetWithID(options.Id)
 	if preset != nil && preset.AI != nil {
 		target.AI = preset.AI()
+	} else if options.CustomAi != nil {
+		target.AI = NewCustomTargetAI()()
 	}
 
 	return target
@@ -178,6 +226,12 @@ func (target *Target) Reset(sim *Simulation) {
 	if target.AI != nil {
 		target.AI.Reset(sim)
 	}
+	if target.tankSwap != nil {
+		target.tankSwap.reset(sim)
+	}
+	if target.threatTable != nil {
+		target.threatTable.reset(sim)
+	}
 }
 
 func (target *Target) NextTarget() *Target {
@@ -188,6 +242,31 @@ func (target *Target) NextTarget() *Target {
 	return target.Env.GetTarget(nextIndex)
 }
 
+// Registers an aura during which the target can't be attacked, e.g. while it is flying or
+// between encounter phases. Damage dealt to the target is discarded and it stops meleeing
+// until the aura expires.
+func (target *Target) RegisterUntargetableAura(config Aura) *Aura {
+	aura := target.RegisterAura(config)
+
+	aura.ApplyOnGain(func(_ *Aura, sim *Simulation) {
+		target.AutoAttacks.CancelAutoSwing(sim)
+	})
+	aura.ApplyOnExpire(func(_ *Aura, sim *Simulation) {
+		if target.CurrentTarget != nil && sim.CurrentTime < sim.Duration {
+			target.AutoAttacks.EnableAutoSwing(sim)
+			target.AutoAttacks.RandomizeMeleeTiming(sim)
+		}
+	})
+
+	target.AddDynamicDamageTakenModifier(func(_ *Simulation, _ *Spell, result *SpellResult) {
+		if aura.IsActive() {
+			result.Damage = 0
+		}
+	})
+
+	return aura
+}
+
 func (target *Target) GetMetricsProto() *proto.UnitMetrics {
 	metrics := target.Metrics.ToProto()
 	metrics.Name = target.Label
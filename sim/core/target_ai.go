@@ -46,6 +46,15 @@ func (target *Target) initialize(config *proto.Target) {
 			},
 		}
 	}
+
+	// Preset AIs may have set up tank swaps from their own inputs already.
+	if config.TankSwap != nil && target.tankSwap == nil {
+		target.EnableTankSwap(config.TankSwap)
+	}
+
+	if config.Threat != nil {
+		target.initThreatTable(config.Threat)
+	}
 }
 
 // Empty Agent interface functions.
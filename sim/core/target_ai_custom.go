@@ -0,0 +1,223 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+// Generic TargetAI which runs the data-driven abilities from a Target's
+// CustomTargetAI config, so new bosses can be modeled without Go code.
+type CustomTargetAI struct {
+	Target *Target
+
+	abilities       []*customTargetAbility
+	phaseStartTimes []time.Duration
+	raidMovement    []customMovementWindow
+}
+
+type customMovementWindow struct {
+	start    time.Duration
+	duration time.Duration
+}
+
+type customTargetAbility struct {
+	config       *proto.TargetAbility
+	spell        *Spell
+	initialDelay time.Duration
+}
+
+func NewCustomTargetAI() AIFactory {
+	return func() TargetAI {
+		return &CustomTargetAI{}
+	}
+}
+
+func (ai *CustomTargetAI) Initialize(target *Target, config *proto.Target) {
+	ai.Target = target
+
+	customConfig := config.CustomAi
+	if customConfig == nil {
+		return
+	}
+
+	for _, startTime := range customConfig.PhaseStartTimes {
+		ai.phaseStartTimes = append(ai.phaseStartTimes, DurationFromSeconds(startTime))
+	}
+
+	for _, window := range customConfig.RaidMovement {
+		if window.Duration > 0 {
+			ai.raidMovement = append(ai.raidMovement, customMovementWindow{
+				start:    DurationFromSeconds(window.Start),
+				duration: DurationFromSeconds(window.Duration),
+			})
+		}
+	}
+
+	for i, abilityConfig := range customConfig.Abilities {
+		ai.abilities = append(ai.abilities, &customTargetAbility{
+			config:       abilityConfig,
+			spell:        ai.registerAbility(int32(i), abilityConfig),
+			initialDelay: DurationFromSeconds(abilityConfig.InitialDelay),
+		})
+	}
+}
+
+func (ai *CustomTargetAI) registerAbility(index int32, config *proto.TargetAbility) *Spell {
+	actionID := ActionID{SpellID: config.SpellId}
+	if config.SpellId == 0 {
+		// Keep abilities without an ID apart in the metrics.
+		actionID.Tag = index + 1
+	}
+
+	school := SpellSchoolFromProto(config.School)
+	isPhysical := school == SpellSchoolPhysical
+
+	flags := SpellFlagNone
+	if config.Interruptible {
+		flags |= SpellFlagInterruptible
+	}
+
+	// Keep the GCD longer than the cast, so the next ability is only picked
+	// once this one has completed.
+	castTime := DurationFromSeconds(config.CastTime)
+	gcd := Ternary(castTime > 0, castTime+BossGCD, BossGCD)
+
+	minDamage := config.MinDamage
+	damageSpread := max(config.MaxDamage-config.MinDamage, 0)
+
+	stunDuration := DurationFromSeconds(config.StunDuration)
+	silenceDuration := DurationFromSeconds(config.SilenceDuration)
+	interruptLockout := DurationFromSeconds(config.InterruptLockout)
+
+	var cooldown Cooldown
+	if config.Cooldown > 0 {
+		cooldown = Cooldown{
+			Timer:    ai.Target.NewTimer(),
+			Duration: DurationFromSeconds(config.Cooldown),
+		}
+	}
+
+	return ai.Target.RegisterSpell(SpellConfig{
+		ActionID:         actionID,
+		SpellSchool:      school,
+		ProcMask:         Ternary(isPhysical, ProcMaskMeleeMHSpecial, ProcMaskSpellDamage),
+		Flags:            flags,
+		DamageMultiplier: 1,
+
+		Cast: CastConfig{
+			DefaultCast: Cast{
+				GCD:      gcd,
+				CastTime: castTime,
+			},
+
+			CD: cooldown,
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+			multiplier := ai.phaseDamageMultiplier(sim, config)
+			outcome := Ternary(isPhysical, spell.OutcomeEnemyMeleeWhite, spell.OutcomeAlwaysHit)
+
+			for _, abilityTarget := range ai.selectTargets(sim, config, target) {
+				damageRoll := minDamage + damageSpread*sim.RandomFloat("Custom Ability Damage")
+				result := spell.CalcAndDealDamage(sim, abilityTarget, damageRoll*multiplier, outcome)
+				if !result.Landed() {
+					continue
+				}
+
+				if interruptLockout > 0 {
+					spell.Interrupt(sim, abilityTarget, interruptLockout)
+				}
+				abilityTarget.Stun(sim, stunDuration)
+				abilityTarget.Silence(sim, silenceDuration)
+			}
+		},
+	})
+}
+
+func (ai *CustomTargetAI) Reset(sim *Simulation) {
+	for _, window := range ai.raidMovement {
+		StartDelayedAction(sim, DelayedActionOptions{
+			DoAt:     window.start,
+			Priority: ActionPriorityDOT,
+
+			OnAction: func(sim *Simulation) {
+				sim.Raid.MoveAllPlayers(sim, window.duration)
+			},
+		})
+	}
+}
+
+// Index of the current phase, based on the configured phase start times.
+func (ai *CustomTargetAI) currentPhase(sim *Simulation) int {
+	phase := 0
+	for _, startTime := range ai.phaseStartTimes {
+		if sim.CurrentTime >= startTime {
+			phase++
+		}
+	}
+	return phase
+}
+
+func (ai *CustomTargetAI) phaseDamageMultiplier(sim *Simulation, config *proto.TargetAbility) float64 {
+	phase := ai.currentPhase(sim)
+	if phase < len(config.PhaseDamageMultipliers) {
+		return config.PhaseDamageMultipliers[phase]
+	}
+	return 1
+}
+
+func (ai *CustomTargetAI) selectTargets(sim *Simulation, config *proto.TargetAbility, defaultTarget *Unit) []*Unit {
+	players := sim.Raid.AllPlayerUnits
+
+	switch config.TargetSelection {
+	case proto.TargetAbility_RandomPlayer:
+		if len(players) == 0 {
+			return nil
+		}
+		idx := int(sim.RandomFloat("Custom Ability Target") * float64(len(players)))
+		return []*Unit{players[min(idx, len(players)-1)]}
+	case proto.TargetAbility_AllPlayers:
+		return players
+	default:
+		return []*Unit{defaultTarget}
+	}
+}
+
+func (ai *CustomTargetAI) ExecuteCustomRotation(sim *Simulation) {
+	target := ai.Target.CurrentTarget
+	if target == nil {
+		// For individual non tank sims we still want abilities to work
+		target = &ai.Target.Env.Raid.Parties[0].Players[0].GetCharacter().Unit
+	}
+
+	nextReadyAt := NeverExpires
+	for _, ability := range ai.abilities {
+		if ai.phaseDamageMultiplier(sim, ability.config) == 0 {
+			continue
+		}
+
+		readyAt := max(ability.initialDelay, ability.spell.ReadyAt())
+		if readyAt > sim.CurrentTime {
+			nextReadyAt = min(nextReadyAt, readyAt)
+			continue
+		}
+
+		if ability.spell.CanCast(sim, target) {
+			ability.spell.Cast(sim, target)
+			return
+		}
+	}
+
+	// Nothing to cast, so skip ahead to the next ability or phase change.
+	for _, startTime := range ai.phaseStartTimes {
+		if startTime > sim.CurrentTime {
+			nextReadyAt = min(nextReadyAt, startTime)
+		}
+	}
+	if nextReadyAt != NeverExpires && ai.Target.GCD.IsReady(sim) {
+		ai.Target.WaitUntil(sim, max(nextReadyAt, ai.Target.NextGCDAt()))
+	}
+}
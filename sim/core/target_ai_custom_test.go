@@ -0,0 +1,145 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func totalCasts(spell *Spell) int32 {
+	casts := int32(0)
+	for _, metrics := range spell.SpellMetrics {
+		casts += metrics.Casts
+	}
+	return casts
+}
+
+func TestCustomTargetAI(t *testing.T) {
+	request := newTestRaidSimRequest(newTestPlayer("Caster"))
+	request.Raid.Tanks = nil
+	request.Encounter.Targets[0] = &proto.Target{
+		Name:    "target",
+		Level:   88,
+		MobType: proto.MobType_MobTypeDemon,
+		CustomAi: &proto.CustomTargetAI{
+			Abilities: []*proto.TargetAbility{
+				{
+					SpellId:      1,
+					School:       proto.SpellSchool_SpellSchoolFire,
+					MinDamage:    1000,
+					MaxDamage:    2000,
+					CastTime:     2,
+					Cooldown:     10,
+					InitialDelay: 5,
+				},
+				{
+					SpellId:                2,
+					School:                 proto.SpellSchool_SpellSchoolShadow,
+					MinDamage:              500,
+					MaxDamage:              500,
+					Cooldown:               5,
+					TargetSelection:        proto.TargetAbility_AllPlayers,
+					PhaseDamageMultipliers: []float64{0, 2},
+				},
+			},
+			PhaseStartTimes: []float64{30},
+		},
+	}
+	sim := newTestSim(request)
+
+	ai, ok := sim.Encounter.Targets[0].AI.(*CustomTargetAI)
+	if !ok {
+		t.Fatalf("Expected targets with a custom AI config to use CustomTargetAI")
+	}
+
+	sim.reset()
+	sim.PrePull()
+	sim.runPendingActions()
+
+	// Casts start at 5s, then every 12s (2s cast + 10s cooldown).
+	if casts := totalCasts(ai.abilities[0].spell); casts != 5 {
+		t.Fatalf("Expected 5 casts of the first ability, got %d", casts)
+	}
+
+	// Only used during the second phase, which starts at 30s.
+	phaseTwoSpell := ai.abilities[1].spell
+	if casts := totalCasts(phaseTwoSpell); casts < 3 || casts > 6 {
+		t.Fatalf("Expected 3-6 casts of the second ability, got %d", casts)
+	}
+	for _, metrics := range phaseTwoSpell.SpellMetrics {
+		if metrics.Hits > 0 && metrics.TotalDamage != float64(metrics.Hits)*1000 {
+			t.Fatalf("Expected phase damage multiplier to double damage, got %0.1f over %d hits", metrics.TotalDamage, metrics.Hits)
+		}
+	}
+}
+
+func TestCustomTargetAICrowdControl(t *testing.T) {
+	request := newTestRaidSimRequest(newTestPlayer("Caster"))
+	request.Encounter.Targets[0].CustomAi = &proto.CustomTargetAI{
+		Abilities: []*proto.TargetAbility{
+			{
+				SpellId:          1,
+				School:           proto.SpellSchool_SpellSchoolShadow,
+				MinDamage:        100,
+				MaxDamage:        100,
+				SilenceDuration:  3,
+				InterruptLockout: 4,
+			},
+			{
+				SpellId:      2,
+				School:       proto.SpellSchool_SpellSchoolShadow,
+				MinDamage:    100,
+				MaxDamage:    100,
+				StunDuration: 2,
+			},
+		},
+	}
+	sim := newTestSim(request)
+	sim.reset()
+
+	ai := sim.Encounter.Targets[0].AI.(*CustomTargetAI)
+	player := &sim.Raid.Parties[0].Players[0].GetCharacter().Unit
+	player.Hardcast = Hardcast{
+		Expires:       sim.CurrentTime + time.Second*2,
+		SpellSchool:   SpellSchoolFire,
+		OnComplete:    func(_ *Simulation, _ *Unit) { t.Fatalf("Interrupted cast should not complete") },
+		Interruptible: true,
+	}
+
+	ai.abilities[0].spell.SkipCastAndApplyEffects(sim, player)
+	if player.Hardcast.Expires > sim.CurrentTime || !player.IsSilenced() {
+		t.Fatalf("Expected the player's cast to be interrupted and the player silenced")
+	}
+	if (&Spell{Unit: player, SpellSchool: SpellSchoolFire, Flags: SpellFlagAPL}).lossOfControlReason(sim) == "" {
+		t.Fatalf("Expected the interrupted school to be locked out")
+	}
+
+	ai.abilities[1].spell.SkipCastAndApplyEffects(sim, player)
+	if !player.IsStunned() || player.stunAura.RemainingDuration(sim) != time.Second*2 {
+		t.Fatalf("Expected the player to be stunned for 2s")
+	}
+}
+
+func TestCustomTargetAIRaidMovement(t *testing.T) {
+	request := newTestRaidSimRequest(newTestPlayer("Caster"))
+	request.Encounter.Targets[0].CustomAi = &proto.CustomTargetAI{
+		RaidMovement: []*proto.TargetTimeWindow{
+			{Start: 10, Duration: 3},
+			{Start: 40, Duration: 5},
+		},
+	}
+	sim := newTestSim(request)
+	player := &sim.Raid.Parties[0].Players[0].GetCharacter().Unit
+
+	sim.reset()
+	sim.PrePull()
+	sim.runPendingActions()
+
+	if casts := totalCasts(player.moveSpell); casts != 2 {
+		t.Fatalf("Expected the raid to move twice, got %d", casts)
+	}
+	if uptime := player.moveAura.metrics.Uptime; uptime != time.Second*8 {
+		t.Fatalf("Expected 8s of movement, got %s", uptime)
+	}
+}
@@ -0,0 +1,59 @@
+package core
+
+import (
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/simsignals"
+)
+
+// A player without any gear, buffs or rotation, for tests of core mechanics.
+func newTestPlayer(name string) *proto.Player {
+	return &proto.Player{
+		Name:      name,
+		Class:     proto.Class_ClassShaman,
+		Consumes:  &proto.Consumes{},
+		Buffs:     &proto.IndividualBuffs{},
+		Spec:      &proto.Player_ElementalShaman{},
+		Equipment: &proto.EquipmentSpec{},
+	}
+}
+
+// A 60s raid sim with the given players in a single party, against a target
+// meleeing the first player for 1000 damage every 1.5s. Tests adjust the
+// request for whatever they cover.
+func newTestRaidSimRequest(players ...*proto.Player) *proto.RaidSimRequest {
+	return &proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{
+			RandomSeed: 100,
+		},
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{
+				{
+					Players: players,
+					Buffs:   &proto.PartyBuffs{},
+				},
+			},
+			Buffs:   &proto.RaidBuffs{},
+			Debuffs: &proto.Debuffs{},
+			Tanks: []*proto.UnitReference{
+				{Type: proto.UnitReference_Player, Index: 0},
+			},
+		},
+		Encounter: &proto.Encounter{
+			Targets: []*proto.Target{
+				{
+					Name:          "target",
+					Level:         88,
+					MobType:       proto.MobType_MobTypeDemon,
+					MinBaseDamage: 1000,
+					SwingSpeed:    1.5,
+					TankIndex:     0,
+				},
+			},
+			Duration: 60,
+		},
+	}
+}
+
+func newTestSim(request *proto.RaidSimRequest) *Simulation {
+	return NewSim(request, simsignals.CreateSignals())
+}
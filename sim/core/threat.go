@@ -0,0 +1,256 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+const (
+	// Threat needed to pull aggro, relative to the threat of the current target.
+	MeleeAggroThreshold  = 1.1
+	RangedAggroThreshold = 1.3
+
+	// How long a taunted target is forced to attack the taunting unit.
+	TauntDuration = time.Second * 3
+
+	// Threat from healing relative to the amount healed, before it's split
+	// between enemies.
+	HealingThreatMultiplier = 0.5
+)
+
+// Tracks the threat of every raid unit on a target, for targets which attack
+// whoever is highest on threat instead of staying on their assigned tank.
+//
+// Threat from resource gains (rage, mana) is only tallied into the metrics at
+// the end of each iteration, so it isn't included here.
+type threatTable struct {
+	target *Target
+	config *proto.ThreatConfig
+
+	// Threat of each unit, indexed by UnitIndex.
+	threat []float64
+
+	// A taunted target attacks the taunting unit until tauntExpires, regardless of threat.
+	tauntExpires time.Duration
+
+	pendingCheck bool
+
+	// Unit currently holding aggro, and since when. Used for metrics.
+	holder      *Unit
+	holderSince time.Duration
+}
+
+func (target *Target) initThreatTable(config *proto.ThreatConfig) {
+	target.threatTable = &threatTable{
+		target: target,
+		config: config,
+		threat: make([]float64, len(target.Env.AllUnits)),
+	}
+}
+
+func (tt *threatTable) reset(sim *Simulation) {
+	clear(tt.threat)
+	tt.tauntExpires = 0
+	tt.pendingCheck = false
+	tt.holder = tt.target.CurrentTarget
+	tt.holderSince = sim.CurrentTime
+
+	if tt.holder != nil {
+		tt.threat[tt.holder.UnitIndex] = tt.config.TankInitialThreat
+	}
+}
+
+func (tt *threatTable) doneIteration(sim *Simulation) {
+	tt.setHolder(sim, nil)
+}
+
+func (tt *threatTable) addThreat(sim *Simulation, unit *Unit, amount float64) {
+	if amount == 0 {
+		return
+	}
+
+	tt.threat[unit.UnitIndex] = max(tt.threat[unit.UnitIndex]+amount, 0)
+	tt.queueAggroCheck(sim)
+}
+
+// Aggro checks are queued rather than done inline, since threat is usually
+// generated in the middle of processing some other spell.
+func (tt *threatTable) queueAggroCheck(sim *Simulation) {
+	if tt.pendingCheck || sim.CurrentTime >= sim.Duration {
+		return
+	}
+	tt.pendingCheck = true
+
+	StartDelayedAction(sim, DelayedActionOptions{
+		DoAt:     sim.CurrentTime,
+		Priority: ActionPriorityDOT,
+
+		OnAction: func(sim *Simulation) {
+			tt.pendingCheck = false
+			tt.checkAggro(sim)
+		},
+	})
+}
+
+// Moves the target onto the unit with the most threat, if that unit has
+// enough threat to pull aggro off the current target.
+func (tt *threatTable) checkAggro(sim *Simulation) {
+	target := tt.target
+	if sim.CurrentTime < tt.tauntExpires || sim.CurrentTime >= sim.Duration {
+		return
+	}
+
+	// A target sent away from its assigned tank, e.g. by a tank swap without an
+	// off tank, waits to be picked back up rather than attacking the raid.
+	current := target.CurrentTarget
+	if current == nil && target.defaultTarget != nil {
+		return
+	}
+
+	currentThreat := 0.0
+	if current != nil {
+		currentThreat = tt.threat[current.UnitIndex]
+	}
+
+	var newTarget *Unit
+	highestThreat := currentThreat
+	for _, unit := range target.Env.Raid.AllUnits {
+		threat := tt.threat[unit.UnitIndex]
+		if unit == current || !unit.IsEnabled() || threat <= highestThreat {
+			continue
+		}
+
+		threshold := RangedAggroThreshold
+		if unit.DistanceFromTarget <= MaxMeleeRange {
+			threshold = MeleeAggroThreshold
+		}
+		if threat > currentThreat*threshold {
+			newTarget = unit
+			highestThreat = threat
+		}
+	}
+
+	if newTarget == nil {
+		return
+	}
+
+	if sim.Log != nil {
+		target.Log(sim, "%s pulled aggro from %s (Threat: %0.3f vs %0.3f)", newTarget.Label, unitLabel(current), highestThreat, currentThreat)
+	}
+	newTarget.Metrics.addAggroPull(target.Index)
+	target.changeCurrentTarget(sim, newTarget)
+}
+
+// Forces the target onto the taunting unit for a few seconds, and raises the
+// unit's threat to that of the highest unit on the table.
+func (tt *threatTable) taunt(sim *Simulation, unit *Unit) {
+	highestThreat := 0.0
+	for _, threat := range tt.threat {
+		highestThreat = max(highestThreat, threat)
+	}
+
+	tt.threat[unit.UnitIndex] = highestThreat
+	tt.tauntExpires = sim.CurrentTime + TauntDuration
+
+	// Check again once the taunt wears off, in case someone else has taken the lead.
+	StartDelayedAction(sim, DelayedActionOptions{
+		DoAt:     tt.tauntExpires,
+		Priority: ActionPriorityDOT,
+
+		OnAction: tt.checkAggro,
+	})
+}
+
+func (tt *threatTable) setHolder(sim *Simulation, unit *Unit) {
+	if tt.holder != nil {
+		tt.holder.Metrics.addTimeTanking(tt.target.Index, sim.CurrentTime-tt.holderSince)
+	}
+
+	tt.holder = unit
+	tt.holderSince = sim.CurrentTime
+}
+
+// Returns the threat table of this unit if it's a target which models threat, otherwise nil.
+func (unit *Unit) getThreatTable() *threatTable {
+	if unit.Type != EnemyUnit {
+		return nil
+	}
+	return unit.Env.Encounter.Targets[unit.Index].threatTable
+}
+
+// Credits threat generated by this unit to the target's threat table, if it has one.
+func (unit *Unit) generateThreat(sim *Simulation, target *Unit, amount float64) {
+	if unit.Type == EnemyUnit || amount == 0 || sim.CurrentTime < 0 {
+		return
+	}
+
+	if tt := target.getThreatTable(); tt != nil {
+		tt.addThreat(sim, unit.threatRecipient(), amount)
+	}
+}
+
+// Credits healing threat generated by this unit to the targets which model
+// threat. Healing generates half as much threat as damage, split evenly
+// between all enemies in combat.
+func (unit *Unit) generateHealingThreat(sim *Simulation, amount float64) {
+	if unit.Type == EnemyUnit || amount == 0 || sim.CurrentTime < 0 {
+		return
+	}
+
+	numTargets := unit.Env.GetNumTargets()
+	if numTargets == 0 {
+		return
+	}
+	amount *= HealingThreatMultiplier / float64(numTargets)
+
+	for _, target := range sim.Encounter.ActiveTargets {
+		if target.threatTable != nil {
+			target.threatTable.addThreat(sim, unit.threatRecipient(), amount)
+		}
+	}
+}
+
+func (unit *Unit) threatRecipient() *Unit {
+	if unit.ThreatRedirect != nil {
+		return unit.ThreatRedirect
+	}
+	return unit
+}
+
+// Returns this unit's threat on the target, or 0 if the target doesn't model threat.
+func (unit *Unit) ThreatOn(target *Unit) float64 {
+	if tt := target.getThreatTable(); tt != nil {
+		return tt.threat[unit.UnitIndex]
+	}
+	return 0
+}
+
+// Adds threat directly to this unit's threat on the target, ignoring threat
+// multipliers and redirects. Negative amounts remove threat.
+func (unit *Unit) AddThreat(sim *Simulation, target *Unit, amount float64) {
+	if tt := target.getThreatTable(); tt != nil {
+		tt.addThreat(sim, unit, amount)
+	}
+}
+
+// Multiplies this unit's threat on the target, e.g. 0 to wipe it completely.
+func (unit *Unit) ScaleThreat(sim *Simulation, target *Unit, multiplier float64) {
+	unit.AddThreat(sim, target, unit.ThreatOn(target)*(multiplier-1))
+}
+
+// Multiplies this unit's threat on every target.
+func (unit *Unit) ScaleThreatOnAllTargets(sim *Simulation, multiplier float64) {
+	for _, target := range sim.Encounter.TargetUnits {
+		unit.ScaleThreat(sim, target, multiplier)
+	}
+}
+
+// Taunts the target, which then attacks this unit. For targets which model
+// threat, the taunting unit's threat is also raised to the top of the table.
+func (unit *Unit) Taunt(sim *Simulation, target *Unit) {
+	if target.Type != EnemyUnit {
+		return
+	}
+	unit.Env.Encounter.Targets[target.Index].SwapTank(sim, unit)
+}
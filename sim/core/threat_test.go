@@ -0,0 +1,140 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func setupThreatSim() *Simulation {
+	caster := newTestPlayer("Caster")
+	caster.DistanceFromTarget = 30
+
+	request := newTestRaidSimRequest(newTestPlayer("Tank"), caster)
+	request.Encounter.Targets[0].Threat = &proto.ThreatConfig{
+		TankInitialThreat: 1000,
+	}
+	return newTestSim(request)
+}
+
+func advanceTo(sim *Simulation, until time.Duration) {
+	for sim.CurrentTime < until && !sim.Step() {
+	}
+}
+
+func TestThreatRangedPull(t *testing.T) {
+	sim := setupThreatSim()
+	target := sim.Encounter.Targets[0]
+	tank := target.CurrentTarget
+	caster := sim.Raid.AllPlayerUnits[1]
+
+	sim.reset()
+	sim.PrePull()
+
+	// Needs 130% of the tank's threat from range.
+	caster.AddThreat(sim, &target.Unit, 1250)
+	advanceTo(sim, time.Second)
+	if target.CurrentTarget != tank {
+		t.Fatalf("Expected tank to keep aggro below 130%% threat")
+	}
+
+	caster.AddThreat(sim, &target.Unit, 100)
+	advanceTo(sim, time.Second*2)
+	if target.CurrentTarget != caster {
+		t.Fatalf("Expected caster to pull aggro above 130%% threat")
+	}
+	if caster.CurrentTarget != &target.Unit {
+		t.Fatalf("Pulling aggro should not change the caster's target")
+	}
+	if am := caster.Metrics.getAggroMetrics(target.Index); am.pullsSum != 1 {
+		t.Fatalf("Expected 1 aggro pull, got %d", am.pullsSum)
+	}
+
+	// Taunting matches the highest threat and holds the target for the taunt duration.
+	tank.Taunt(sim, &target.Unit)
+	if target.CurrentTarget != tank || tank.ThreatOn(&target.Unit) != 1350 {
+		t.Fatalf("Expected taunt to move the target back with matching threat, got %0.1f", tank.ThreatOn(&target.Unit))
+	}
+
+	caster.AddThreat(sim, &target.Unit, 1000)
+	advanceTo(sim, time.Second*4)
+	if target.CurrentTarget != tank {
+		t.Fatalf("Expected taunt to hold the target")
+	}
+	advanceTo(sim, time.Second*6)
+	if target.CurrentTarget != caster {
+		t.Fatalf("Expected caster to pull aggro after the taunt wears off")
+	}
+}
+
+func TestThreatRedirectAndDrop(t *testing.T) {
+	sim := setupThreatSim()
+	target := sim.Encounter.Targets[0]
+	tank := target.CurrentTarget
+	caster := sim.Raid.AllPlayerUnits[1]
+
+	sim.reset()
+	sim.PrePull()
+
+	caster.ThreatRedirect = tank
+	caster.generateThreat(sim, &target.Unit, 5000)
+	if tank.ThreatOn(&target.Unit) != 6000 || caster.ThreatOn(&target.Unit) != 0 {
+		t.Fatalf("Expected threat to be redirected to the tank")
+	}
+
+	caster.ThreatRedirect = nil
+	caster.generateThreat(sim, &target.Unit, 5000)
+	caster.ScaleThreatOnAllTargets(sim, 0)
+	advanceTo(sim, time.Second)
+	if caster.ThreatOn(&target.Unit) != 0 || target.CurrentTarget != tank {
+		t.Fatalf("Expected dropped threat to leave the tank holding aggro")
+	}
+}
+
+func TestThreatTimeTanking(t *testing.T) {
+	sim := setupThreatSim()
+	target := sim.Encounter.Targets[0]
+	tank := target.CurrentTarget
+
+	sim.runOnce()
+
+	if am := tank.Metrics.getAggroMetrics(target.Index); am.timeTankingSum != sim.Duration {
+		t.Fatalf("Expected tank to hold aggro for the whole fight, got %s", am.timeTankingSum)
+	}
+}
+
+func TestThreatHealingSplit(t *testing.T) {
+	request := newTestRaidSimRequest(newTestPlayer("Tank"), newTestPlayer("Healer"))
+	request.Encounter.Targets = append(request.Encounter.Targets, &proto.Target{Name: "Add", Level: 88, TankIndex: -1})
+	for _, target := range request.Encounter.Targets {
+		target.Threat = &proto.ThreatConfig{}
+	}
+	sim := newTestSim(request)
+	healer := sim.Raid.AllPlayerUnits[1]
+
+	sim.reset()
+	sim.PrePull()
+
+	healer.generateHealingThreat(sim, 1000)
+	for _, target := range sim.Encounter.TargetUnits {
+		if threat := healer.ThreatOn(target); threat != 250 {
+			t.Errorf("Expected half the healing split between both targets, got %0.1f on %s", threat, target.Label)
+		}
+	}
+}
+
+func TestThreatIgnoredDuringPrepull(t *testing.T) {
+	sim := setupThreatSim()
+	target := sim.Encounter.Targets[0]
+	caster := sim.Raid.AllPlayerUnits[1]
+
+	sim.reset()
+	sim.CurrentTime = -time.Second
+
+	caster.generateThreat(sim, &target.Unit, 1000)
+	caster.generateHealingThreat(sim, 1000)
+	if threat := caster.ThreatOn(&target.Unit); threat != 0 {
+		t.Fatalf("Expected no threat from prepull actions, got %0.1f", threat)
+	}
+}
@@ -0,0 +1,209 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+// Length of each bucket in timeline metrics.
+const TimelineBucketDuration = time.Second
+
+// A resource whose level is sampled for timeline metrics.
+type resourceLevel struct {
+	resourceType proto.ResourceType
+	getLevel     func() float64
+}
+
+// Includes a class-specific resource, e.g. holy power, in timeline metrics.
+// Resources with a bar in core are included automatically.
+func (unit *Unit) RegisterResourceLevel(resourceType proto.ResourceType, getLevel func() float64) {
+	unit.resourceLevels = append(unit.resourceLevels, resourceLevel{
+		resourceType: resourceType,
+		getLevel:     getLevel,
+	})
+}
+
+type resourceTimeline struct {
+	resourceLevel
+	levelSums []float64
+}
+
+// Time-bucketed metrics, summed over all iterations.
+type timelineMetrics struct {
+	unit *Unit
+
+	// For pets, whose damage also counts towards their owner's DPS.
+	owner *timelineMetrics
+
+	coveredSeconds []float64
+	damage         []float64
+	samples        []int32
+	resources      []*resourceTimeline
+
+	// Seconds each aura was active, by bucket.
+	auraActive map[*Aura][]float64
+}
+
+func (env *Environment) enableTimelineMetrics() {
+	for _, unit := range env.AllUnits {
+		unit.Metrics.timeline = newTimelineMetrics(unit)
+	}
+
+	for _, party := range env.Raid.Parties {
+		for _, player := range party.Players {
+			character := player.GetCharacter()
+			for _, pet := range character.Pets {
+				pet.Metrics.timeline.owner = character.Metrics.timeline
+			}
+		}
+	}
+}
+
+func newTimelineMetrics(unit *Unit) *timelineMetrics {
+	tm := &timelineMetrics{
+		unit:       unit,
+		auraActive: make(map[*Aura][]float64),
+	}
+
+	addResource := func(resourceType proto.ResourceType, getLevel func() float64) {
+		tm.resources = append(tm.resources, &resourceTimeline{
+			resourceLevel: resourceLevel{resourceType: resourceType, getLevel: getLevel},
+		})
+	}
+	if unit.HasManaBar() {
+		addResource(proto.ResourceType_ResourceTypeMana, unit.CurrentMana)
+	}
+	if unit.HasEnergyBar() {
+		addResource(proto.ResourceType_ResourceTypeEnergy, unit.CurrentEnergy)
+	}
+	if unit.HasRageBar() {
+		addResource(proto.ResourceType_ResourceTypeRage, unit.CurrentRage)
+	}
+	if unit.HasRunicPowerBar() {
+		addResource(proto.ResourceType_ResourceTypeRunicPower, unit.CurrentRunicPower)
+	}
+	if unit.HasFocusBar() {
+		addResource(proto.ResourceType_ResourceTypeFocus, unit.CurrentFocus)
+	}
+	for _, level := range unit.resourceLevels {
+		addResource(level.resourceType, level.getLevel)
+	}
+
+	return tm
+}
+
+func growBuckets[T any](buckets []T, idx int) []T {
+	for len(buckets) <= idx {
+		var zero T
+		buckets = append(buckets, zero)
+	}
+	return buckets
+}
+
+// Adds the seconds between start and end to each bucket they overlap.
+func addBucketedSeconds(buckets []float64, start time.Duration, end time.Duration) []float64 {
+	start = max(start, 0)
+	for start < end {
+		idx := int(start / TimelineBucketDuration)
+		bucketEnd := min(time.Duration(idx+1)*TimelineBucketDuration, end)
+		buckets = growBuckets(buckets, idx)
+		buckets[idx] += (bucketEnd - start).Seconds()
+		start = bucketEnd
+	}
+	return buckets
+}
+
+func (tm *timelineMetrics) reset(sim *Simulation) {
+	if len(tm.resources) == 0 {
+		return
+	}
+
+	StartPeriodicAction(sim, PeriodicActionOptions{
+		Period:          TimelineBucketDuration,
+		TickImmediately: true,
+		OnAction:        tm.sampleResources,
+	})
+}
+
+func (tm *timelineMetrics) sampleResources(sim *Simulation) {
+	if sim.CurrentTime < 0 || sim.CurrentTime >= sim.Duration {
+		return
+	}
+
+	idx := int(sim.CurrentTime / TimelineBucketDuration)
+	tm.samples = growBuckets(tm.samples, idx)
+	tm.samples[idx]++
+	for _, resource := range tm.resources {
+		resource.levelSums = growBuckets(resource.levelSums, idx)
+		resource.levelSums[idx] += resource.getLevel()
+	}
+}
+
+func (tm *timelineMetrics) addDamage(sim *Simulation, damage float64) {
+	// Damage landing exactly at the end of the fight still counts towards the last bucket.
+	idx := int(min(sim.CurrentTime, sim.Duration-1) / TimelineBucketDuration)
+	tm.damage = growBuckets(tm.damage, idx)
+	tm.damage[idx] += damage
+
+	if tm.owner != nil {
+		tm.owner.addDamage(sim, damage)
+	}
+}
+
+func (tm *timelineMetrics) addAuraUptime(aura *Aura, start time.Duration, end time.Duration) {
+	tm.auraActive[aura] = addBucketedSeconds(tm.auraActive[aura], start, end)
+}
+
+func (tm *timelineMetrics) doneIteration(sim *Simulation) {
+	tm.coveredSeconds = addBucketedSeconds(tm.coveredSeconds, 0, sim.Duration)
+}
+
+func (tm *timelineMetrics) ToProto(numIterations float64) *proto.TimelineMetrics {
+	numBuckets := len(tm.coveredSeconds)
+	metrics := &proto.TimelineMetrics{
+		BucketSeconds:     TimelineBucketDuration.Seconds(),
+		CoveredSecondsAvg: make([]float64, numBuckets),
+	}
+
+	// Divides each value by the matching divisor, ignoring values past the last bucket.
+	perBucket := func(values []float64, divisor func(idx int) float64) []float64 {
+		result := make([]float64, numBuckets)
+		for i := range min(len(values), numBuckets) {
+			if d := divisor(i); d > 0 {
+				result[i] = values[i] / d
+			}
+		}
+		return result
+	}
+	byCoveredSeconds := func(idx int) float64 { return tm.coveredSeconds[idx] }
+
+	for i, covered := range tm.coveredSeconds {
+		metrics.CoveredSecondsAvg[i] = covered / numIterations
+	}
+	metrics.Dps = perBucket(tm.damage, byCoveredSeconds)
+
+	for _, resource := range tm.resources {
+		metrics.Resources = append(metrics.Resources, &proto.ResourceTimelineMetrics{
+			Type: resource.resourceType,
+			LevelAvg: perBucket(resource.levelSums, func(idx int) float64 {
+				if idx >= len(tm.samples) {
+					return 0
+				}
+				return float64(tm.samples[idx])
+			}),
+		})
+	}
+
+	// Auras are listed in registration order, to keep results deterministic.
+	for _, aura := range tm.unit.auras {
+		if active, ok := tm.auraActive[aura]; ok {
+			metrics.Auras = append(metrics.Auras, &proto.AuraTimelineMetrics{
+				Id:           aura.ActionID.ToProto(),
+				ActiveChance: perBucket(active, byCoveredSeconds),
+			})
+		}
+	}
+
+	return metrics
+}
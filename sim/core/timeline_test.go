@@ -0,0 +1,63 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func TestAddBucketedSeconds(t *testing.T) {
+	buckets := addBucketedSeconds(nil, -time.Second, time.Millisecond*2500)
+	buckets = addBucketedSeconds(buckets, time.Millisecond*1500, time.Millisecond*1750)
+
+	expected := []float64{1, 1.25, 0.5}
+	if len(buckets) != len(expected) {
+		t.Fatalf("Expected %d buckets, got %d", len(expected), len(buckets))
+	}
+	for i := range expected {
+		if buckets[i] != expected[i] {
+			t.Errorf("Expected %0.2f seconds in bucket %d, got %0.2f", expected[i], i, buckets[i])
+		}
+	}
+}
+
+func TestTimelineMetrics(t *testing.T) {
+	request := newTestRaidSimRequest(newTestPlayer("Tank"))
+	request.SimOptions.Timeline = true
+	request.Encounter.Duration = 30
+	sim := newTestSim(request)
+	target := sim.Encounter.Targets[0]
+	tank := target.CurrentTarget
+
+	tank.Metrics.timeline.resources = append(tank.Metrics.timeline.resources, &resourceTimeline{
+		resourceLevel: resourceLevel{
+			resourceType: proto.ResourceType_ResourceTypeMana,
+			getLevel:     func() float64 { return sim.CurrentTime.Seconds() },
+		},
+	})
+
+	sim.runOnce()
+
+	targetTimeline := target.Metrics.timeline.ToProto(1)
+	if len(targetTimeline.Dps) != 30 {
+		t.Fatalf("Expected 30 buckets, got %d", len(targetTimeline.Dps))
+	}
+	damage := 0.0
+	for _, dps := range targetTimeline.Dps {
+		damage += dps * targetTimeline.BucketSeconds
+	}
+	if damage != tank.Metrics.dtps.Total {
+		t.Errorf("Expected timeline damage %0.1f to match damage taken %0.1f", damage, tank.Metrics.dtps.Total)
+	}
+
+	tankTimeline := tank.Metrics.timeline.ToProto(1)
+	if len(tankTimeline.Resources) != 1 {
+		t.Fatalf("Expected a resource timeline for the tank")
+	}
+	for i, level := range tankTimeline.Resources[0].LevelAvg {
+		if level != float64(i) {
+			t.Fatalf("Expected resource to be sampled at the start of bucket %d, got %0.2f", i, level)
+		}
+	}
+}
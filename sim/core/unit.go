@@ -73,6 +73,12 @@ type Unit struct {
 	moveSpell               *Spell
 	movementAction          *MovementAction
 
+	// Loss of control state, applied by encounter AIs and interrupts.
+	stunAura             *Aura
+	silenceAura          *Aura
+	lockedOutSchool      SpellSchool
+	schoolLockoutExpires time.Duration
+
 	// How much uptime of Dark Intent the unit will have
 	DarkIntentUptimePercent float64
 
@@ -171,6 +177,16 @@ type Unit struct {
 	defaultTarget   *Unit
 	SecondaryTarget *Unit // Only used for NPCs in tank swap AIs currently.
 
+	// If set, threat generated by this unit is credited to this unit instead,
+	// e.g. during Tricks of the Trade or Misdirection.
+	ThreatRedirect *Unit
+
+	// Absorb effects on this unit, for effective health metrics.
+	absorbEffects []absorbEffect
+
+	// Class-specific resources to include in timeline metrics.
+	resourceLevels []resourceLevel
+
 	// The currently-channeled DOT spell, otherwise nil.
 	ChanneledDot *Dot
 
@@ -203,8 +219,9 @@ func (unit *Unit) IsEnabled() bool {
 	return unit.enabled
 }
 
+// Units without a health bar, e.g. target dummies, can't die.
 func (unit *Unit) IsActive() bool {
-	return unit.IsEnabled() && unit.CurrentHealthPercent() > 0
+	return unit.IsEnabled() && (!unit.HasHealthBar() || unit.CurrentHealthPercent() > 0)
 }
 
 func (unit *Unit) IsOpponent(other *Unit) bool {
@@ -538,6 +555,10 @@ func (unit *Unit) finalize() {
 	unit.applyParryHaste()
 	unit.updateCastSpeed()
 	unit.initMovement()
+	unit.initCrowdControl()
+	if unit.HasHealthBar() {
+		unit.healthBar.registerHealthGainSpell()
+	}
 
 	// All stats added up to this point are part of the 'initial' stats.
 	unit.initialStatsWithoutDeps = unit.stats
@@ -567,9 +588,13 @@ func (unit *Unit) reset(sim *Simulation, _ Agent) {
 	unit.resetCDs(sim)
 	unit.Hardcast.Expires = startingCDTime
 	unit.ChanneledDot = nil
+	unit.lockedOutSchool = SpellSchoolNone
 	unit.QueuedSpell = nil
 	unit.DistanceFromTarget = unit.StartDistanceFromTarget
 	unit.Metrics.reset()
+	if unit.Metrics.timeline != nil {
+		unit.Metrics.timeline.reset(sim)
+	}
 	unit.ResetStatDeps()
 	unit.statsWithoutDeps = unit.initialStatsWithoutDeps
 	unit.stats = unit.initialStats
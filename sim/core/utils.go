@@ -253,21 +253,40 @@ type aggregator struct {
 	n     int
 	sum   float64
 	sumSq float64
+
+	// Rounding errors of the last additions to sum and sumSq, which are carried
+	// into the next ones. Squares of large values would otherwise lose enough
+	// precision that the totals depend on the order they're added in.
+	sumError   float64
+	sumSqError float64
+}
+
+// Kahan summation: adds v to sum, compensating for the rounding error of
+// previous additions, and returns the new sum and rounding error.
+func compensatedAdd(sum float64, err float64, v float64) (float64, float64) {
+	y := v - err
+	t := sum + y
+	return t, (t - sum) - y
 }
 
 func (x *aggregator) add(v float64) {
 	x.n++
-	x.sum += v
-	x.sumSq += v * v
+	x.sum, x.sumError = compensatedAdd(x.sum, x.sumError, v)
+	x.sumSq, x.sumSqError = compensatedAdd(x.sumSq, x.sumSqError, v*v)
 }
 
 func (x *aggregator) scale(f float64) {
 	x.sum *= f
+	x.sumError *= f
 	x.sumSq *= f * f
+	x.sumSqError *= f * f
 }
 
 func (x *aggregator) merge(y *aggregator) *aggregator {
-	return &aggregator{n: x.n + y.n, sum: x.sum + y.sum, sumSq: x.sumSq + y.sumSq}
+	z := &aggregator{n: x.n + y.n}
+	z.sum, z.sumError = compensatedAdd(x.sum, x.sumError+y.sumError, y.sum)
+	z.sumSq, z.sumSqError = compensatedAdd(x.sumSq, x.sumSqError+y.sumSqError, y.sumSq)
+	return z
 }
 
 func (x *aggregator) meanAndStdDev() (float64, float64) {
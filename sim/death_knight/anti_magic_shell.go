@@ -32,7 +32,8 @@ func (dk *DeathKnight) registerAntiMagicShellSpell() {
 		ThreatMultiplier: 1,
 
 		Shield: core.ShieldConfig{
-			SelfOnly: true,
+			SelfOnly:       true,
+			AbsorbManually: true,
 			Aura: core.Aura{
 				Label:    "Anti-Magic Shell",
 				ActionID: actionID,
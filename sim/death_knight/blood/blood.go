@@ -105,7 +105,8 @@ func (bdk *BloodDeathKnight) ApplyTalents() {
 		ThreatMultiplier: 1,
 
 		Shield: core.ShieldConfig{
-			SelfOnly: true,
+			SelfOnly:       true,
+			AbsorbManually: true,
 			Aura: core.Aura{
 				Label:    "Blood Shield",
 				Duration: core.NeverExpires,
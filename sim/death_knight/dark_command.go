@@ -0,0 +1,31 @@
+package death_knight
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (dk *DeathKnight) registerDarkCommandSpell() {
+	dk.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 56222},
+		SpellSchool: core.SpellSchoolShadow,
+		ProcMask:    core.ProcMaskEmpty,
+		Flags:       core.SpellFlagAPL,
+		MaxRange:    30,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    dk.NewTimer(),
+				Duration: 8 * time.Second,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			result := spell.CalcAndDealOutcome(sim, target, spell.OutcomeMagicHit)
+			if result.Landed() {
+				spell.Unit.Taunt(sim, target)
+			}
+		},
+	})
+}
@@ -151,6 +151,8 @@ func (dk *DeathKnight) Initialize() {
 	dk.registerAntiMagicShellSpell()
 	dk.registerRunicPowerDecay()
 	dk.registerBloodStrikeSpell()
+	dk.registerMindFreezeSpell()
+	dk.registerDarkCommandSpell()
 }
 
 func (dk *DeathKnight) Reset(sim *core.Simulation) {
@@ -358,6 +360,7 @@ const (
 	DeathKnightSpellDeathPact
 	DeathKnightSpellUnholyBlight
 	DeathKnightSpellBloodStrike
+	DeathKnightSpellMindFreeze
 
 	DeathKnightSpellKillingMachine     // Used to react to km procs
 	DeathKnightSpellConvertToDeathRune // Used to react to death rune gains
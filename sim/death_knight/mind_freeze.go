@@ -0,0 +1,33 @@
+package death_knight
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (dk *DeathKnight) registerMindFreezeSpell() {
+	dk.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 47528},
+		SpellSchool:    core.SpellSchoolFrost,
+		ProcMask:       core.ProcMaskEmpty,
+		Flags:          core.SpellFlagAPL,
+		ClassSpellMask: DeathKnightSpellMindFreeze,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    dk.NewTimer(),
+				Duration: 10 * time.Second,
+			},
+		},
+
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			result := spell.CalcAndDealOutcome(sim, target, spell.OutcomeMeleeSpecialHit)
+			if result.Landed() {
+				spell.Interrupt(sim, target, 4*time.Second)
+			}
+		},
+	})
+}
@@ -51,17 +51,24 @@ type Druid struct {
 	HurricaneTickSpell    *DruidSpell
 	InsectSwarm           *DruidSpell
 	GiftOfTheWild         *DruidSpell
+	Growl                 *DruidSpell
+	HealingTouch          *DruidSpell
 	Lacerate              *DruidSpell
 	Languish              *DruidSpell
+	Lifebloom             *DruidSpell
+	LifebloomBloom        *DruidSpell
 	MangleBear            *DruidSpell
 	MangleCat             *DruidSpell
 	Maul                  *DruidSpell
 	MaulQueueSpell        *DruidSpell
 	Moonfire              *DruidSpell
+	Nourish               *DruidSpell
 	Pulverize             *DruidSpell
 	Rebirth               *DruidSpell
 	Rake                  *DruidSpell
 	Ravage                *DruidSpell
+	Regrowth              *DruidSpell
+	Rejuvenation          *DruidSpell
 	Rip                   *DruidSpell
 	SavageRoar            *DruidSpell
 	Shred                 *DruidSpell
@@ -70,12 +77,15 @@ type Druid struct {
 	Starsurge             *DruidSpell
 	Sunfire               *DruidSpell
 	SurvivalInstincts     *DruidSpell
+	Swiftmend             *DruidSpell
 	SwipeBear             *DruidSpell
 	SwipeCat              *DruidSpell
 	TigersFury            *DruidSpell
 	Thrash                *DruidSpell
+	TreeOfLife            *DruidSpell
 	Typhoon               *DruidSpell
 	Wrath                 *DruidSpell
+	WildGrowth            *DruidSpell
 	WildMushrooms         *DruidSpell
 	WildMushroomsDetonate *DruidSpell
 
@@ -93,6 +103,7 @@ type Druid struct {
 	EnrageAura               *core.Aura
 	FaerieFireAuras          core.AuraArray
 	FrenziedRegenerationAura *core.Aura
+	HarmonyAura              *core.Aura
 	LunarEclipseProcAura     *core.Aura
 	MaulQueueAura            *core.Aura
 	MoonkinT84PCAura         *core.Aura
@@ -110,6 +121,7 @@ type Druid struct {
 	StrengthOfThePantherAura *core.Aura
 	SurvivalInstinctsAura    *core.Aura
 	TigersFuryAura           *core.Aura
+	TreeOfLifeAura           *core.Aura
 
 	BleedCategories core.ExclusiveCategoryArray
 
@@ -119,6 +131,9 @@ type Druid struct {
 
 	ProcOoc func(sim *core.Simulation)
 
+	// Current Harmony mastery bonus, set by Restoration.
+	HarmonyBonus float64
+
 	ExtendingMoonfireStacks int
 
 	Treants       *Treants
@@ -127,6 +142,9 @@ type Druid struct {
 	form         DruidForm
 	disabledMCDs []*core.MajorCooldown
 
+	activeRejuvenations int
+	lifebloomTarget     *core.Unit
+
 	// Leather specialization tracker
 	LeatherSpec *core.Aura
 
@@ -189,6 +207,10 @@ const (
 	DruidSpellMarkOfTheWild
 	DruidSpellSwiftmend
 	DruidSpellWildGrowth
+	DruidSpellLifebloomBloom
+	DruidSpellEfflorescence
+	DruidSpellLivingSeed
+	DruidSpellTreeOfLife
 
 	DruidSpellLast
 	DruidSpellsAll      = DruidSpellLast<<1 - 1
@@ -198,7 +220,8 @@ const (
 	DruidSpellMangle    = DruidSpellMangleBear | DruidSpellMangleCat
 	DruidArcaneSpells   = DruidSpellMoonfire | DruidSpellMoonfireDoT | DruidSpellStarfire | DruidSpellStarsurge | DruidSpellStarfall
 	DruidNatureSpells   = DruidSpellWrath | DruidSpellInsectSwarm | DruidSpellStarsurge | DruidSpellSunfire | DruidSpellSunfireDoT | DruidSpellTyphoon | DruidSpellHurricane
-	DruidHealingSpells  = DruidSpellHealingTouch | DruidSpellRegrowth | DruidSpellRejuvenation | DruidSpellLifebloom | DruidSpellNourish | DruidSpellSwiftmend
+	DruidHealingSpells  = DruidSpellHealingTouch | DruidSpellRegrowth | DruidSpellRejuvenation | DruidSpellLifebloom | DruidSpellNourish | DruidSpellSwiftmend | DruidSpellWildGrowth | DruidSpellLifebloomBloom | DruidSpellEfflorescence | DruidSpellLivingSeed
+	DruidDirectHeals    = DruidSpellHealingTouch | DruidSpellRegrowth | DruidSpellNourish | DruidSpellSwiftmend | DruidSpellLifebloomBloom
 	DruidDamagingSpells = DruidArcaneSpells | DruidNatureSpells
 )
 
@@ -311,6 +334,17 @@ func (druid *Druid) RegisterBalanceSpells() {
 	druid.registerWildMushrooms()
 }
 
+func (druid *Druid) RegisterRestorationSpells() {
+	druid.registerHealingTouchSpell()
+	druid.registerNourishSpell()
+	druid.registerRegrowthSpell()
+	druid.registerRejuvenationSpell()
+	druid.registerLifebloomSpell()
+	druid.registerWildGrowthSpell()
+	druid.registerSwiftmendSpell()
+	druid.registerTreeOfLifeCD()
+}
+
 func (druid *Druid) RegisterFeralCatSpells() {
 	druid.registerBearFormSpell()
 	druid.registerBerserkCD()
@@ -340,6 +374,7 @@ func (druid *Druid) RegisterFeralTankSpells() {
 	druid.registerDemoralizingRoarSpell()
 	druid.registerEnrageSpell()
 	druid.registerFrenziedRegenerationCD()
+	druid.registerGrowlSpell()
 	druid.registerMangleBearSpell()
 	druid.registerMaulSpell()
 	druid.registerLacerateSpell()
@@ -370,6 +405,8 @@ func (druid *Druid) Reset(_ *core.Simulation) {
 	druid.form = druid.StartingForm
 	druid.disabledMCDs = []*core.MajorCooldown{}
 	druid.RebirthUsed = false
+	druid.activeRejuvenations = 0
+	druid.lifebloomTarget = nil
 }
 
 func New(char *core.Character, form DruidForm, selfBuffs SelfBuffs, talents string) *Druid {
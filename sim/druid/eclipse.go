@@ -58,6 +58,13 @@ func (druid *Druid) EnableEclipseBar() {
 		gainMask:         SolarEnergy | LunarEnergy,
 		eclipseCallbacks: druid.eclipseEnergyBar.eclipseCallbacks,
 	}
+
+	druid.RegisterResourceLevel(proto.ResourceType_ResourceTypeSolarEnergy, func() float64 {
+		return float64(druid.CurrentSolarEnergy())
+	})
+	druid.RegisterResourceLevel(proto.ResourceType_ResourceTypeLunarEnergy, func() float64 {
+		return float64(druid.CurrentLunarEnergy())
+	})
 }
 
 func getEclipseMasteryBonus(masteryPoints float64) float64 {
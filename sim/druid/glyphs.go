@@ -33,6 +33,14 @@ func (druid *Druid) ApplyGlyphs() {
 		})
 	}
 
+	if druid.HasPrimeGlyph(proto.DruidPrimeGlyph_GlyphOfRejuvenation) {
+		druid.AddStaticMod(core.SpellModConfig{
+			ClassMask:  DruidSpellRejuvenation,
+			FloatValue: 0.1,
+			Kind:       core.SpellMod_DamageDone_Flat,
+		})
+	}
+
 	if druid.HasMajorGlyph(proto.DruidMajorGlyph_GlyphOfStarfall) {
 		druid.AddStaticMod(core.SpellModConfig{
 			ClassMask: DruidSpellStarfall,
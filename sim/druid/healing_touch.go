@@ -0,0 +1,39 @@
+package druid
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func (druid *Druid) registerHealingTouchSpell() {
+	druid.HealingTouch = druid.RegisterSpell(Humanoid|Tree, core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 5185},
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL | SpellFlagOmenTrigger,
+		ClassSpellMask: DruidSpellHealingTouch,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 30,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Second * 3,
+			},
+		},
+
+		BonusCoefficient:         0.806,
+		DamageMultiplier:         1,
+		DamageMultiplierAdditive: 1,
+		CritMultiplier:           druid.DefaultHealingCritMultiplier(),
+		ThreatMultiplier:         1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseHealing := sim.Roll(core.CalcScalingSpellEffectVarianceMinMax(proto.Class_ClassDruid, 7.716, 0.12))
+			spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+		},
+	})
+}
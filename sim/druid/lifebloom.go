@@ -0,0 +1,110 @@
+package druid
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func (druid *Druid) registerLifebloomSpell() {
+	bonusCrit := core.TernaryFloat64(druid.HasPrimeGlyph(proto.DruidPrimeGlyph_GlyphOfLifebloom), 10, 0)
+
+	druid.LifebloomBloom = druid.RegisterSpell(Humanoid|Tree, core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 33778},
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+		ClassSpellMask: DruidSpellLifebloomBloom,
+
+		BonusCritPercent:         bonusCrit,
+		DamageMultiplier:         1,
+		DamageMultiplierAdditive: 1,
+		CritMultiplier:           druid.DefaultHealingCritMultiplier(),
+		ThreatMultiplier:         1,
+	})
+
+	// Stack count of each target's Lifebloom, kept around so the bloom can use it once the aura has faded.
+	bloomStacks := make([]int32, len(druid.Env.AllUnits))
+
+	druid.Lifebloom = druid.RegisterSpell(Humanoid|Tree, core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 33763},
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL | SpellFlagOmenTrigger,
+		ClassSpellMask: DruidSpellLifebloom,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 7,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+		},
+
+		BonusCritPercent:         bonusCrit,
+		DamageMultiplier:         1,
+		DamageMultiplierAdditive: 1,
+		CritMultiplier:           druid.DefaultHealingCritMultiplier(),
+		ThreatMultiplier:         1,
+
+		Hot: core.DotConfig{
+			Aura: core.Aura{
+				Label:     "Lifebloom",
+				MaxStacks: 3,
+				OnStacksChange: func(aura *core.Aura, sim *core.Simulation, oldStacks int32, newStacks int32) {
+					if newStacks > 0 {
+						bloomStacks[aura.Unit.UnitIndex] = newStacks
+					}
+				},
+			},
+			NumberOfTicks:       10,
+			TickLength:          time.Second * 1,
+			AffectedByCastSpeed: true,
+			BonusCoefficient:    0.0234,
+
+			OnSnapshot: func(sim *core.Simulation, target *core.Unit, dot *core.Dot, isRollover bool) {
+				if isRollover {
+					return
+				}
+				dot.SnapshotHeal(target, 0.0234*druid.ClassSpellScaling)
+				dot.SnapshotBaseDamage *= float64(max(dot.Aura.GetStacks(), 1))
+			},
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.CalcAndDealPeriodicSnapshotHealing(sim, target, dot.OutcomeSnapshotCrit)
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			// Lifebloom can only be active on one target at a time outside of Tree of Life.
+			if druid.lifebloomTarget != nil && druid.lifebloomTarget != target && !druid.treeOfLifeActive() {
+				spell.Hot(druid.lifebloomTarget).Deactivate(sim)
+			}
+			druid.lifebloomTarget = target
+
+			hot := spell.Hot(target)
+			hot.Apply(sim)
+			hot.AddStack(sim)
+			hot.TakeSnapshot(sim, false)
+		},
+	})
+
+	for _, unit := range druid.Env.AllUnits {
+		hot := druid.Lifebloom.Hot(unit)
+		if hot == nil {
+			continue
+		}
+
+		// Blooms only when the HoT runs its course, not when it is moved to another target.
+		hot.ApplyOnExpire(func(aura *core.Aura, sim *core.Simulation) {
+			if hot.RemainingTicks() > 0 {
+				return
+			}
+
+			bloom := druid.LifebloomBloom
+			baseHealing := 2.643*druid.ClassSpellScaling + 0.284*bloom.HealingPower(aura.Unit)
+			bloom.CalcAndDealHealing(sim, aura.Unit, baseHealing*float64(bloomStacks[aura.Unit.UnitIndex]), bloom.OutcomeHealingCrit)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package druid
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func (druid *Druid) registerNourishSpell() {
+	// Nature's Bounty shortens Nourish while Rejuvenation is active on 3 or more targets.
+	naturesBountyReduction := time.Millisecond * 500 * time.Duration(druid.Talents.NaturesBounty) / 3
+
+	druid.Nourish = druid.RegisterSpell(Humanoid|Tree, core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 50464},
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL | SpellFlagOmenTrigger,
+		ClassSpellMask: DruidSpellNourish,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 10,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Second * 3,
+			},
+			ModifyCast: func(sim *core.Simulation, spell *core.Spell, cast *core.Cast) {
+				if druid.activeRejuvenations >= 3 {
+					cast.CastTime -= naturesBountyReduction
+				}
+			},
+		},
+
+		BonusCoefficient:         0.266,
+		DamageMultiplier:         1,
+		DamageMultiplierAdditive: 1,
+		CritMultiplier:           druid.DefaultHealingCritMultiplier(),
+		ThreatMultiplier:         1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseHealing := sim.Roll(core.CalcScalingSpellEffectVarianceMinMax(proto.Class_ClassDruid, 2.38, 0.1))
+
+			// Heals for an additional 20% if one of your Rejuvenation, Regrowth, Lifebloom or Wild Growth effects is on the target.
+			if druid.hasHotOn(target) {
+				baseHealing *= 1.2
+			}
+
+			spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+		},
+	})
+}
+
+func (druid *Druid) hasHotOn(target *core.Unit) bool {
+	for _, hotSpell := range []*DruidSpell{druid.Rejuvenation, druid.Regrowth, druid.Lifebloom, druid.WildGrowth} {
+		if hotSpell == nil {
+			continue
+		}
+		if hot := hotSpell.Hot(target); hot != nil && hot.IsActive() {
+			return true
+		}
+	}
+	return false
+}
@@ -22,6 +22,11 @@ func (druid *Druid) applyOmenOfClarity() {
 				druid.ForceOfNature,
 				druid.Starsurge,
 
+				// Restoration
+				druid.HealingTouch,
+				druid.Nourish,
+				druid.Regrowth,
+
 				// Feral
 				druid.DemoralizingRoar,
 				druid.FerociousBite,
@@ -69,6 +74,18 @@ func (druid *Druid) applyOmenOfClarity() {
 		druid.ClearcastingAura.Activate(sim)
 	}
 
+	// Heavily based on comment here
+	// https://github.com/JamminL/wotlk-classic-bugs/issues/66#issuecomment-1182017571
+	// Instants are treated as 1.5
+	// Uses current cast time rather than default cast time (PPM is constant with haste)
+	castTimeProcChance := func(spell *core.Spell) float64 {
+		castTime := spell.CurCast.CastTime.Seconds()
+		if castTime == 0 {
+			castTime = 1.5
+		}
+		return (castTime / 60) * 3.5
+	}
+
 	druid.RegisterAura(core.Aura{
 		Label:    "Omen of Clarity",
 		Duration: core.NeverExpires,
@@ -92,16 +109,7 @@ func (druid *Druid) applyOmenOfClarity() {
 			} else if druid.AutoAttacks.PPMProc(sim, 3.5, core.ProcMaskMeleeWhiteHit, "Omen of Clarity", spell) { // Melee
 				druid.ProcOoc(sim)
 			} else if spell.Flags.Matches(SpellFlagOmenTrigger) { // Spells
-				// Heavily based on comment here
-				// https://github.com/JamminL/wotlk-classic-bugs/issues/66#issuecomment-1182017571
-				// Instants are treated as 1.5
-				// Uses current cast time rather than default cast time (PPM is constant with haste)
-				castTime := spell.CurCast.CastTime.Seconds()
-				if castTime == 0 {
-					castTime = 1.5
-				}
-
-				chanceToProc := (castTime / 60) * 3.5
+				chanceToProc := castTimeProcChance(spell)
 				if druid.Typhoon.IsEqual(spell) { // Add Typhoon
 					chanceToProc *= 0.25
 				} else if druid.Moonfire.IsEqual(spell) { // Add Moonfire
@@ -118,5 +126,15 @@ func (druid *Druid) applyOmenOfClarity() {
 				}
 			}
 		},
+		OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
+			// Heals never hit, so they roll for Clearcasting when the cast completes instead.
+			if !spell.Flags.Matches(core.SpellFlagHelpful) || !spell.Flags.Matches(SpellFlagOmenTrigger) {
+				return
+			}
+
+			if sim.Proc(castTimeProcChance(spell)*0.666, "Clearcasting") {
+				druid.ProcOoc(sim)
+			}
+		},
 	})
 }
@@ -0,0 +1,88 @@
+package druid
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func (druid *Druid) registerRegrowthSpell() {
+	hasGlyph := druid.HasPrimeGlyph(proto.DruidPrimeGlyph_GlyphOfRegrowth)
+
+	druid.Regrowth = druid.RegisterSpell(Humanoid|Tree, core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 8936},
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL | SpellFlagOmenTrigger,
+		ClassSpellMask: DruidSpellRegrowth,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 29,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 1500,
+			},
+			ModifyCast: func(sim *core.Simulation, spell *core.Spell, cast *core.Cast) {
+				// Regrowth is instant in Tree of Life. This can't be a cast time spell mod
+				// since that would also shorten the HoT's hasted tick period.
+				if druid.treeOfLifeActive() {
+					cast.CastTime = 0
+				}
+			},
+		},
+
+		BonusCoefficient:         0.2936,
+		DamageMultiplier:         1,
+		DamageMultiplierAdditive: 1,
+		CritMultiplier:           druid.DefaultHealingCritMultiplier(),
+		ThreatMultiplier:         1,
+
+		Hot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Regrowth",
+			},
+			NumberOfTicks:       3,
+			TickLength:          time.Second * 2,
+			AffectedByCastSpeed: true,
+			BonusCoefficient:    0.0296,
+
+			OnSnapshot: func(sim *core.Simulation, target *core.Unit, dot *core.Dot, isRollover bool) {
+				if isRollover {
+					return
+				}
+				dot.SnapshotHeal(target, 0.3647*druid.ClassSpellScaling)
+				dot.SnapshotAttackerMultiplier *= druid.regrowthHotMultiplier()
+			},
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.CalcAndDealPeriodicSnapshotHealing(sim, target, dot.OutcomeSnapshotCrit)
+
+				if hasGlyph && target.HasHealthBar() && target.CurrentHealthPercent() <= 0.5 {
+					dot.ApplyRollover(sim)
+				}
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseHealing := sim.Roll(core.CalcScalingSpellEffectVarianceMinMax(proto.Class_ClassDruid, 3.453, 0.116))
+			spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+			spell.Hot(target).Apply(sim)
+		},
+	})
+}
+
+// The Regrowth HoT shares its spell with the direct heal, so it has to swap the
+// direct Harmony bonus already in the spell multiplier for the periodic one.
+func (druid *Druid) regrowthHotMultiplier() float64 {
+	if druid.HarmonyAura == nil {
+		return 1
+	}
+
+	periodicMultiplier := 1.0
+	if druid.HarmonyAura.IsActive() {
+		periodicMultiplier += druid.HarmonyBonus
+	}
+	return periodicMultiplier / (1 + druid.HarmonyBonus)
+}
@@ -0,0 +1,85 @@
+package druid
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (druid *Druid) registerRejuvenationSpell() {
+	// Gift of the Earthmother makes Rejuvenation heal instantly for a portion of its total periodic effect.
+	var instantRejuvenation *DruidSpell
+	if druid.Talents.GiftOfTheEarthmother > 0 {
+		instantRejuvenation = druid.RegisterSpell(Humanoid|Tree, core.SpellConfig{
+			ActionID:       core.ActionID{SpellID: 64801},
+			SpellSchool:    core.SpellSchoolNature,
+			ProcMask:       core.ProcMaskSpellHealing,
+			Flags:          core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell | core.SpellFlagIgnoreAttackerModifiers,
+			ClassSpellMask: DruidSpellRejuvenation,
+
+			DamageMultiplier:         1,
+			DamageMultiplierAdditive: 1,
+			CritMultiplier:           druid.DefaultHealingCritMultiplier(),
+			ThreatMultiplier:         1,
+
+			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+				rejuvenation := druid.Rejuvenation.Hot(target)
+				totalHealing := rejuvenation.SnapshotBaseDamage * rejuvenation.SnapshotAttackerMultiplier * float64(rejuvenation.ExpectedTickCount())
+				spell.CalcAndDealHealing(sim, target, totalHealing*0.05*float64(druid.Talents.GiftOfTheEarthmother), spell.OutcomeHealingCrit)
+			},
+		})
+	}
+
+	druid.Rejuvenation = druid.RegisterSpell(Humanoid|Tree, core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 774},
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL | SpellFlagOmenTrigger,
+		ClassSpellMask: DruidSpellRejuvenation,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 20,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+		},
+
+		DamageMultiplier:         1,
+		DamageMultiplierAdditive: 1,
+		CritMultiplier:           druid.DefaultHealingCritMultiplier(),
+		ThreatMultiplier:         1,
+
+		Hot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Rejuvenation",
+				OnGain: func(aura *core.Aura, sim *core.Simulation) {
+					druid.activeRejuvenations++
+				},
+				OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+					druid.activeRejuvenations--
+				},
+			},
+			NumberOfTicks:       4,
+			TickLength:          time.Second * 3,
+			AffectedByCastSpeed: true,
+			BonusCoefficient:    0.134,
+
+			OnSnapshot: func(sim *core.Simulation, target *core.Unit, dot *core.Dot, _ bool) {
+				dot.SnapshotHeal(target, 1.164*druid.ClassSpellScaling)
+			},
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.CalcAndDealPeriodicSnapshotHealing(sim, target, dot.OutcomeSnapshotCrit)
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.Hot(target).Apply(sim)
+
+			if instantRejuvenation != nil {
+				instantRejuvenation.Cast(sim, target)
+			}
+		},
+	})
+}
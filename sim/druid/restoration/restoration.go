@@ -1,6 +1,8 @@
 package restoration
 
 import (
+	"time"
+
 	"github.com/wowsims/cata/sim/core"
 	"github.com/wowsims/cata/sim/core/proto"
 	"github.com/wowsims/cata/sim/druid"
@@ -49,8 +51,80 @@ func (resto *RestorationDruid) GetDruid() *druid.Druid {
 
 func (resto *RestorationDruid) Initialize() {
 	resto.Druid.Initialize()
+	resto.RegisterRestorationSpells()
 }
 
 func (resto *RestorationDruid) Reset(sim *core.Simulation) {
 	resto.Druid.Reset(sim)
 }
+
+func (resto *RestorationDruid) ApplyTalents() {
+	resto.Druid.ApplyTalents()
+
+	// Gift of Nature
+	resto.PseudoStats.HealingDealtMultiplier *= 1.25
+	core.MakePermanent(resto.RegisterAura(core.Aura{
+		Label:    "Gift of Nature",
+		ActionID: core.ActionID{SpellID: 87305},
+	}))
+
+	// Meditation
+	resto.PseudoStats.SpiritRegenRateCombat = 0.5
+
+	resto.applyHarmony()
+}
+
+func harmonyBonus(masteryPoints float64) float64 {
+	return (10 + masteryPoints*1.25) / 100
+}
+
+// Mastery: Harmony, increases direct healing, and direct heals increase periodic healing for 10 seconds.
+func (resto *RestorationDruid) applyHarmony() {
+	resto.HarmonyBonus = harmonyBonus(resto.GetMasteryPoints())
+
+	directMod := resto.AddDynamicMod(core.SpellModConfig{
+		ClassMask:  druid.DruidDirectHeals,
+		FloatValue: resto.HarmonyBonus,
+		Kind:       core.SpellMod_DamageDone_Pct,
+	})
+	periodicMod := resto.AddDynamicMod(core.SpellModConfig{
+		ClassMask:  druid.DruidSpellRejuvenation | druid.DruidSpellLifebloom | druid.DruidSpellWildGrowth,
+		FloatValue: resto.HarmonyBonus,
+		Kind:       core.SpellMod_DamageDone_Pct,
+	})
+
+	resto.AddOnMasteryStatChanged(func(sim *core.Simulation, oldMastery, newMastery float64) {
+		resto.HarmonyBonus = harmonyBonus(core.MasteryRatingToMasteryPoints(newMastery))
+		directMod.UpdateFloatValue(resto.HarmonyBonus)
+		periodicMod.UpdateFloatValue(resto.HarmonyBonus)
+	})
+
+	resto.HarmonyAura = resto.RegisterAura(core.Aura{
+		Label:    "Harmony",
+		ActionID: core.ActionID{SpellID: 100977},
+		Duration: time.Second * 10,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			periodicMod.Activate()
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			periodicMod.Deactivate()
+		},
+	})
+
+	core.MakePermanent(resto.RegisterAura(core.Aura{
+		Label:    "Harmony Mastery",
+		ActionID: core.ActionID{SpellID: 77495},
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			directMod.Activate()
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			directMod.Deactivate()
+		},
+		OnHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			// Lifebloom's bloom is the only direct heal that doesn't trigger Harmony.
+			if spell.ClassSpellMask&(druid.DruidSpellHealingTouch|druid.DruidSpellNourish|druid.DruidSpellRegrowth|druid.DruidSpellSwiftmend) != 0 {
+				resto.HarmonyAura.Activate(sim)
+			}
+		},
+	}))
+}
@@ -1,67 +1,71 @@
 package restoration
 
 import (
+	"testing"
+
 	_ "github.com/wowsims/cata/sim/common" // imported to get caster sets included. (we use spellfire here)
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
 )
 
 func init() {
 	RegisterRestorationDruid()
 }
 
-// func TestRestoration(t *testing.T) {
-// 	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator(core.CharacterSuiteConfig{
-// 		Class: proto.Class_ClassDruid,
-// 		Race:  proto.Race_RaceTauren,
-
-// 		GearSet:     core.GetGearSet("../../../ui/restoration_druid/gear_sets", "p1"),
-// 		Talents:     StandardTalents,
-// 		Glyphs:      StandardGlyphs,
-// 		Consumes:    FullConsumes,
-// 		SpecOptions: core.SpecOptionsCombo{Label: "Standard", SpecOptions: PlayerOptionsStandard},
-// 		Rotation:    core.RotationCombo{Label: "Default", Rotation: DefaultRotation},
+func TestRestoration(t *testing.T) {
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator(core.CharacterSuiteConfig{
+		Class:    proto.Class_ClassDruid,
+		Race:     proto.Race_RaceTauren,
+		IsHealer: true,
 
-// 		ItemFilter: core.ItemFilter{
-// 			WeaponTypes: []proto.WeaponType{
-// 				proto.WeaponType_WeaponTypeDagger,
-// 				proto.WeaponType_WeaponTypeMace,
-// 				proto.WeaponType_WeaponTypeOffHand,
-// 				proto.WeaponType_WeaponTypeStaff,
-// 				proto.WeaponType_WeaponTypePolearm,
-// 			},
-// 			ArmorType: proto.ArmorType_ArmorTypeLeather,
-// 			RangedWeaponTypes: []proto.RangedWeaponType{
-// 				proto.RangedWeaponType_RangedWeaponTypeRelic,
-// 			},
-// 		},
-// 	}))
-// }
+		// The restoration gear sets still hold pre-Cataclysm items, so share the balance gear.
+		GearSet:     core.GetGearSet("../../../ui/druid/balance/gear_sets", "t13"),
+		Talents:     StandardTalents,
+		Glyphs:      StandardGlyphs,
+		Consumes:    FullConsumes,
+		SpecOptions: core.SpecOptionsCombo{Label: "Standard", SpecOptions: PlayerOptionsStandard},
+		Rotation:    core.GetAplRotation("../../../ui/druid/restoration/apls", "default"),
 
-// var StandardTalents = "05320031103--230023312131502331050313051"
-// var StandardGlyphs = &proto.Glyphs{
-// 	Major1: int32(proto.DruidMajorGlyph_GlyphOfWildGrowth),
-// 	Major2: int32(proto.DruidMajorGlyph_GlyphOfSwiftmend),
-// 	Major3: int32(proto.DruidMajorGlyph_GlyphOfNourish),
-// }
+		ItemFilter: core.ItemFilter{
+			WeaponTypes: []proto.WeaponType{
+				proto.WeaponType_WeaponTypeDagger,
+				proto.WeaponType_WeaponTypeMace,
+				proto.WeaponType_WeaponTypeOffHand,
+				proto.WeaponType_WeaponTypeStaff,
+				proto.WeaponType_WeaponTypePolearm,
+			},
+			ArmorType: proto.ArmorType_ArmorTypeLeather,
+			RangedWeaponTypes: []proto.RangedWeaponType{
+				proto.RangedWeaponType_RangedWeaponTypeRelic,
+			},
+		},
+	}))
+}
 
-// var FullConsumes = &proto.Consumes{
-// 	Flask:           proto.Flask_FlaskOfBlindingLight,
-// 	Food:            proto.Food_FoodBlackenedBasilisk,
-// 	DefaultPotion:   proto.Potions_SuperManaPotion,
-// 	PrepopPotion:    proto.Potions_DestructionPotion,
-// 	DefaultConjured: proto.Conjured_ConjuredDarkRune,
-// }
+var StandardTalents = "302--222301332103223110311"
+var StandardGlyphs = &proto.Glyphs{
+	Prime1: int32(proto.DruidPrimeGlyph_GlyphOfLifebloom),
+	Prime2: int32(proto.DruidPrimeGlyph_GlyphOfRejuvenation),
+	Prime3: int32(proto.DruidPrimeGlyph_GlyphOfSwiftmend),
+	Major1: int32(proto.DruidMajorGlyph_GlyphOfWildGrowth),
+	Major2: int32(proto.DruidMajorGlyph_GlyphOfInnervate),
+	Major3: int32(proto.DruidMajorGlyph_GlyphOfHealingTouch),
+	Minor1: int32(proto.DruidMinorGlyph_GlyphOfMarkOfTheWild),
+}
 
-// var PlayerOptionsStandard = &proto.Player_RestorationDruid{
-// 	RestorationDruid: &proto.RestorationDruid{
-// 		Options: &proto.RestorationDruid_Options{
-// 			InnervateTarget: &proto.UnitReference{Type: proto.UnitReference_Player, Index: 0}, // self innervate
-// 		},
-// 	},
-// }
+var FullConsumes = &proto.Consumes{
+	Flask:         proto.Flask_FlaskOfTheDraconicMind,
+	Food:          proto.Food_FoodSeafoodFeast,
+	DefaultPotion: proto.Potions_MythicalManaPotion,
+	PrepopPotion:  proto.Potions_VolcanicPotion,
+}
 
-// var DefaultRotation = core.APLRotationFromJsonString(`{
-// 	"type": "TypeAPL",
-// 	"priorityList": [
-// 		{"action":{"autocastOtherCooldowns":{}}}
-// 	]
-// }`)
+var PlayerOptionsStandard = &proto.Player_RestorationDruid{
+	RestorationDruid: &proto.RestorationDruid{
+		Options: &proto.RestorationDruid_Options{
+			ClassOptions: &proto.DruidOptions{
+				InnervateTarget: &proto.UnitReference{Type: proto.UnitReference_Player, Index: 0}, // self innervate
+			},
+		},
+	},
+}
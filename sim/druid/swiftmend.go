@@ -0,0 +1,102 @@
+package druid
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func (druid *Druid) registerSwiftmendSpell() {
+	consumesHot := !druid.HasPrimeGlyph(proto.DruidPrimeGlyph_GlyphOfSwiftmend)
+	efflorescence := druid.registerEfflorescenceSpell()
+
+	druid.Swiftmend = druid.RegisterSpell(Humanoid|Tree, core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 18562},
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL | SpellFlagOmenTrigger,
+		ClassSpellMask: DruidSpellSwiftmend,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 10,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    druid.NewTimer(),
+				Duration: time.Second * 15,
+			},
+		},
+
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return druid.swiftmendableHot(target) != nil
+		},
+
+		BonusCoefficient:         0.536,
+		DamageMultiplier:         1,
+		DamageMultiplierAdditive: 1,
+		CritMultiplier:           druid.DefaultHealingCritMultiplier(),
+		ThreatMultiplier:         1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			hot := druid.swiftmendableHot(target)
+			healed := spell.CalcAndDealHealing(sim, target, 4.648*druid.ClassSpellScaling, spell.OutcomeHealingCrit).Damage
+
+			if consumesHot {
+				hot.Deactivate(sim)
+			}
+
+			if efflorescence != nil {
+				groundHot := efflorescence.SelfHot()
+				groundHot.SnapshotBaseDamage = healed * 0.04 * float64(druid.Talents.Efflorescence)
+				groundHot.Apply(sim)
+			}
+		},
+	})
+}
+
+// Swiftmend consumes Regrowth before Rejuvenation, since Regrowth usually has less healing left.
+func (druid *Druid) swiftmendableHot(target *core.Unit) *core.Dot {
+	for _, hotSpell := range []*DruidSpell{druid.Regrowth, druid.Rejuvenation} {
+		if hot := hotSpell.Hot(target); hot != nil && hot.IsActive() {
+			return hot
+		}
+	}
+	return nil
+}
+
+func (druid *Druid) registerEfflorescenceSpell() *DruidSpell {
+	if druid.Talents.Efflorescence == 0 {
+		return nil
+	}
+
+	return druid.RegisterSpell(Any, core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 81269},
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell | core.SpellFlagIgnoreAttackerModifiers,
+		ClassSpellMask: DruidSpellEfflorescence,
+
+		DamageMultiplier:         1,
+		DamageMultiplierAdditive: 1,
+		ThreatMultiplier:         1,
+
+		Hot: core.DotConfig{
+			SelfOnly: true,
+			Aura: core.Aura{
+				Label: "Efflorescence",
+			},
+			NumberOfTicks: 7,
+			TickLength:    time.Second * 1,
+
+			OnTick: func(sim *core.Simulation, _ *core.Unit, dot *core.Dot) {
+				for _, unit := range druid.Env.Raid.GetLowestHealthUnits(3) {
+					dot.Spell.CalcAndDealPeriodicHealing(sim, unit, dot.SnapshotBaseDamage, dot.Spell.OutcomeHealing)
+				}
+			},
+		},
+	})
+}
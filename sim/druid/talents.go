@@ -97,6 +97,18 @@ func (druid *Druid) ApplyTalents() {
 	druid.applyFurySwipes()
 	druid.applyPrimalMadness()
 	druid.applyStampede()
+
+	// Restoration
+	druid.applyBlessingOfTheGrove()
+	druid.applyNaturalist()
+	druid.applyImprovedRejuvenation()
+	druid.applyLivingSeed()
+	druid.applyRevitalize()
+	druid.applyNaturesBounty()
+	druid.applyEmpoweredTouch()
+	druid.applyMalfurionsGift()
+	druid.applyGiftOfTheEarthmother()
+	druid.applySwiftRejuvenation()
 	druid.ApplyGlyphs()
 }
 
@@ -213,8 +225,11 @@ func (druid *Druid) applyGenesis() {
 			Kind:      core.SpellMod_DotNumberOfTicks_Flat,
 		})
 
-		// TODO: periodic healing spells
-		// TODO: swiftmend
+		druid.AddStaticMod(core.SpellModConfig{
+			ClassMask:  DruidSpellHoT | DruidSpellSwiftmend,
+			FloatValue: 0.02 * float64(druid.Talents.Genesis),
+			Kind:       core.SpellMod_DamageDone_Pct,
+		})
 	}
 }
 
@@ -950,3 +965,190 @@ func (druid *Druid) applyInfectedWounds() {
 		},
 	})
 }
+
+func (druid *Druid) applyBlessingOfTheGrove() {
+	if druid.Talents.BlessingOfTheGrove == 0 {
+		return
+	}
+
+	druid.AddStaticMod(core.SpellModConfig{
+		ClassMask:  DruidSpellRejuvenation,
+		FloatValue: 0.02 * float64(druid.Talents.BlessingOfTheGrove),
+		Kind:       core.SpellMod_DamageDone_Pct,
+	})
+}
+
+func (druid *Druid) applyNaturalist() {
+	if druid.Talents.Naturalist == 0 {
+		return
+	}
+
+	druid.AddStaticMod(core.SpellModConfig{
+		ClassMask: DruidSpellHealingTouch | DruidSpellNourish,
+		TimeValue: time.Millisecond * -250 * time.Duration(druid.Talents.Naturalist),
+		Kind:      core.SpellMod_CastTime_Flat,
+	})
+}
+
+func (druid *Druid) applyImprovedRejuvenation() {
+	if druid.Talents.ImprovedRejuvenation == 0 {
+		return
+	}
+
+	druid.AddStaticMod(core.SpellModConfig{
+		ClassMask:  DruidSpellRejuvenation | DruidSpellSwiftmend,
+		FloatValue: 0.05 * float64(druid.Talents.ImprovedRejuvenation),
+		Kind:       core.SpellMod_DamageDone_Pct,
+	})
+}
+
+func (druid *Druid) applyLivingSeed() {
+	if druid.Talents.LivingSeed == 0 {
+		return
+	}
+
+	seedPct := []float64{0, 0.1, 0.2, 0.3}[druid.Talents.LivingSeed]
+
+	livingSeedHeal := druid.RegisterSpell(Any, core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 48503},
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell | core.SpellFlagIgnoreModifiers,
+		ClassSpellMask: DruidSpellLivingSeed,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+	})
+
+	seedAmounts := make([]float64, len(druid.Env.AllUnits))
+	seedAuras := druid.NewAllyAuraArray(func(unit *core.Unit) *core.Aura {
+		return unit.RegisterAura(core.Aura{
+			Label:    "Living Seed" + druid.Label,
+			ActionID: core.ActionID{SpellID: 48504},
+			Duration: time.Second * 15,
+			OnSpellHitTaken: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+				if result.Damage <= 0 || !result.Landed() {
+					return
+				}
+				aura.Deactivate(sim)
+				livingSeedHeal.CalcAndDealHealing(sim, aura.Unit, seedAmounts[aura.Unit.UnitIndex], livingSeedHeal.OutcomeHealing)
+			},
+		})
+	})
+
+	core.MakeProcTriggerAura(&druid.Unit, core.ProcTrigger{
+		Name:           "Living Seed Trigger",
+		Callback:       core.CallbackOnHealDealt,
+		ClassSpellMask: DruidSpellSwiftmend | DruidSpellRegrowth | DruidSpellNourish | DruidSpellHealingTouch,
+		Outcome:        core.OutcomeCrit,
+		Handler: func(sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			seedAmounts[result.Target.UnitIndex] = result.Damage * seedPct
+			seedAuras.Get(result.Target).Activate(sim)
+		},
+	})
+}
+
+func (druid *Druid) applyRevitalize() {
+	if druid.Talents.Revitalize == 0 {
+		return
+	}
+
+	manaMetrics := druid.NewManaMetrics(core.ActionID{SpellID: 81094})
+	manaPct := 0.01 * float64(druid.Talents.Revitalize)
+
+	core.MakeProcTriggerAura(&druid.Unit, core.ProcTrigger{
+		Name:           "Revitalize",
+		Callback:       core.CallbackOnPeriodicHealDealt,
+		ClassSpellMask: DruidSpellRejuvenation | DruidSpellLifebloom,
+		ProcChance:     0.2,
+		ICD:            time.Second * 12,
+		Handler: func(sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			druid.AddMana(sim, druid.MaxMana()*manaPct, manaMetrics)
+		},
+	})
+}
+
+func (druid *Druid) applyNaturesBounty() {
+	if druid.Talents.NaturesBounty == 0 {
+		return
+	}
+
+	// The Nourish cast time reduction is handled in nourish.go.
+	druid.AddStaticMod(core.SpellModConfig{
+		ClassMask:  DruidSpellRegrowth,
+		FloatValue: 20 * float64(druid.Talents.NaturesBounty),
+		Kind:       core.SpellMod_BonusCrit_Percent,
+	})
+}
+
+func (druid *Druid) applyEmpoweredTouch() {
+	if druid.Talents.EmpoweredTouch == 0 {
+		return
+	}
+
+	druid.AddStaticMod(core.SpellModConfig{
+		ClassMask:  DruidSpellHealingTouch | DruidSpellRegrowth | DruidSpellNourish,
+		FloatValue: 0.05 * float64(druid.Talents.EmpoweredTouch),
+		Kind:       core.SpellMod_DamageDone_Pct,
+	})
+
+	core.MakeProcTriggerAura(&druid.Unit, core.ProcTrigger{
+		Name:           "Empowered Touch",
+		Callback:       core.CallbackOnHealDealt,
+		ClassSpellMask: DruidSpellHealingTouch | DruidSpellNourish,
+		ProcChance:     0.5 * float64(druid.Talents.EmpoweredTouch),
+		ExtraCondition: func(sim *core.Simulation, spell *core.Spell, result *core.SpellResult) bool {
+			return druid.Lifebloom != nil && druid.Lifebloom.Hot(result.Target).IsActive()
+		},
+		Handler: func(sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			druid.Lifebloom.Hot(result.Target).ApplyRollover(sim)
+		},
+	})
+}
+
+func (druid *Druid) applyMalfurionsGift() {
+	if druid.Talents.MalfurionsGift == 0 {
+		return
+	}
+
+	druid.AddStaticMod(core.SpellModConfig{
+		ClassMask: DruidSpellTranquility,
+		TimeValue: time.Second * -150 * time.Duration(druid.Talents.MalfurionsGift),
+		Kind:      core.SpellMod_Cooldown_Flat,
+	})
+
+	core.MakeProcTriggerAura(&druid.Unit, core.ProcTrigger{
+		Name:           "Malfurion's Gift",
+		Callback:       core.CallbackOnPeriodicHealDealt,
+		ClassSpellMask: DruidSpellLifebloom,
+		ProcChance:     0.02 * float64(druid.Talents.MalfurionsGift),
+		Handler: func(sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			druid.ProcOoc(sim)
+		},
+	})
+}
+
+func (druid *Druid) applyGiftOfTheEarthmother() {
+	if druid.Talents.GiftOfTheEarthmother == 0 {
+		return
+	}
+
+	// The instant Rejuvenation heal is handled in rejuvenation.go.
+	druid.AddStaticMod(core.SpellModConfig{
+		ClassMask:  DruidSpellLifebloom | DruidSpellLifebloomBloom,
+		FloatValue: 0.05 * float64(druid.Talents.GiftOfTheEarthmother),
+		Kind:       core.SpellMod_DamageDone_Pct,
+	})
+}
+
+func (druid *Druid) applySwiftRejuvenation() {
+	if !druid.Talents.SwiftRejuvenation {
+		return
+	}
+
+	druid.AddStaticMod(core.SpellModConfig{
+		ClassMask: DruidSpellRejuvenation,
+		TimeValue: time.Millisecond * -500,
+		Kind:      core.SpellMod_GlobalCooldown_Flat,
+	})
+}
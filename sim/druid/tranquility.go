@@ -59,10 +59,11 @@ func (druid *Druid) registerTranquilityCD() {
 
 	// Then register the primary channel spell.
 	druid.RegisterSpell(Humanoid|Tree, core.SpellConfig{
-		ActionID:    core.ActionID{SpellID: 740},
-		SpellSchool: core.SpellSchoolNature,
-		ProcMask:    core.ProcMaskSpellHealing,
-		Flags:       core.SpellFlagHelpful | core.SpellFlagAPL | core.SpellFlagChanneled,
+		ActionID:       core.ActionID{SpellID: 740},
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL | core.SpellFlagChanneled,
+		ClassSpellMask: DruidSpellTranquility,
 
 		ManaCost: core.ManaCostOptions{
 			BaseCostPercent: 32,
@@ -0,0 +1,63 @@
+package druid
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (druid *Druid) registerTreeOfLifeCD() {
+	if !druid.Talents.TreeOfLife {
+		return
+	}
+
+	actionID := core.ActionID{SpellID: 33891}
+
+	druid.TreeOfLifeAura = druid.RegisterAura(core.Aura{
+		Label:    "Tree of Life",
+		ActionID: actionID,
+		Duration: time.Second*25 + time.Second*6*time.Duration(druid.Talents.NaturalShapeshifter),
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			druid.form = Tree
+			druid.PseudoStats.HealingDealtMultiplier *= 1.15
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			if druid.InForm(Tree) {
+				druid.form = Humanoid
+			}
+			druid.PseudoStats.HealingDealtMultiplier /= 1.15
+		},
+	})
+
+	druid.TreeOfLife = druid.RegisterSpell(Humanoid|Tree, core.SpellConfig{
+		ActionID:       actionID,
+		Flags:          core.SpellFlagAPL,
+		ClassSpellMask: DruidSpellTreeOfLife,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 6,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    druid.NewTimer(),
+				Duration: time.Minute * 3,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			druid.TreeOfLifeAura.Activate(sim)
+		},
+	})
+
+	druid.AddMajorCooldown(core.MajorCooldown{
+		Spell: druid.TreeOfLife.Spell,
+		Type:  core.CooldownTypeDPS,
+	})
+}
+
+func (druid *Druid) treeOfLifeActive() bool {
+	return druid.TreeOfLifeAura != nil && druid.TreeOfLifeAura.IsActive()
+}
@@ -0,0 +1,91 @@
+package druid
+
+import (
+	"slices"
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func (druid *Druid) registerWildGrowthSpell() {
+	if !druid.Talents.WildGrowth {
+		return
+	}
+
+	numTargets := int32(5)
+	cooldown := time.Second * 8
+	if druid.HasMajorGlyph(proto.DruidMajorGlyph_GlyphOfWildGrowth) {
+		numTargets++
+		cooldown += time.Second * 2
+	}
+
+	druid.WildGrowth = druid.RegisterSpell(Humanoid|Tree, core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 48438},
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL | SpellFlagOmenTrigger,
+		ClassSpellMask: DruidSpellWildGrowth,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 27,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    druid.NewTimer(),
+				Duration: cooldown,
+			},
+		},
+
+		DamageMultiplier:         1,
+		DamageMultiplierAdditive: 1,
+		CritMultiplier:           druid.DefaultHealingCritMultiplier(),
+		ThreatMultiplier:         1,
+
+		Hot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Wild Growth",
+			},
+			NumberOfTicks:       7,
+			TickLength:          time.Second * 1,
+			AffectedByCastSpeed: true,
+			BonusCoefficient:    0.0968,
+
+			OnSnapshot: func(sim *core.Simulation, target *core.Unit, dot *core.Dot, _ bool) {
+				dot.SnapshotHeal(target, 0.4175*druid.ClassSpellScaling)
+			},
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				// The healing is front-loaded, dropping linearly from +15% on the first tick to -15% on the last.
+				numTicks := dot.ExpectedTickCount()
+				tickMultiplier := 1.15
+				if numTicks > 1 {
+					tickMultiplier -= 0.3 * float64(dot.TickCount()-1) / float64(numTicks-1)
+				}
+
+				snapshotBaseHealing := dot.SnapshotBaseDamage
+				dot.SnapshotBaseDamage *= tickMultiplier
+				dot.CalcAndDealPeriodicSnapshotHealing(sim, target, dot.OutcomeSnapshotCrit)
+				dot.SnapshotBaseDamage = snapshotBaseHealing
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			maxTargets := numTargets
+			if druid.treeOfLifeActive() {
+				maxTargets += 2
+			}
+
+			spell.Hot(target).Apply(sim)
+
+			others := slices.DeleteFunc(druid.Env.Raid.GetActiveAllyUnits(), func(unit *core.Unit) bool {
+				return unit == target
+			})
+			for _, unit := range core.LowestHealthUnits(others, maxTargets-1) {
+				spell.Hot(unit).Apply(sim)
+			}
+		},
+	})
+}
@@ -0,0 +1,392 @@
+package bot
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+const ascendantCouncilID int32 = 43686
+
+func addAscendantCouncil(raidPrefix string) {
+	// TODO: Add support for 10-man and Normal variants
+	createAscendantCouncilHeroicPreset(raidPrefix, 25, 84_000_000, 95_000)
+}
+
+func createAscendantCouncilHeroicPreset(raidPrefix string, raidSize int32, councilHealth float64, councilMinBaseDamage float64) {
+	targetName := fmt.Sprintf("Ascendant Council %d H", raidSize)
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:              ascendantCouncilID,
+			Name:            targetName,
+			Level:           88,
+			MobType:         proto.MobType_MobTypeElemental,
+			TankIndex:       0,
+			SecondTankIndex: 1,
+
+			Stats: stats.Stats{
+				stats.Health:      councilHealth,
+				stats.Armor:       11977,
+				stats.AttackPower: 0, // actual value doesn't matter in Cata, as long as damage parameters are fit consistently
+			}.ToProtoArray(),
+
+			SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:    2.0,
+			MinBaseDamage: councilMinBaseDamage,
+			DamageSpread:  0.4,
+			TargetInputs:  ascendantCouncilTargetInputs(),
+		},
+
+		AI: makeAscendantCouncilAI(raidSize),
+	})
+
+	core.AddPresetEncounter(targetName, []string{
+		raidPrefix + "/" + targetName,
+	})
+}
+
+func ascendantCouncilTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:       "Phase 2 start",
+			Tooltip:     "Remaining fight duration % at which Feludius and Ignacious retreat and Arion and Terrastra engage.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 65,
+		},
+		{
+			Label:       "Phase 3 start",
+			Tooltip:     "Remaining fight duration % at which the council merges into Elementium Monstrosity.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 30,
+		},
+	}
+}
+
+func makeAscendantCouncilAI(raidSize int32) core.AIFactory {
+	return func() core.TargetAI {
+		return &AscendantCouncilAI{
+			raidSize: raidSize,
+		}
+	}
+}
+
+// The council is modeled as a single target whose active boss pair changes with each phase.
+// Phase 1 is tanked by Tank 1, phase 2 by Tank 2 and Elementium Monstrosity by Tank 1 again.
+type AscendantCouncilAI struct {
+	// Unit references
+	Target   *core.Target
+	MainTank *core.Unit
+	OffTank  *core.Unit
+
+	// Static parameters associated with a given preset
+	raidSize int32
+
+	// Dynamic parameters taken from user inputs
+	phase2Start float64
+	phase3Start float64
+
+	// State
+	phase                    int32
+	electricInstabilityTicks int32
+
+	// Spell + aura references
+	transitionAura      *core.Aura
+	risingFlames        *core.Spell
+	glaciate            *core.Spell
+	quake               *core.Spell
+	thundershock        *core.Spell
+	electricInstability *core.Spell
+	lavaSeed            *core.Spell
+}
+
+func (ai *AscendantCouncilAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+	ai.MainTank = target.CurrentTarget
+	ai.OffTank = target.SecondaryTarget
+
+	ai.phase2Start = config.TargetInputs[0].NumberValue
+	ai.phase3Start = config.TargetInputs[1].NumberValue
+
+	// Each new phase is picked up by the other tank.
+	target.EnableTankSwap(&proto.TankSwapConfig{})
+
+	ai.transitionAura = target.RegisterUntargetableAura(core.Aura{
+		Label:    "Council Transition",
+		ActionID: core.ActionID{SpellID: 82285},
+		Duration: time.Second * 10,
+	})
+
+	ai.registerPhase1Spells()
+	ai.registerPhase2Spells()
+	ai.registerPhase3Spells()
+}
+
+func (ai *AscendantCouncilAI) Reset(sim *core.Simulation) {
+	// Randomize GCD and swing timings to prevent fake APL-Haste couplings.
+	ai.Target.ExtendGCDUntil(sim, core.DurationFromSeconds(sim.RandomFloat("Specials Timing")*core.BossGCD.Seconds()))
+	ai.Target.AutoAttacks.RandomizeMeleeTiming(sim)
+
+	ai.phase = 1
+	ai.electricInstabilityTicks = 0
+	ai.glaciate.CD.Set(time.Second * 30)
+	ai.risingFlames.CD.Set(time.Second * 20)
+}
+
+func (ai *AscendantCouncilAI) registerPhase1Spells() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	glaciateBase := []float64{45000, 54000}[scalingIndex]
+	risingFlamesTick := []float64{3000, 4000}[scalingIndex]
+
+	// Glaciate damage falls off with distance, so the raid is assumed to take a fifth of the
+	// point blank damage after moving away from Feludius.
+	ai.glaciate = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 82746},
+		SpellSchool:      core.SpellSchoolFrost,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.BossGCD,
+				CastTime: time.Second * 3,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 33,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+				spell.CalcAndDealDamage(sim, aoeTarget, glaciateBase*0.2, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+
+	// Ignacious channels Rising Flames for 4 seconds, pulsing fire damage on the whole raid.
+	ai.risingFlames = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 82636},
+		SpellSchool:      core.SpellSchoolFire,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 30,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:   time.Second,
+				NumTicks: 4,
+				Priority: core.ActionPriorityDOT,
+
+				OnAction: func(sim *core.Simulation) {
+					for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+						spell.CalcAndDealDamage(sim, aoeTarget, risingFlamesTick, spell.OutcomeAlwaysHit)
+					}
+				},
+			})
+		},
+	})
+}
+
+func (ai *AscendantCouncilAI) registerPhase2Spells() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	thundershockBase := []float64{20000, 24000}[scalingIndex]
+
+	// The raid avoids Quake by picking up Swirling Winds and Thundershock by getting Grounded,
+	// so both force a short move.
+	ai.quake = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID: core.ActionID{SpellID: 83565},
+		ProcMask: core.ProcMaskEmpty,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 66,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			sim.Raid.MoveAllPlayers(sim, time.Second*3)
+		},
+	})
+
+	// The reduced damage taken by players who prepared for Thundershock is baked into the base value.
+	ai.thundershock = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 83067},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 66,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			sim.Raid.MoveAllPlayers(sim, time.Second*3)
+
+			for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+				spell.CalcAndDealDamage(sim, aoeTarget, thundershockBase, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+
+func (ai *AscendantCouncilAI) registerPhase3Spells() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	electricInstabilityBase := []float64{2500, 3000}[scalingIndex]
+
+	// Electric Instability hits a few random players every second, and its damage grows by 10%
+	// every time it ticks.
+	ai.electricInstability = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 84529},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			players := sim.Raid.AllPlayerUnits
+			numTargets := min(len(players), int(ai.raidSize/5))
+			damage := electricInstabilityBase * (1 + 0.1*float64(ai.electricInstabilityTicks))
+			ai.electricInstabilityTicks++
+
+			for range numTargets {
+				target := players[int(sim.RandomFloat("Electric Instability Target")*float64(len(players)))]
+				spell.CalcAndDealDamage(sim, target, damage, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+
+	// Players need to move out of the Lava Seeds dropped under them.
+	ai.lavaSeed = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID: core.ActionID{SpellID: 84913},
+		ProcMask: core.ProcMaskEmpty,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 23,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			sim.Raid.MoveAllPlayers(sim, time.Second*2)
+		},
+	})
+}
+
+func (ai *AscendantCouncilAI) startPhase2(sim *core.Simulation) {
+	ai.phase = 2
+	ai.transitionAura.Duration = time.Second * 10
+	ai.transitionAura.Activate(sim)
+
+	ai.quake.CD.Set(sim.CurrentTime + ai.transitionAura.Duration + time.Second*30)
+	ai.thundershock.CD.Set(sim.CurrentTime + ai.transitionAura.Duration + time.Second*63)
+
+	ai.Target.SwapTanks(sim)
+}
+
+func (ai *AscendantCouncilAI) startPhase3(sim *core.Simulation) {
+	ai.phase = 3
+	ai.transitionAura.Duration = time.Second * 15
+	ai.transitionAura.Activate(sim)
+
+	ai.lavaSeed.CD.Set(sim.CurrentTime + ai.transitionAura.Duration + time.Second*10)
+
+	ai.Target.SwapTanks(sim)
+
+	core.StartDelayedAction(sim, core.DelayedActionOptions{
+		DoAt:     sim.CurrentTime + ai.transitionAura.Duration,
+		Priority: core.ActionPriorityDOT,
+
+		OnAction: func(sim *core.Simulation) {
+			core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:   time.Second,
+				Priority: core.ActionPriorityDOT,
+
+				OnAction: func(sim *core.Simulation) {
+					ai.electricInstability.SkipCastAndApplyEffects(sim, sim.Raid.AllPlayerUnits[0])
+				},
+			})
+		},
+	})
+}
+
+func (ai *AscendantCouncilAI) ExecuteCustomRotation(sim *core.Simulation) {
+	target := ai.Target.CurrentTarget
+	if target == nil {
+		// For individual non tank sims we still want abilities to work
+		target = &ai.Target.Env.Raid.Parties[0].Players[0].GetCharacter().Unit
+	}
+
+	remainingPercent := sim.GetRemainingDurationPercent() * 100
+
+	if (ai.phase == 1) && (remainingPercent <= ai.phase2Start) {
+		ai.startPhase2(sim)
+	}
+
+	if (ai.phase == 2) && (remainingPercent <= ai.phase3Start) {
+		ai.startPhase3(sim)
+	}
+
+	if ai.transitionAura.IsActive() {
+		ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+		return
+	}
+
+	switch ai.phase {
+	case 1:
+		if ai.glaciate.IsReady(sim) && sim.Proc(0.75, "Glaciate AI") {
+			ai.glaciate.Cast(sim, target)
+			return
+		}
+
+		if ai.risingFlames.IsReady(sim) && sim.Proc(0.75, "Rising Flames AI") {
+			ai.risingFlames.Cast(sim, target)
+			return
+		}
+	case 2:
+		if ai.quake.IsReady(sim) {
+			ai.quake.Cast(sim, target)
+		}
+
+		if ai.thundershock.IsReady(sim) {
+			ai.thundershock.Cast(sim, target)
+		}
+	case 3:
+		if ai.lavaSeed.IsReady(sim) {
+			ai.lavaSeed.Cast(sim, target)
+		}
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
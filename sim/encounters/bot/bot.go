@@ -0,0 +1,9 @@
+package bot
+
+func Register() {
+	addHalfus("Bastion of Twilight")
+	addValionaTheralion("Bastion of Twilight")
+	addAscendantCouncil("Bastion of Twilight")
+	addChogall("Bastion of Twilight")
+	addSinestra("Bastion of Twilight")
+}
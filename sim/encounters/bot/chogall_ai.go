@@ -0,0 +1,292 @@
+package bot
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+const chogallID int32 = 43324
+
+func addChogall(raidPrefix string) {
+	// TODO: Add support for 10-man and Normal variants
+	createChogallHeroicPreset(raidPrefix, 25, 94_248_000, 98_000)
+}
+
+func createChogallHeroicPreset(raidPrefix string, raidSize int32, bossHealth float64, bossMinBaseDamage float64) {
+	targetName := fmt.Sprintf("Cho'gall %d H", raidSize)
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:              chogallID,
+			Name:            targetName,
+			Level:           88,
+			MobType:         proto.MobType_MobTypeHumanoid,
+			TankIndex:       0,
+			SecondTankIndex: 1,
+
+			Stats: stats.Stats{
+				stats.Health:      bossHealth,
+				stats.Armor:       11977,
+				stats.AttackPower: 0, // actual value doesn't matter in Cata, as long as damage parameters are fit consistently
+			}.ToProtoArray(),
+
+			SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:    2.0,
+			MinBaseDamage: bossMinBaseDamage,
+			DamageSpread:  0.4,
+			TargetInputs:  chogallTargetInputs(),
+		},
+
+		AI: makeChogallAI(raidSize),
+	})
+
+	core.AddPresetEncounter(targetName, []string{
+		raidPrefix + "/" + targetName,
+	})
+}
+
+func chogallTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:       "Phase 2 start",
+			Tooltip:     "Remaining fight duration % at which Cho'gall reaches 25% health and starts casting Darkened Creations.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 25,
+		},
+		{
+			Label:       "Tank swap stacks",
+			Tooltip:     "Number of Fury of Cho'gall stacks at which the other tank taunts Cho'gall. Set to 0 to disable tank swaps.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 2,
+		},
+	}
+}
+
+func makeChogallAI(raidSize int32) core.AIFactory {
+	return func() core.TargetAI {
+		return &ChogallAI{
+			raidSize: raidSize,
+		}
+	}
+}
+
+type ChogallAI struct {
+	// Unit references
+	Target   *core.Target
+	MainTank *core.Unit
+	OffTank  *core.Unit
+
+	// Static parameters associated with a given preset
+	raidSize int32
+
+	// Dynamic parameters taken from user inputs
+	phase2Start float64
+
+	// State
+	inPhase2        bool
+	corruptionLevel int32
+
+	// Spell + aura references
+	furyOfChogall    *core.Spell
+	depravity        *core.Spell
+	corruptionOldGod *core.Spell
+	summonAdherent   *core.Spell
+}
+
+func (ai *ChogallAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+	ai.MainTank = target.CurrentTarget
+	ai.OffTank = target.SecondaryTarget
+
+	ai.phase2Start = config.TargetInputs[0].NumberValue
+
+	ai.registerFuryOfChogall(int32(config.TargetInputs[1].NumberValue))
+	ai.registerAdherents()
+	ai.registerCorruptionOfTheOldGod()
+}
+
+func (ai *ChogallAI) Reset(sim *core.Simulation) {
+	// Randomize GCD and swing timings to prevent fake APL-Haste couplings.
+	ai.Target.ExtendGCDUntil(sim, core.DurationFromSeconds(sim.RandomFloat("Specials Timing")*core.BossGCD.Seconds()))
+	ai.Target.AutoAttacks.RandomizeMeleeTiming(sim)
+
+	ai.inPhase2 = false
+	ai.corruptionLevel = 0
+
+	ai.furyOfChogall.CD.Set(time.Second * 46)
+	ai.summonAdherent.CD.Set(time.Second * 58)
+}
+
+func (ai *ChogallAI) registerFuryOfChogall(tankSwapStacks int32) {
+	ai.Target.EnableTankSwap(&proto.TankSwapConfig{
+		DebuffSpellId:       82524,
+		DebuffName:          "Fury of Cho'gall",
+		MaxStacks:           100,
+		DamageTakenPerStack: 0.2,
+		DamageTakenSchools:  []proto.SpellSchool{proto.SpellSchool_SpellSchoolPhysical, proto.SpellSchool_SpellSchoolShadow},
+		StacksFromAi:        true,
+		SwapAtStacks:        tankSwapStacks,
+	})
+
+	// Fury of Cho'gall hits the tank for a full melee swing and leaves a permanent debuff that
+	// increases Physical and Shadow damage taken by 20% per stack.
+	ai.furyOfChogall = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 82524},
+		SpellSchool:      core.SpellSchoolPhysical | core.SpellSchoolShadow,
+		ProcMask:         core.ProcMaskMeleeMHSpecial,
+		Flags:            core.SpellFlagMeleeMetrics,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 47,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseDamage := spell.Unit.AutoAttacks.MH().EnemyWeaponDamage(sim, spell.MeleeAttackPower(), 0.4)
+			spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeEnemyMeleeWhite)
+			ai.Target.AddTankSwapStack(sim, target)
+		},
+	})
+}
+
+func (ai *ChogallAI) registerAdherents() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	depravityBase := []float64{26000, 31200}[scalingIndex]
+
+	// Each Corrupting Adherent casts Depravity on the raid until it is interrupted, and the raid
+	// has to move away from the Festering Blood pools it leaves behind.
+	ai.depravity = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 81713},
+		SpellSchool:      core.SpellSchoolShadow,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+				spell.CalcAndDealDamage(sim, aoeTarget, depravityBase, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+
+	ai.summonAdherent = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID: core.ActionID{SpellID: 81628},
+		ProcMask: core.ProcMaskEmpty,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.BossGCD,
+				CastTime: time.Second * 2,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 92,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, _ *core.Spell) {
+			core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:   time.Second * 12,
+				NumTicks: 2,
+				Priority: core.ActionPriorityDOT,
+
+				OnAction: func(sim *core.Simulation) {
+					ai.depravity.SkipCastAndApplyEffects(sim, target)
+				},
+			})
+
+			core.StartDelayedAction(sim, core.DelayedActionOptions{
+				DoAt:     sim.CurrentTime + time.Second*30,
+				Priority: core.ActionPriorityDOT,
+
+				OnAction: func(sim *core.Simulation) {
+					sim.Raid.MoveAllPlayers(sim, time.Second*2)
+				},
+			})
+		},
+	})
+}
+
+func (ai *ChogallAI) registerCorruptionOfTheOldGod() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	corruptionBase := []float64{4000, 5000}[scalingIndex]
+
+	// Corruption of the Old God pulses every 2 seconds during phase 2, and its damage grows
+	// with the raid's Corruption.
+	ai.corruptionOldGod = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 82361},
+		SpellSchool:      core.SpellSchoolShadow,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			ai.corruptionLevel = min(ai.corruptionLevel+2, 100)
+			damage := corruptionBase * (1 + 0.03*float64(ai.corruptionLevel))
+
+			for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+				spell.CalcAndDealDamage(sim, aoeTarget, damage, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+
+func (ai *ChogallAI) startPhase2(sim *core.Simulation) {
+	ai.inPhase2 = true
+
+	// No more adherents are summoned once Cho'gall is below 25%.
+	ai.summonAdherent.CD.Set(core.NeverExpires)
+
+	core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+		Period:   time.Second * 2,
+		Priority: core.ActionPriorityDOT,
+
+		OnAction: func(sim *core.Simulation) {
+			ai.corruptionOldGod.SkipCastAndApplyEffects(sim, sim.Raid.AllPlayerUnits[0])
+		},
+	})
+}
+
+func (ai *ChogallAI) ExecuteCustomRotation(sim *core.Simulation) {
+	target := ai.Target.CurrentTarget
+	if target == nil {
+		// For individual non tank sims we still want abilities to work
+		target = &ai.Target.Env.Raid.Parties[0].Players[0].GetCharacter().Unit
+	}
+
+	if !ai.inPhase2 && (sim.GetRemainingDurationPercent()*100 <= ai.phase2Start) {
+		ai.startPhase2(sim)
+	}
+
+	if ai.furyOfChogall.IsReady(sim) {
+		ai.furyOfChogall.Cast(sim, target)
+		return
+	}
+
+	if ai.summonAdherent.IsReady(sim) {
+		ai.summonAdherent.Cast(sim, target)
+		return
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
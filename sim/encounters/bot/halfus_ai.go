@@ -0,0 +1,258 @@
+package bot
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+const halfusID int32 = 44600
+
+func addHalfus(raidPrefix string) {
+	// TODO: Add support for 10-man and Normal variants
+	createHalfusHeroicPreset(raidPrefix, 25, 71_552_000, 91_500)
+}
+
+func createHalfusHeroicPreset(raidPrefix string, raidSize int32, bossHealth float64, bossMinBaseDamage float64) {
+	targetName := fmt.Sprintf("Halfus Wyrmbreaker %d H", raidSize)
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:              halfusID,
+			Name:            targetName,
+			Level:           88,
+			MobType:         proto.MobType_MobTypeHumanoid,
+			TankIndex:       0,
+			SecondTankIndex: 1,
+
+			Stats: stats.Stats{
+				stats.Health:      bossHealth,
+				stats.Armor:       11977,
+				stats.AttackPower: 0, // actual value doesn't matter in Cata, as long as damage parameters are fit consistently
+			}.ToProtoArray(),
+
+			// The Nether Scion's Frenzied Assault is always active on Heroic, doubling Halfus' attack speed.
+			SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:    1.0,
+			MinBaseDamage: bossMinBaseDamage,
+			DamageSpread:  0.4,
+			TargetInputs:  halfusTargetInputs(),
+		},
+
+		AI: makeHalfusAI(raidSize, true),
+	})
+
+	core.AddPresetEncounter(targetName, []string{
+		raidPrefix + "/" + targetName,
+	})
+}
+
+func halfusTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:       "Drakes killed",
+			Tooltip:     "Number of drakes killed before the burn. Each dead drake gives Halfus a stack of Dragon's Vengeance, increasing his damage done by 100%.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 0,
+		},
+		{
+			Label:       "Tank swap stacks",
+			Tooltip:     "Number of Malevolent Strikes stacks at which Tank 2 taunts Halfus. Set to 0 to disable tank swaps.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 8,
+		},
+	}
+}
+
+func makeHalfusAI(raidSize int32, isHeroic bool) core.AIFactory {
+	return func() core.TargetAI {
+		return &HalfusAI{
+			raidSize: raidSize,
+			isHeroic: isHeroic,
+		}
+	}
+}
+
+type HalfusAI struct {
+	// Unit references
+	Target   *core.Target
+	MainTank *core.Unit
+	OffTank  *core.Unit
+
+	// Static parameters associated with a given preset
+	raidSize int32
+	isHeroic bool
+
+	// Dynamic parameters taken from user inputs
+	drakesKilled int32
+
+	// Spell + aura references
+	shadowNova       *core.Spell
+	furiousRoar      *core.Spell
+	dragonsVengeance *core.Aura
+}
+
+func (ai *HalfusAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+	ai.MainTank = target.CurrentTarget
+	ai.OffTank = target.SecondaryTarget
+
+	ai.drakesKilled = int32(config.TargetInputs[0].NumberValue)
+
+	ai.registerMalevolentStrikes(int32(config.TargetInputs[1].NumberValue))
+	ai.registerDragonsVengeance()
+	ai.registerShadowNova()
+	ai.registerFuriousRoar()
+}
+
+func (ai *HalfusAI) Reset(sim *core.Simulation) {
+	// Randomize GCD and swing timings to prevent fake APL-Haste couplings.
+	ai.Target.ExtendGCDUntil(sim, core.DurationFromSeconds(sim.RandomFloat("Specials Timing")*core.BossGCD.Seconds()))
+	ai.Target.AutoAttacks.RandomizeMeleeTiming(sim)
+
+	ai.shadowNova.CD.Set(core.DurationFromSeconds(sim.RandomFloat("Shadow Nova Timing") * ai.shadowNova.CD.Duration.Seconds()))
+	ai.furiousRoar.CD.Set(ai.furiousRoar.CD.Duration)
+}
+
+// Halfus' melee hits stack Malevolent Strikes on the tank, reducing their healing taken by 6% per
+// stack, until the other tank taunts him.
+func (ai *HalfusAI) registerMalevolentStrikes(tankSwapStacks int32) {
+	ai.Target.EnableTankSwap(&proto.TankSwapConfig{
+		DebuffSpellId:        83908,
+		DebuffName:           "Malevolent Strikes",
+		DebuffDuration:       8,
+		MaxStacks:            12,
+		HealingTakenPerStack: -0.06,
+		SwapAtStacks:         tankSwapStacks,
+	})
+}
+
+func (ai *HalfusAI) registerDragonsVengeance() {
+	if ai.drakesKilled <= 0 {
+		return
+	}
+
+	ai.dragonsVengeance = ai.Target.RegisterAura(core.Aura{
+		Label:     "Dragon's Vengeance",
+		ActionID:  core.ActionID{SpellID: 87683},
+		Duration:  core.NeverExpires,
+		MaxStacks: 5,
+
+		OnReset: func(aura *core.Aura, sim *core.Simulation) {
+			aura.Activate(sim)
+			aura.SetStacks(sim, ai.drakesKilled)
+		},
+
+		OnStacksChange: func(aura *core.Aura, sim *core.Simulation, oldStacks int32, newStacks int32) {
+			aura.Unit.PseudoStats.DamageDealtMultiplier *= (1.0 + float64(newStacks)) / (1.0 + float64(oldStacks))
+		},
+	})
+}
+
+func (ai *HalfusAI) registerShadowNova() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	shadowNovaBase := []float64{23125, 27750}[scalingIndex]
+	shadowNovaVariance := []float64{3750, 4500}[scalingIndex]
+
+	ai.shadowNova = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 86168},
+		SpellSchool:      core.SpellSchoolShadow,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.BossGCD,
+				CastTime: time.Millisecond * 1500,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 15,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+				damageRoll := shadowNovaBase + shadowNovaVariance*sim.RandomFloat("Shadow Nova Damage")
+				spell.CalcAndDealDamage(sim, aoeTarget, damageRoll, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+
+func (ai *HalfusAI) registerFuriousRoar() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	furiousRoarBase := []float64{15000, 18000}[scalingIndex]
+	furiousRoarVariance := []float64{2000, 2400}[scalingIndex]
+
+	ai.furiousRoar = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 86169},
+		SpellSchool:      core.SpellSchoolPhysical,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 30,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		// Halfus roars three times in a row, each roar hitting the whole raid.
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:          time.Millisecond * 1500,
+				NumTicks:        3,
+				TickImmediately: true,
+				Priority:        core.ActionPriorityDOT,
+
+				OnAction: func(sim *core.Simulation) {
+					for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+						damageRoll := furiousRoarBase + furiousRoarVariance*sim.RandomFloat("Furious Roar Damage")
+						spell.CalcAndDealDamage(sim, aoeTarget, damageRoll, spell.OutcomeAlwaysHit)
+					}
+				},
+			})
+
+			spell.Unit.AutoAttacks.PauseMeleeBy(sim, time.Millisecond*4500)
+		},
+	})
+}
+
+func (ai *HalfusAI) ExecuteCustomRotation(sim *core.Simulation) {
+	target := ai.Target.CurrentTarget
+	if target == nil {
+		// For individual non tank sims we still want abilities to work
+		target = &ai.Target.Env.Raid.Parties[0].Players[0].GetCharacter().Unit
+	}
+
+	if ai.furiousRoar.IsReady(sim) {
+		ai.furiousRoar.Cast(sim, target)
+		return
+	}
+
+	if ai.shadowNova.IsReady(sim) && sim.Proc(0.75, "Shadow Nova AI") {
+		ai.shadowNova.Cast(sim, target)
+		return
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
@@ -0,0 +1,268 @@
+package bot
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+const sinestraID int32 = 45213
+
+func addSinestra(raidPrefix string) {
+	// Sinestra only exists on Heroic difficulty.
+	createSinestraHeroicPreset(raidPrefix, 25, 61_210_000, 110_000)
+}
+
+func createSinestraHeroicPreset(raidPrefix string, raidSize int32, bossHealth float64, bossMinBaseDamage float64) {
+	targetName := fmt.Sprintf("Sinestra %d H", raidSize)
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        sinestraID,
+			Name:      targetName,
+			Level:     88,
+			MobType:   proto.MobType_MobTypeDragonkin,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      bossHealth,
+				stats.Armor:       11977,
+				stats.AttackPower: 0, // actual value doesn't matter in Cata, as long as damage parameters are fit consistently
+			}.ToProtoArray(),
+
+			SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:    2.0,
+			MinBaseDamage: bossMinBaseDamage,
+			DamageSpread:  0.4,
+			TargetInputs:  sinestraTargetInputs(),
+		},
+
+		AI: makeSinestraAI(raidSize),
+	})
+
+	core.AddPresetEncounter(targetName, []string{
+		raidPrefix + "/" + targetName,
+	})
+}
+
+func sinestraTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:       "Phase 2 start",
+			Tooltip:     "Remaining fight duration % at which Sinestra reaches 30% health and becomes immune behind her Twilight Carapace.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 55,
+		},
+		{
+			Label:       "Phase 2 duration",
+			Tooltip:     "Seconds the raid spends killing the Twilight Drakes and Calen's Twilight Egg before Sinestra can be attacked again.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 60,
+		},
+	}
+}
+
+func makeSinestraAI(raidSize int32) core.AIFactory {
+	return func() core.TargetAI {
+		return &SinestraAI{
+			raidSize: raidSize,
+		}
+	}
+}
+
+type SinestraAI struct {
+	// Unit references
+	Target *core.Target
+
+	// Static parameters associated with a given preset
+	raidSize int32
+
+	// Dynamic parameters taken from user inputs
+	phase2Start    float64
+	phase2Duration time.Duration
+
+	// State
+	phase int32
+
+	// Spell + aura references
+	twilightCarapace *core.Aura
+	flameBreath      *core.Spell
+	wrack            *core.Spell
+	twilightSlicer   *core.Spell
+}
+
+func (ai *SinestraAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+
+	ai.phase2Start = config.TargetInputs[0].NumberValue
+	ai.phase2Duration = core.DurationFromSeconds(config.TargetInputs[1].NumberValue)
+
+	ai.twilightCarapace = target.RegisterUntargetableAura(core.Aura{
+		Label:    "Twilight Carapace",
+		ActionID: core.ActionID{SpellID: 87654},
+		Duration: ai.phase2Duration,
+	})
+
+	ai.registerFlameBreath()
+	ai.registerWrack()
+	ai.registerTwilightSlicer()
+}
+
+func (ai *SinestraAI) Reset(sim *core.Simulation) {
+	// Randomize GCD and swing timings to prevent fake APL-Haste couplings.
+	ai.Target.ExtendGCDUntil(sim, core.DurationFromSeconds(sim.RandomFloat("Specials Timing")*core.BossGCD.Seconds()))
+	ai.Target.AutoAttacks.RandomizeMeleeTiming(sim)
+
+	ai.phase = 1
+	ai.flameBreath.CD.Set(time.Second * 20)
+	ai.wrack.CD.Set(time.Second * 15)
+	ai.twilightSlicer.CD.Set(time.Second * 28)
+}
+
+func (ai *SinestraAI) registerFlameBreath() {
+	flameBreathBase := 29250.0
+	flameBreathVariance := 1500.0
+
+	ai.flameBreath = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 90125},
+		SpellSchool:      core.SpellSchoolFire,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.BossGCD,
+				CastTime: time.Second * 2,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 20,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+				damageRoll := flameBreathBase + flameBreathVariance*sim.RandomFloat("Flame Breath Damage")
+				spell.CalcAndDealDamage(sim, aoeTarget, damageRoll, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+
+func (ai *SinestraAI) registerWrack() {
+	wrackBase := 2000.0
+
+	// Wrack is dispelled around the raid so that it keeps jumping to new players, and every
+	// dispel makes it tick harder. Modeled as a single ramping shadow DoT on two players.
+	ai.wrack = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 89421},
+		SpellSchool:      core.SpellSchoolShadow,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 75,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			players := sim.Raid.AllPlayerUnits
+			ticks := 0
+
+			core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:   time.Second * 2,
+				NumTicks: 30,
+				Priority: core.ActionPriorityDOT,
+
+				OnAction: func(sim *core.Simulation) {
+					ticks++
+					damage := wrackBase * (1 + float64(ticks/5))
+
+					for range min(len(players), 2) {
+						target := players[int(sim.RandomFloat("Wrack Target")*float64(len(players)))]
+						spell.CalcAndDealDamage(sim, target, damage, spell.OutcomeAlwaysHit)
+					}
+				},
+			})
+		},
+	})
+}
+
+func (ai *SinestraAI) registerTwilightSlicer() {
+	// Players have to run around the Twilight Slicer beam between the two orbs.
+	ai.twilightSlicer = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID: core.ActionID{SpellID: 92851},
+		ProcMask: core.ProcMaskEmpty,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 28,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			sim.Raid.MoveAllPlayers(sim, time.Second*3)
+		},
+	})
+}
+
+func (ai *SinestraAI) ExecuteCustomRotation(sim *core.Simulation) {
+	target := ai.Target.CurrentTarget
+	if target == nil {
+		// For individual non tank sims we still want abilities to work
+		target = &ai.Target.Env.Raid.Parties[0].Players[0].GetCharacter().Unit
+	}
+
+	if (ai.phase == 1) && (sim.GetRemainingDurationPercent()*100 <= ai.phase2Start) {
+		ai.phase = 2
+		ai.twilightCarapace.Activate(sim)
+	}
+
+	if (ai.phase == 2) && !ai.twilightCarapace.IsActive() {
+		ai.phase = 3
+		ai.flameBreath.CD.Set(sim.CurrentTime + time.Second*5)
+		ai.wrack.CD.Set(sim.CurrentTime + time.Second*10)
+		ai.twilightSlicer.CD.Set(sim.CurrentTime + time.Second*15)
+	}
+
+	// Sinestra doesn't use her phase 1 abilities while she is shielded.
+	if ai.phase == 2 {
+		ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+		return
+	}
+
+	if ai.twilightSlicer.IsReady(sim) {
+		ai.twilightSlicer.Cast(sim, target)
+	}
+
+	if ai.wrack.IsReady(sim) {
+		ai.wrack.Cast(sim, target)
+		return
+	}
+
+	if ai.flameBreath.IsReady(sim) && sim.Proc(0.75, "Flame Breath AI") {
+		ai.flameBreath.Cast(sim, target)
+		return
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
@@ -0,0 +1,327 @@
+package bot
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+const valionaID int32 = 45992
+const theralionID int32 = 45993
+
+func addValionaTheralion(raidPrefix string) {
+	// TODO: Add support for 10-man and Normal variants
+	createValionaTheralionHeroicPreset(raidPrefix, 25, 46_805_000, 104_000)
+}
+
+func createValionaTheralionHeroicPreset(raidPrefix string, raidSize int32, dragonHealth float64, dragonMinBaseDamage float64) {
+	encounterName := fmt.Sprintf("Valiona & Theralion %d H", raidSize)
+	valionaName := fmt.Sprintf("Valiona %d H", raidSize)
+	theralionName := fmt.Sprintf("Theralion %d H", raidSize)
+
+	for _, dragon := range []struct {
+		id        int32
+		name      string
+		tankIndex int32
+	}{
+		{valionaID, valionaName, 0},
+		{theralionID, theralionName, 1},
+	} {
+		core.AddPresetTarget(&core.PresetTarget{
+			PathPrefix: raidPrefix,
+
+			Config: &proto.Target{
+				Id:        dragon.id,
+				Name:      dragon.name,
+				Level:     88,
+				MobType:   proto.MobType_MobTypeDragonkin,
+				TankIndex: dragon.tankIndex,
+
+				Stats: stats.Stats{
+					stats.Health:      dragonHealth,
+					stats.Armor:       11977,
+					stats.AttackPower: 0, // actual value doesn't matter in Cata, as long as damage parameters are fit consistently
+				}.ToProtoArray(),
+
+				SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+				SwingSpeed:    2.0,
+				MinBaseDamage: dragonMinBaseDamage,
+				DamageSpread:  0.4,
+				TargetInputs:  core.Ternary(dragon.id == valionaID, valionaTheralionTargetInputs(), []*proto.TargetInput{}),
+			},
+
+			AI: makeValionaTheralionAI(raidSize, dragon.id == valionaID),
+		})
+	}
+
+	core.AddPresetEncounter(encounterName, []string{
+		raidPrefix + "/" + valionaName,
+		raidPrefix + "/" + theralionName,
+	})
+}
+
+func valionaTheralionTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:       "Valiona ground phase duration",
+			Tooltip:     "Seconds Valiona spends on the ground (with Theralion flying) before the dragons switch.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 100,
+		},
+		{
+			Label:       "Theralion ground phase duration",
+			Tooltip:     "Seconds Theralion spends on the ground (with Valiona flying and casting Deep Breath) before the dragons switch back.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 40,
+		},
+	}
+}
+
+func makeValionaTheralionAI(raidSize int32, isValiona bool) core.AIFactory {
+	return func() core.TargetAI {
+		return &ValionaTheralionAI{
+			raidSize:  raidSize,
+			isValiona: isValiona,
+		}
+	}
+}
+
+// Valiona and Theralion take turns on the ground. The flying dragon can't be attacked, so the
+// raid and its tank swap to the grounded one. Valiona's AI drives the phase changes for both.
+type ValionaTheralionAI struct {
+	// Unit references
+	Target    *core.Target
+	Valiona   *core.Target
+	Theralion *core.Target
+
+	// Static parameters associated with a given preset
+	raidSize  int32
+	isValiona bool
+
+	// Dynamic parameters taken from user inputs
+	valionaGroundDuration   time.Duration
+	theralionGroundDuration time.Duration
+
+	// Tracks whether Valiona still has to breathe during the current air phase
+	deepBreathPending bool
+
+	// Spell + aura references
+	airborneAura      *core.Aura
+	blackout          *core.Spell
+	deepBreath        *core.Spell
+	fabulousFlames    *core.Spell
+	twilightMeteorite *core.Spell
+}
+
+func (ai *ValionaTheralionAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+	ai.Target.AutoAttacks.MHConfig().ActionID.Tag = core.TernaryInt32(ai.isValiona, valionaID, theralionID)
+
+	if ai.isValiona {
+		ai.Valiona = target
+		ai.Theralion = target.NextTarget()
+		ai.valionaGroundDuration = core.DurationFromSeconds(config.TargetInputs[0].NumberValue)
+		ai.theralionGroundDuration = core.DurationFromSeconds(config.TargetInputs[1].NumberValue)
+	} else {
+		ai.Theralion = target
+		ai.Valiona = target.NextTarget()
+	}
+
+	ai.airborneAura = target.RegisterUntargetableAura(core.Aura{
+		Label:    "Airborne",
+		ActionID: core.ActionID{SpellID: 86622}.WithTag(1),
+		Duration: core.NeverExpires,
+	})
+
+	if ai.isValiona {
+		ai.registerBlackout()
+		ai.registerDeepBreath()
+	} else {
+		ai.registerFabulousFlames()
+		ai.registerTwilightMeteorite()
+	}
+}
+
+func (ai *ValionaTheralionAI) Reset(sim *core.Simulation) {
+	// Randomize GCD and swing timings to prevent fake APL-Haste couplings.
+	ai.Target.ExtendGCDUntil(sim, core.DurationFromSeconds(sim.RandomFloat("Specials Timing")*core.BossGCD.Seconds()))
+	ai.Target.AutoAttacks.RandomizeMeleeTiming(sim)
+
+	// Without the other dragon in the encounter there's nobody to switch with.
+	if ai.Valiona == ai.Theralion {
+		return
+	}
+
+	if !ai.isValiona {
+		// Theralion starts the encounter in the air.
+		ai.airborneAura.Activate(sim)
+		return
+	}
+
+	ai.blackout.CD.Set(time.Second * 10)
+	ai.deepBreathPending = false
+	ai.scheduleSwitch(sim, ai.Valiona, ai.Theralion, ai.valionaGroundDuration)
+}
+
+// Once the grounded dragon's phase is over, it takes off and the airborne one lands.
+func (ai *ValionaTheralionAI) scheduleSwitch(sim *core.Simulation, grounded *core.Target, airborne *core.Target, groundDuration time.Duration) {
+	core.StartDelayedAction(sim, core.DelayedActionOptions{
+		DoAt:     sim.CurrentTime + groundDuration,
+		Priority: core.ActionPriorityDOT,
+
+		OnAction: func(sim *core.Simulation) {
+			airborne.AI.(*ValionaTheralionAI).airborneAura.Deactivate(sim)
+			grounded.AI.(*ValionaTheralionAI).airborneAura.Activate(sim)
+
+			// Everyone attacking the dragon that took off switches to the one that landed.
+			for _, unit := range sim.Raid.AllUnits {
+				if unit.CurrentTarget == &grounded.Unit {
+					unit.CurrentTarget = &airborne.Unit
+				}
+			}
+
+			ai.deepBreathPending = grounded == ai.Valiona
+
+			nextGroundDuration := core.Ternary(airborne == ai.Valiona, ai.valionaGroundDuration, ai.theralionGroundDuration)
+			ai.scheduleSwitch(sim, airborne, grounded, nextGroundDuration)
+		},
+	})
+}
+
+func (ai *ValionaTheralionAI) registerBlackout() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+
+	// Blackout is split between everyone standing in it, which is assumed to be the whole raid.
+	blackoutBase := []float64{162500, 350000}[scalingIndex] / float64(ai.raidSize)
+
+	ai.blackout = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 86788},
+		SpellSchool:      core.SpellSchoolShadow,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 45,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+				spell.CalcAndDealDamage(sim, aoeTarget, blackoutBase, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+
+func (ai *ValionaTheralionAI) registerDeepBreath() {
+	// Valiona crosses the room three times, and the raid has to run out of the breath each time.
+	ai.deepBreath = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID: core.ActionID{SpellID: 86059},
+		ProcMask: core.ProcMaskEmpty,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			ai.deepBreathPending = false
+
+			core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:   time.Second * 10,
+				NumTicks: 3,
+				Priority: core.ActionPriorityDOT,
+
+				OnAction: func(sim *core.Simulation) {
+					sim.Raid.MoveAllPlayers(sim, time.Second*3)
+				},
+			})
+		},
+	})
+}
+
+func (ai *ValionaTheralionAI) registerFabulousFlames() {
+	// Theralion covers a random player in fire while flying, forcing them to move out of it.
+	ai.fabulousFlames = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID: core.ActionID{SpellID: 86505},
+		ProcMask: core.ProcMaskEmpty,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 15,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			players := sim.Raid.AllPlayerUnits
+			target := players[int(sim.RandomFloat("Fabulous Flames Target")*float64(len(players)))]
+			target.MoveDuration(time.Second*2, sim)
+		},
+	})
+}
+
+func (ai *ValionaTheralionAI) registerTwilightMeteorite() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	twilightMeteoriteBase := []float64{47500, 57000}[scalingIndex]
+	twilightMeteoriteVariance := []float64{5000, 6000}[scalingIndex]
+
+	// Split between the target and anyone stacked with them, assumed to be five players.
+	ai.twilightMeteorite = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 88518},
+		SpellSchool:      core.SpellSchoolShadow,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 6,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			players := sim.Raid.AllPlayerUnits
+			target := players[int(sim.RandomFloat("Twilight Meteorite Target")*float64(len(players)))]
+			damageRoll := (twilightMeteoriteBase + twilightMeteoriteVariance*sim.RandomFloat("Twilight Meteorite Damage")) / 5
+			spell.CalcAndDealDamage(sim, target, damageRoll, spell.OutcomeAlwaysHit)
+		},
+	})
+}
+
+func (ai *ValionaTheralionAI) ExecuteCustomRotation(sim *core.Simulation) {
+	target := ai.Target.CurrentTarget
+	if target == nil {
+		// For individual non tank sims we still want abilities to work
+		target = &ai.Target.Env.Raid.Parties[0].Players[0].GetCharacter().Unit
+	}
+
+	if ai.airborneAura.IsActive() {
+		if ai.isValiona && ai.deepBreathPending {
+			ai.deepBreath.Cast(sim, target)
+		}
+
+		if !ai.isValiona && ai.fabulousFlames.IsReady(sim) {
+			ai.fabulousFlames.Cast(sim, target)
+		}
+
+		if !ai.isValiona && ai.twilightMeteorite.IsReady(sim) {
+			ai.twilightMeteorite.Cast(sim, target)
+		}
+	} else if ai.isValiona && ai.blackout.IsReady(sim) && sim.Proc(0.75, "Blackout AI") {
+		ai.blackout.Cast(sim, target)
+		return
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
@@ -1,5 +1,23 @@
 package dragonsoul
 
+import "github.com/wowsims/cata/sim/core"
+
 func Register() {
+	addMorchok("Dragon Soul")
+	addZonozz("Dragon Soul")
+	addYorsahj("Dragon Soul")
+	addHagara("Dragon Soul")
+	addUltraxion("Dragon Soul")
 	addBlackhorn("Dragon Soul")
+	addSpine("Dragon Soul")
+	addMadness("Dragon Soul")
+}
+
+// Moves everyone attacking one unit over to another.
+func retarget(sim *core.Simulation, from *core.Unit, to *core.Unit) {
+	for _, unit := range sim.Raid.AllUnits {
+		if unit.CurrentTarget == from {
+			unit.CurrentTarget = to
+		}
+	}
 }
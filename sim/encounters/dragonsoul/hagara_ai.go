@@ -0,0 +1,458 @@
+package dragonsoul
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func addHagara(raidPrefix string) {
+	createHagaraHeroicPreset(raidPrefix, 25, 55689, 56136, 100_640_000, 250_000, 1_090_000)
+}
+
+func createHagaraHeroicPreset(raidPrefix string, raidSize int32, bossNpcId int32, addNpcId int32, bossHealth float64, bossMinBaseDamage float64, addHealth float64) {
+	bossName := fmt.Sprintf("Hagara the Stormbinder %d H", raidSize)
+	addName := fmt.Sprintf("Frozen Binding Crystal %d H", raidSize)
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        bossNpcId,
+			Name:      bossName,
+			Level:     88,
+			MobType:   proto.MobType_MobTypeHumanoid,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      bossHealth,
+				stats.Armor:       11977,
+				stats.AttackPower: 0, // actual value doesn't matter in Cata, as long as damage parameters are fit consistently
+			}.ToProtoArray(),
+
+			SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:    1.5,
+			MinBaseDamage: bossMinBaseDamage,
+			DamageSpread:  0.3,
+			DualWield:     true,
+			TargetInputs:  hagaraTargetInputs(),
+		},
+
+		AI: makeHagaraAI(raidSize),
+	})
+
+	// The four Frozen Binding Crystals of the Frozen Tempest are modeled as a single add that can
+	// only be attacked during that intermission.
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        addNpcId,
+			Name:      addName,
+			Level:     87,
+			MobType:   proto.MobType_MobTypeMechanical,
+			TankIndex: 2,
+
+			Stats: stats.Stats{
+				stats.Health:      addHealth,
+				stats.Armor:       11977,
+				stats.AttackPower: 0,
+			}.ToProtoArray(),
+
+			TargetInputs: []*proto.TargetInput{},
+		},
+
+		AI: makeBindingCrystalAI(),
+	})
+
+	core.AddPresetEncounter(bossName, []string{
+		raidPrefix + "/" + bossName,
+		raidPrefix + "/" + addName,
+	})
+}
+
+func hagaraTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:       "Phase duration",
+			Tooltip:     "Seconds Hagara is tanked between intermissions",
+			InputType:   proto.InputType_Number,
+			NumberValue: 60,
+		},
+		{
+			Label:       "Frozen Tempest duration",
+			Tooltip:     "Seconds the raid needs to destroy the Frozen Binding Crystals during the Frozen Tempest intermission",
+			InputType:   proto.InputType_Number,
+			NumberValue: 30,
+		},
+		{
+			Label:       "Lightning Storm duration",
+			Tooltip:     "Seconds the raid needs to charge the Crystal Conductors during the Lightning Storm intermission, with no target to attack",
+			InputType:   proto.InputType_Number,
+			NumberValue: 40,
+		},
+	}
+}
+
+func makeHagaraAI(raidSize int32) core.AIFactory {
+	return func() core.TargetAI {
+		return &HagaraAI{
+			raidSize: raidSize,
+		}
+	}
+}
+
+type hagaraIntermission int32
+
+const (
+	hagaraFrozenTempest hagaraIntermission = iota
+	hagaraLightningStorm
+)
+
+// Hagara alternates between tank phases and two intermissions, picked at random for the
+// first one. She can't be attacked during intermissions, and once an intermission ends she is
+// stunned by Feedback and takes double damage for a short while.
+type HagaraAI struct {
+	// Unit references
+	Target  *core.Target
+	Crystal *BindingCrystalAI
+
+	// Static parameters associated with a given preset
+	raidSize int32
+
+	// Dynamic parameters taken from user inputs
+	phaseDuration          time.Duration
+	frozenTempestDuration  time.Duration
+	lightningStormDuration time.Duration
+
+	// State
+	nextIntermission hagaraIntermission
+
+	// Spell + aura references
+	intermissionAura *core.Aura
+	feedback         *core.Aura
+	stormTick        *core.Spell
+	shatteredIce     *core.Spell
+	iceLance         *core.Spell
+	iceTomb          *core.Spell
+}
+
+func (ai *HagaraAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+
+	ai.phaseDuration = core.DurationFromSeconds(config.TargetInputs[0].NumberValue)
+	ai.frozenTempestDuration = core.DurationFromSeconds(config.TargetInputs[1].NumberValue)
+	ai.lightningStormDuration = core.DurationFromSeconds(config.TargetInputs[2].NumberValue)
+
+	ai.intermissionAura = target.RegisterUntargetableAura(core.Aura{
+		Label:    "Intermission",
+		Duration: core.NeverExpires,
+	})
+
+	ai.registerFeedback()
+	ai.registerStormTick()
+	ai.registerShatteredIce()
+	ai.registerIceLance()
+	ai.registerIceTomb()
+}
+
+func (ai *HagaraAI) Reset(sim *core.Simulation) {
+	// Randomize GCD and swing timings to prevent fake APL-Haste couplings.
+	ai.Target.ExtendGCDUntil(sim, core.DurationFromSeconds(sim.RandomFloat("Specials Timing")*core.BossGCD.Seconds()))
+	ai.Target.AutoAttacks.RandomizeMeleeTiming(sim)
+
+	ai.nextIntermission = core.Ternary(sim.RandomFloat("First Intermission") < 0.5, hagaraFrozenTempest, hagaraLightningStorm)
+
+	ai.Crystal = nil
+	for _, target := range sim.Encounter.Targets {
+		if crystal, ok := target.AI.(*BindingCrystalAI); ok {
+			ai.Crystal = crystal
+		}
+	}
+
+	ai.startPhase(sim)
+}
+
+func (ai *HagaraAI) startPhase(sim *core.Simulation) {
+	ai.shatteredIce.CD.Set(sim.CurrentTime + time.Second*5)
+	ai.iceLance.CD.Set(sim.CurrentTime + time.Second*12)
+	ai.iceTomb.CD.Set(sim.CurrentTime + time.Second*20)
+
+	core.StartDelayedAction(sim, core.DelayedActionOptions{
+		DoAt:     sim.CurrentTime + ai.phaseDuration,
+		Priority: core.ActionPriorityDOT,
+
+		OnAction: func(sim *core.Simulation) {
+			ai.startIntermission(sim)
+		},
+	})
+}
+
+func (ai *HagaraAI) startIntermission(sim *core.Simulation) {
+	intermission := ai.nextIntermission
+	ai.nextIntermission = 1 - intermission
+	ai.intermissionAura.Activate(sim)
+
+	duration := ai.lightningStormDuration
+	if intermission == hagaraFrozenTempest {
+		duration = ai.frozenTempestDuration
+
+		if ai.Crystal != nil {
+			ai.Crystal.notSummonedAura.Deactivate(sim)
+			retarget(sim, &ai.Target.Unit, &ai.Crystal.Target.Unit)
+		}
+	}
+
+	// Both intermissions pulse damage on the raid and keep it moving, around the Ice Waves or
+	// between the conductors.
+	tickAction := core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+		Period:   time.Second * 3,
+		Priority: core.ActionPriorityDOT,
+
+		OnAction: func(sim *core.Simulation) {
+			ai.stormTick.SkipCastAndApplyEffects(sim, sim.Raid.AllPlayerUnits[0])
+		},
+	})
+
+	moveAction := core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+		Period:   time.Second * 10,
+		Priority: core.ActionPriorityDOT,
+
+		OnAction: func(sim *core.Simulation) {
+			sim.Raid.MoveAllPlayers(sim, time.Second*2)
+		},
+	})
+
+	core.StartDelayedAction(sim, core.DelayedActionOptions{
+		DoAt:     sim.CurrentTime + duration,
+		Priority: core.ActionPriorityDOT,
+
+		OnAction: func(sim *core.Simulation) {
+			tickAction.Cancel(sim)
+			moveAction.Cancel(sim)
+
+			if (intermission == hagaraFrozenTempest) && (ai.Crystal != nil) {
+				ai.Crystal.notSummonedAura.Activate(sim)
+				retarget(sim, &ai.Crystal.Target.Unit, &ai.Target.Unit)
+			}
+
+			ai.intermissionAura.Deactivate(sim)
+			ai.feedback.Activate(sim)
+		},
+	})
+}
+
+func (ai *HagaraAI) registerFeedback() {
+	// Feedback stuns Hagara and doubles the damage she takes.
+	ai.feedback = ai.Target.RegisterAura(core.Aura{
+		Label:    "Feedback",
+		ActionID: core.ActionID{SpellID: 108934},
+		Duration: time.Second * 15,
+
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			aura.Unit.PseudoStats.DamageTakenMultiplier *= 2
+			ai.Target.AutoAttacks.CancelAutoSwing(sim)
+		},
+
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			aura.Unit.PseudoStats.DamageTakenMultiplier /= 2
+
+			if sim.CurrentTime < sim.Duration {
+				if ai.Target.CurrentTarget != nil {
+					ai.Target.AutoAttacks.EnableAutoSwing(sim)
+					ai.Target.AutoAttacks.RandomizeMeleeTiming(sim)
+				}
+
+				ai.startPhase(sim)
+			}
+		},
+	})
+}
+
+func (ai *HagaraAI) registerStormTick() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	stormTickBase := []float64{14000, 16000}[scalingIndex]
+
+	ai.stormTick = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 105256},
+		SpellSchool:      core.SpellSchoolFrost,
+		ProcMask:         core.ProcMaskEmpty,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+				spell.CalcAndDealDamage(sim, aoeTarget, stormTickBase, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+
+func (ai *HagaraAI) registerShatteredIce() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	shatteredIceBase := []float64{90000, 110000}[scalingIndex]
+
+	ai.shatteredIce = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 105289},
+		SpellSchool:      core.SpellSchoolFrost,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 12,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealDamage(sim, target, shatteredIceBase, spell.OutcomeAlwaysHit)
+		},
+	})
+}
+
+func (ai *HagaraAI) registerIceLance() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	iceLanceBase := []float64{40000, 45000}[scalingIndex]
+	numSoakers := core.TernaryInt(ai.raidSize == 10, 3, 6)
+
+	// Three Ice Lances fire at whoever stands in front of them for 15 seconds.
+	ai.iceLance = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 105297},
+		SpellSchool:      core.SpellSchoolFrost,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 30,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			players := sim.Raid.AllPlayerUnits
+			soakers := make([]*core.Unit, 0, numSoakers)
+			for range min(len(players), numSoakers) {
+				soakers = append(soakers, players[int(sim.RandomFloat("Ice Lance Target")*float64(len(players)))])
+			}
+
+			core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:   time.Second,
+				NumTicks: 15,
+				Priority: core.ActionPriorityDOT,
+
+				OnAction: func(sim *core.Simulation) {
+					for _, soaker := range soakers {
+						spell.CalcAndDealDamage(sim, soaker, iceLanceBase, spell.OutcomeAlwaysHit)
+					}
+				},
+			})
+		},
+	})
+}
+
+func (ai *HagaraAI) registerIceTomb() {
+	// Ice Tomb encases several players, and the rest of the raid has to break them out.
+	ai.iceTomb = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID: core.ActionID{SpellID: 104448},
+		ProcMask: core.ProcMaskEmpty,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 30,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			sim.Raid.MoveAllPlayers(sim, time.Second*3)
+		},
+	})
+}
+
+func (ai *HagaraAI) ExecuteCustomRotation(sim *core.Simulation) {
+	target := ai.Target.CurrentTarget
+	if target == nil {
+		// For individual non tank sims we still want abilities to work
+		target = &ai.Target.Env.Raid.Parties[0].Players[0].GetCharacter().Unit
+	}
+
+	if ai.intermissionAura.IsActive() || ai.feedback.IsActive() {
+		ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+		return
+	}
+
+	if (ai.Target.CurrentTarget != nil) && ai.shatteredIce.IsReady(sim) {
+		ai.shatteredIce.Cast(sim, target)
+		return
+	}
+
+	if ai.iceLance.IsReady(sim) && sim.Proc(0.75, "Ice Lance AI") {
+		ai.iceLance.Cast(sim, target)
+		return
+	}
+
+	if ai.iceTomb.IsReady(sim) && sim.Proc(0.75, "Ice Tomb AI") {
+		ai.iceTomb.Cast(sim, target)
+		return
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
+
+func makeBindingCrystalAI() core.AIFactory {
+	return func() core.TargetAI {
+		return &BindingCrystalAI{}
+	}
+}
+
+type BindingCrystalAI struct {
+	Target *core.Target
+
+	// Spell + aura references
+	notSummonedAura *core.Aura
+}
+
+func (ai *BindingCrystalAI) Initialize(target *core.Target, _ *proto.Target) {
+	ai.Target = target
+
+	ai.notSummonedAura = target.RegisterUntargetableAura(core.Aura{
+		Label:    "Not Summoned",
+		Duration: core.NeverExpires,
+	})
+}
+
+func (ai *BindingCrystalAI) Reset(sim *core.Simulation) {
+	ai.notSummonedAura.Activate(sim)
+}
+
+func (ai *BindingCrystalAI) ExecuteCustomRotation(sim *core.Simulation) {
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
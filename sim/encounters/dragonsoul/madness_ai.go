@@ -0,0 +1,470 @@
+package dragonsoul
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func addMadness(raidPrefix string) {
+	createMadnessHeroicPreset(raidPrefix, 25, 56846, 56471, 56173, 56710, 24_150_000, 10_300_000, 115_000_000, 5_580_000, 230_000, 200_000)
+}
+
+func createMadnessHeroicPreset(raidPrefix string, raidSize int32, limbNpcId int32, corruptionNpcId int32, deathwingNpcId int32, terrorNpcId int32, limbHealth float64, corruptionHealth float64, deathwingHealth float64, terrorHealth float64, corruptionMinBaseDamage float64, terrorMinBaseDamage float64) {
+	suffix := fmt.Sprintf(" %d H", raidSize)
+	encounterName := "Madness of Deathwing" + suffix
+	var targetPaths []string
+
+	for _, unit := range []struct {
+		npcId         int32
+		name          string
+		health        float64
+		role          madnessRole
+		mobType       proto.MobType
+		tankIndex     int32
+		minBaseDamage float64
+	}{
+		{limbNpcId, "Arm Tentacle", limbHealth, madnessLimb, proto.MobType_MobTypeUnknown, 2, 0},
+		{corruptionNpcId, "Mutated Corruption", corruptionHealth, madnessCorruption, proto.MobType_MobTypeUnknown, 0, corruptionMinBaseDamage},
+		{deathwingNpcId, "Deathwing", deathwingHealth, madnessDeathwing, proto.MobType_MobTypeDragonkin, 2, 0},
+		{terrorNpcId, "Elementium Terror", terrorHealth, madnessTerror, proto.MobType_MobTypeElemental, 1, terrorMinBaseDamage},
+	} {
+		targetName := unit.name + suffix
+		targetPaths = append(targetPaths, raidPrefix+"/"+targetName)
+
+		core.AddPresetTarget(&core.PresetTarget{
+			PathPrefix: raidPrefix,
+
+			Config: &proto.Target{
+				Id:        unit.npcId,
+				Name:      targetName,
+				Level:     88,
+				MobType:   unit.mobType,
+				TankIndex: unit.tankIndex,
+
+				Stats: stats.Stats{
+					stats.Health:      unit.health,
+					stats.Armor:       11977,
+					stats.AttackPower: 0, // actual value doesn't matter in Cata, as long as damage parameters are fit consistently
+				}.ToProtoArray(),
+
+				SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+				SwingSpeed:    core.TernaryFloat64(unit.minBaseDamage > 0, 2.0, 0),
+				MinBaseDamage: unit.minBaseDamage,
+				DamageSpread:  0.4,
+				TargetInputs:  core.Ternary(unit.role == madnessLimb, madnessTargetInputs(), []*proto.TargetInput{}),
+			},
+
+			AI: makeMadnessAI(raidSize, unit.role),
+		})
+	}
+
+	core.AddPresetEncounter(encounterName, targetPaths)
+}
+
+func madnessTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:       "Limb kill time",
+			Tooltip:     "Seconds the raid spends on each of Deathwing's four limbs before moving on to the next platform",
+			InputType:   proto.InputType_Number,
+			NumberValue: 60,
+		},
+		{
+			Label:       "Platform travel time",
+			Tooltip:     "Seconds the raid spends jumping over to the next platform after each limb dies",
+			InputType:   proto.InputType_Number,
+			NumberValue: 8,
+		},
+		{
+			Label:       "Elementium Terror kill time",
+			Tooltip:     "Seconds the raid spends on each pair of Elementium Terrors in phase 2 before going back to Deathwing",
+			InputType:   proto.InputType_Number,
+			NumberValue: 15,
+		},
+	}
+}
+
+type madnessRole int32
+
+const (
+	madnessLimb madnessRole = iota
+	madnessCorruption
+	madnessDeathwing
+	madnessTerror
+)
+
+const madnessNumLimbs = 4
+const madnessTerrorInterval = time.Second * 90
+
+func makeMadnessAI(raidSize int32, role madnessRole) core.AIFactory {
+	return func() core.TargetAI {
+		return &MadnessAI{
+			raidSize: raidSize,
+			role:     role,
+		}
+	}
+}
+
+// In phase 1 the raid kills Deathwing's limbs one platform at a time, while the Mutated
+// Corruption on each platform is tanked. Once all four limbs are down, Deathwing's head becomes
+// attackable, Corrupted Blood ramps up as his health drops, and Elementium Terrors have to be
+// killed as they spawn. The limb's AI drives the phases for the whole encounter.
+type MadnessAI struct {
+	// Unit references
+	Target     *core.Target
+	Corruption *MadnessAI
+	Deathwing  *MadnessAI
+	Terror     *MadnessAI
+
+	// Static parameters associated with a given preset
+	raidSize int32
+	role     madnessRole
+
+	// Dynamic parameters taken from user inputs
+	limbKillTime   time.Duration
+	travelTime     time.Duration
+	terrorKillTime time.Duration
+
+	// State
+	phase2StartedAt time.Duration
+
+	// Spell + aura references
+	untargetableAura *core.Aura
+	elementiumBolt   *core.Spell
+	impale           *core.Spell
+	corruptedBlood   *core.Spell
+	tetanus          core.AuraArray
+}
+
+func (ai *MadnessAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+
+	if ai.role == madnessLimb {
+		ai.limbKillTime = core.DurationFromSeconds(config.TargetInputs[0].NumberValue)
+		ai.travelTime = core.DurationFromSeconds(config.TargetInputs[1].NumberValue)
+		ai.terrorKillTime = core.DurationFromSeconds(config.TargetInputs[2].NumberValue)
+	}
+
+	ai.untargetableAura = target.RegisterUntargetableAura(core.Aura{
+		Label:    "Out of Reach",
+		Duration: core.NeverExpires,
+	})
+
+	switch ai.role {
+	case madnessLimb:
+		ai.registerElementiumBolt()
+	case madnessCorruption:
+		ai.registerImpale()
+	case madnessDeathwing:
+		ai.registerCorruptedBlood()
+	case madnessTerror:
+		ai.registerTetanus()
+	}
+}
+
+func (ai *MadnessAI) Reset(sim *core.Simulation) {
+	// Randomize GCD and swing timings to prevent fake APL-Haste couplings.
+	ai.Target.ExtendGCDUntil(sim, core.DurationFromSeconds(sim.RandomFloat("Specials Timing")*core.BossGCD.Seconds()))
+	ai.Target.AutoAttacks.RandomizeMeleeTiming(sim)
+
+	switch ai.role {
+	case madnessLimb:
+		ai.Corruption = ai.findPart(sim, madnessCorruption)
+		ai.Deathwing = ai.findPart(sim, madnessDeathwing)
+		ai.Terror = ai.findPart(sim, madnessTerror)
+
+		// Whoever is assigned to the Elementium Terrors helps out on the limbs until phase 2.
+		if ai.Terror != nil {
+			core.StartDelayedAction(sim, core.DelayedActionOptions{
+				DoAt:     sim.CurrentTime,
+				Priority: core.ActionPriorityDOT,
+
+				OnAction: func(sim *core.Simulation) {
+					retarget(sim, &ai.Terror.Target.Unit, &ai.Target.Unit)
+				},
+			})
+		}
+
+		ai.startPlatform(sim, 1)
+	case madnessCorruption:
+		ai.impale.CD.Set(time.Second * 20)
+	case madnessDeathwing, madnessTerror:
+		ai.untargetableAura.Activate(sim)
+	}
+}
+
+func (ai *MadnessAI) findPart(sim *core.Simulation, role madnessRole) *MadnessAI {
+	for _, target := range sim.Encounter.Targets {
+		if madnessAI, ok := target.AI.(*MadnessAI); ok && madnessAI.role == role {
+			return madnessAI
+		}
+	}
+	return nil
+}
+
+func (ai *MadnessAI) startPlatform(sim *core.Simulation, platform int32) {
+	ai.untargetableAura.Deactivate(sim)
+
+	if ai.Corruption != nil {
+		ai.Corruption.untargetableAura.Deactivate(sim)
+		ai.Corruption.impale.CD.Set(sim.CurrentTime + time.Second*20)
+	}
+
+	ai.elementiumBolt.CD.Set(sim.CurrentTime + time.Second*30)
+
+	core.StartDelayedAction(sim, core.DelayedActionOptions{
+		DoAt:     sim.CurrentTime + ai.limbKillTime,
+		Priority: core.ActionPriorityDOT,
+
+		OnAction: func(sim *core.Simulation) {
+			ai.endPlatform(sim, platform)
+		},
+	})
+}
+
+func (ai *MadnessAI) endPlatform(sim *core.Simulation, platform int32) {
+	ai.untargetableAura.Activate(sim)
+
+	if ai.Corruption != nil {
+		ai.Corruption.untargetableAura.Activate(sim)
+	}
+
+	if platform == madnessNumLimbs {
+		ai.startPhase2(sim)
+		return
+	}
+
+	sim.Raid.MoveAllPlayers(sim, ai.travelTime)
+
+	core.StartDelayedAction(sim, core.DelayedActionOptions{
+		DoAt:     sim.CurrentTime + ai.travelTime,
+		Priority: core.ActionPriorityDOT,
+
+		OnAction: func(sim *core.Simulation) {
+			ai.startPlatform(sim, platform+1)
+		},
+	})
+}
+
+func (ai *MadnessAI) startPhase2(sim *core.Simulation) {
+	if ai.Deathwing == nil {
+		return
+	}
+
+	deathwingUnit := &ai.Deathwing.Target.Unit
+	ai.Deathwing.phase2StartedAt = sim.CurrentTime
+	ai.Deathwing.untargetableAura.Deactivate(sim)
+
+	core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+		Period:   time.Second,
+		Priority: core.ActionPriorityDOT,
+
+		OnAction: func(sim *core.Simulation) {
+			ai.Deathwing.corruptedBlood.SkipCastAndApplyEffects(sim, sim.Raid.AllPlayerUnits[0])
+		},
+	})
+
+	retarget(sim, &ai.Target.Unit, deathwingUnit)
+
+	if ai.Corruption != nil {
+		retarget(sim, &ai.Corruption.Target.Unit, deathwingUnit)
+	}
+
+	if (ai.Terror == nil) || (ai.terrorKillTime <= 0) {
+		return
+	}
+
+	// Elementium Terrors emerge from the Elementium Fragments every 90 seconds, with the first
+	// pair showing up shortly after the phase starts.
+	terrorUnit := &ai.Terror.Target.Unit
+	spawnTerrors := func(sim *core.Simulation) {
+		ai.Terror.untargetableAura.Deactivate(sim)
+		retarget(sim, deathwingUnit, terrorUnit)
+
+		if terrorTank := ai.Terror.Target.CurrentTarget; terrorTank != nil {
+			terrorTank.CurrentTarget = terrorUnit
+		}
+
+		core.StartDelayedAction(sim, core.DelayedActionOptions{
+			DoAt:     sim.CurrentTime + ai.terrorKillTime,
+			Priority: core.ActionPriorityDOT,
+
+			OnAction: func(sim *core.Simulation) {
+				ai.Terror.untargetableAura.Activate(sim)
+				retarget(sim, terrorUnit, deathwingUnit)
+			},
+		})
+	}
+
+	core.StartDelayedAction(sim, core.DelayedActionOptions{
+		DoAt:     sim.CurrentTime + time.Second*35,
+		Priority: core.ActionPriorityDOT,
+
+		OnAction: func(sim *core.Simulation) {
+			spawnTerrors(sim)
+
+			core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:   madnessTerrorInterval,
+				Priority: core.ActionPriorityDOT,
+
+				OnAction: spawnTerrors,
+			})
+		},
+	})
+}
+
+func (ai *MadnessAI) registerElementiumBolt() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	elementiumBoltBase := []float64{120000, 150000}[scalingIndex]
+
+	// The raid runs into Nozdormu's Time Zone to slow down each Elementium Bolt and soak its
+	// reduced blast.
+	ai.elementiumBolt = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 105651},
+		SpellSchool:      core.SpellSchoolFire,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Minute * 5,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			sim.Raid.MoveAllPlayers(sim, time.Second*3)
+
+			for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+				spell.CalcAndDealDamage(sim, aoeTarget, elementiumBoltBase, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+
+func (ai *MadnessAI) registerImpale() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	impaleBase := []float64{400000, 500000}[scalingIndex]
+
+	// Impale is a huge physical hit that the tanks have to cover with cooldowns.
+	ai.impale = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 106400},
+		SpellSchool:      core.SpellSchoolPhysical,
+		ProcMask:         core.ProcMaskMeleeMHSpecial,
+		Flags:            core.SpellFlagMeleeMetrics,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 35,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealDamage(sim, target, impaleBase, spell.OutcomeEnemyMeleeWhite)
+		},
+	})
+}
+
+func (ai *MadnessAI) registerCorruptedBlood() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	corruptedBloodBase := []float64{4000, 5000}[scalingIndex]
+
+	// Corrupted Blood pulses on the raid every second in phase 2 and grows as Deathwing's
+	// health drops, reaching ten times its initial damage by the end of the fight.
+	ai.corruptedBlood = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 106834},
+		SpellSchool:      core.SpellSchoolShadow,
+		ProcMask:         core.ProcMaskEmpty,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			phase2Duration := sim.Duration - ai.phase2StartedAt
+			progress := core.TernaryFloat64(phase2Duration > 0, (sim.CurrentTime-ai.phase2StartedAt).Seconds()/phase2Duration.Seconds(), 1)
+			damage := corruptedBloodBase * (1 + 9*min(progress, 1))
+
+			for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+				spell.CalcAndDealDamage(sim, aoeTarget, damage, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+
+func (ai *MadnessAI) registerTetanus() {
+	// Every Elementium Terror melee hit adds a stack of Tetanus to its tank, increasing the
+	// physical damage they take by 10% per stack.
+	ai.tetanus = ai.Target.NewAllyAuraArray(func(unit *core.Unit) *core.Aura {
+		if unit.Type != core.PlayerUnit {
+			return nil
+		}
+
+		return unit.GetOrRegisterAura(core.Aura{
+			Label:     "Tetanus",
+			ActionID:  core.ActionID{SpellID: 106728},
+			Duration:  time.Second * 6,
+			MaxStacks: 100,
+
+			OnStacksChange: func(aura *core.Aura, sim *core.Simulation, oldStacks int32, newStacks int32) {
+				aura.Unit.PseudoStats.SchoolDamageTakenMultiplier[stats.SchoolIndexPhysical] *= (1.0 + 0.1*float64(newStacks)) / (1.0 + 0.1*float64(oldStacks))
+			},
+		})
+	})
+
+	core.MakePermanent(ai.Target.RegisterAura(core.Aura{
+		Label: "Tetanus Trigger",
+
+		OnSpellHitDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if !spell.ProcMask.Matches(core.ProcMaskMeleeMHAuto) || !result.Landed() {
+				return
+			}
+
+			if debuff := ai.tetanus.Get(result.Target); debuff != nil {
+				debuff.Activate(sim)
+				debuff.AddStack(sim)
+			}
+		},
+	}))
+}
+
+func (ai *MadnessAI) ExecuteCustomRotation(sim *core.Simulation) {
+	target := ai.Target.CurrentTarget
+	if target == nil {
+		// For individual non tank sims we still want abilities to work
+		target = &ai.Target.Env.Raid.Parties[0].Players[0].GetCharacter().Unit
+	}
+
+	if ai.untargetableAura.IsActive() {
+		ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+		return
+	}
+
+	switch ai.role {
+	case madnessLimb:
+		if ai.elementiumBolt.IsReady(sim) {
+			ai.elementiumBolt.Cast(sim, target)
+		}
+	case madnessCorruption:
+		if (ai.Target.CurrentTarget != nil) && ai.impale.IsReady(sim) {
+			ai.impale.Cast(sim, target)
+			return
+		}
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
@@ -0,0 +1,384 @@
+package dragonsoul
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func addMorchok(raidPrefix string) {
+	createMorchokHeroicPreset(raidPrefix, 25, 55265, 57773, 51_014_000, 240_000)
+}
+
+func createMorchokHeroicPreset(raidPrefix string, raidSize int32, bossNpcId int32, addNpcId int32, bossHealth float64, bossMinBaseDamage float64) {
+	bossName := fmt.Sprintf("Morchok %d H", raidSize)
+	addName := fmt.Sprintf("Kohcrom %d H", raidSize)
+
+	// On Heroic, Morchok splits off Kohcrom with half of his health, so both are modeled with
+	// the same stats and each is tanked separately.
+	for _, unit := range []struct {
+		npcId     int32
+		name      string
+		tankIndex int32
+		isBoss    bool
+	}{
+		{bossNpcId, bossName, 0, true},
+		{addNpcId, addName, 1, false},
+	} {
+		core.AddPresetTarget(&core.PresetTarget{
+			PathPrefix: raidPrefix,
+
+			Config: &proto.Target{
+				Id:        unit.npcId,
+				Name:      unit.name,
+				Level:     88,
+				MobType:   proto.MobType_MobTypeElemental,
+				TankIndex: unit.tankIndex,
+
+				Stats: stats.Stats{
+					stats.Health:      bossHealth,
+					stats.Armor:       11977,
+					stats.AttackPower: 0, // actual value doesn't matter in Cata, as long as damage parameters are fit consistently
+				}.ToProtoArray(),
+
+				SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+				SwingSpeed:    2.0,
+				MinBaseDamage: bossMinBaseDamage,
+				DamageSpread:  0.4,
+				TargetInputs:  core.Ternary(unit.isBoss, morchokTargetInputs(), []*proto.TargetInput{}),
+			},
+
+			AI: makeMorchokAI(raidSize, unit.isBoss),
+		})
+	}
+
+	core.AddPresetEncounter(bossName, []string{
+		raidPrefix + "/" + bossName,
+		raidPrefix + "/" + addName,
+	})
+}
+
+func morchokTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:       "Kohcrom summon time",
+			Tooltip:     "Simulation time (in seconds) at which Morchok reaches 90% health and splits off Kohcrom",
+			InputType:   proto.InputType_Number,
+			NumberValue: 25,
+		},
+		{
+			Label:     "Attack Kohcrom",
+			Tooltip:   "Whether the raid switches over to Kohcrom once he is summoned, instead of staying on Morchok",
+			InputType: proto.InputType_Bool,
+			BoolValue: false,
+		},
+		{
+			Label:       "Black Blood interval",
+			Tooltip:     "Elapsed time (in seconds) between Earthen Vortex casts, each followed by Black Blood of the Earth",
+			InputType:   proto.InputType_Number,
+			NumberValue: 90,
+		},
+	}
+}
+
+func makeMorchokAI(raidSize int32, isBoss bool) core.AIFactory {
+	return func() core.TargetAI {
+		return &MorchokAI{
+			raidSize: raidSize,
+			isBoss:   isBoss,
+		}
+	}
+}
+
+// Morchok and Kohcrom share the same ability set. Morchok's AI also drives the split and the
+// Black Blood of the Earth phases, during which both of them pull the raid in and then force it
+// to hide behind the Resonating Crystals.
+type MorchokAI struct {
+	// Unit references
+	Target  *core.Target
+	Kohcrom *MorchokAI
+
+	// Static parameters associated with a given preset
+	raidSize int32
+	isBoss   bool
+
+	// Dynamic parameters taken from user inputs
+	summonKohcromAt    time.Duration
+	attackKohcrom      bool
+	blackBloodInterval time.Duration
+
+	// Spell + aura references
+	notSummonedAura   *core.Aura
+	blackBloodAura    *core.Aura
+	furious           *core.Aura
+	stomp             *core.Spell
+	resonatingCrystal *core.Spell
+}
+
+func (ai *MorchokAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+
+	if ai.isBoss {
+		ai.summonKohcromAt = core.DurationFromSeconds(config.TargetInputs[0].NumberValue)
+		ai.attackKohcrom = config.TargetInputs[1].BoolValue
+		ai.blackBloodInterval = core.DurationFromSeconds(config.TargetInputs[2].NumberValue)
+	} else {
+		ai.notSummonedAura = target.RegisterUntargetableAura(core.Aura{
+			Label:    "Not Summoned",
+			Duration: core.NeverExpires,
+		})
+	}
+
+	ai.registerBlackBlood()
+	ai.registerFurious()
+	ai.registerStomp()
+	ai.registerResonatingCrystal()
+}
+
+func (ai *MorchokAI) Reset(sim *core.Simulation) {
+	// Randomize GCD and swing timings to prevent fake APL-Haste couplings.
+	ai.Target.ExtendGCDUntil(sim, core.DurationFromSeconds(sim.RandomFloat("Specials Timing")*core.BossGCD.Seconds()))
+	ai.Target.AutoAttacks.RandomizeMeleeTiming(sim)
+
+	ai.stomp.CD.Set(time.Second * 12)
+	ai.resonatingCrystal.CD.Set(time.Second * 19)
+
+	if !ai.isBoss {
+		ai.notSummonedAura.Activate(sim)
+		return
+	}
+
+	ai.Kohcrom = nil
+	for _, target := range sim.Encounter.Targets {
+		if morchokAI, ok := target.AI.(*MorchokAI); ok && !morchokAI.isBoss {
+			ai.Kohcrom = morchokAI
+		}
+	}
+
+	if ai.Kohcrom != nil {
+		ai.scheduleSplit(sim)
+	}
+
+	if ai.blackBloodInterval > 0 {
+		core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+			Period:   ai.blackBloodInterval,
+			Priority: core.ActionPriorityDOT,
+
+			OnAction: func(sim *core.Simulation) {
+				ai.blackBloodAura.Activate(sim)
+
+				if (ai.Kohcrom != nil) && !ai.Kohcrom.notSummonedAura.IsActive() {
+					ai.Kohcrom.blackBloodAura.Activate(sim)
+				}
+			},
+		})
+	}
+}
+
+func (ai *MorchokAI) scheduleSplit(sim *core.Simulation) {
+	kohcromUnit := &ai.Kohcrom.Target.Unit
+
+	// Whoever is assigned to Kohcrom helps out on Morchok until he shows up.
+	core.StartDelayedAction(sim, core.DelayedActionOptions{
+		DoAt:     sim.CurrentTime,
+		Priority: core.ActionPriorityDOT,
+
+		OnAction: func(sim *core.Simulation) {
+			retarget(sim, kohcromUnit, &ai.Target.Unit)
+		},
+	})
+
+	core.StartDelayedAction(sim, core.DelayedActionOptions{
+		DoAt:     ai.summonKohcromAt,
+		Priority: core.ActionPriorityDOT,
+
+		OnAction: func(sim *core.Simulation) {
+			ai.Kohcrom.notSummonedAura.Deactivate(sim)
+
+			if ai.attackKohcrom {
+				retarget(sim, &ai.Target.Unit, kohcromUnit)
+			}
+
+			// The tanks stay on their own targets either way.
+			if bossTank := ai.Target.CurrentTarget; bossTank != nil {
+				bossTank.CurrentTarget = &ai.Target.Unit
+			}
+
+			if addTank := ai.Kohcrom.Target.CurrentTarget; addTank != nil {
+				addTank.CurrentTarget = kohcromUnit
+			}
+		},
+	})
+}
+
+func (ai *MorchokAI) registerBlackBlood() {
+	earthenVortexDuration := time.Second * 5
+	blackBloodDuration := time.Second * 17
+
+	// Earthen Vortex pulls everyone in and stuns them, then the raid has to run behind the
+	// Resonating Crystals to hide from Black Blood of the Earth. Morchok doesn't melee until
+	// it is over.
+	ai.blackBloodAura = ai.Target.RegisterAura(core.Aura{
+		Label:    "Black Blood of the Earth",
+		ActionID: core.ActionID{SpellID: 103851},
+		Duration: earthenVortexDuration + blackBloodDuration,
+
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			ai.Target.AutoAttacks.CancelAutoSwing(sim)
+
+			if ai.isBoss {
+				sim.Raid.MoveAllPlayers(sim, earthenVortexDuration)
+
+				core.StartDelayedAction(sim, core.DelayedActionOptions{
+					DoAt:     sim.CurrentTime + earthenVortexDuration,
+					Priority: core.ActionPriorityDOT,
+
+					OnAction: func(sim *core.Simulation) {
+						sim.Raid.MoveAllPlayers(sim, time.Second*3)
+					},
+				})
+			}
+		},
+
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			ai.stomp.CD.Set(sim.CurrentTime + time.Second*5)
+			ai.resonatingCrystal.CD.Set(sim.CurrentTime + time.Second*10)
+
+			if ai.Target.CurrentTarget != nil && sim.CurrentTime < sim.Duration {
+				ai.Target.AutoAttacks.EnableAutoSwing(sim)
+				ai.Target.AutoAttacks.RandomizeMeleeTiming(sim)
+			}
+		},
+	})
+}
+
+func (ai *MorchokAI) registerFurious() {
+	// Below 20% health Morchok and Kohcrom attack 30% faster.
+	ai.furious = ai.Target.RegisterAura(core.Aura{
+		Label:    "Furious",
+		ActionID: core.ActionID{SpellID: 103846},
+		Duration: core.NeverExpires,
+
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			aura.Unit.MultiplyAttackSpeed(sim, 1.3)
+		},
+
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			aura.Unit.MultiplyAttackSpeed(sim, 1/1.3)
+		},
+	})
+}
+
+func (ai *MorchokAI) registerStomp() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	stompTotal := []float64{1_200_000, 3_000_000}[scalingIndex]
+
+	// Stomp is split between everyone within range, with the two closest players (the tanks)
+	// taking a double share.
+	ai.stomp = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 103414},
+		SpellSchool:      core.SpellSchoolPhysical,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 12,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			share := stompTotal / float64(ai.raidSize+2)
+
+			for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+				isTank := (aoeTarget == ai.Target.CurrentTarget) || ((ai.Kohcrom != nil) && (aoeTarget == ai.Kohcrom.Target.CurrentTarget))
+				spell.CalcAndDealDamage(sim, aoeTarget, core.TernaryFloat64(isTank, 2*share, share), spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+
+func (ai *MorchokAI) registerResonatingCrystal() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	crystalBase := []float64{140000, 160000}[scalingIndex]
+	numSoakers := core.TernaryInt(ai.raidSize == 10, 3, 6)
+
+	// The crystal explodes after 12 seconds, hitting the players who moved out to soak it.
+	ai.resonatingCrystal = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 103640},
+		SpellSchool:      core.SpellSchoolShadow,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 30,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			core.StartDelayedAction(sim, core.DelayedActionOptions{
+				DoAt:     sim.CurrentTime + time.Second*12,
+				Priority: core.ActionPriorityDOT,
+
+				OnAction: func(sim *core.Simulation) {
+					players := sim.Raid.AllPlayerUnits
+					for range min(len(players), numSoakers) {
+						target := players[int(sim.RandomFloat("Resonating Crystal Target")*float64(len(players)))]
+						spell.CalcAndDealDamage(sim, target, crystalBase, spell.OutcomeAlwaysHit)
+					}
+				},
+			})
+		},
+	})
+}
+
+func (ai *MorchokAI) ExecuteCustomRotation(sim *core.Simulation) {
+	target := ai.Target.CurrentTarget
+	if target == nil {
+		// For individual non tank sims we still want abilities to work
+		target = &ai.Target.Env.Raid.Parties[0].Players[0].GetCharacter().Unit
+	}
+
+	if !ai.furious.IsActive() && sim.IsExecutePhase20() {
+		ai.furious.Activate(sim)
+	}
+
+	if ((ai.notSummonedAura != nil) && ai.notSummonedAura.IsActive()) || ai.blackBloodAura.IsActive() {
+		ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+		return
+	}
+
+	if ai.stomp.IsReady(sim) {
+		ai.stomp.Cast(sim, target)
+		return
+	}
+
+	if ai.resonatingCrystal.IsReady(sim) && sim.Proc(0.75, "Resonating Crystal AI") {
+		ai.resonatingCrystal.Cast(sim, target)
+		return
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
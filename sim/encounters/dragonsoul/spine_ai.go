@@ -0,0 +1,362 @@
+package dragonsoul
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func addSpine(raidPrefix string) {
+	createSpineHeroicPreset(raidPrefix, 25, 56162, 56161, 56341, 7_770_000, 3_400_000, 2_410_000, 190_000)
+}
+
+func createSpineHeroicPreset(raidPrefix string, raidSize int32, amalgamationNpcId int32, corruptionNpcId int32, tendonsNpcId int32, amalgamationHealth float64, corruptionHealth float64, tendonsHealth float64, amalgamationMinBaseDamage float64) {
+	suffix := fmt.Sprintf(" %d H", raidSize)
+	encounterName := "Spine of Deathwing" + suffix
+	var targetPaths []string
+
+	for _, unit := range []struct {
+		npcId   int32
+		name    string
+		health  float64
+		role    spineRole
+		mobType proto.MobType
+	}{
+		{amalgamationNpcId, "Hideous Amalgamation", amalgamationHealth, spineAmalgamation, proto.MobType_MobTypeElemental},
+		{corruptionNpcId, "Corruption", corruptionHealth, spineCorruption, proto.MobType_MobTypeUnknown},
+		{tendonsNpcId, "Burning Tendons", tendonsHealth, spineTendons, proto.MobType_MobTypeDragonkin},
+	} {
+		targetName := unit.name + suffix
+		targetPaths = append(targetPaths, raidPrefix+"/"+targetName)
+
+		core.AddPresetTarget(&core.PresetTarget{
+			PathPrefix: raidPrefix,
+
+			Config: &proto.Target{
+				Id:      unit.npcId,
+				Name:    targetName,
+				Level:   88,
+				MobType: unit.mobType,
+
+				// Only the Hideous Amalgamations are tanked.
+				TankIndex: core.TernaryInt32(unit.role == spineAmalgamation, 0, 2),
+
+				Stats: stats.Stats{
+					stats.Health:      unit.health,
+					stats.Armor:       11977,
+					stats.AttackPower: 0, // actual value doesn't matter in Cata, as long as damage parameters are fit consistently
+				}.ToProtoArray(),
+
+				SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+				SwingSpeed:    core.TernaryFloat64(unit.role == spineAmalgamation, 2.0, 0),
+				MinBaseDamage: core.TernaryFloat64(unit.role == spineAmalgamation, amalgamationMinBaseDamage, 0),
+				DamageSpread:  0.4,
+				TargetInputs:  core.Ternary(unit.role == spineAmalgamation, spineTargetInputs(), []*proto.TargetInput{}),
+			},
+
+			AI: makeSpineAI(raidSize, unit.role),
+		})
+	}
+
+	core.AddPresetEncounter(encounterName, targetPaths)
+}
+
+func spineTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:       "Amalgamation lifetime",
+			Tooltip:     "Seconds each Hideous Amalgamation lives while absorbing Corrupted Blood, before it is killed next to a plate",
+			InputType:   proto.InputType_Number,
+			NumberValue: 40,
+		},
+		{
+			Label:       "Burning Tendons window",
+			Tooltip:     "Seconds the Burning Tendons stay exposed after each Nuclear Blast pries a plate loose",
+			InputType:   proto.InputType_Number,
+			NumberValue: 20,
+		},
+	}
+}
+
+type spineRole int32
+
+const (
+	spineAmalgamation spineRole = iota
+	spineCorruption
+	spineTendons
+)
+
+func makeSpineAI(raidSize int32, role spineRole) core.AIFactory {
+	return func() core.TargetAI {
+		return &SpineAI{
+			raidSize: raidSize,
+			role:     role,
+		}
+	}
+}
+
+// The raid repeatedly kills Hideous Amalgamations, which grow stronger with every Absorbed
+// Blood stack, next to one of Deathwing's armor plates. Each Nuclear Blast exposes the Burning
+// Tendons for a short window, during which the raid switches over to them. The Hideous
+// Amalgamation's AI drives the cycle for the whole encounter.
+type SpineAI struct {
+	// Unit references
+	Target  *core.Target
+	Tendons *SpineAI
+
+	// Static parameters associated with a given preset
+	raidSize int32
+	role     spineRole
+
+	// Dynamic parameters taken from user inputs
+	amalgamationLifetime time.Duration
+	tendonsWindow        time.Duration
+
+	// Spell + aura references
+	untargetableAura *core.Aura
+	absorbedBlood    *core.Aura
+	nuclearBlast     *core.Spell
+	searingPlasma    *core.Spell
+	fieryGrip        *core.Spell
+}
+
+func (ai *SpineAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+
+	switch ai.role {
+	case spineAmalgamation:
+		ai.amalgamationLifetime = core.DurationFromSeconds(config.TargetInputs[0].NumberValue)
+		ai.tendonsWindow = core.DurationFromSeconds(config.TargetInputs[1].NumberValue)
+
+		ai.untargetableAura = target.RegisterUntargetableAura(core.Aura{
+			Label:    "Not Spawned",
+			Duration: core.NeverExpires,
+		})
+
+		ai.registerAbsorbedBlood()
+		ai.registerNuclearBlast()
+	case spineCorruption:
+		ai.registerSearingPlasma()
+		ai.registerFieryGrip()
+	case spineTendons:
+		ai.untargetableAura = target.RegisterUntargetableAura(core.Aura{
+			Label:    "Armor Plate",
+			Duration: core.NeverExpires,
+		})
+	}
+}
+
+func (ai *SpineAI) Reset(sim *core.Simulation) {
+	// Randomize GCD and swing timings to prevent fake APL-Haste couplings.
+	ai.Target.ExtendGCDUntil(sim, core.DurationFromSeconds(sim.RandomFloat("Specials Timing")*core.BossGCD.Seconds()))
+	ai.Target.AutoAttacks.RandomizeMeleeTiming(sim)
+
+	switch ai.role {
+	case spineAmalgamation:
+		ai.Tendons = nil
+		for _, target := range sim.Encounter.Targets {
+			if spineAI, ok := target.AI.(*SpineAI); ok && spineAI.role == spineTendons {
+				ai.Tendons = spineAI
+			}
+		}
+
+		ai.spawnAmalgamation(sim)
+	case spineCorruption:
+		ai.searingPlasma.CD.Set(time.Second * 8)
+		ai.fieryGrip.CD.Set(time.Second * 15)
+	case spineTendons:
+		ai.untargetableAura.Activate(sim)
+	}
+}
+
+func (ai *SpineAI) spawnAmalgamation(sim *core.Simulation) {
+	ai.absorbedBlood.Activate(sim)
+	ai.absorbedBlood.SetStacks(sim, 0)
+
+	// A stack of Absorbed Blood for every Corrupted Blood residue it soaks up, reaching the
+	// maximum of 9 stacks right before it dies.
+	core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+		Period:   ai.amalgamationLifetime / 10,
+		NumTicks: 9,
+		Priority: core.ActionPriorityDOT,
+
+		OnAction: func(sim *core.Simulation) {
+			ai.absorbedBlood.AddStack(sim)
+		},
+	})
+
+	core.StartDelayedAction(sim, core.DelayedActionOptions{
+		DoAt:     sim.CurrentTime + ai.amalgamationLifetime,
+		Priority: core.ActionPriorityDOT,
+
+		OnAction: func(sim *core.Simulation) {
+			ai.nuclearBlast.SkipCastAndApplyEffects(sim, sim.Raid.AllPlayerUnits[0])
+			ai.exposeTendons(sim)
+		},
+	})
+}
+
+func (ai *SpineAI) exposeTendons(sim *core.Simulation) {
+	ai.absorbedBlood.Deactivate(sim)
+	ai.untargetableAura.Activate(sim)
+
+	if ai.Tendons != nil {
+		ai.Tendons.untargetableAura.Deactivate(sim)
+		retarget(sim, &ai.Target.Unit, &ai.Tendons.Target.Unit)
+	}
+
+	core.StartDelayedAction(sim, core.DelayedActionOptions{
+		DoAt:     sim.CurrentTime + ai.tendonsWindow,
+		Priority: core.ActionPriorityDOT,
+
+		OnAction: func(sim *core.Simulation) {
+			if ai.Tendons != nil {
+				ai.Tendons.untargetableAura.Activate(sim)
+				retarget(sim, &ai.Tendons.Target.Unit, &ai.Target.Unit)
+			}
+
+			ai.untargetableAura.Deactivate(sim)
+			ai.spawnAmalgamation(sim)
+		},
+	})
+}
+
+func (ai *SpineAI) registerAbsorbedBlood() {
+	ai.absorbedBlood = ai.Target.RegisterAura(core.Aura{
+		Label:     "Absorbed Blood",
+		ActionID:  core.ActionID{SpellID: 105248},
+		Duration:  core.NeverExpires,
+		MaxStacks: 9,
+
+		OnStacksChange: func(aura *core.Aura, sim *core.Simulation, oldStacks int32, newStacks int32) {
+			aura.Unit.PseudoStats.DamageDealtMultiplier *= (1.0 + 0.1*float64(newStacks)) / (1.0 + 0.1*float64(oldStacks))
+		},
+	})
+}
+
+func (ai *SpineAI) registerNuclearBlast() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	nuclearBlastBase := []float64{55000, 65000}[scalingIndex]
+
+	// The raid runs out of the Nuclear Blast, but still takes a hit from the Superheated Nucleus.
+	ai.nuclearBlast = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 105845},
+		SpellSchool:      core.SpellSchoolFire,
+		ProcMask:         core.ProcMaskEmpty,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+				spell.CalcAndDealDamage(sim, aoeTarget, nuclearBlastBase, spell.OutcomeAlwaysHit)
+			}
+
+			sim.Raid.MoveAllPlayers(sim, time.Second*3)
+		},
+	})
+}
+
+func (ai *SpineAI) registerSearingPlasma() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	searingPlasmaBase := []float64{200000, 280000}[scalingIndex]
+
+	// Searing Plasma is a large heal absorb, modeled as damage since it has to be healed through
+	// either way.
+	ai.searingPlasma = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 105479},
+		SpellSchool:      core.SpellSchoolFire,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 8,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			players := sim.Raid.AllPlayerUnits
+			target := players[int(sim.RandomFloat("Searing Plasma Target")*float64(len(players)))]
+			spell.CalcAndDealDamage(sim, target, searingPlasmaBase, spell.OutcomeAlwaysHit)
+		},
+	})
+}
+
+func (ai *SpineAI) registerFieryGrip() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	fieryGripTick := []float64{40000, 50000}[scalingIndex]
+
+	// Fiery Grip stuns a random player and burns them until the Corruption takes enough damage.
+	ai.fieryGrip = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 105490},
+		SpellSchool:      core.SpellSchoolFire,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 30,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			players := sim.Raid.AllPlayerUnits
+			target := players[int(sim.RandomFloat("Fiery Grip Target")*float64(len(players)))]
+			target.MoveDuration(time.Second*4, sim)
+
+			core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:   time.Second,
+				NumTicks: 4,
+				Priority: core.ActionPriorityDOT,
+
+				OnAction: func(sim *core.Simulation) {
+					spell.CalcAndDealDamage(sim, target, fieryGripTick, spell.OutcomeAlwaysHit)
+				},
+			})
+		},
+	})
+}
+
+func (ai *SpineAI) ExecuteCustomRotation(sim *core.Simulation) {
+	target := ai.Target.CurrentTarget
+	if target == nil {
+		// For individual non tank sims we still want abilities to work
+		target = &ai.Target.Env.Raid.Parties[0].Players[0].GetCharacter().Unit
+	}
+
+	if ai.role == spineCorruption {
+		if ai.searingPlasma.IsReady(sim) && sim.Proc(0.75, "Searing Plasma AI") {
+			ai.searingPlasma.Cast(sim, target)
+			return
+		}
+
+		if ai.fieryGrip.IsReady(sim) {
+			ai.fieryGrip.Cast(sim, target)
+			return
+		}
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
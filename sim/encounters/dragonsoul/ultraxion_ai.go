@@ -0,0 +1,262 @@
+package dragonsoul
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func addUltraxion(raidPrefix string) {
+	createUltraxionHeroicPreset(raidPrefix, 25, 55294, 158_950_000, 270_000)
+}
+
+func createUltraxionHeroicPreset(raidPrefix string, raidSize int32, bossNpcId int32, bossHealth float64, bossMinBaseDamage float64) {
+	bossName := fmt.Sprintf("Ultraxion %d H", raidSize)
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        bossNpcId,
+			Name:      bossName,
+			Level:     88,
+			MobType:   proto.MobType_MobTypeDragonkin,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      bossHealth,
+				stats.Armor:       11977,
+				stats.AttackPower: 0, // actual value doesn't matter in Cata, as long as damage parameters are fit consistently
+			}.ToProtoArray(),
+
+			SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:    2.0,
+			MinBaseDamage: bossMinBaseDamage,
+			DamageSpread:  0.4,
+			TargetInputs:  ultraxionTargetInputs(),
+		},
+
+		AI: makeUltraxionAI(raidSize),
+	})
+
+	core.AddPresetEncounter(bossName, []string{
+		raidPrefix + "/" + bossName,
+	})
+}
+
+func ultraxionTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:       "Heroic Will downtime",
+			Tooltip:     "Seconds each player spends unable to act while in Heroic Will to avoid Hour of Twilight or Fading Light",
+			InputType:   proto.InputType_Number,
+			NumberValue: 1.5,
+		},
+	}
+}
+
+func makeUltraxionAI(raidSize int32) core.AIFactory {
+	return func() core.TargetAI {
+		return &UltraxionAI{
+			raidSize: raidSize,
+		}
+	}
+}
+
+// Ultraxion is a pure burn fight. Twilight Instability hits the raid more and more often as
+// Unstable Monstrosity ramps up, and every Hour of Twilight and Fading Light costs the affected
+// players some casting time in Heroic Will.
+type UltraxionAI struct {
+	// Unit references
+	Target *core.Target
+
+	// Static parameters associated with a given preset
+	raidSize int32
+
+	// Dynamic parameters taken from user inputs
+	heroicWillDowntime time.Duration
+
+	// Spell + aura references
+	twilightInstability *core.Spell
+	hourOfTwilight      *core.Spell
+	fadingLight         *core.Spell
+}
+
+func (ai *UltraxionAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+
+	ai.heroicWillDowntime = core.DurationFromSeconds(config.TargetInputs[0].NumberValue)
+
+	ai.registerTwilightInstability()
+	ai.registerHourOfTwilight()
+	ai.registerFadingLight()
+}
+
+func (ai *UltraxionAI) Reset(sim *core.Simulation) {
+	// Randomize GCD and swing timings to prevent fake APL-Haste couplings.
+	ai.Target.ExtendGCDUntil(sim, core.DurationFromSeconds(sim.RandomFloat("Specials Timing")*core.BossGCD.Seconds()))
+	ai.Target.AutoAttacks.RandomizeMeleeTiming(sim)
+
+	ai.hourOfTwilight.CD.Set(time.Second * 45)
+	ai.fadingLight.CD.Set(time.Second * 10)
+
+	ai.scheduleTwilightInstability(sim)
+}
+
+// Unstable Monstrosity starts out firing Twilight Instability every 6 seconds and speeds up by
+// a second every minute, down to once per second.
+func (ai *UltraxionAI) scheduleTwilightInstability(sim *core.Simulation) {
+	period := max(time.Second*6-time.Second*time.Duration(sim.CurrentTime/time.Minute), time.Second)
+
+	core.StartDelayedAction(sim, core.DelayedActionOptions{
+		DoAt:     sim.CurrentTime + period,
+		Priority: core.ActionPriorityDOT,
+
+		OnAction: func(sim *core.Simulation) {
+			ai.twilightInstability.SkipCastAndApplyEffects(sim, sim.Raid.AllPlayerUnits[0])
+			ai.scheduleTwilightInstability(sim)
+		},
+	})
+}
+
+func (ai *UltraxionAI) heroicWill(sim *core.Simulation, unit *core.Unit) {
+	if ai.heroicWillDowntime > 0 {
+		unit.MoveDuration(ai.heroicWillDowntime, sim)
+	}
+}
+
+func (ai *UltraxionAI) registerTwilightInstability() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	twilightInstabilityTotal := []float64{500000, 1200000}[scalingIndex]
+
+	// Twilight Instability is split between a random player and everyone near them.
+	ai.twilightInstability = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 109176},
+		SpellSchool:      core.SpellSchoolShadow,
+		ProcMask:         core.ProcMaskEmpty,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			players := sim.Raid.AllPlayerUnits
+			target := players[int(sim.RandomFloat("Twilight Instability Target")*float64(len(players)))]
+			spell.CalcAndDealDamage(sim, target, twilightInstabilityTotal/float64(ai.raidSize)*2, spell.OutcomeAlwaysHit)
+		},
+	})
+}
+
+func (ai *UltraxionAI) registerHourOfTwilight() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	hourOfTwilightTotal := []float64{800000, 1800000}[scalingIndex]
+	numSoakers := core.TernaryInt(ai.raidSize == 10, 1, 3)
+
+	// Hour of Twilight is soaked by a few players, while everyone else uses Heroic Will to
+	// step out of the Twilight Realm just before it lands.
+	ai.hourOfTwilight = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 109417},
+		SpellSchool:      core.SpellSchoolShadow,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.BossGCD,
+				CastTime: time.Second * 5,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 45,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			// In individual sims the player is assumed to be one of the Heroic Will users.
+			players := sim.Raid.AllPlayerUnits
+			firstSoaker := core.TernaryInt(len(players) > numSoakers, len(players)-numSoakers, len(players))
+
+			for idx, player := range players {
+				if idx >= firstSoaker {
+					spell.CalcAndDealDamage(sim, player, hourOfTwilightTotal/float64(numSoakers), spell.OutcomeAlwaysHit)
+				} else {
+					ai.heroicWill(sim, player)
+				}
+			}
+		},
+	})
+}
+
+func (ai *UltraxionAI) registerFadingLight() {
+	numTargets := core.TernaryInt(ai.raidSize == 10, 1, 3)
+
+	// Fading Light marks the tank and a few random players, who have to use Heroic Will before
+	// it expires.
+	ai.fadingLight = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID: core.ActionID{SpellID: 109075},
+		ProcMask: core.ProcMaskEmpty,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 15,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, _ *core.Spell) {
+			marked := []*core.Unit{}
+			if ai.Target.CurrentTarget != nil {
+				marked = append(marked, ai.Target.CurrentTarget)
+			}
+
+			players := sim.Raid.AllPlayerUnits
+			for range min(len(players), numTargets) {
+				marked = append(marked, players[int(sim.RandomFloat("Fading Light Target")*float64(len(players)))])
+			}
+
+			core.StartDelayedAction(sim, core.DelayedActionOptions{
+				DoAt:     sim.CurrentTime + time.Second*5,
+				Priority: core.ActionPriorityDOT,
+
+				OnAction: func(sim *core.Simulation) {
+					for _, unit := range marked {
+						ai.heroicWill(sim, unit)
+					}
+				},
+			})
+		},
+	})
+}
+
+func (ai *UltraxionAI) ExecuteCustomRotation(sim *core.Simulation) {
+	target := ai.Target.CurrentTarget
+	if target == nil {
+		// For individual non tank sims we still want abilities to work
+		target = &ai.Target.Env.Raid.Parties[0].Players[0].GetCharacter().Unit
+	}
+
+	if ai.hourOfTwilight.IsReady(sim) {
+		ai.hourOfTwilight.Cast(sim, target)
+		return
+	}
+
+	if ai.fadingLight.IsReady(sim) && sim.Proc(0.75, "Fading Light AI") {
+		ai.fadingLight.Cast(sim, target)
+		return
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
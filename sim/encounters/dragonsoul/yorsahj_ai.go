@@ -0,0 +1,467 @@
+package dragonsoul
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func addYorsahj(raidPrefix string) {
+	createYorsahjHeroicPreset(raidPrefix, 25, 55312, 55865, 112_850_000, 230_000, 1_450_000)
+}
+
+func createYorsahjHeroicPreset(raidPrefix string, raidSize int32, bossNpcId int32, addNpcId int32, bossHealth float64, bossMinBaseDamage float64, addHealth float64) {
+	bossName := fmt.Sprintf("Yor'sahj the Unsleeping %d H", raidSize)
+	addName := fmt.Sprintf("Globule %d H", raidSize)
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        bossNpcId,
+			Name:      bossName,
+			Level:     88,
+			MobType:   proto.MobType_MobTypeUnknown,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      bossHealth,
+				stats.Armor:       11977,
+				stats.AttackPower: 0, // actual value doesn't matter in Cata, as long as damage parameters are fit consistently
+			}.ToProtoArray(),
+
+			SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:    2.0,
+			MinBaseDamage: bossMinBaseDamage,
+			DamageSpread:  0.4,
+			TargetInputs:  yorsahjTargetInputs(),
+		},
+
+		AI: makeYorsahjAI(raidSize),
+	})
+
+	// The globules never melee and are only attackable while they crawl towards Yor'sahj, so
+	// they are modeled as a single untanked add.
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        addNpcId,
+			Name:      addName,
+			Level:     87,
+			MobType:   proto.MobType_MobTypeUnknown,
+			TankIndex: 2,
+
+			Stats: stats.Stats{
+				stats.Health:      addHealth,
+				stats.Armor:       11977,
+				stats.AttackPower: 0,
+			}.ToProtoArray(),
+
+			TargetInputs: []*proto.TargetInput{},
+		},
+
+		AI: makeGlobuleAI(),
+	})
+
+	core.AddPresetEncounter(bossName, []string{
+		raidPrefix + "/" + bossName,
+		raidPrefix + "/" + addName,
+	})
+}
+
+type yorsahjOoze int32
+
+const (
+	yorsahjShadowed yorsahjOoze = 1 << iota // Black, Forgotten Ones
+	yorsahjCobalt                           // Blue, Mana Void
+	yorsahjCrimson                          // Red, Searing Blood
+	yorsahjAcidic                           // Green, Digestive Acid
+	yorsahjGlowing                          // Yellow, faster abilities
+	yorsahjDark                             // Purple, Deep Corruption
+)
+
+// Each heroic spawn summons four of the six globules.
+var yorsahjOozeCombinations = []yorsahjOoze{
+	yorsahjDark | yorsahjAcidic | yorsahjShadowed | yorsahjCobalt,
+	yorsahjDark | yorsahjGlowing | yorsahjShadowed | yorsahjCobalt,
+	yorsahjCrimson | yorsahjAcidic | yorsahjShadowed | yorsahjCobalt,
+	yorsahjDark | yorsahjCrimson | yorsahjAcidic | yorsahjGlowing,
+	yorsahjCrimson | yorsahjGlowing | yorsahjShadowed | yorsahjCobalt,
+	yorsahjAcidic | yorsahjGlowing | yorsahjCrimson | yorsahjShadowed,
+}
+
+// The order in which the raid picks the globule to kill, for each kill priority option.
+var yorsahjKillPriorities = [][]yorsahjOoze{
+	{yorsahjDark, yorsahjCobalt, yorsahjGlowing, yorsahjAcidic, yorsahjCrimson, yorsahjShadowed},
+	{yorsahjGlowing, yorsahjDark, yorsahjCobalt, yorsahjAcidic, yorsahjCrimson, yorsahjShadowed},
+	{yorsahjShadowed, yorsahjDark, yorsahjCobalt, yorsahjGlowing, yorsahjAcidic, yorsahjCrimson},
+}
+
+func yorsahjTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:       "Globule spawn interval",
+			Tooltip:     "Elapsed time (in seconds) between Yor'sahj summoning globules",
+			InputType:   proto.InputType_Number,
+			NumberValue: 90,
+		},
+		{
+			Label:       "Globule kill time",
+			Tooltip:     "Seconds the raid spends attacking the globule it kills before going back to Yor'sahj",
+			InputType:   proto.InputType_Number,
+			NumberValue: 15,
+		},
+		{
+			Label:     "Kill priority",
+			Tooltip:   "Which globule the raid kills out of each spawn. The remaining ones reach Yor'sahj and empower him.",
+			InputType: proto.InputType_Enum,
+			EnumValue: 0,
+			EnumOptions: []string{
+				"Purple > Blue > Yellow > Green > Red > Black",
+				"Yellow > Purple > Blue > Green > Red > Black",
+				"Black > Purple > Blue > Yellow > Green > Red",
+			},
+		},
+	}
+}
+
+func makeYorsahjAI(raidSize int32) core.AIFactory {
+	return func() core.TargetAI {
+		return &YorsahjAI{
+			raidSize: raidSize,
+		}
+	}
+}
+
+// Yor'sahj periodically summons globules. The raid kills one of them, and every globule that
+// reaches him grants him its ability until the next spawn. The Cobalt globule's Mana Void and
+// the Shadowed globule's Forgotten Ones pull the raid off the boss for a few seconds.
+type YorsahjAI struct {
+	// Unit references
+	Target  *core.Target
+	Globule *GlobuleAI
+
+	// Static parameters associated with a given preset
+	raidSize int32
+
+	// Dynamic parameters taken from user inputs
+	spawnInterval time.Duration
+	killTime      time.Duration
+	killPriority  []yorsahjOoze
+
+	// State
+	empowerments yorsahjOoze
+
+	// Spell + aura references
+	voidBolt       *core.Spell
+	deepCorruption *core.Spell
+	digestiveAcid  *core.Spell
+	searingBlood   *core.Spell
+}
+
+func (ai *YorsahjAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+
+	ai.spawnInterval = core.DurationFromSeconds(config.TargetInputs[0].NumberValue)
+	ai.killTime = core.DurationFromSeconds(config.TargetInputs[1].NumberValue)
+	ai.killPriority = yorsahjKillPriorities[config.TargetInputs[2].EnumValue]
+
+	ai.registerVoidBolt()
+	ai.registerDeepCorruption()
+	ai.registerDigestiveAcid()
+	ai.registerSearingBlood()
+}
+
+func (ai *YorsahjAI) Reset(sim *core.Simulation) {
+	// Randomize GCD and swing timings to prevent fake APL-Haste couplings.
+	ai.Target.ExtendGCDUntil(sim, core.DurationFromSeconds(sim.RandomFloat("Specials Timing")*core.BossGCD.Seconds()))
+	ai.Target.AutoAttacks.RandomizeMeleeTiming(sim)
+
+	ai.empowerments = 0
+	ai.voidBolt.CD.Set(time.Second * 6)
+
+	ai.Globule = nil
+	for _, target := range sim.Encounter.Targets {
+		if globule, ok := target.AI.(*GlobuleAI); ok {
+			ai.Globule = globule
+		}
+	}
+
+	if ai.spawnInterval > 0 {
+		core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+			Period:   ai.spawnInterval,
+			Priority: core.ActionPriorityDOT,
+
+			OnAction: func(sim *core.Simulation) {
+				ai.summonGlobules(sim)
+			},
+		})
+	}
+}
+
+func (ai *YorsahjAI) summonGlobules(sim *core.Simulation) {
+	ai.empowerments = 0
+
+	spawned := yorsahjOozeCombinations[int(sim.RandomFloat("Globule Combination")*float64(len(yorsahjOozeCombinations)))]
+	for _, ooze := range ai.killPriority {
+		if spawned&ooze != 0 {
+			spawned &^= ooze
+			break
+		}
+	}
+
+	if ai.Globule != nil {
+		ai.Globule.switchTo(sim, &ai.Target.Unit, ai.killTime)
+	}
+
+	core.StartDelayedAction(sim, core.DelayedActionOptions{
+		DoAt:     sim.CurrentTime + ai.killTime,
+		Priority: core.ActionPriorityDOT,
+
+		OnAction: func(sim *core.Simulation) {
+			ai.empower(sim, spawned)
+		},
+	})
+}
+
+func (ai *YorsahjAI) empower(sim *core.Simulation, oozes yorsahjOoze) {
+	ai.empowerments = oozes
+
+	if oozes&yorsahjCobalt != 0 && ai.Globule != nil {
+		// The Mana Void forms shortly after and has to be killed before it drains the healers.
+		core.StartDelayedAction(sim, core.DelayedActionOptions{
+			DoAt:     sim.CurrentTime + time.Second*2,
+			Priority: core.ActionPriorityDOT,
+
+			OnAction: func(sim *core.Simulation) {
+				ai.Globule.switchTo(sim, &ai.Target.Unit, time.Second*5)
+			},
+		})
+	}
+
+	if oozes&yorsahjShadowed != 0 {
+		// The Forgotten Ones are cleaved down while they run to random players.
+		sim.Raid.MoveAllPlayers(sim, time.Second*2)
+	}
+
+	ai.deepCorruption.CD.Set(sim.CurrentTime + time.Second*3)
+	ai.digestiveAcid.CD.Set(sim.CurrentTime + time.Second*5)
+	ai.searingBlood.CD.Set(sim.CurrentTime + time.Second*5)
+}
+
+func (ai *YorsahjAI) isEmpowered(ooze yorsahjOoze) bool {
+	return ai.empowerments&ooze != 0
+}
+
+// With the Glowing globule's blessing, every ability comes 50% faster.
+func (ai *YorsahjAI) abilityCooldown(base time.Duration) time.Duration {
+	if ai.isEmpowered(yorsahjGlowing) {
+		return base * 2 / 3
+	}
+	return base
+}
+
+func (ai *YorsahjAI) registerVoidBolt() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	voidBoltBase := []float64{95000, 115000}[scalingIndex]
+
+	ai.voidBolt = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 104849},
+		SpellSchool:      core.SpellSchoolShadow,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 6,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealDamage(sim, target, voidBoltBase, spell.OutcomeAlwaysHit)
+			spell.CD.Set(sim.CurrentTime + ai.abilityCooldown(spell.CD.Duration))
+		},
+	})
+}
+
+func (ai *YorsahjAI) registerDeepCorruption() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	deepCorruptionBase := []float64{40000, 45000}[scalingIndex]
+
+	ai.deepCorruption = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 105171},
+		SpellSchool:      core.SpellSchoolShadow,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 10,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+				spell.CalcAndDealDamage(sim, aoeTarget, deepCorruptionBase, spell.OutcomeAlwaysHit)
+			}
+
+			spell.CD.Set(sim.CurrentTime + ai.abilityCooldown(spell.CD.Duration))
+		},
+	})
+}
+
+func (ai *YorsahjAI) registerDigestiveAcid() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	digestiveAcidBase := []float64{30000, 35000}[scalingIndex]
+
+	// Digestive Acid bounces between players, so the raid spreads out and keeps moving.
+	ai.digestiveAcid = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 105031},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 8,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+				spell.CalcAndDealDamage(sim, aoeTarget, digestiveAcidBase, spell.OutcomeAlwaysHit)
+			}
+
+			sim.Raid.MoveAllPlayers(sim, time.Second)
+			spell.CD.Set(sim.CurrentTime + ai.abilityCooldown(spell.CD.Duration))
+		},
+	})
+}
+
+func (ai *YorsahjAI) registerSearingBlood() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	searingBloodBase := []float64{60000, 70000}[scalingIndex]
+	numTargets := core.TernaryInt(ai.raidSize == 10, 3, 8)
+
+	ai.searingBlood = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 105033},
+		SpellSchool:      core.SpellSchoolFire,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 6,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			players := sim.Raid.AllPlayerUnits
+			for range min(len(players), numTargets) {
+				target := players[int(sim.RandomFloat("Searing Blood Target")*float64(len(players)))]
+				spell.CalcAndDealDamage(sim, target, searingBloodBase, spell.OutcomeAlwaysHit)
+			}
+
+			spell.CD.Set(sim.CurrentTime + ai.abilityCooldown(spell.CD.Duration))
+		},
+	})
+}
+
+func (ai *YorsahjAI) ExecuteCustomRotation(sim *core.Simulation) {
+	target := ai.Target.CurrentTarget
+	if target == nil {
+		// For individual non tank sims we still want abilities to work
+		target = &ai.Target.Env.Raid.Parties[0].Players[0].GetCharacter().Unit
+	}
+
+	if ai.isEmpowered(yorsahjDark) && ai.deepCorruption.IsReady(sim) {
+		ai.deepCorruption.Cast(sim, target)
+	}
+
+	if ai.isEmpowered(yorsahjAcidic) && ai.digestiveAcid.IsReady(sim) {
+		ai.digestiveAcid.Cast(sim, target)
+	}
+
+	if ai.isEmpowered(yorsahjCrimson) && ai.searingBlood.IsReady(sim) {
+		ai.searingBlood.Cast(sim, target)
+	}
+
+	if (ai.Target.CurrentTarget != nil) && ai.voidBolt.IsReady(sim) {
+		ai.voidBolt.Cast(sim, target)
+		return
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
+
+func makeGlobuleAI() core.AIFactory {
+	return func() core.TargetAI {
+		return &GlobuleAI{}
+	}
+}
+
+type GlobuleAI struct {
+	Target *core.Target
+
+	// Spell + aura references
+	notSummonedAura *core.Aura
+}
+
+func (ai *GlobuleAI) Initialize(target *core.Target, _ *proto.Target) {
+	ai.Target = target
+
+	ai.notSummonedAura = target.RegisterUntargetableAura(core.Aura{
+		Label:    "Not Summoned",
+		Duration: core.NeverExpires,
+	})
+}
+
+func (ai *GlobuleAI) Reset(sim *core.Simulation) {
+	ai.notSummonedAura.Activate(sim)
+}
+
+// Pulls everyone but the boss' tank onto the add for the given duration.
+func (ai *GlobuleAI) switchTo(sim *core.Simulation, boss *core.Unit, duration time.Duration) {
+	ai.notSummonedAura.Deactivate(sim)
+	retarget(sim, boss, &ai.Target.Unit)
+
+	if bossTank := boss.CurrentTarget; bossTank != nil {
+		bossTank.CurrentTarget = boss
+	}
+
+	core.StartDelayedAction(sim, core.DelayedActionOptions{
+		DoAt:     sim.CurrentTime + duration,
+		Priority: core.ActionPriorityDOT,
+
+		OnAction: func(sim *core.Simulation) {
+			ai.notSummonedAura.Activate(sim)
+			retarget(sim, &ai.Target.Unit, boss)
+		},
+	})
+}
+
+func (ai *GlobuleAI) ExecuteCustomRotation(sim *core.Simulation) {
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
@@ -0,0 +1,368 @@
+package dragonsoul
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func addZonozz(raidPrefix string) {
+	createZonozzHeroicPreset(raidPrefix, 25, 55308, 112_850_000, 260_000)
+}
+
+func createZonozzHeroicPreset(raidPrefix string, raidSize int32, bossNpcId int32, bossHealth float64, bossMinBaseDamage float64) {
+	bossName := fmt.Sprintf("Warlord Zon'ozz %d H", raidSize)
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        bossNpcId,
+			Name:      bossName,
+			Level:     88,
+			MobType:   proto.MobType_MobTypeHumanoid,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      bossHealth,
+				stats.Armor:       11977,
+				stats.AttackPower: 0, // actual value doesn't matter in Cata, as long as damage parameters are fit consistently
+			}.ToProtoArray(),
+
+			SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:    2.0,
+			MinBaseDamage: bossMinBaseDamage,
+			DamageSpread:  0.4,
+			TargetInputs:  zonozzTargetInputs(),
+		},
+
+		AI: makeZonozzAI(raidSize),
+	})
+
+	core.AddPresetEncounter(bossName, []string{
+		raidPrefix + "/" + bossName,
+	})
+}
+
+func zonozzTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:       "Void of the Unmaking interval",
+			Tooltip:     "Elapsed time (in seconds) between Void of the Unmaking casts",
+			InputType:   proto.InputType_Number,
+			NumberValue: 90,
+		},
+		{
+			Label:       "Void of the Unmaking bounces",
+			Tooltip:     "Number of times the raid bounces Void of the Unmaking before it hits Zon'ozz. Each bounce adds a stack of Void Diffusion, increasing the damage Zon'ozz takes during Black Blood of Go'rath by 5%.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 9,
+		},
+	}
+}
+
+func makeZonozzAI(raidSize int32) core.AIFactory {
+	return func() core.TargetAI {
+		return &ZonozzAI{
+			raidSize: raidSize,
+		}
+	}
+}
+
+const zonozzBounceInterval = time.Second * 3
+const zonozzBlackBloodDuration = time.Second * 30
+
+// Zon'ozz casts Void of the Unmaking on a timer. The raid bounces it a number of times and then
+// lets it hit him, which starts Black Blood of Go'rath: his Focused Anger resets, and he takes
+// extra damage for every bounce until the phase ends.
+type ZonozzAI struct {
+	// Unit references
+	Target *core.Target
+
+	// Static parameters associated with a given preset
+	raidSize int32
+
+	// Dynamic parameters taken from user inputs
+	voidInterval time.Duration
+	voidBounces  int32
+
+	// Spell + aura references
+	focusedAnger      *core.Aura
+	voidDiffusion     *core.Aura
+	blackBlood        *core.Aura
+	blackBloodTick    *core.Spell
+	voidBounce        *core.Spell
+	psychicDrain      *core.Spell
+	disruptingShadows *core.Spell
+}
+
+func (ai *ZonozzAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+
+	ai.voidInterval = core.DurationFromSeconds(config.TargetInputs[0].NumberValue)
+	ai.voidBounces = int32(config.TargetInputs[1].NumberValue)
+
+	ai.registerFocusedAnger()
+	ai.registerVoidOfTheUnmaking()
+	ai.registerBlackBlood()
+	ai.registerPsychicDrain()
+	ai.registerDisruptingShadows()
+}
+
+func (ai *ZonozzAI) Reset(sim *core.Simulation) {
+	// Randomize GCD and swing timings to prevent fake APL-Haste couplings.
+	ai.Target.ExtendGCDUntil(sim, core.DurationFromSeconds(sim.RandomFloat("Specials Timing")*core.BossGCD.Seconds()))
+	ai.Target.AutoAttacks.RandomizeMeleeTiming(sim)
+
+	ai.psychicDrain.CD.Set(time.Second * 13)
+	ai.disruptingShadows.CD.Set(time.Second * 25)
+
+	core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+		Period:   time.Second * 6,
+		Priority: core.ActionPriorityDOT,
+
+		OnAction: func(sim *core.Simulation) {
+			if !ai.blackBlood.IsActive() {
+				ai.focusedAnger.Activate(sim)
+				ai.focusedAnger.AddStack(sim)
+			}
+		},
+	})
+
+	if ai.voidInterval > 0 {
+		core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+			Period:   ai.voidInterval,
+			Priority: core.ActionPriorityDOT,
+
+			OnAction: func(sim *core.Simulation) {
+				ai.castVoidOfTheUnmaking(sim)
+			},
+		})
+	}
+}
+
+func (ai *ZonozzAI) registerFocusedAnger() {
+	// Zon'ozz deals 5% more damage for every 6 seconds he isn't hit by Void of the Unmaking.
+	ai.focusedAnger = ai.Target.RegisterAura(core.Aura{
+		Label:     "Focused Anger",
+		ActionID:  core.ActionID{SpellID: 104543},
+		Duration:  core.NeverExpires,
+		MaxStacks: 100,
+
+		OnStacksChange: func(aura *core.Aura, sim *core.Simulation, oldStacks int32, newStacks int32) {
+			aura.Unit.PseudoStats.DamageDealtMultiplier *= (1.0 + 0.05*float64(newStacks)) / (1.0 + 0.05*float64(oldStacks))
+		},
+	})
+}
+
+func (ai *ZonozzAI) registerVoidOfTheUnmaking() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	voidDiffusionBase := []float64{60000, 70000}[scalingIndex]
+
+	ai.voidDiffusion = ai.Target.RegisterAura(core.Aura{
+		Label:     "Void Diffusion",
+		ActionID:  core.ActionID{SpellID: 104031},
+		Duration:  zonozzBlackBloodDuration,
+		MaxStacks: 100,
+
+		OnStacksChange: func(aura *core.Aura, sim *core.Simulation, oldStacks int32, newStacks int32) {
+			aura.Unit.PseudoStats.DamageTakenMultiplier *= (1.0 + 0.05*float64(newStacks)) / (1.0 + 0.05*float64(oldStacks))
+		},
+	})
+
+	// Every bounce splashes the whole raid, and the splash grows with each bounce.
+	ai.voidBounce = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 104031}.WithTag(1),
+		SpellSchool:      core.SpellSchoolShadow,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+				spell.CalcAndDealDamage(sim, aoeTarget, voidDiffusionBase, spell.OutcomeAlwaysHit)
+			}
+
+			spell.DamageMultiplierAdditive += 0.1
+		},
+	})
+}
+
+func (ai *ZonozzAI) castVoidOfTheUnmaking(sim *core.Simulation) {
+	ai.voidBounce.DamageMultiplierAdditive = 1
+
+	core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+		Period:   zonozzBounceInterval,
+		NumTicks: int(ai.voidBounces),
+		Priority: core.ActionPriorityDOT,
+
+		OnAction: func(sim *core.Simulation) {
+			ai.voidBounce.SkipCastAndApplyEffects(sim, sim.Raid.AllPlayerUnits[0])
+		},
+	})
+
+	core.StartDelayedAction(sim, core.DelayedActionOptions{
+		DoAt:     sim.CurrentTime + zonozzBounceInterval*time.Duration(ai.voidBounces+1),
+		Priority: core.ActionPriorityDOT,
+
+		OnAction: func(sim *core.Simulation) {
+			ai.focusedAnger.Deactivate(sim)
+
+			if ai.voidBounces > 0 {
+				ai.voidDiffusion.Activate(sim)
+				ai.voidDiffusion.SetStacks(sim, ai.voidBounces)
+			}
+
+			ai.blackBlood.Activate(sim)
+		},
+	})
+}
+
+func (ai *ZonozzAI) registerBlackBlood() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	blackBloodTickBase := []float64{18000, 22000}[scalingIndex]
+
+	ai.blackBloodTick = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 104377},
+		SpellSchool:      core.SpellSchoolShadow,
+		ProcMask:         core.ProcMaskEmpty,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+				spell.CalcAndDealDamage(sim, aoeTarget, blackBloodTickBase, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+
+	// Zon'ozz channels Black Blood of Go'rath instead of meleeing, pulsing shadow damage on the
+	// raid every 2 seconds.
+	var tickAction *core.PendingAction
+
+	ai.blackBlood = ai.Target.RegisterAura(core.Aura{
+		Label:    "Black Blood of Go'rath",
+		ActionID: core.ActionID{SpellID: 104377},
+		Duration: zonozzBlackBloodDuration,
+
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			ai.Target.AutoAttacks.CancelAutoSwing(sim)
+
+			tickAction = core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:   time.Second * 2,
+				Priority: core.ActionPriorityDOT,
+
+				OnAction: func(sim *core.Simulation) {
+					ai.blackBloodTick.SkipCastAndApplyEffects(sim, sim.Raid.AllPlayerUnits[0])
+				},
+			})
+		},
+
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			tickAction.Cancel(sim)
+
+			if ai.Target.CurrentTarget != nil && sim.CurrentTime < sim.Duration {
+				ai.Target.AutoAttacks.EnableAutoSwing(sim)
+				ai.Target.AutoAttacks.RandomizeMeleeTiming(sim)
+			}
+		},
+	})
+}
+
+func (ai *ZonozzAI) registerPsychicDrain() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	psychicDrainBase := []float64{120000, 150000}[scalingIndex]
+
+	// Psychic Drain is a frontal cone that only the tank stands in.
+	ai.psychicDrain = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 104322},
+		SpellSchool:      core.SpellSchoolShadow,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 20,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealDamage(sim, target, psychicDrainBase, spell.OutcomeAlwaysHit)
+		},
+	})
+}
+
+func (ai *ZonozzAI) registerDisruptingShadows() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	disruptingShadowsBase := []float64{70000, 80000}[scalingIndex]
+	numTargets := core.TernaryInt(ai.raidSize == 10, 3, 8)
+
+	ai.disruptingShadows = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 103434},
+		SpellSchool:      core.SpellSchoolShadow,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 25,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			players := sim.Raid.AllPlayerUnits
+			for range min(len(players), numTargets) {
+				target := players[int(sim.RandomFloat("Disrupting Shadows Target")*float64(len(players)))]
+				spell.CalcAndDealDamage(sim, target, disruptingShadowsBase, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+
+func (ai *ZonozzAI) ExecuteCustomRotation(sim *core.Simulation) {
+	target := ai.Target.CurrentTarget
+	if target == nil {
+		// For individual non tank sims we still want abilities to work
+		target = &ai.Target.Env.Raid.Parties[0].Players[0].GetCharacter().Unit
+	}
+
+	if ai.blackBlood.IsActive() {
+		ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+		return
+	}
+
+	if (ai.Target.CurrentTarget != nil) && ai.psychicDrain.IsReady(sim) {
+		ai.psychicDrain.Cast(sim, target)
+		return
+	}
+
+	if ai.disruptingShadows.IsReady(sim) && sim.Proc(0.75, "Disrupting Shadows AI") {
+		ai.disruptingShadows.Cast(sim, target)
+		return
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
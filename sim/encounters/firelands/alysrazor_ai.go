@@ -0,0 +1,340 @@
+package firelands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func addAlysrazor(raidPrefix string) {
+	createAlysrazorPreset(raidPrefix, 25, false, 52530, 37_520_000, 80_000)
+	createAlysrazorPreset(raidPrefix, 25, true, 52630, 52_530_000, 112_000)
+}
+
+func createAlysrazorPreset(raidPrefix string, raidSize int32, isHeroic bool, bossNpcId int32, bossHealth float64, bossMinBaseDamage float64) {
+	targetName := fmt.Sprintf("Alysrazor %d", raidSize)
+
+	if isHeroic {
+		targetName += " H"
+	}
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        bossNpcId,
+			Name:      targetName,
+			Level:     88,
+			MobType:   proto.MobType_MobTypeBeast,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      bossHealth,
+				stats.Armor:       11977,
+				stats.AttackPower: 0, // actual value doesn't matter in Cata, as long as damage parameters are fit consistently
+			}.ToProtoArray(),
+
+			SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:    2.0,
+			MinBaseDamage: bossMinBaseDamage,
+			DamageSpread:  0.4,
+			TargetInputs:  alysrazorTargetInputs(),
+		},
+
+		AI: makeAlysrazorAI(raidSize, isHeroic),
+	})
+
+	core.AddPresetEncounter(targetName, []string{
+		raidPrefix + "/" + targetName,
+	})
+}
+
+func alysrazorTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:       "Flight phase duration",
+			Tooltip:     "Seconds Alysrazor spends circling the arena in each flight phase, during which she can't be attacked.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 165,
+		},
+		{
+			Label:       "Burnout duration",
+			Tooltip:     "Seconds Alysrazor spends grounded and out of energy after each Fiery Vortex.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 30,
+		},
+		{
+			Label:       "Re-ignition duration",
+			Tooltip:     "Seconds Alysrazor is tanked on the ground after re-igniting, before she takes off again.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 25,
+		},
+	}
+}
+
+func makeAlysrazorAI(raidSize int32, isHeroic bool) core.AIFactory {
+	return func() core.TargetAI {
+		return &AlysrazorAI{
+			raidSize: raidSize,
+			isHeroic: isHeroic,
+		}
+	}
+}
+
+type alysrazorStage int32
+
+const (
+	alysrazorFlight alysrazorStage = iota
+	alysrazorFieryVortex
+	alysrazorBurnout
+	alysrazorReignition
+)
+
+const alysrazorFieryVortexDuration = time.Second * 30
+
+// Alysrazor cycles between a long flight phase, the Fiery Vortex, and a short window on the
+// ground. She can only be attacked while she is on the ground.
+type AlysrazorAI struct {
+	// Unit references
+	Target *core.Target
+
+	// Static parameters associated with a given preset
+	raidSize int32
+	isHeroic bool
+
+	// Dynamic parameters taken from user inputs
+	flightDuration     time.Duration
+	burnoutDuration    time.Duration
+	reignitionDuration time.Duration
+
+	// State
+	stage alysrazorStage
+
+	// Spell + aura references
+	airborneAura *core.Aura
+	blazingClaw  *core.Spell
+	clawDebuff   core.AuraArray
+	fieryTornado *core.Spell
+	firestorm    *core.Spell
+}
+
+func (ai *AlysrazorAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+
+	ai.flightDuration = core.DurationFromSeconds(config.TargetInputs[0].NumberValue)
+	ai.burnoutDuration = core.DurationFromSeconds(config.TargetInputs[1].NumberValue)
+	ai.reignitionDuration = core.DurationFromSeconds(config.TargetInputs[2].NumberValue)
+
+	ai.airborneAura = target.RegisterUntargetableAura(core.Aura{
+		Label:    "Airborne",
+		ActionID: core.ActionID{SpellID: 99464},
+		Duration: core.NeverExpires,
+	})
+
+	ai.registerBlazingClaw()
+	ai.registerFieryTornado()
+	ai.registerFirestorm()
+}
+
+func (ai *AlysrazorAI) Reset(sim *core.Simulation) {
+	// Randomize GCD and swing timings to prevent fake APL-Haste couplings.
+	ai.Target.ExtendGCDUntil(sim, core.DurationFromSeconds(sim.RandomFloat("Specials Timing")*core.BossGCD.Seconds()))
+	ai.Target.AutoAttacks.RandomizeMeleeTiming(sim)
+
+	ai.enterStage(sim, alysrazorFlight)
+}
+
+func (ai *AlysrazorAI) stageDuration(stage alysrazorStage) time.Duration {
+	switch stage {
+	case alysrazorFlight:
+		return ai.flightDuration
+	case alysrazorFieryVortex:
+		return alysrazorFieryVortexDuration
+	case alysrazorBurnout:
+		return ai.burnoutDuration
+	default:
+		return ai.reignitionDuration
+	}
+}
+
+func (ai *AlysrazorAI) enterStage(sim *core.Simulation, stage alysrazorStage) {
+	ai.stage = stage
+
+	switch stage {
+	case alysrazorFlight:
+		ai.airborneAura.Activate(sim)
+		ai.firestorm.CD.Set(sim.CurrentTime + time.Second*45)
+	case alysrazorFieryVortex:
+		ai.fieryTornado.CD.Set(sim.CurrentTime + time.Second*5)
+	case alysrazorBurnout:
+		// She lands without any energy left and doesn't fight back until she re-ignites.
+		ai.airborneAura.Deactivate(sim)
+		ai.Target.AutoAttacks.CancelAutoSwing(sim)
+	case alysrazorReignition:
+		ai.Target.AutoAttacks.EnableAutoSwing(sim)
+		ai.Target.AutoAttacks.RandomizeMeleeTiming(sim)
+		ai.blazingClaw.CD.Set(sim.CurrentTime + time.Second*3)
+	}
+
+	core.StartDelayedAction(sim, core.DelayedActionOptions{
+		DoAt:     sim.CurrentTime + ai.stageDuration(stage),
+		Priority: core.ActionPriorityDOT,
+
+		OnAction: func(sim *core.Simulation) {
+			ai.enterStage(sim, (stage+1)%4)
+		},
+	})
+}
+
+func (ai *AlysrazorAI) registerBlazingClaw() {
+	// Blazing Claw hits the tank in a frontal cone and increases the fire and physical damage
+	// they take by 10% per stack.
+	ai.clawDebuff = ai.Target.NewAllyAuraArray(func(unit *core.Unit) *core.Aura {
+		if unit.Type != core.PlayerUnit {
+			return nil
+		}
+
+		return unit.GetOrRegisterAura(core.Aura{
+			Label:     "Blazing Claw",
+			ActionID:  core.ActionID{SpellID: 99844},
+			Duration:  time.Second * 15,
+			MaxStacks: 100,
+
+			OnStacksChange: func(aura *core.Aura, sim *core.Simulation, oldStacks int32, newStacks int32) {
+				multiplier := (1.0 + 0.1*float64(newStacks)) / (1.0 + 0.1*float64(oldStacks))
+				aura.Unit.PseudoStats.SchoolDamageTakenMultiplier[stats.SchoolIndexPhysical] *= multiplier
+				aura.Unit.PseudoStats.SchoolDamageTakenMultiplier[stats.SchoolIndexFire] *= multiplier
+			},
+		})
+	})
+
+	ai.blazingClaw = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 99844},
+		SpellSchool:      core.SpellSchoolPhysical | core.SpellSchoolFire,
+		ProcMask:         core.ProcMaskMeleeMHSpecial,
+		Flags:            core.SpellFlagMeleeMetrics,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 3,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseDamage := spell.Unit.AutoAttacks.MH().EnemyWeaponDamage(sim, spell.MeleeAttackPower(), 0.4)
+			result := spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeEnemyMeleeWhite)
+
+			if debuff := ai.clawDebuff.Get(target); debuff != nil && result.Landed() {
+				debuff.Activate(sim)
+				debuff.AddStack(sim)
+			}
+		},
+	})
+}
+
+func (ai *AlysrazorAI) registerFieryTornado() {
+	// 0 - 10N, 1 - 25N, 2 - 10H, 3 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, core.TernaryInt(ai.isHeroic, 2, 0), core.TernaryInt(ai.isHeroic, 3, 1))
+	harshWindsBase := []float64{5000, 6000, 7500, 9000}[scalingIndex]
+
+	// The raid stacks inside the ring of Fiery Tornadoes and has to keep dodging them, taking
+	// some Harsh Winds damage along the way.
+	ai.fieryTornado = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 99816},
+		SpellSchool:      core.SpellSchoolFire,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 8,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+				spell.CalcAndDealDamage(sim, aoeTarget, harshWindsBase, spell.OutcomeAlwaysHit)
+			}
+
+			sim.Raid.MoveAllPlayers(sim, time.Second*3)
+		},
+	})
+}
+
+func (ai *AlysrazorAI) registerFirestorm() {
+	// 0 - 10N, 1 - 25N, 2 - 10H, 3 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, core.TernaryInt(ai.isHeroic, 2, 0), core.TernaryInt(ai.isHeroic, 3, 1))
+	firestormTick := []float64{0, 0, 12000, 15000}[scalingIndex]
+
+	// On Heroic, Alysrazor casts Firestorm during the flight phase, and the raid has to hide
+	// behind a Volcanic Fire for the duration.
+	ai.firestorm = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 100744},
+		SpellSchool:      core.SpellSchoolFire,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 83,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			sim.Raid.MoveAllPlayers(sim, time.Second*3)
+
+			core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:   time.Second,
+				NumTicks: 10,
+				Priority: core.ActionPriorityDOT,
+
+				OnAction: func(sim *core.Simulation) {
+					for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+						spell.CalcAndDealDamage(sim, aoeTarget, firestormTick, spell.OutcomeAlwaysHit)
+					}
+				},
+			})
+		},
+	})
+}
+
+func (ai *AlysrazorAI) ExecuteCustomRotation(sim *core.Simulation) {
+	target := ai.Target.CurrentTarget
+	if target == nil {
+		// For individual non tank sims we still want abilities to work
+		target = &ai.Target.Env.Raid.Parties[0].Players[0].GetCharacter().Unit
+	}
+
+	switch ai.stage {
+	case alysrazorFlight:
+		if ai.isHeroic && ai.firestorm.IsReady(sim) {
+			ai.firestorm.Cast(sim, target)
+		}
+	case alysrazorFieryVortex:
+		if ai.fieryTornado.IsReady(sim) {
+			ai.fieryTornado.Cast(sim, target)
+		}
+	case alysrazorReignition:
+		if (ai.Target.CurrentTarget != nil) && ai.blazingClaw.IsReady(sim) && sim.Proc(0.75, "Blazing Claw AI") {
+			ai.blazingClaw.Cast(sim, target)
+			return
+		}
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
@@ -1,6 +1,22 @@
 package firelands
 
+import "github.com/wowsims/cata/sim/core"
+
 func Register() {
+	addShannox("Firelands")
+	addRhyolith("Firelands")
+	addAlysrazor("Firelands")
 	addBethtilac("Firelands")
 	addBaleroc("Firelands")
+	addMajordomo("Firelands")
+	addRagnaros("Firelands")
+}
+
+// Moves everyone attacking one unit over to another.
+func retarget(sim *core.Simulation, from *core.Unit, to *core.Unit) {
+	for _, unit := range sim.Raid.AllUnits {
+		if unit.CurrentTarget == from {
+			unit.CurrentTarget = to
+		}
+	}
 }
@@ -0,0 +1,321 @@
+package firelands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func addMajordomo(raidPrefix string) {
+	createMajordomoPreset(raidPrefix, 25, false, 52571, 51_744_000, 110_000)
+	createMajordomoPreset(raidPrefix, 25, true, 52671, 72_440_000, 155_000)
+}
+
+func createMajordomoPreset(raidPrefix string, raidSize int32, isHeroic bool, bossNpcId int32, bossHealth float64, bossMinBaseDamage float64) {
+	targetName := fmt.Sprintf("Majordomo Staghelm %d", raidSize)
+
+	if isHeroic {
+		targetName += " H"
+	}
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        bossNpcId,
+			Name:      targetName,
+			Level:     88,
+			MobType:   proto.MobType_MobTypeHumanoid,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      bossHealth,
+				stats.Armor:       11977,
+				stats.AttackPower: 0, // actual value doesn't matter in Cata, as long as damage parameters are fit consistently
+			}.ToProtoArray(),
+
+			SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:    2.0,
+			MinBaseDamage: bossMinBaseDamage,
+			DamageSpread:  0.4,
+			TargetInputs:  majordomoTargetInputs(),
+		},
+
+		AI: makeMajordomoAI(raidSize, isHeroic),
+	})
+
+	core.AddPresetEncounter(targetName, []string{
+		raidPrefix + "/" + targetName,
+	})
+}
+
+func majordomoTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:       "Scorpion form duration",
+			Tooltip:     "Seconds the raid stays stacked, keeping Majordomo in Scorpion form, before spreading out.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 90,
+		},
+		{
+			Label:       "Cat form duration",
+			Tooltip:     "Seconds the raid stays spread out, keeping Majordomo in Cat form, before stacking up again. Set to 0 to keep him in Scorpion form for the whole fight.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 45,
+		},
+	}
+}
+
+func makeMajordomoAI(raidSize int32, isHeroic bool) core.AIFactory {
+	return func() core.TargetAI {
+		return &MajordomoAI{
+			raidSize: raidSize,
+			isHeroic: isHeroic,
+		}
+	}
+}
+
+// Majordomo switches between Scorpion form while the raid is stacked and Cat form while it is
+// spread. Each form has a special ability that he uses faster and faster as he gains Adrenaline,
+// and every transformation gives him a stack of Fury.
+type MajordomoAI struct {
+	// Unit references
+	Target *core.Target
+
+	// Static parameters associated with a given preset
+	raidSize int32
+	isHeroic bool
+
+	// Dynamic parameters taken from user inputs
+	scorpionDuration time.Duration
+	catDuration      time.Duration
+
+	// State
+	inCatForm bool
+
+	// Spell + aura references
+	fury          *core.Aura
+	adrenaline    *core.Aura
+	flameScythe   *core.Spell
+	leapingFlames *core.Spell
+	searingSeeds  *core.Spell
+}
+
+func (ai *MajordomoAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+
+	ai.scorpionDuration = core.DurationFromSeconds(config.TargetInputs[0].NumberValue)
+	ai.catDuration = core.DurationFromSeconds(config.TargetInputs[1].NumberValue)
+
+	ai.registerFuryAndAdrenaline()
+	ai.registerFlameScythe()
+	ai.registerLeapingFlames()
+	ai.registerSearingSeeds()
+}
+
+func (ai *MajordomoAI) Reset(sim *core.Simulation) {
+	// Randomize GCD and swing timings to prevent fake APL-Haste couplings.
+	ai.Target.ExtendGCDUntil(sim, core.DurationFromSeconds(sim.RandomFloat("Specials Timing")*core.BossGCD.Seconds()))
+	ai.Target.AutoAttacks.RandomizeMeleeTiming(sim)
+
+	ai.inCatForm = false
+	ai.resetSpecialTimer(sim)
+
+	if ai.catDuration > 0 {
+		ai.scheduleTransformation(sim, ai.scorpionDuration)
+	}
+}
+
+func (ai *MajordomoAI) scheduleTransformation(sim *core.Simulation, formDuration time.Duration) {
+	core.StartDelayedAction(sim, core.DelayedActionOptions{
+		DoAt:     sim.CurrentTime + formDuration,
+		Priority: core.ActionPriorityDOT,
+
+		OnAction: func(sim *core.Simulation) {
+			ai.inCatForm = !ai.inCatForm
+			ai.adrenaline.Deactivate(sim)
+			ai.fury.Activate(sim)
+			ai.fury.AddStack(sim)
+			ai.searingSeeds.SkipCastAndApplyEffects(sim, sim.Raid.AllPlayerUnits[0])
+			ai.resetSpecialTimer(sim)
+
+			ai.scheduleTransformation(sim, core.TernaryDuration(ai.inCatForm, ai.catDuration, ai.scorpionDuration))
+		},
+	})
+}
+
+// Each Adrenaline stack makes the current form's special come 20% faster.
+func (ai *MajordomoAI) specialInterval() time.Duration {
+	baseInterval := core.TernaryDuration(ai.inCatForm, time.Second*13, time.Second*16)
+	return core.DurationFromSeconds(baseInterval.Seconds() / (1 + 0.2*float64(ai.adrenaline.GetStacks())))
+}
+
+func (ai *MajordomoAI) resetSpecialTimer(sim *core.Simulation) {
+	interval := ai.specialInterval()
+	ai.flameScythe.CD.Set(sim.CurrentTime + interval)
+	ai.leapingFlames.CD.Set(sim.CurrentTime + interval)
+}
+
+func (ai *MajordomoAI) registerFuryAndAdrenaline() {
+	ai.fury = ai.Target.RegisterAura(core.Aura{
+		Label:     "Fury",
+		ActionID:  core.ActionID{SpellID: 97235},
+		Duration:  core.NeverExpires,
+		MaxStacks: 100,
+
+		OnStacksChange: func(aura *core.Aura, sim *core.Simulation, oldStacks int32, newStacks int32) {
+			aura.Unit.PseudoStats.DamageDealtMultiplier *= (1.0 + 0.08*float64(newStacks)) / (1.0 + 0.08*float64(oldStacks))
+		},
+	})
+
+	ai.adrenaline = ai.Target.RegisterAura(core.Aura{
+		Label:     "Adrenaline",
+		ActionID:  core.ActionID{SpellID: 97238},
+		Duration:  core.NeverExpires,
+		MaxStacks: 100,
+	})
+}
+
+func (ai *MajordomoAI) gainAdrenaline(sim *core.Simulation) {
+	ai.adrenaline.Activate(sim)
+	ai.adrenaline.AddStack(sim)
+	ai.resetSpecialTimer(sim)
+}
+
+func (ai *MajordomoAI) registerFlameScythe() {
+	// 0 - 10N, 1 - 25N, 2 - 10H, 3 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, core.TernaryInt(ai.isHeroic, 2, 0), core.TernaryInt(ai.isHeroic, 3, 1))
+	flameScytheBase := []float64{500000, 1300000, 750000, 1800000}[scalingIndex]
+
+	// Flame Scythe is split between everyone in front of Majordomo, which is the whole raid while
+	// it is stacked on the tank.
+	ai.flameScythe = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 98474},
+		SpellSchool:      core.SpellSchoolFire,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 16,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+				spell.CalcAndDealDamage(sim, aoeTarget, flameScytheBase/float64(ai.raidSize), spell.OutcomeAlwaysHit)
+			}
+
+			ai.gainAdrenaline(sim)
+		},
+	})
+}
+
+func (ai *MajordomoAI) registerLeapingFlames() {
+	// 0 - 10N, 1 - 25N, 2 - 10H, 3 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, core.TernaryInt(ai.isHeroic, 2, 0), core.TernaryInt(ai.isHeroic, 3, 1))
+	leapingFlamesBase := []float64{26000, 31000, 36000, 43000}[scalingIndex]
+
+	// Majordomo leaps to a random player, who has to run out of the flames he leaves behind.
+	ai.leapingFlames = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 98476},
+		SpellSchool:      core.SpellSchoolFire,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 13,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			players := sim.Raid.AllPlayerUnits
+			target := players[int(sim.RandomFloat("Leaping Flames Target")*float64(len(players)))]
+			spell.CalcAndDealDamage(sim, target, leapingFlamesBase, spell.OutcomeAlwaysHit)
+			target.MoveDuration(time.Second*2, sim)
+
+			ai.gainAdrenaline(sim)
+		},
+	})
+}
+
+func (ai *MajordomoAI) registerSearingSeeds() {
+	// 0 - 10N, 1 - 25N, 2 - 10H, 3 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, core.TernaryInt(ai.isHeroic, 2, 0), core.TernaryInt(ai.isHeroic, 3, 1))
+	searingSeedsBase := []float64{50000, 55000, 60000, 63000}[scalingIndex]
+
+	// Majordomo briefly returns to his night elf form whenever he transforms, planting Searing
+	// Seeds on the raid. Players spread out before their seed explodes, and take a fraction of
+	// the explosion from the seeds around them.
+	ai.searingSeeds = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 98450},
+		SpellSchool:      core.SpellSchoolFire,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+				explodeAt := sim.CurrentTime + core.DurationFromSeconds(10+50*sim.RandomFloat("Searing Seeds Timing"))
+
+				core.StartDelayedAction(sim, core.DelayedActionOptions{
+					DoAt:     explodeAt - time.Second*2,
+					Priority: core.ActionPriorityDOT,
+
+					OnAction: func(sim *core.Simulation) {
+						aoeTarget.MoveDuration(time.Second*2, sim)
+					},
+				})
+
+				core.StartDelayedAction(sim, core.DelayedActionOptions{
+					DoAt:     explodeAt,
+					Priority: core.ActionPriorityDOT,
+
+					OnAction: func(sim *core.Simulation) {
+						spell.CalcAndDealDamage(sim, aoeTarget, searingSeedsBase*0.1, spell.OutcomeAlwaysHit)
+					},
+				})
+			}
+		},
+	})
+}
+
+func (ai *MajordomoAI) ExecuteCustomRotation(sim *core.Simulation) {
+	target := ai.Target.CurrentTarget
+	if target == nil {
+		// For individual non tank sims we still want abilities to work
+		target = &ai.Target.Env.Raid.Parties[0].Players[0].GetCharacter().Unit
+	}
+
+	if ai.inCatForm && ai.leapingFlames.IsReady(sim) {
+		ai.leapingFlames.Cast(sim, target)
+		return
+	}
+
+	if !ai.inCatForm && ai.flameScythe.IsReady(sim) {
+		ai.flameScythe.Cast(sim, target)
+		return
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
@@ -0,0 +1,603 @@
+package firelands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func addRagnaros(raidPrefix string) {
+	createRagnarosPreset(raidPrefix, 25, false, 52409, 53140, 99_500_000, 130_000, 1_460_000)
+	createRagnarosPreset(raidPrefix, 25, true, 52509, 53240, 139_300_000, 182_000, 2_044_000)
+}
+
+func createRagnarosPreset(raidPrefix string, raidSize int32, isHeroic bool, bossNpcId int32, addNpcId int32, bossHealth float64, bossMinBaseDamage float64, addHealth float64) {
+	suffix := fmt.Sprintf(" %d", raidSize)
+	if isHeroic {
+		suffix += " H"
+	}
+
+	bossName := "Ragnaros" + suffix
+	addName := "Son of Flame" + suffix
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        bossNpcId,
+			Name:      bossName,
+			Level:     88,
+			MobType:   proto.MobType_MobTypeElemental,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      bossHealth,
+				stats.Armor:       11977,
+				stats.AttackPower: 0, // actual value doesn't matter in Cata, as long as damage parameters are fit consistently
+			}.ToProtoArray(),
+
+			SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:    2.0,
+			MinBaseDamage: bossMinBaseDamage,
+			DamageSpread:  0.4,
+			TargetInputs:  ragnarosTargetInputs(isHeroic),
+		},
+
+		AI: makeRagnarosAI(raidSize, isHeroic),
+	})
+
+	// The Sons of Flame only show up during intermissions and never melee, so they are
+	// modeled as a single untanked add that can only be attacked while Ragnaros is submerged.
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        addNpcId,
+			Name:      addName,
+			Level:     87,
+			MobType:   proto.MobType_MobTypeElemental,
+			TankIndex: 2,
+
+			Stats: stats.Stats{
+				stats.Health:      addHealth,
+				stats.Armor:       11977,
+				stats.AttackPower: 0,
+			}.ToProtoArray(),
+
+			TargetInputs: []*proto.TargetInput{},
+		},
+
+		AI: makeSonOfFlameAI(),
+	})
+
+	core.AddPresetEncounter(bossName, []string{
+		raidPrefix + "/" + bossName,
+		raidPrefix + "/" + addName,
+	})
+}
+
+func ragnarosTargetInputs(isHeroic bool) []*proto.TargetInput {
+	inputs := []*proto.TargetInput{
+		{
+			Label:       "First intermission start",
+			Tooltip:     "Remaining fight duration % at which Ragnaros reaches 70% health and submerges for the first time.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 75,
+		},
+		{
+			Label:       "Second intermission start",
+			Tooltip:     "Remaining fight duration % at which Ragnaros reaches 40% health and submerges for the second time.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 45,
+		},
+		{
+			Label:       "Intermission duration",
+			Tooltip:     "Seconds Ragnaros stays submerged during each intermission, while the raid kills the Sons of Flame.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 45,
+		},
+	}
+
+	if isHeroic {
+		inputs = append(inputs, &proto.TargetInput{
+			Label:       "Phase 4 start",
+			Tooltip:     "Remaining fight duration % at which Ragnaros reaches 10% health and the raid has to keep moving around the Dreadflame.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 20,
+		})
+	}
+
+	return inputs
+}
+
+func makeRagnarosAI(raidSize int32, isHeroic bool) core.AIFactory {
+	return func() core.TargetAI {
+		return &RagnarosAI{
+			raidSize: raidSize,
+			isHeroic: isHeroic,
+		}
+	}
+}
+
+// Ragnaros' AI drives the whole encounter: phases 1 to 3 are separated by intermissions during
+// which the raid switches to the Sons of Flame, and on Heroic a fourth phase adds constant
+// movement and ramping raid damage.
+type RagnarosAI struct {
+	// Unit references
+	Target     *core.Target
+	SonOfFlame *SonOfFlameAI
+
+	// Static parameters associated with a given preset
+	raidSize int32
+	isHeroic bool
+
+	// Dynamic parameters taken from user inputs
+	intermission1Start   float64
+	intermission2Start   float64
+	intermissionDuration time.Duration
+	phase4Start          float64
+
+	// State
+	phase int32
+
+	// Spell + aura references
+	submergedAura   *core.Aura
+	burningWound    core.AuraArray
+	burningWoundDot *core.Spell
+	wrathOfRagnaros *core.Spell
+	sulfurasSmash   *core.Spell
+	moltenSeed      *core.Spell
+	engulfingFlames *core.Spell
+	livingMeteor    *core.Spell
+	superheated     *core.Aura
+	superheatedTick *core.Spell
+	dreadflame      *core.Spell
+}
+
+func (ai *RagnarosAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+
+	ai.intermission1Start = config.TargetInputs[0].NumberValue
+	ai.intermission2Start = config.TargetInputs[1].NumberValue
+	ai.intermissionDuration = core.DurationFromSeconds(config.TargetInputs[2].NumberValue)
+	if ai.isHeroic {
+		ai.phase4Start = config.TargetInputs[3].NumberValue
+	}
+
+	ai.submergedAura = target.RegisterUntargetableAura(core.Aura{
+		Label:    "Submerged",
+		ActionID: core.ActionID{SpellID: 100051},
+		Duration: core.NeverExpires,
+	})
+
+	ai.registerBurningWound()
+	ai.registerWrathOfRagnaros()
+	ai.registerSulfurasSmash()
+	ai.registerMoltenSeed()
+	ai.registerEngulfingFlames()
+	ai.registerLivingMeteor()
+	ai.registerSuperheated()
+	ai.registerDreadflame()
+}
+
+func (ai *RagnarosAI) Reset(sim *core.Simulation) {
+	// Randomize GCD and swing timings to prevent fake APL-Haste couplings.
+	ai.Target.ExtendGCDUntil(sim, core.DurationFromSeconds(sim.RandomFloat("Specials Timing")*core.BossGCD.Seconds()))
+	ai.Target.AutoAttacks.RandomizeMeleeTiming(sim)
+
+	ai.phase = 1
+	ai.wrathOfRagnaros.CD.Set(time.Second * 6)
+	ai.sulfurasSmash.CD.Set(time.Second * 30)
+
+	ai.SonOfFlame = nil
+	for _, target := range sim.Encounter.Targets {
+		if sonOfFlame, ok := target.AI.(*SonOfFlameAI); ok {
+			ai.SonOfFlame = sonOfFlame
+		}
+	}
+}
+
+func (ai *RagnarosAI) startIntermission(sim *core.Simulation) {
+	ai.submergedAura.Activate(sim)
+
+	if ai.SonOfFlame != nil {
+		ai.SonOfFlame.emergeAura.Deactivate(sim)
+		retarget(sim, &ai.Target.Unit, &ai.SonOfFlame.Target.Unit)
+	}
+
+	core.StartDelayedAction(sim, core.DelayedActionOptions{
+		DoAt:     sim.CurrentTime + ai.intermissionDuration,
+		Priority: core.ActionPriorityDOT,
+
+		OnAction: func(sim *core.Simulation) {
+			ai.endIntermission(sim)
+		},
+	})
+}
+
+func (ai *RagnarosAI) endIntermission(sim *core.Simulation) {
+	ai.phase++
+	ai.submergedAura.Deactivate(sim)
+
+	if ai.SonOfFlame != nil {
+		ai.SonOfFlame.emergeAura.Activate(sim)
+		retarget(sim, &ai.SonOfFlame.Target.Unit, &ai.Target.Unit)
+	}
+
+	ai.sulfurasSmash.CD.Set(sim.CurrentTime + time.Second*15)
+	ai.engulfingFlames.CD.Set(sim.CurrentTime + time.Second*40)
+
+	if ai.phase == 2 {
+		ai.moltenSeed.CD.Set(sim.CurrentTime + time.Second*15)
+	} else {
+		ai.livingMeteor.CD.Set(sim.CurrentTime + time.Second*45)
+	}
+}
+
+func (ai *RagnarosAI) startPhase4(sim *core.Simulation) {
+	ai.phase = 4
+	ai.superheated.Activate(sim)
+	ai.dreadflame.CD.Set(sim.CurrentTime + time.Second*5)
+}
+
+func (ai *RagnarosAI) registerBurningWound() {
+	// 0 - 10N, 1 - 25N, 2 - 10H, 3 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, core.TernaryInt(ai.isHeroic, 2, 0), core.TernaryInt(ai.isHeroic, 3, 1))
+	burningWoundTick := []float64{2500, 3000, 3500, 4000}[scalingIndex]
+
+	ai.burningWoundDot = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 99399},
+		SpellSchool:      core.SpellSchoolFire,
+		ProcMask:         core.ProcMaskEmpty,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			stacks := ai.burningWound.Get(target).GetStacks()
+			spell.CalcAndDealDamage(sim, target, burningWoundTick*float64(stacks), spell.OutcomeAlwaysHit)
+		},
+	})
+
+	// Every melee hit in phase 1 adds a stack of Burning Wound to the tank.
+	ai.burningWound = ai.Target.NewAllyAuraArray(func(unit *core.Unit) *core.Aura {
+		if unit.Type != core.PlayerUnit {
+			return nil
+		}
+
+		var dot *core.PendingAction
+
+		return unit.GetOrRegisterAura(core.Aura{
+			Label:     "Burning Wound",
+			ActionID:  core.ActionID{SpellID: 99399},
+			Duration:  time.Second * 20,
+			MaxStacks: 100,
+
+			OnGain: func(aura *core.Aura, sim *core.Simulation) {
+				dot = core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+					Period:   time.Second * 2,
+					Priority: core.ActionPriorityDOT,
+
+					OnAction: func(sim *core.Simulation) {
+						ai.burningWoundDot.SkipCastAndApplyEffects(sim, aura.Unit)
+					},
+				})
+			},
+
+			OnExpire: func(_ *core.Aura, sim *core.Simulation) {
+				dot.Cancel(sim)
+			},
+		})
+	})
+
+	core.MakePermanent(ai.Target.RegisterAura(core.Aura{
+		Label: "Burning Wound Trigger",
+
+		OnSpellHitDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if (ai.phase != 1) || !spell.ProcMask.Matches(core.ProcMaskMeleeMHAuto) || !result.Landed() {
+				return
+			}
+
+			if debuff := ai.burningWound.Get(result.Target); debuff != nil {
+				debuff.Activate(sim)
+				debuff.AddStack(sim)
+			}
+		},
+	}))
+}
+
+func (ai *RagnarosAI) registerWrathOfRagnaros() {
+	// 0 - 10N, 1 - 25N, 2 - 10H, 3 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, core.TernaryInt(ai.isHeroic, 2, 0), core.TernaryInt(ai.isHeroic, 3, 1))
+	wrathBase := []float64{40000, 45000, 52000, 58000}[scalingIndex]
+	numTargets := core.TernaryInt(ai.raidSize == 10, 1, 3)
+
+	ai.wrathOfRagnaros = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 98263},
+		SpellSchool:      core.SpellSchoolFire,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 25,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			players := sim.Raid.AllPlayerUnits
+			for range min(len(players), numTargets) {
+				target := players[int(sim.RandomFloat("Wrath of Ragnaros Target")*float64(len(players)))]
+				spell.CalcAndDealDamage(sim, target, wrathBase, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+
+func (ai *RagnarosAI) registerSulfurasSmash() {
+	// Sulfuras Smash sends out Lava Waves that the raid has to dodge.
+	ai.sulfurasSmash = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID: core.ActionID{SpellID: 98710},
+		ProcMask: core.ProcMaskEmpty,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.BossGCD,
+				CastTime: time.Millisecond * 2500,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 30,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			sim.Raid.MoveAllPlayers(sim, time.Second*2)
+		},
+	})
+}
+
+func (ai *RagnarosAI) registerMoltenSeed() {
+	// 0 - 10N, 1 - 25N, 2 - 10H, 3 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, core.TernaryInt(ai.isHeroic, 2, 0), core.TernaryInt(ai.isHeroic, 3, 1))
+	moltenSeedBase := []float64{52000, 55000, 68000, 72000}[scalingIndex]
+
+	// Molten Seeds land under everyone in phase 2 and explode 10 seconds later, so the raid
+	// stacks up to take a single hit and then moves away from the Molten Elementals.
+	ai.moltenSeed = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 98498},
+		SpellSchool:      core.SpellSchoolFire,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 60,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+				spell.CalcAndDealDamage(sim, aoeTarget, moltenSeedBase, spell.OutcomeAlwaysHit)
+			}
+
+			sim.Raid.MoveAllPlayers(sim, time.Second*3)
+		},
+	})
+}
+
+func (ai *RagnarosAI) registerEngulfingFlames() {
+	// Engulfing Flames covers part of the room, which the raid has to leave.
+	ai.engulfingFlames = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID: core.ActionID{SpellID: 99171},
+		ProcMask: core.ProcMaskEmpty,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 40,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			sim.Raid.MoveAllPlayers(sim, time.Second*2)
+		},
+	})
+}
+
+func (ai *RagnarosAI) registerLivingMeteor() {
+	// Living Meteors chase random players in phase 3, who have to kite them until they are
+	// knocked away.
+	ai.livingMeteor = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID: core.ActionID{SpellID: 99268},
+		ProcMask: core.ProcMaskEmpty,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 45,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			players := sim.Raid.AllPlayerUnits
+			for range min(len(players), core.TernaryInt(ai.raidSize == 10, 1, 3)) {
+				target := players[int(sim.RandomFloat("Living Meteor Target")*float64(len(players)))]
+				target.MoveDuration(time.Second*6, sim)
+			}
+		},
+	})
+}
+
+func (ai *RagnarosAI) registerSuperheated() {
+	superheatedBase := core.TernaryFloat64(ai.raidSize == 10, 1500, 1800)
+
+	ai.superheatedTick = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 100593},
+		SpellSchool:      core.SpellSchoolFire,
+		ProcMask:         core.ProcMaskEmpty,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			damage := superheatedBase * float64(ai.superheated.GetStacks())
+
+			for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+				spell.CalcAndDealDamage(sim, aoeTarget, damage, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+
+	// In Heroic phase 4 the whole raid takes fire damage every second, gaining a stack every
+	// 10 seconds until Ragnaros dies.
+	ai.superheated = ai.Target.RegisterAura(core.Aura{
+		Label:     "Superheated",
+		ActionID:  core.ActionID{SpellID: 100593},
+		Duration:  core.NeverExpires,
+		MaxStacks: 100,
+
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			aura.SetStacks(sim, 1)
+
+			core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:   time.Second,
+				Priority: core.ActionPriorityDOT,
+
+				OnAction: func(sim *core.Simulation) {
+					ai.superheatedTick.SkipCastAndApplyEffects(sim, sim.Raid.AllPlayerUnits[0])
+				},
+			})
+
+			core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:   time.Second * 10,
+				Priority: core.ActionPriorityDOT,
+
+				OnAction: func(sim *core.Simulation) {
+					aura.AddStack(sim)
+				},
+			})
+		},
+	})
+}
+
+func (ai *RagnarosAI) registerDreadflame() {
+	// Dreadflame keeps spreading over the platform in phase 4, forcing frequent repositioning.
+	ai.dreadflame = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID: core.ActionID{SpellID: 100675},
+		ProcMask: core.ProcMaskEmpty,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 15,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			sim.Raid.MoveAllPlayers(sim, time.Second*2)
+		},
+	})
+}
+
+func (ai *RagnarosAI) ExecuteCustomRotation(sim *core.Simulation) {
+	target := ai.Target.CurrentTarget
+	if target == nil {
+		// For individual non tank sims we still want abilities to work
+		target = &ai.Target.Env.Raid.Parties[0].Players[0].GetCharacter().Unit
+	}
+
+	remainingPercent := sim.GetRemainingDurationPercent() * 100
+
+	if !ai.submergedAura.IsActive() {
+		if ((ai.phase == 1) && (remainingPercent <= ai.intermission1Start)) || ((ai.phase == 2) && (remainingPercent <= ai.intermission2Start)) {
+			ai.startIntermission(sim)
+		} else if ai.isHeroic && (ai.phase == 3) && (remainingPercent <= ai.phase4Start) {
+			ai.startPhase4(sim)
+		}
+	}
+
+	if ai.submergedAura.IsActive() {
+		ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+		return
+	}
+
+	if (ai.phase < 4) && ai.sulfurasSmash.IsReady(sim) {
+		ai.sulfurasSmash.Cast(sim, target)
+		return
+	}
+
+	switch ai.phase {
+	case 1:
+		if ai.wrathOfRagnaros.IsReady(sim) && sim.Proc(0.75, "Wrath of Ragnaros AI") {
+			ai.wrathOfRagnaros.Cast(sim, target)
+			return
+		}
+	case 2:
+		if ai.moltenSeed.IsReady(sim) {
+			ai.moltenSeed.Cast(sim, target)
+		}
+
+		if ai.engulfingFlames.IsReady(sim) {
+			ai.engulfingFlames.Cast(sim, target)
+		}
+	case 3:
+		if ai.livingMeteor.IsReady(sim) {
+			ai.livingMeteor.Cast(sim, target)
+		}
+
+		if ai.engulfingFlames.IsReady(sim) {
+			ai.engulfingFlames.Cast(sim, target)
+		}
+	case 4:
+		if ai.dreadflame.IsReady(sim) {
+			ai.dreadflame.Cast(sim, target)
+		}
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
+
+func makeSonOfFlameAI() core.AIFactory {
+	return func() core.TargetAI {
+		return &SonOfFlameAI{}
+	}
+}
+
+type SonOfFlameAI struct {
+	Target *core.Target
+
+	// Spell + aura references
+	emergeAura *core.Aura
+}
+
+func (ai *SonOfFlameAI) Initialize(target *core.Target, _ *proto.Target) {
+	ai.Target = target
+
+	ai.emergeAura = target.RegisterUntargetableAura(core.Aura{
+		Label:    "Not Summoned",
+		Duration: core.NeverExpires,
+	})
+}
+
+func (ai *SonOfFlameAI) Reset(sim *core.Simulation) {
+	ai.emergeAura.Activate(sim)
+}
+
+func (ai *SonOfFlameAI) ExecuteCustomRotation(sim *core.Simulation) {
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
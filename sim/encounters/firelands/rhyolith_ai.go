@@ -0,0 +1,365 @@
+package firelands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func addRhyolith(raidPrefix string) {
+	createRhyolithPreset(raidPrefix, 25, false, 52558, 52577, 53087, 47_236_000, 14_760_000, 100_000)
+	createRhyolithPreset(raidPrefix, 25, true, 52658, 52677, 53187, 66_130_000, 20_664_000, 140_000)
+}
+
+func createRhyolithPreset(raidPrefix string, raidSize int32, isHeroic bool, bossNpcId int32, leftLegNpcId int32, rightLegNpcId int32, bossHealth float64, legHealth float64, bossMinBaseDamage float64) {
+	suffix := fmt.Sprintf(" %d", raidSize)
+	if isHeroic {
+		suffix += " H"
+	}
+
+	encounterName := "Lord Rhyolith" + suffix
+	var targetPaths []string
+
+	for _, unit := range []struct {
+		npcId  int32
+		name   string
+		health float64
+		role   rhyolithRole
+	}{
+		{leftLegNpcId, "Left Foot", legHealth, rhyolithLeftLeg},
+		{rightLegNpcId, "Right Foot", legHealth, rhyolithRightLeg},
+		{bossNpcId, "Lord Rhyolith", bossHealth, rhyolithBody},
+	} {
+		targetName := unit.name + suffix
+		targetPaths = append(targetPaths, raidPrefix+"/"+targetName)
+
+		core.AddPresetTarget(&core.PresetTarget{
+			PathPrefix: raidPrefix,
+
+			Config: &proto.Target{
+				Id:      unit.npcId,
+				Name:    targetName,
+				Level:   88,
+				MobType: proto.MobType_MobTypeElemental,
+
+				// Only Rhyolith himself is tanked, once he is knocked off his feet in phase 2.
+				TankIndex: core.TernaryInt32(unit.role == rhyolithBody, 0, 2),
+
+				Stats: stats.Stats{
+					stats.Health:      unit.health,
+					stats.Armor:       11977,
+					stats.AttackPower: 0, // actual value doesn't matter in Cata, as long as damage parameters are fit consistently
+				}.ToProtoArray(),
+
+				SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+				SwingSpeed:    core.TernaryFloat64(unit.role == rhyolithBody, 2.0, 0),
+				MinBaseDamage: core.TernaryFloat64(unit.role == rhyolithBody, bossMinBaseDamage, 0),
+				DamageSpread:  0.4,
+				TargetInputs:  core.Ternary(unit.role == rhyolithLeftLeg, rhyolithTargetInputs(), []*proto.TargetInput{}),
+			},
+
+			AI: makeRhyolithAI(raidSize, isHeroic, unit.role),
+		})
+	}
+
+	core.AddPresetEncounter(encounterName, targetPaths)
+}
+
+func rhyolithTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:       "Leg switch interval",
+			Tooltip:     "Seconds between the raid switching from one foot to the other to steer Rhyolith.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 10,
+		},
+		{
+			Label:       "Volcano interval",
+			Tooltip:     "Seconds between Rhyolith being steered into an active volcano, removing Obsidian Armor stacks from his feet.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 25,
+		},
+		{
+			Label:       "Phase 2 start",
+			Tooltip:     "Remaining fight duration % at which Rhyolith reaches 25% health, loses his armor and has to be tanked.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 25,
+		},
+	}
+}
+
+type rhyolithRole int32
+
+const (
+	rhyolithLeftLeg rhyolithRole = iota
+	rhyolithRightLeg
+	rhyolithBody
+)
+
+func makeRhyolithAI(raidSize int32, isHeroic bool, role rhyolithRole) core.AIFactory {
+	return func() core.TargetAI {
+		return &RhyolithAI{
+			raidSize: raidSize,
+			isHeroic: isHeroic,
+			role:     role,
+		}
+	}
+}
+
+// During phase 1 the raid attacks Rhyolith's feet, switching between them to steer him into
+// volcanoes. In phase 2 the feet are gone and Rhyolith himself is tanked and burned down. The
+// left foot's AI drives the leg switches and the phase transition for the whole encounter.
+type RhyolithAI struct {
+	// Unit references
+	Target *core.Target
+
+	// Static parameters associated with a given preset
+	raidSize int32
+	isHeroic bool
+	role     rhyolithRole
+
+	// Dynamic parameters taken from user inputs
+	legSwitchInterval time.Duration
+	volcanoInterval   time.Duration
+	phase2Start       float64
+
+	// State
+	inPhase2        bool
+	immolationTicks int32
+
+	// Spell + aura references
+	unreachableAura *core.Aura
+	obsidianArmor   *core.Aura
+	concussiveStomp *core.Spell
+	immolation      *core.Spell
+}
+
+func (ai *RhyolithAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+
+	ai.unreachableAura = target.RegisterUntargetableAura(core.Aura{
+		Label:    "Unreachable",
+		Duration: core.NeverExpires,
+	})
+
+	switch ai.role {
+	case rhyolithLeftLeg:
+		ai.legSwitchInterval = core.DurationFromSeconds(config.TargetInputs[0].NumberValue)
+		ai.volcanoInterval = core.DurationFromSeconds(config.TargetInputs[1].NumberValue)
+		ai.phase2Start = config.TargetInputs[2].NumberValue
+		ai.registerObsidianArmor()
+		ai.registerConcussiveStomp()
+	case rhyolithRightLeg:
+		ai.registerObsidianArmor()
+	case rhyolithBody:
+		ai.registerImmolation()
+	}
+}
+
+func (ai *RhyolithAI) Reset(sim *core.Simulation) {
+	// Randomize GCD and swing timings to prevent fake APL-Haste couplings.
+	ai.Target.ExtendGCDUntil(sim, core.DurationFromSeconds(sim.RandomFloat("Specials Timing")*core.BossGCD.Seconds()))
+	ai.Target.AutoAttacks.RandomizeMeleeTiming(sim)
+
+	ai.inPhase2 = false
+	ai.immolationTicks = 0
+
+	switch ai.role {
+	case rhyolithBody:
+		// Rhyolith towers above the raid until phase 2.
+		ai.unreachableAura.Activate(sim)
+	case rhyolithLeftLeg:
+		ai.concussiveStomp.CD.Set(time.Second * 15)
+		ai.startPhase1(sim)
+	}
+}
+
+// Returns the AI for the given part of Rhyolith, if it is part of the encounter.
+func (ai *RhyolithAI) findPart(role rhyolithRole) *RhyolithAI {
+	for _, target := range ai.Target.Env.Encounter.Targets {
+		if rhyolithAI, ok := target.AI.(*RhyolithAI); ok && rhyolithAI.role == role {
+			return rhyolithAI
+		}
+	}
+	return nil
+}
+
+func (ai *RhyolithAI) startPhase1(sim *core.Simulation) {
+	rightLeg := ai.findPart(rhyolithRightLeg)
+
+	if (rightLeg != nil) && (ai.legSwitchInterval > 0) {
+		core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+			Period:   ai.legSwitchInterval,
+			Priority: core.ActionPriorityDOT,
+
+			OnAction: func(sim *core.Simulation) {
+				if ai.inPhase2 {
+					return
+				}
+
+				// Units on the left foot go right and vice versa.
+				for _, unit := range sim.Raid.AllUnits {
+					switch unit.CurrentTarget {
+					case &ai.Target.Unit:
+						unit.CurrentTarget = &rightLeg.Target.Unit
+					case &rightLeg.Target.Unit:
+						unit.CurrentTarget = &ai.Target.Unit
+					}
+				}
+			},
+		})
+	}
+
+	if ai.volcanoInterval > 0 {
+		core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+			Period:   ai.volcanoInterval,
+			Priority: core.ActionPriorityDOT,
+
+			OnAction: func(sim *core.Simulation) {
+				if ai.inPhase2 {
+					return
+				}
+
+				for _, leg := range []*RhyolithAI{ai, rightLeg} {
+					if leg != nil {
+						leg.obsidianArmor.SetStacks(sim, max(leg.obsidianArmor.GetStacks()-core.TernaryInt32(ai.isHeroic, 16, 10), 0))
+					}
+				}
+			},
+		})
+	}
+}
+
+func (ai *RhyolithAI) startPhase2(sim *core.Simulation) {
+	ai.inPhase2 = true
+
+	body := ai.findPart(rhyolithBody)
+	if body == nil {
+		return
+	}
+
+	body.unreachableAura.Deactivate(sim)
+
+	core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+		Period:   time.Second,
+		Priority: core.ActionPriorityDOT,
+
+		OnAction: func(sim *core.Simulation) {
+			body.immolation.SkipCastAndApplyEffects(sim, sim.Raid.AllPlayerUnits[0])
+		},
+	})
+
+	for _, part := range []rhyolithRole{rhyolithLeftLeg, rhyolithRightLeg} {
+		if leg := ai.findPart(part); leg != nil {
+			leg.inPhase2 = true
+			leg.unreachableAura.Activate(sim)
+			retarget(sim, &leg.Target.Unit, &body.Target.Unit)
+		}
+	}
+}
+
+func (ai *RhyolithAI) registerObsidianArmor() {
+	// Each stack of Obsidian Armor reduces damage taken by 1%.
+	ai.obsidianArmor = ai.Target.RegisterAura(core.Aura{
+		Label:     "Obsidian Armor",
+		ActionID:  core.ActionID{SpellID: 98632},
+		Duration:  core.NeverExpires,
+		MaxStacks: 80,
+
+		OnReset: func(aura *core.Aura, sim *core.Simulation) {
+			aura.Activate(sim)
+			aura.SetStacks(sim, aura.MaxStacks)
+		},
+
+		OnStacksChange: func(aura *core.Aura, sim *core.Simulation, oldStacks int32, newStacks int32) {
+			aura.Unit.PseudoStats.DamageTakenMultiplier *= (1.0 - 0.01*float64(newStacks)) / (1.0 - 0.01*float64(oldStacks))
+		},
+	})
+}
+
+func (ai *RhyolithAI) registerConcussiveStomp() {
+	// 0 - 10N, 1 - 25N, 2 - 10H, 3 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, core.TernaryInt(ai.isHeroic, 2, 0), core.TernaryInt(ai.isHeroic, 3, 1))
+	concussiveStompBase := []float64{14000, 16500, 19000, 23000}[scalingIndex]
+
+	// Concussive Stomp knocks back the raid and spawns new volcanoes.
+	ai.concussiveStomp = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 97282},
+		SpellSchool:      core.SpellSchoolPhysical,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.BossGCD,
+				CastTime: time.Second * 3,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 30,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+				spell.CalcAndDealDamage(sim, aoeTarget, concussiveStompBase, spell.OutcomeAlwaysHit)
+			}
+
+			sim.Raid.MoveAllPlayers(sim, time.Second*2)
+		},
+	})
+}
+
+func (ai *RhyolithAI) registerImmolation() {
+	// 0 - 10N, 1 - 25N, 2 - 10H, 3 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, core.TernaryInt(ai.isHeroic, 2, 0), core.TernaryInt(ai.isHeroic, 3, 1))
+	immolationBase := []float64{3000, 3500, 4000, 5000}[scalingIndex]
+
+	// Rhyolith burns the whole raid every second in phase 2, and the damage keeps growing
+	// until he dies.
+	ai.immolation = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 99846},
+		SpellSchool:      core.SpellSchoolFire,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			ai.immolationTicks++
+			damage := immolationBase * (1 + 0.05*float64(ai.immolationTicks))
+
+			for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+				spell.CalcAndDealDamage(sim, aoeTarget, damage, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+
+func (ai *RhyolithAI) ExecuteCustomRotation(sim *core.Simulation) {
+	target := ai.Target.CurrentTarget
+	if target == nil {
+		// For individual non tank sims we still want abilities to work
+		target = &ai.Target.Env.Raid.Parties[0].Players[0].GetCharacter().Unit
+	}
+
+	switch ai.role {
+	case rhyolithLeftLeg:
+		if !ai.inPhase2 && (sim.GetRemainingDurationPercent()*100 <= ai.phase2Start) {
+			ai.startPhase2(sim)
+		}
+
+		if !ai.inPhase2 && ai.concussiveStomp.IsReady(sim) {
+			ai.concussiveStomp.Cast(sim, target)
+			return
+		}
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
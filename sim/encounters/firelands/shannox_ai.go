@@ -0,0 +1,373 @@
+package firelands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func addShannox(raidPrefix string) {
+	createShannoxPreset(raidPrefix, 25, false, 53691, 53694, 53695, 28_336_000, 95_000, 4_250_000, 60_000)
+	createShannoxPreset(raidPrefix, 25, true, 53791, 53794, 53795, 39_671_000, 133_000, 5_951_000, 84_000)
+}
+
+func createShannoxPreset(raidPrefix string, raidSize int32, isHeroic bool, bossNpcId int32, riplimbNpcId int32, ragefaceNpcId int32, bossHealth float64, bossMinBaseDamage float64, dogHealth float64, dogMinBaseDamage float64) {
+	suffix := fmt.Sprintf(" %d", raidSize)
+	if isHeroic {
+		suffix += " H"
+	}
+
+	encounterName := "Shannox" + suffix
+	var targetPaths []string
+
+	for _, unit := range []struct {
+		npcId           int32
+		name            string
+		tankIndex       int32
+		secondTankIndex int32
+		health          float64
+		minBaseDamage   float64
+	}{
+		{bossNpcId, "Shannox", 0, 1, bossHealth, bossMinBaseDamage},
+		{riplimbNpcId, "Riplimb", 1, 0, dogHealth, dogMinBaseDamage},
+		// Rageface fixates random players instead of being tanked.
+		{ragefaceNpcId, "Rageface", 2, 2, dogHealth, 0},
+	} {
+		targetName := unit.name + suffix
+		targetPaths = append(targetPaths, raidPrefix+"/"+targetName)
+
+		core.AddPresetTarget(&core.PresetTarget{
+			PathPrefix: raidPrefix,
+
+			Config: &proto.Target{
+				Id:              unit.npcId,
+				Name:            targetName,
+				Level:           88,
+				MobType:         core.Ternary(unit.npcId == bossNpcId, proto.MobType_MobTypeGiant, proto.MobType_MobTypeBeast),
+				TankIndex:       unit.tankIndex,
+				SecondTankIndex: unit.secondTankIndex,
+
+				Stats: stats.Stats{
+					stats.Health:      unit.health,
+					stats.Armor:       11977,
+					stats.AttackPower: 0, // actual value doesn't matter in Cata, as long as damage parameters are fit consistently
+				}.ToProtoArray(),
+
+				SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+				SwingSpeed:    core.TernaryFloat64(unit.minBaseDamage > 0, 2.0, 0),
+				MinBaseDamage: unit.minBaseDamage,
+				DamageSpread:  0.4,
+				TargetInputs:  core.Ternary(unit.npcId == bossNpcId, shannoxTargetInputs(), []*proto.TargetInput{}),
+			},
+
+			AI: makeShannoxAI(raidSize, isHeroic, unit.npcId == bossNpcId, unit.npcId == ragefaceNpcId),
+		})
+	}
+
+	core.AddPresetEncounter(encounterName, targetPaths)
+}
+
+func shannoxTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:     "Include Hurl Spear",
+			Tooltip:   "Model the raid damage and movement caused by Shannox throwing his spear at Riplimb.",
+			InputType: proto.InputType_Bool,
+			BoolValue: true,
+		},
+		{
+			Label:       "Jagged Tear tank swap stacks",
+			Tooltip:     "Number of Jagged Tear stacks at which the tanks swap between Shannox and Riplimb. Set to 0 to disable tank swaps.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 10,
+		},
+	}
+}
+
+func makeShannoxAI(raidSize int32, isHeroic bool, isShannox bool, isRageface bool) core.AIFactory {
+	return func() core.TargetAI {
+		return &ShannoxAI{
+			raidSize:   raidSize,
+			isHeroic:   isHeroic,
+			isShannox:  isShannox,
+			isRageface: isRageface,
+		}
+	}
+}
+
+// Shannox and his two dogs are tanked next to each other, so cleaves and AoE hit all three.
+// Shannox is tanked by Tank 1 and Riplimb by Tank 2, and the tanks swap once Jagged Tear stacks
+// get too high.
+type ShannoxAI struct {
+	// Unit references
+	Target   *core.Target
+	MainTank *core.Unit
+	OffTank  *core.Unit
+
+	// Static parameters associated with a given preset
+	raidSize   int32
+	isHeroic   bool
+	isShannox  bool
+	isRageface bool
+
+	// Dynamic parameters taken from user inputs
+	includeHurlSpear bool
+
+	// Spell + aura references
+	slash     *core.Spell
+	tearTick  *core.Spell
+	hurlSpear *core.Spell
+	limbRip   *core.Spell
+	faceRage  *core.Spell
+}
+
+func (ai *ShannoxAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+	ai.MainTank = target.CurrentTarget
+	ai.OffTank = target.SecondaryTarget
+
+	switch {
+	case ai.isShannox:
+		ai.includeHurlSpear = config.TargetInputs[0].BoolValue
+		ai.registerJaggedTear(int32(config.TargetInputs[1].NumberValue))
+		ai.registerArcingSlash()
+		ai.registerHurlSpear()
+	case ai.isRageface:
+		ai.registerFaceRage()
+	default:
+		ai.registerLimbRip()
+	}
+}
+
+func (ai *ShannoxAI) Reset(sim *core.Simulation) {
+	// Randomize GCD and swing timings to prevent fake APL-Haste couplings.
+	ai.Target.ExtendGCDUntil(sim, core.DurationFromSeconds(sim.RandomFloat("Specials Timing")*core.BossGCD.Seconds()))
+	ai.Target.AutoAttacks.RandomizeMeleeTiming(sim)
+
+	switch {
+	case ai.isShannox:
+		ai.slash.CD.Set(time.Second * 6)
+		ai.hurlSpear.CD.Set(time.Second * 23)
+	case ai.isRageface:
+		ai.faceRage.CD.Set(time.Second * 15)
+	default:
+		ai.limbRip.CD.Set(time.Second * 8)
+	}
+}
+
+func (ai *ShannoxAI) registerJaggedTear(tankSwapStacks int32) {
+	// 0 - 10N, 1 - 25N, 2 - 10H, 3 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, core.TernaryInt(ai.isHeroic, 2, 0), core.TernaryInt(ai.isHeroic, 3, 1))
+	jaggedTearTick := []float64{2500, 3000, 3500, 4000}[scalingIndex]
+
+	ai.tearTick = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 99937},
+		SpellSchool:      core.SpellSchoolPhysical,
+		ProcMask:         core.ProcMaskEmpty,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			debuff := ai.Target.TankSwapDebuff(target)
+			spell.CalcAndDealDamage(sim, target, jaggedTearTick*float64(debuff.GetStacks()), spell.OutcomeAlwaysHit)
+		},
+	})
+
+	// Jagged Tear is a stacking bleed applied by Arcing Slash. Once it gets too high, Shannox's
+	// tank hands him over and picks up Riplimb until the stacks fall off.
+	ai.Target.EnableTankSwap(&proto.TankSwapConfig{
+		DebuffSpellId:   99937,
+		DebuffName:      "Jagged Tear",
+		DebuffDuration:  30,
+		MaxStacks:       100,
+		StacksFromAi:    true,
+		SwapAtStacks:    tankSwapStacks,
+		ExchangeTargets: true,
+	})
+
+	for _, tank := range []*core.Unit{ai.MainTank, ai.OffTank} {
+		debuff := ai.Target.TankSwapDebuff(tank)
+		if debuff == nil {
+			continue
+		}
+
+		var bleed *core.PendingAction
+
+		debuff.ApplyOnGain(func(aura *core.Aura, sim *core.Simulation) {
+			bleed = core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:   time.Second * 3,
+				Priority: core.ActionPriorityDOT,
+
+				OnAction: func(sim *core.Simulation) {
+					ai.tearTick.SkipCastAndApplyEffects(sim, aura.Unit)
+				},
+			})
+		})
+
+		debuff.ApplyOnExpire(func(_ *core.Aura, sim *core.Simulation) {
+			bleed.Cancel(sim)
+		})
+	}
+}
+
+func (ai *ShannoxAI) registerArcingSlash() {
+	ai.slash = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 99931},
+		SpellSchool:      core.SpellSchoolPhysical,
+		ProcMask:         core.ProcMaskMeleeMHSpecial,
+		Flags:            core.SpellFlagMeleeMetrics,
+		DamageMultiplier: 1.5,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 12,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseDamage := spell.Unit.AutoAttacks.MH().EnemyWeaponDamage(sim, spell.MeleeAttackPower(), 0.4)
+			result := spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeEnemyMeleeWhite)
+
+			if result.Landed() {
+				ai.Target.AddTankSwapStack(sim, target)
+			}
+		},
+	})
+}
+
+func (ai *ShannoxAI) registerHurlSpear() {
+	// 0 - 10N, 1 - 25N, 2 - 10H, 3 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, core.TernaryInt(ai.isHeroic, 2, 0), core.TernaryInt(ai.isHeroic, 3, 1))
+	hurlSpearBase := []float64{11000, 13000, 15000, 18000}[scalingIndex]
+
+	// The spear explodes where it lands and sends out lines of fire that the raid has to step
+	// out of. On Heroic it also leaves Magma Rupture pools all over the room.
+	ai.hurlSpear = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 100002},
+		SpellSchool:      core.SpellSchoolFire,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 42,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+				spell.CalcAndDealDamage(sim, aoeTarget, hurlSpearBase, spell.OutcomeAlwaysHit)
+			}
+
+			sim.Raid.MoveAllPlayers(sim, core.TernaryDuration(ai.isHeroic, time.Second*3, time.Second*2))
+		},
+	})
+}
+
+func (ai *ShannoxAI) registerLimbRip() {
+	ai.limbRip = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 99832},
+		SpellSchool:      core.SpellSchoolPhysical,
+		ProcMask:         core.ProcMaskMeleeMHSpecial,
+		Flags:            core.SpellFlagMeleeMetrics,
+		DamageMultiplier: 1.2,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 12,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseDamage := spell.Unit.AutoAttacks.MH().EnemyWeaponDamage(sim, spell.MeleeAttackPower(), 0.4)
+			spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeEnemyMeleeWhite)
+		},
+	})
+}
+
+func (ai *ShannoxAI) registerFaceRage() {
+	// 0 - 10N, 1 - 25N, 2 - 10H, 3 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, core.TernaryInt(ai.isHeroic, 2, 0), core.TernaryInt(ai.isHeroic, 3, 1))
+	faceRageTick := []float64{5000, 6000, 7500, 9000}[scalingIndex]
+
+	// Rageface leaps on a random player and mauls them until a big enough hit breaks the fixate.
+	ai.faceRage = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 99945},
+		SpellSchool:      core.SpellSchoolPhysical,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 30,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			players := sim.Raid.AllPlayerUnits
+			target := players[int(sim.RandomFloat("Face Rage Target")*float64(len(players)))]
+
+			core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:   time.Millisecond * 500,
+				NumTicks: 10,
+				Priority: core.ActionPriorityDOT,
+
+				OnAction: func(sim *core.Simulation) {
+					spell.CalcAndDealDamage(sim, target, faceRageTick, spell.OutcomeAlwaysHit)
+				},
+			})
+		},
+	})
+}
+
+func (ai *ShannoxAI) ExecuteCustomRotation(sim *core.Simulation) {
+	target := ai.Target.CurrentTarget
+	if target == nil {
+		// For individual non tank sims we still want abilities to work
+		target = &ai.Target.Env.Raid.Parties[0].Players[0].GetCharacter().Unit
+	}
+
+	switch {
+	case ai.isShannox:
+		if ai.includeHurlSpear && ai.hurlSpear.IsReady(sim) {
+			ai.hurlSpear.Cast(sim, target)
+		}
+
+		if ai.slash.IsReady(sim) && sim.Proc(0.75, "Arcing Slash AI") {
+			ai.slash.Cast(sim, target)
+			return
+		}
+	case ai.isRageface:
+		if ai.faceRage.IsReady(sim) {
+			ai.faceRage.Cast(sim, target)
+		}
+	default:
+		if ai.limbRip.IsReady(sim) && sim.Proc(0.75, "Limb Rip AI") {
+			ai.limbRip.Cast(sim, target)
+			return
+		}
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
@@ -4,15 +4,19 @@ import (
 	"github.com/wowsims/cata/sim/core"
 	"github.com/wowsims/cata/sim/core/proto"
 	"github.com/wowsims/cata/sim/core/stats"
+	"github.com/wowsims/cata/sim/encounters/bot"
 	"github.com/wowsims/cata/sim/encounters/bwd"
 	"github.com/wowsims/cata/sim/encounters/dragonsoul"
 	"github.com/wowsims/cata/sim/encounters/firelands"
+	"github.com/wowsims/cata/sim/encounters/totfw"
 )
 
 func init() {
 	AddDefaultPresetEncounter()
 	addMovementAI()
 	bwd.Register()
+	bot.Register()
+	totfw.Register()
 	firelands.Register()
 	dragonsoul.Register()
 }
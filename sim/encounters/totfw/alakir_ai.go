@@ -0,0 +1,327 @@
+package totfw
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+const alakirID int32 = 46753
+
+func addAlakir(raidPrefix string) {
+	// TODO: Add support for 10-man and Normal variants
+	createAlakirHeroicPreset(raidPrefix, 25, 77_040_000, 100_000)
+}
+
+func createAlakirHeroicPreset(raidPrefix string, raidSize int32, bossHealth float64, bossMinBaseDamage float64) {
+	targetName := fmt.Sprintf("Al'Akir %d H", raidSize)
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        alakirID,
+			Name:      targetName,
+			Level:     88,
+			MobType:   proto.MobType_MobTypeElemental,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      bossHealth,
+				stats.Armor:       11977,
+				stats.AttackPower: 0, // actual value doesn't matter in Cata, as long as damage parameters are fit consistently
+			}.ToProtoArray(),
+
+			SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:    2.0,
+			MinBaseDamage: bossMinBaseDamage,
+			DamageSpread:  0.4,
+			TargetInputs:  alakirTargetInputs(),
+		},
+
+		AI: makeAlakirAI(raidSize),
+	})
+
+	core.AddPresetEncounter(targetName, []string{
+		raidPrefix + "/" + targetName,
+	})
+}
+
+func alakirTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:       "Phase 2 start",
+			Tooltip:     "Remaining fight duration % at which Al'Akir reaches 80% health and starts casting Acid Rain.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 85,
+		},
+		{
+			Label:       "Phase 3 start",
+			Tooltip:     "Remaining fight duration % at which Al'Akir reaches 25% health, destroys the platform and the raid starts flying.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 35,
+		},
+		{
+			Label:       "Phase 3 melee uptime",
+			Tooltip:     "Fraction of melee damage that still lands on Al'Akir while the raid is flying in phase 3, from 0 to 1.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 0.8,
+		},
+	}
+}
+
+func makeAlakirAI(raidSize int32) core.AIFactory {
+	return func() core.TargetAI {
+		return &AlakirAI{
+			raidSize: raidSize,
+		}
+	}
+}
+
+type AlakirAI struct {
+	// Unit references
+	Target *core.Target
+
+	// Static parameters associated with a given preset
+	raidSize int32
+
+	// Dynamic parameters taken from user inputs
+	phase2Start       float64
+	phase3Start       float64
+	phase3MeleeUptime float64
+
+	// State
+	phase int32
+
+	// Spell + aura references
+	relentlessStorm *core.Aura
+	windBurst       *core.Spell
+	squallLine      *core.Spell
+	acidRain        *core.Aura
+	acidRainTick    *core.Spell
+	lightningRod    *core.Spell
+}
+
+func (ai *AlakirAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+
+	ai.phase2Start = config.TargetInputs[0].NumberValue
+	ai.phase3Start = config.TargetInputs[1].NumberValue
+	ai.phase3MeleeUptime = core.Clamp(config.TargetInputs[2].NumberValue, 0, 1)
+
+	ai.relentlessStorm = target.RegisterUntargetableAura(core.Aura{
+		Label:    "Relentless Storm",
+		ActionID: core.ActionID{SpellID: 88866},
+		Duration: time.Second * 10,
+	})
+
+	// Once the platform is gone the raid flies around Al'Akir, and melee players lose uptime
+	// chasing him and dodging the Lightning Clouds.
+	target.AddDynamicDamageTakenModifier(func(_ *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+		if (ai.phase == 3) && spell.ProcMask.Matches(core.ProcMaskMelee) {
+			result.Damage *= ai.phase3MeleeUptime
+		}
+	})
+
+	ai.registerPhase1Spells()
+	ai.registerAcidRain()
+	ai.registerLightningRod()
+}
+
+func (ai *AlakirAI) Reset(sim *core.Simulation) {
+	// Randomize GCD and swing timings to prevent fake APL-Haste couplings.
+	ai.Target.ExtendGCDUntil(sim, core.DurationFromSeconds(sim.RandomFloat("Specials Timing")*core.BossGCD.Seconds()))
+	ai.Target.AutoAttacks.RandomizeMeleeTiming(sim)
+
+	ai.phase = 1
+	ai.windBurst.CD.Set(time.Second * 22)
+	ai.squallLine.CD.Set(time.Second * 10)
+}
+
+func (ai *AlakirAI) registerPhase1Spells() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	windBurstBase := []float64{24000, 30000}[scalingIndex]
+
+	// Wind Burst hits the whole raid and knocks everyone back towards the edge of the platform.
+	ai.windBurst = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 87770},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.BossGCD,
+				CastTime: time.Second * 5,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 25,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+				spell.CalcAndDealDamage(sim, aoeTarget, windBurstBase, spell.OutcomeAlwaysHit)
+			}
+
+			sim.Raid.MoveAllPlayers(sim, time.Second*2)
+		},
+	})
+
+	// The raid has to move through the gap in each Squall Line as it sweeps over the platform.
+	ai.squallLine = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID: core.ActionID{SpellID: 91129},
+		ProcMask: core.ProcMaskEmpty,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 30,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			sim.Raid.MoveAllPlayers(sim, time.Second*3)
+		},
+	})
+}
+
+func (ai *AlakirAI) registerAcidRain() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	acidRainBase := []float64{1750, 2000}[scalingIndex]
+
+	ai.acidRainTick = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 88301},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			damage := acidRainBase * float64(ai.acidRain.GetStacks())
+
+			for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+				spell.CalcAndDealDamage(sim, aoeTarget, damage, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+
+	// Acid Rain pulses on the whole raid every 2 seconds, and gains a stack every 15 seconds.
+	ai.acidRain = ai.Target.RegisterAura(core.Aura{
+		Label:     "Acid Rain",
+		ActionID:  core.ActionID{SpellID: 88290},
+		Duration:  core.NeverExpires,
+		MaxStacks: 100,
+
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			aura.SetStacks(sim, 1)
+
+			core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:   time.Second * 2,
+				Priority: core.ActionPriorityDOT,
+
+				OnAction: func(sim *core.Simulation) {
+					if aura.IsActive() {
+						ai.acidRainTick.SkipCastAndApplyEffects(sim, sim.Raid.AllPlayerUnits[0])
+					}
+				},
+			})
+
+			core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:   time.Second * 15,
+				Priority: core.ActionPriorityDOT,
+
+				OnAction: func(sim *core.Simulation) {
+					if aura.IsActive() {
+						aura.AddStack(sim)
+					}
+				},
+			})
+		},
+	})
+}
+
+func (ai *AlakirAI) registerLightningRod() {
+	// Lightning Rod targets a few players in phase 3, who have to fly away from the raid.
+	ai.lightningRod = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID: core.ActionID{SpellID: 89668},
+		ProcMask: core.ProcMaskEmpty,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 15,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			players := sim.Raid.AllPlayerUnits
+			for range min(len(players), int(ai.raidSize/8)) {
+				target := players[int(sim.RandomFloat("Lightning Rod Target")*float64(len(players)))]
+				target.MoveDuration(time.Second*3, sim)
+			}
+		},
+	})
+}
+
+func (ai *AlakirAI) startPhase3(sim *core.Simulation) {
+	ai.phase = 3
+	ai.acidRain.Deactivate(sim)
+	ai.relentlessStorm.Activate(sim)
+
+	// Everyone is knocked off the platform and has to start flying.
+	sim.Raid.MoveAllPlayers(sim, ai.relentlessStorm.Duration)
+
+	ai.lightningRod.CD.Set(sim.CurrentTime + ai.relentlessStorm.Duration + time.Second*5)
+}
+
+func (ai *AlakirAI) ExecuteCustomRotation(sim *core.Simulation) {
+	target := ai.Target.CurrentTarget
+	if target == nil {
+		// For individual non tank sims we still want abilities to work
+		target = &ai.Target.Env.Raid.Parties[0].Players[0].GetCharacter().Unit
+	}
+
+	remainingPercent := sim.GetRemainingDurationPercent() * 100
+
+	if (ai.phase == 1) && (remainingPercent <= ai.phase2Start) {
+		ai.phase = 2
+		ai.acidRain.Activate(sim)
+	}
+
+	if (ai.phase < 3) && (remainingPercent <= ai.phase3Start) {
+		ai.startPhase3(sim)
+	}
+
+	switch ai.phase {
+	case 1:
+		if ai.squallLine.IsReady(sim) {
+			ai.squallLine.Cast(sim, target)
+		}
+
+		if ai.windBurst.IsReady(sim) && sim.Proc(0.75, "Wind Burst AI") {
+			ai.windBurst.Cast(sim, target)
+			return
+		}
+	case 2:
+		if ai.squallLine.IsReady(sim) {
+			ai.squallLine.Cast(sim, target)
+		}
+	case 3:
+		if !ai.relentlessStorm.IsActive() && ai.lightningRod.IsReady(sim) {
+			ai.lightningRod.Cast(sim, target)
+		}
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
@@ -0,0 +1,326 @@
+package totfw
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+const anshalID int32 = 45870
+const nezirID int32 = 45871
+const rohashID int32 = 45872
+
+func addConclaveOfWind(raidPrefix string) {
+	// TODO: Add support for 10-man and Normal variants
+	createConclaveOfWindHeroicPreset(raidPrefix, 25, 30_270_000, 88_000)
+}
+
+func createConclaveOfWindHeroicPreset(raidPrefix string, raidSize int32, bossHealth float64, bossMinBaseDamage float64) {
+	encounterName := fmt.Sprintf("Conclave of Wind %d H", raidSize)
+
+	var targetPaths []string
+	for _, boss := range []struct {
+		id         int32
+		name       string
+		tankIndex  int32
+		swingSpeed float64
+	}{
+		{anshalID, "Anshal", 0, 2.0},
+		{nezirID, "Nezir", 1, 2.0},
+		// Rohash stays in the middle of his platform and never melees.
+		{rohashID, "Rohash", 2, 0},
+	} {
+		targetName := fmt.Sprintf("%s %d H", boss.name, raidSize)
+		targetPaths = append(targetPaths, raidPrefix+"/"+targetName)
+
+		core.AddPresetTarget(&core.PresetTarget{
+			PathPrefix: raidPrefix,
+
+			Config: &proto.Target{
+				Id:        boss.id,
+				Name:      targetName,
+				Level:     88,
+				MobType:   proto.MobType_MobTypeElemental,
+				TankIndex: boss.tankIndex,
+
+				Stats: stats.Stats{
+					stats.Health:      bossHealth,
+					stats.Armor:       11977,
+					stats.AttackPower: 0, // actual value doesn't matter in Cata, as long as damage parameters are fit consistently
+				}.ToProtoArray(),
+
+				SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+				SwingSpeed:    boss.swingSpeed,
+				MinBaseDamage: bossMinBaseDamage,
+				DamageSpread:  0.4,
+				TargetInputs:  core.Ternary(boss.id == rohashID, conclaveTargetInputs(), []*proto.TargetInput{}),
+			},
+
+			AI: makeConclaveAI(raidSize, boss.id),
+		})
+	}
+
+	core.AddPresetEncounter(encounterName, targetPaths)
+}
+
+func conclaveTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:       "Ultimate interval",
+			Tooltip:     "Seconds between the Conclave's ultimate abilities, during which all three bosses can't be attacked.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 90,
+		},
+	}
+}
+
+func makeConclaveAI(raidSize int32, bossID int32) core.AIFactory {
+	return func() core.TargetAI {
+		return &ConclaveAI{
+			raidSize: raidSize,
+			bossID:   bossID,
+		}
+	}
+}
+
+// Every boss of the Conclave runs its own copy of this AI. Rohash's copy also drives the
+// shared ultimate phase, during which the whole Conclave is out of reach.
+type ConclaveAI struct {
+	// Unit references
+	Target *core.Target
+
+	// Static parameters associated with a given preset
+	raidSize int32
+	bossID   int32
+
+	// Dynamic parameters taken from user inputs
+	ultimateInterval time.Duration
+
+	// Spell + aura references
+	ultimateAura *core.Aura
+	sleetStorm   *core.Spell
+	permafrost   *core.Spell
+	windBlast    *core.Spell
+	slicingGale  *core.Spell
+}
+
+const conclaveUltimateDuration = time.Second * 15
+
+func (ai *ConclaveAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+
+	ai.ultimateAura = target.RegisterUntargetableAura(core.Aura{
+		Label:    "Gather Strength",
+		ActionID: core.ActionID{SpellID: 86307},
+		Duration: conclaveUltimateDuration,
+	})
+
+	switch ai.bossID {
+	case nezirID:
+		ai.registerSleetStorm()
+		ai.registerPermafrost()
+	case rohashID:
+		ai.ultimateInterval = core.DurationFromSeconds(config.TargetInputs[0].NumberValue)
+		ai.registerWindBlast()
+		ai.registerSlicingGale()
+	}
+}
+
+func (ai *ConclaveAI) Reset(sim *core.Simulation) {
+	// Randomize GCD and swing timings to prevent fake APL-Haste couplings.
+	ai.Target.ExtendGCDUntil(sim, core.DurationFromSeconds(sim.RandomFloat("Specials Timing")*core.BossGCD.Seconds()))
+	ai.Target.AutoAttacks.RandomizeMeleeTiming(sim)
+
+	if ai.bossID != rohashID || ai.ultimateInterval <= 0 {
+		return
+	}
+
+	ai.windBlast.CD.Set(time.Second * 30)
+
+	core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+		Period:   ai.ultimateInterval,
+		Priority: core.ActionPriorityDOT,
+
+		OnAction: func(sim *core.Simulation) {
+			ai.startUltimates(sim)
+		},
+	})
+}
+
+// All three bosses reach full energy at the same time and channel their ultimates.
+func (ai *ConclaveAI) startUltimates(sim *core.Simulation) {
+	var nezirAI *ConclaveAI
+
+	for _, target := range sim.Encounter.Targets {
+		conclaveAI, ok := target.AI.(*ConclaveAI)
+		if !ok {
+			continue
+		}
+
+		conclaveAI.ultimateAura.Activate(sim)
+
+		if conclaveAI.bossID == nezirID {
+			nezirAI = conclaveAI
+		}
+	}
+
+	// Sleet Storm is split between everyone standing on Nezir's platform, which is where most
+	// of the raid waits out the ultimates.
+	if nezirAI != nil {
+		core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+			Period:   time.Second,
+			NumTicks: int(conclaveUltimateDuration / time.Second),
+			Priority: core.ActionPriorityDOT,
+
+			OnAction: func(sim *core.Simulation) {
+				nezirAI.sleetStorm.SkipCastAndApplyEffects(sim, sim.Raid.AllPlayerUnits[0])
+			},
+		})
+	}
+}
+
+func (ai *ConclaveAI) registerSleetStorm() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	sleetStormBase := []float64{100000, 300000}[scalingIndex] / float64(ai.raidSize)
+
+	ai.sleetStorm = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 84644},
+		SpellSchool:      core.SpellSchoolFrost,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, aoeTarget := range sim.Raid.AllPlayerUnits {
+				spell.CalcAndDealDamage(sim, aoeTarget, sleetStormBase, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+
+func (ai *ConclaveAI) registerPermafrost() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	permafrostTick := []float64{9500, 14250}[scalingIndex]
+
+	// Nezir channels Permafrost in a cone in front of him, which only his tank stands in.
+	ai.permafrost = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 86082},
+		SpellSchool:      core.SpellSchoolFrost,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 11,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:   time.Millisecond * 500,
+				NumTicks: 6,
+				Priority: core.ActionPriorityDOT,
+
+				OnAction: func(sim *core.Simulation) {
+					spell.CalcAndDealDamage(sim, target, permafrostTick, spell.OutcomeAlwaysHit)
+				},
+			})
+		},
+	})
+}
+
+func (ai *ConclaveAI) registerWindBlast() {
+	// Rohash turns around while casting Wind Blast, and everyone on his platform has to move
+	// with him to stay out of it.
+	ai.windBlast = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID: core.ActionID{SpellID: 86193},
+		ProcMask: core.ProcMaskEmpty,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 60,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			sim.Raid.MoveAllPlayers(sim, time.Second*4)
+		},
+	})
+}
+
+func (ai *ConclaveAI) registerSlicingGale() {
+	// 0 - 10H, 1 - 25H
+	scalingIndex := core.TernaryInt(ai.raidSize == 10, 0, 1)
+	slicingGaleBase := []float64{6000, 7200}[scalingIndex]
+
+	// Rohash casts Slicing Gale on a random player whenever nobody is in melee range of him.
+	ai.slicingGale = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 86182},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreResists,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			players := sim.Raid.AllPlayerUnits
+			target := players[int(sim.RandomFloat("Slicing Gale Target")*float64(len(players)))]
+			spell.CalcAndDealDamage(sim, target, slicingGaleBase, spell.OutcomeAlwaysHit)
+		},
+	})
+}
+
+func (ai *ConclaveAI) ExecuteCustomRotation(sim *core.Simulation) {
+	target := ai.Target.CurrentTarget
+	if target == nil {
+		// For individual non tank sims we still want abilities to work
+		target = &ai.Target.Env.Raid.Parties[0].Players[0].GetCharacter().Unit
+	}
+
+	if ai.ultimateAura.IsActive() {
+		ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+		return
+	}
+
+	switch ai.bossID {
+	case nezirID:
+		// Permafrost only ever hits Nezir's tank.
+		if (ai.Target.CurrentTarget != nil) && ai.permafrost.IsReady(sim) && sim.Proc(0.75, "Permafrost AI") {
+			ai.permafrost.Cast(sim, target)
+			return
+		}
+	case rohashID:
+		if ai.windBlast.IsReady(sim) {
+			ai.windBlast.Cast(sim, target)
+			return
+		}
+
+		if sim.Proc(0.25, "Slicing Gale AI") {
+			ai.slicingGale.Cast(sim, target)
+			return
+		}
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
@@ -0,0 +1,6 @@
+package totfw
+
+func Register() {
+	addConclaveOfWind("Throne of the Four Winds")
+	addAlakir("Throne of the Four Winds")
+}
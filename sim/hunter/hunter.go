@@ -46,6 +46,7 @@ type Hunter struct {
 	ScorpidSting  *core.Spell
 	SilencingShot *core.Spell
 	TrapLauncher  *core.Spell
+	Misdirection  *core.Spell
 
 	// BM only spells
 
@@ -160,6 +161,7 @@ func (hunter *Hunter) RegisterSpells() {
 	hunter.registerTrapLauncher()
 	hunter.registerHuntersMarkSpell()
 	hunter.registerAspectOfTheFoxSpell()
+	hunter.registerMisdirectionSpell()
 }
 
 func (hunter *Hunter) AddStatDependencies() {
@@ -242,6 +244,7 @@ const (
 	HunterSpellKillShot
 	HunterSpellRapidFire
 	HunterSpellBestialWrath
+	HunterSpellMisdirection
 	HunterPetFocusDump
 	HunterSpellsTierTwelve = HunterSpellArcaneShot | HunterSpellKillCommand | HunterSpellChimeraShot | HunterSpellExplosiveShot |
 		HunterSpellMultiShot | HunterSpellAimedShot
@@ -0,0 +1,76 @@
+package hunter
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (hunter *Hunter) registerMisdirectionSpell() {
+	actionID := core.ActionID{SpellID: 34477}
+
+	var mdTarget *core.Unit
+	if hunter.Options.MisdirectionTarget != nil {
+		mdTarget = hunter.GetUnit(hunter.Options.MisdirectionTarget)
+	}
+
+	var castTarget *core.Unit
+	threatTransferAura := hunter.RegisterAura(core.Aura{
+		Label:    "Misdirection Threat Transfer",
+		ActionID: core.ActionID{SpellID: 35079},
+		Duration: time.Second * 4,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			hunter.ThreatRedirect = castTarget
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			hunter.ThreatRedirect = nil
+		},
+	})
+
+	// The threat of the next damaging attack and everything for 4 seconds after it goes to the target.
+	applicationAura := hunter.RegisterAura(core.Aura{
+		Label:    "Misdirection",
+		ActionID: actionID,
+		Duration: time.Second * 30,
+		OnSpellHitDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if result.Landed() && result.Damage > 0 {
+				threatTransferAura.Activate(sim)
+				aura.Deactivate(sim)
+			}
+		},
+	})
+
+	hunter.Misdirection = hunter.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		Flags:          core.SpellFlagAPL | core.SpellFlagHelpful,
+		ClassSpellMask: HunterSpellMisdirection,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: time.Second,
+			},
+			IgnoreHaste: true,
+			CD: core.Cooldown{
+				Timer:    hunter.NewTimer(),
+				Duration: time.Second * 30,
+			},
+		},
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			castTarget = nil
+			if mdTarget != nil {
+				castTarget = mdTarget
+			} else if target.Type != core.EnemyUnit && target != &hunter.Unit {
+				castTarget = target
+			}
+			applicationAura.Activate(sim)
+		},
+	})
+
+	hunter.AddMajorCooldown(core.MajorCooldown{
+		Spell: hunter.Misdirection,
+		Type:  core.CooldownTypeDPS,
+		ShouldActivate: func(sim *core.Simulation, character *core.Character) bool {
+			return mdTarget != nil
+		},
+	})
+}
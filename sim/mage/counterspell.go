@@ -0,0 +1,40 @@
+package mage
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (mage *Mage) registerCounterspellSpell() {
+	mage.Counterspell = mage.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 2139},
+		SpellSchool:    core.SpellSchoolArcane,
+		ProcMask:       core.ProcMaskEmpty,
+		Flags:          core.SpellFlagAPL,
+		ClassSpellMask: MageSpellCounterspell,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 9,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				NonEmpty: true,
+			},
+			CD: core.Cooldown{
+				Timer:    mage.NewTimer(),
+				Duration: time.Second * 24,
+			},
+			IgnoreHaste: true,
+		},
+
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			result := spell.CalcAndDealOutcome(sim, target, spell.OutcomeMagicHit)
+			if result.Landed() {
+				spell.Interrupt(sim, target, time.Second*6)
+			}
+		},
+	})
+}
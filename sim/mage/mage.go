@@ -38,6 +38,7 @@ type Mage struct {
 	Pyroblast               *core.Spell
 	SummonWaterElemental    *core.Spell
 	IcyVeins                *core.Spell
+	Counterspell            *core.Spell
 
 	arcanePowerGCDmod *core.SpellMod
 
@@ -121,6 +122,7 @@ func (mage *Mage) Initialize() {
 	mage.registerCombustionSpell()
 	mage.registerBlastWaveSpell()
 	mage.registerDragonsBreathSpell()
+	mage.registerCounterspellSpell()
 	// mage.registerSummonWaterElementalCD()
 
 	mage.applyArcaneMissileProc()
@@ -331,6 +333,7 @@ const (
 	MageSpellMageArmor
 	MageSpellCombustion
 	MageSpellCombustionApplication
+	MageSpellCounterspell
 	MageSpellLast
 	MageSpellsAll        = MageSpellLast<<1 - 1
 	MageSpellLivingBomb  = MageSpellLivingBombDot | MageSpellLivingBombExplosion
@@ -0,0 +1,62 @@
+package paladin
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func (paladin *Paladin) registerAuraMastery() {
+	if !paladin.Talents.AuraMastery {
+		return
+	}
+
+	actionID := core.ActionID{SpellID: 31821}
+
+	// Only the Devotion Aura effect, which reduces magic damage taken by the raid, is modelled.
+	const multiplier = 0.8
+	auraMasteryAuras := paladin.NewAllyAuraArray(func(unit *core.Unit) *core.Aura {
+		return unit.GetOrRegisterAura(core.Aura{
+			Label:    "Aura Mastery-" + paladin.Label,
+			ActionID: actionID,
+			Duration: time.Second * 6,
+			OnGain: func(aura *core.Aura, sim *core.Simulation) {
+				for school := stats.SchoolIndexArcane; school < stats.SchoolLen; school++ {
+					aura.Unit.PseudoStats.SchoolDamageTakenMultiplier[school] *= multiplier
+				}
+			},
+			OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+				for school := stats.SchoolIndexArcane; school < stats.SchoolLen; school++ {
+					aura.Unit.PseudoStats.SchoolDamageTakenMultiplier[school] /= multiplier
+				}
+			},
+		})
+	})
+
+	paladin.AuraMastery = paladin.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		Flags:          core.SpellFlagNoOnCastComplete | core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: SpellMaskAuraMastery,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				NonEmpty: true,
+			},
+			CD: core.Cooldown{
+				Timer:    paladin.NewTimer(),
+				Duration: 2 * time.Minute,
+			},
+		},
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return paladin.PaladinAura == proto.PaladinAura_Devotion
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			for _, unit := range paladin.Env.Raid.GetActiveAllyUnits() {
+				auraMasteryAuras.Get(unit).Activate(sim)
+			}
+		},
+	})
+}
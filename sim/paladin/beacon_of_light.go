@@ -0,0 +1,95 @@
+package paladin
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func (paladin *Paladin) registerBeaconOfLight() {
+	if !paladin.Talents.BeaconOfLight {
+		return
+	}
+
+	actionID := core.ActionID{SpellID: 53563}
+	beaconTarget := paladin.GetUnit(paladin.BeaconTarget)
+
+	if paladin.HasMajorGlyph(proto.PaladinMajorGlyph_GlyphOfBeaconOfLight) {
+		paladin.AddStaticMod(core.SpellModConfig{
+			Kind:      core.SpellMod_PowerCost_Pct,
+			ClassMask: SpellMaskBeaconOfLight,
+			IntValue:  -100,
+		})
+	}
+
+	// Only one unit can have the paladin's Beacon of Light at a time.
+	var activeBeacon *core.Aura
+	paladin.BeaconOfLightAuras = paladin.NewAllyAuraArray(func(unit *core.Unit) *core.Aura {
+		return unit.RegisterAura(core.Aura{
+			Label:    "Beacon of Light-" + paladin.Label,
+			ActionID: actionID,
+			Duration: time.Minute * 5,
+			OnGain: func(aura *core.Aura, sim *core.Simulation) {
+				if activeBeacon != nil && activeBeacon != aura {
+					activeBeacon.Deactivate(sim)
+				}
+				activeBeacon = aura
+			},
+			OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+				if activeBeacon == aura {
+					activeBeacon = nil
+				}
+			},
+		})
+	})
+
+	paladin.BeaconOfLight = paladin.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskEmpty,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: SpellMaskBeaconOfLight,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 6,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, _ *core.Spell) {
+			if beaconTarget != nil {
+				paladin.BeaconOfLightAuras.Get(beaconTarget).Activate(sim)
+			} else {
+				paladin.BeaconOfLightAuras.Get(target).Activate(sim)
+			}
+		},
+	})
+
+	var transferAmount float64
+	transferSpell := paladin.RegisterSpell(paladin.healConfig(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 53652},
+		Flags:          core.SpellFlagIgnoreAttackerModifiers | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+		ClassSpellMask: SpellMaskBeaconOfLight,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealHealing(sim, target, transferAmount, spell.OutcomeHealing)
+		},
+	}))
+
+	core.MakePermanent(paladin.RegisterAura(core.Aura{
+		Label: "Beacon of Light Transfer",
+		OnHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if activeBeacon == nil || activeBeacon.Unit == result.Target || !spell.Matches(SpellMaskCanTriggerBeaconOfLight) {
+				return
+			}
+
+			// Holy Light is transferred in full, other heals at half strength.
+			transferAmount = result.Damage * core.TernaryFloat64(spell.Matches(SpellMaskHolyLight), 1, 0.5)
+			transferSpell.Cast(sim, activeBeacon.Unit)
+		},
+	}))
+}
@@ -0,0 +1,59 @@
+package paladin
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func (paladin *Paladin) registerDivineFavor() {
+	if !paladin.Talents.DivineFavor {
+		return
+	}
+
+	actionID := core.ActionID{SpellID: 31842}
+
+	paladin.DivineFavorAura = paladin.RegisterAura(core.Aura{
+		Label:    "Divine Favor" + paladin.Label,
+		ActionID: actionID,
+		Duration: core.TernaryDuration(paladin.HasPrimeGlyph(proto.PaladinPrimeGlyph_GlyphOfDivineFavor), 30, 20) * time.Second,
+
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			paladin.MultiplyCastSpeed(1.2)
+			paladin.AddStatDynamic(sim, stats.SpellCritPercent, 20)
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			paladin.MultiplyCastSpeed(1 / 1.2)
+			paladin.AddStatDynamic(sim, stats.SpellCritPercent, -20)
+		},
+	})
+
+	paladin.DivineFavor = paladin.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		Flags:          core.SpellFlagNoOnCastComplete | core.SpellFlagAPL,
+		ClassSpellMask: SpellMaskDivineFavor,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 3,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				NonEmpty: true,
+			},
+			CD: core.Cooldown{
+				Timer:    paladin.NewTimer(),
+				Duration: 3 * time.Minute,
+			},
+		},
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			paladin.DivineFavorAura.Activate(sim)
+		},
+	})
+
+	paladin.AddMajorCooldown(core.MajorCooldown{
+		Spell: paladin.DivineFavor,
+		Type:  core.CooldownTypeDPS,
+	})
+}
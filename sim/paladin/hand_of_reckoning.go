@@ -0,0 +1,35 @@
+package paladin
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (paladin *Paladin) registerHandOfReckoningSpell() {
+	paladin.HandOfReckoning = paladin.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 62124},
+		SpellSchool: core.SpellSchoolHoly,
+		ProcMask:    core.ProcMaskEmpty,
+		Flags:       core.SpellFlagAPL,
+		MaxRange:    30,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 3,
+		},
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    paladin.NewTimer(),
+				Duration: time.Second * 8,
+			},
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			result := spell.CalcAndDealOutcome(sim, target, spell.OutcomeMagicHit)
+			if result.Landed() {
+				spell.Unit.Taunt(sim, target)
+			}
+		},
+	})
+}
@@ -0,0 +1,100 @@
+package paladin
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+// Config shared by all of the paladin's heals.
+func (paladin *Paladin) healConfig(config core.SpellConfig) core.SpellConfig {
+	config.SpellSchool = core.SpellSchoolHoly
+	config.ProcMask = core.ProcMaskSpellHealing
+	config.Flags |= core.SpellFlagHelpful
+	config.DamageMultiplier = 1
+	config.CritMultiplier = paladin.DefaultHealingCritMultiplier()
+	config.ThreatMultiplier = 1
+	return config
+}
+
+func (paladin *Paladin) registerHolyLight() {
+	minHealing, maxHealing := core.CalcScalingSpellEffectVarianceMinMax(proto.Class_ClassPaladin, 3.19400000572, 0.10800000280)
+
+	paladin.HolyLight = paladin.RegisterSpell(paladin.healConfig(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 635},
+		Flags:          core.SpellFlagAPL,
+		ClassSpellMask: SpellMaskHolyLight,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 12,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 2500,
+			},
+		},
+
+		BonusCoefficient: 0.43200001121,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseHealing := sim.Roll(minHealing, maxHealing)
+			spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+		},
+	}))
+}
+
+func (paladin *Paladin) registerDivineLight() {
+	minHealing, maxHealing := core.CalcScalingSpellEffectVarianceMinMax(proto.Class_ClassPaladin, 6.38700008392, 0.10800000280)
+
+	paladin.DivineLight = paladin.RegisterSpell(paladin.healConfig(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 82326},
+		Flags:          core.SpellFlagAPL,
+		ClassSpellMask: SpellMaskDivineLight,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 30,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 2500,
+			},
+		},
+
+		BonusCoefficient: 0.64800000191,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseHealing := sim.Roll(minHealing, maxHealing)
+			spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+		},
+	}))
+}
+
+func (paladin *Paladin) registerFlashOfLight() {
+	minHealing, maxHealing := core.CalcScalingSpellEffectVarianceMinMax(proto.Class_ClassPaladin, 6.13199996948, 0.10800000280)
+
+	paladin.FlashOfLight = paladin.RegisterSpell(paladin.healConfig(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 19750},
+		Flags:          core.SpellFlagAPL,
+		ClassSpellMask: SpellMaskFlashOfLight,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 31,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 1500,
+			},
+		},
+
+		BonusCoefficient: 0.43200001121,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseHealing := sim.Roll(minHealing, maxHealing)
+			spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+		},
+	}))
+}
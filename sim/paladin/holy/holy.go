@@ -1,6 +1,8 @@
 package holy
 
 import (
+	"time"
+
 	"github.com/wowsims/cata/sim/core"
 	"github.com/wowsims/cata/sim/core/proto"
 	"github.com/wowsims/cata/sim/core/stats"
@@ -32,6 +34,8 @@ func NewHolyPaladin(character *core.Character, options *proto.Player) *HolyPalad
 		Options: holyOptions.Options,
 	}
 
+	holy.BeaconTarget = holyOptions.Options.BeaconTarget
+
 	return holy
 }
 
@@ -48,12 +52,75 @@ func (holy *HolyPaladin) GetPaladin() *paladin.Paladin {
 func (holy *HolyPaladin) ApplyTalents() {
 	holy.Paladin.ApplyTalents()
 	holy.ApplyArmorSpecializationEffect(stats.Intellect, proto.ArmorType_ArmorTypePlate, 86525)
+
+	// Walk in the Light
+	holy.PseudoStats.HealingDealtMultiplier *= 1.1
+	core.MakePermanent(holy.RegisterAura(core.Aura{
+		Label:    "Walk in the Light",
+		ActionID: core.ActionID{SpellID: 85102},
+	}))
+
+	// Meditation
+	holy.PseudoStats.SpiritRegenRateCombat = 0.5
 }
 
 func (holy *HolyPaladin) Initialize() {
 	holy.Paladin.Initialize()
+	holy.Paladin.RegisterHealingSpells()
+	holy.registerHolyShock()
+	holy.applyIlluminatedHealing()
 }
 
 func (holy *HolyPaladin) Reset(sim *core.Simulation) {
 	holy.Paladin.Reset(sim)
 }
+
+func illuminatedHealingBonus(masteryPoints float64) float64 {
+	return (12 + masteryPoints*1.5) / 100
+}
+
+// Mastery: Illuminated Healing, direct heals also place an absorb shield on the target.
+func (holy *HolyPaladin) applyIlluminatedHealing() {
+	shieldSpell := holy.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 86273},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagNoOnCastComplete | core.SpellFlagHelpful | core.SpellFlagPassiveSpell,
+		ClassSpellMask: paladin.SpellMaskIlluminatedHealing,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+
+		Shield: core.ShieldConfig{
+			Aura: core.Aura{
+				Label:    "Illuminated Healing",
+				Duration: time.Second * 15,
+			},
+		},
+	})
+
+	absorbPercent := illuminatedHealingBonus(holy.GetMasteryPoints())
+	holy.AddOnMasteryStatChanged(func(sim *core.Simulation, oldMastery, newMastery float64) {
+		absorbPercent = illuminatedHealingBonus(core.MasteryRatingToMasteryPoints(newMastery))
+	})
+
+	core.MakePermanent(holy.RegisterAura(core.Aura{
+		Label:    "Illuminated Healing Mastery",
+		ActionID: core.ActionID{SpellID: 76669},
+		OnHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if !spell.Matches(paladin.SpellMaskDirectHeals) || result.Damage <= 0 {
+				return
+			}
+
+			// The shield stacks with itself, up to a third of the paladin's maximum health.
+			shield := shieldSpell.Shield(result.Target)
+			shieldAmount := result.Damage * absorbPercent
+			if shield.IsActive() {
+				shieldAmount += shield.ShieldStrength / shieldSpell.DamageMultiplier
+			}
+			shieldAmount = min(shieldAmount, holy.MaxHealth()/3/shieldSpell.DamageMultiplier)
+
+			shield.Apply(sim, shieldAmount)
+		},
+	}))
+}
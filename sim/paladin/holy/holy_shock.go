@@ -0,0 +1,56 @@
+package holy
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/paladin"
+)
+
+func (holy *HolyPaladin) registerHolyShock() {
+	actionID := core.ActionID{SpellID: 20473}
+	hpMetrics := holy.NewHolyPowerMetrics(actionID)
+	minHealing, maxHealing := core.CalcScalingSpellEffectVarianceMinMax(proto.Class_ClassPaladin, 2.72000002861, 0.07999999821)
+
+	if holy.HasPrimeGlyph(proto.PaladinPrimeGlyph_GlyphOfHolyShock) {
+		holy.AddStaticMod(core.SpellModConfig{
+			Kind:       core.SpellMod_BonusCrit_Percent,
+			ClassMask:  paladin.SpellMaskHolyShockHeal,
+			FloatValue: 5,
+		})
+	}
+
+	holy.HolyShock = holy.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: paladin.SpellMaskHolyShockHeal,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 7,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    holy.NewTimer(),
+				Duration: time.Second * 6,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   holy.DefaultHealingCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		BonusCoefficient: 0.26899999380,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseHealing := sim.Roll(minHealing, maxHealing)
+			spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+			holy.GainHolyPower(sim, 1, hpMetrics)
+		},
+	})
+}
@@ -1,103 +1,73 @@
 package holy
 
 import (
+	"testing"
+
 	_ "github.com/wowsims/cata/sim/common" // imported to get item effects included.
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
 )
 
 func init() {
 	RegisterHolyPaladin()
 }
 
-// func TestHoly(t *testing.T) {
-// 	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator(core.CharacterSuiteConfig{
-// 		Class:      proto.Class_ClassPaladin,
-// 		Race:       proto.Race_RaceBloodElf,
-// 		OtherRaces: []proto.Race{proto.Race_RaceHuman},
-
-// 		GearSet:     core.GetGearSet("../../../ui/holy_paladin/gear_sets", "p1"),
-// 		Talents:     StandardTalents,
-// 		Glyphs:      StandardGlyphs,
-// 		Consumes:    FullConsumes,
-// 		SpecOptions: core.SpecOptionsCombo{Label: "Basic", SpecOptions: BasicOptions},
-// 		Rotation:    core.RotationCombo{Label: "Default", Rotation: DefaultRotation},
-
-// 		IsHealer:        true,
-// 		InFrontOfTarget: true,
-
-// 		ItemFilter: core.ItemFilter{
-// 			WeaponTypes: []proto.WeaponType{
-// 				proto.WeaponType_WeaponTypeSword,
-// 				proto.WeaponType_WeaponTypePolearm,
-// 				proto.WeaponType_WeaponTypeMace,
-// 				proto.WeaponType_WeaponTypeShield,
-// 			},
-// 			ArmorType: proto.ArmorType_ArmorTypePlate,
-// 			RangedWeaponTypes: []proto.RangedWeaponType{
-// 				proto.RangedWeaponType_RangedWeaponTypeRelic,
-// 			},
-// 		},
-// 	}))
-// }
-
-// func BenchmarkSimulate(b *testing.B) {
-// 	rsr := &proto.RaidSimRequest{
-// 		Raid: core.SinglePlayerRaidProto(
-// 			&proto.Player{
-// 				Race:          proto.Race_RaceBloodElf,
-// 				Class:         proto.Class_ClassPaladin,
-// 				Equipment:     core.GetGearSet("../../../ui/holy_paladin/gear_sets", "p1").GearSet,
-// 				Consumes:      FullConsumes,
-// 				Spec:          BasicOptions,
-// 				TalentsString: StandardTalents,
-// 				Glyphs:        StandardGlyphs,
-// 				Buffs:         core.FullIndividualBuffs,
-// 			},
-// 			core.FullPartyBuffs,
-// 			core.FullRaidBuffs,
-// 			core.FullDebuffs),
-// 		Encounter: &proto.Encounter{
-// 			Duration: 300,
-// 			Targets: []*proto.Target{
-// 				core.NewDefaultTarget(),
-// 			},
-// 		},
-// 		SimOptions: core.AverageDefaultSimTestOptions,
-// 	}
+func TestHoly(t *testing.T) {
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator(core.CharacterSuiteConfig{
+		Class:      proto.Class_ClassPaladin,
+		Race:       proto.Race_RaceBloodElf,
+		OtherRaces: []proto.Race{proto.Race_RaceHuman},
 
-// 	core.RaidBenchmark(b, rsr)
-// }
+		GearSet:     core.GetGearSet("../../../ui/paladin/holy/gear_sets", "preraid"),
+		Talents:     StandardTalents,
+		Glyphs:      StandardGlyphs,
+		Consumes:    FullConsumes,
+		SpecOptions: core.SpecOptionsCombo{Label: "Basic", SpecOptions: BasicOptions},
+		Rotation:    core.GetAplRotation("../../../ui/paladin/holy/apls", "default"),
 
-// var StandardTalents = "50350151020013053100515221-50023131203"
-// var StandardGlyphs = &proto.Glyphs{
-// 	Major1: int32(proto.PaladinMajorGlyph_GlyphOfHolyLight),
-// 	Major2: int32(proto.PaladinMajorGlyph_GlyphOfSealOfWisdom),
-// 	Major3: int32(proto.PaladinMajorGlyph_GlyphOfBeaconOfLight),
-// 	Minor1: int32(proto.PaladinMinorGlyph_GlyphOfLayOnHands),
-// 	Minor2: int32(proto.PaladinMinorGlyph_GlyphOfSenseUndead),
-// }
+		IsHealer:        true,
+		InFrontOfTarget: true,
 
-// var defaultProtOptions = &proto.HolyPaladin_Options{
-// 	Judgement: proto.PaladinJudgement_JudgementOfWisdom,
-// 	Aura:      proto.PaladinAura_DevotionAura,
-// }
+		ItemFilter: core.ItemFilter{
+			WeaponTypes: []proto.WeaponType{
+				proto.WeaponType_WeaponTypeSword,
+				proto.WeaponType_WeaponTypePolearm,
+				proto.WeaponType_WeaponTypeMace,
+				proto.WeaponType_WeaponTypeShield,
+			},
+			ArmorType: proto.ArmorType_ArmorTypePlate,
+			RangedWeaponTypes: []proto.RangedWeaponType{
+				proto.RangedWeaponType_RangedWeaponTypeRelic,
+			},
+		},
+	}))
+}
 
-// var BasicOptions = &proto.Player_HolyPaladin{
-// 	HolyPaladin: &proto.HolyPaladin{
-// 		Options: defaultProtOptions,
-// 	},
-// }
+var StandardTalents = "03332001222131312301-302-03"
+var StandardGlyphs = &proto.Glyphs{
+	Prime1: int32(proto.PaladinPrimeGlyph_GlyphOfHolyShock),
+	Prime2: int32(proto.PaladinPrimeGlyph_GlyphOfSealOfInsight),
+	Prime3: int32(proto.PaladinPrimeGlyph_GlyphOfWordOfGlory),
+	Major1: int32(proto.PaladinMajorGlyph_GlyphOfBeaconOfLight),
+	Major2: int32(proto.PaladinMajorGlyph_GlyphOfDivinePlea),
+	Major3: int32(proto.PaladinMajorGlyph_GlyphOfLightOfDawn),
+}
 
-// var FullConsumes = &proto.Consumes{
-// 	Flask:           proto.Flask_FlaskOfStoneblood,
-// 	Food:            proto.Food_FoodDragonfinFilet,
-// 	DefaultPotion:   proto.Potions_IndestructiblePotion,
-// 	PrepopPotion:    proto.Potions_IndestructiblePotion,
-// 	DefaultConjured: proto.Conjured_ConjuredDarkRune,
-// }
+var BasicOptions = &proto.Player_HolyPaladin{
+	HolyPaladin: &proto.HolyPaladin{
+		Options: &proto.HolyPaladin_Options{
+			ClassOptions: &proto.PaladinOptions{
+				Seal: proto.PaladinSeal_Insight,
+				Aura: proto.PaladinAura_Devotion,
+			},
+			BeaconTarget: &proto.UnitReference{Type: proto.UnitReference_Player, Index: 0},
+		},
+	},
+}
 
-// var DefaultRotation = core.APLRotationFromJsonString(`{
-// 	"type": "TypeAPL",
-// 	"priorityList": [
-// 		{"action":{"autocastOtherCooldowns":{}}}
-// 	]
-// }`)
+var FullConsumes = &proto.Consumes{
+	Flask:         proto.Flask_FlaskOfTheDraconicMind,
+	Food:          proto.Food_FoodSeafoodFeast,
+	DefaultPotion: proto.Potions_MythicalManaPotion,
+	PrepopPotion:  proto.Potions_VolcanicPotion,
+}
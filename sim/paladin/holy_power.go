@@ -30,6 +30,10 @@ func (paladin *Paladin) initializeHolyPowerBar() {
 		paladin:   paladin,
 		holyPower: paladin.StartingHolyPower,
 	}
+
+	paladin.RegisterResourceLevel(proto.ResourceType_ResourceTypeHolyPower, func() float64 {
+		return float64(paladin.CurrentHolyPower())
+	})
 }
 
 func (pb *HolyPowerBar) Reset() {
@@ -0,0 +1,49 @@
+package paladin
+
+import (
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func (paladin *Paladin) registerLightOfDawn() {
+	if !paladin.Talents.LightOfDawn {
+		return
+	}
+
+	actionID := core.ActionID{SpellID: 85222}
+	hpMetrics := paladin.NewHolyPowerMetrics(actionID)
+	minHealing, maxHealing := core.CalcScalingSpellEffectVarianceMinMax(proto.Class_ClassPaladin, 0.62699997425, 0.10800000280)
+
+	numTargets := core.TernaryInt32(paladin.HasMajorGlyph(proto.PaladinMajorGlyph_GlyphOfLightOfDawn), 6, 5)
+
+	paladin.LightOfDawn = paladin.RegisterSpell(paladin.healConfig(core.SpellConfig{
+		ActionID:       actionID,
+		Flags:          core.SpellFlagAPL,
+		ClassSpellMask: SpellMaskLightOfDawn,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+		},
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return paladin.GetHolyPowerValue() > 0
+		},
+
+		BonusCoefficient: 0.13199999928,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			holyPower := float64(paladin.GetHolyPowerValue())
+
+			// Light of Dawn heals the most injured allies in front of the paladin.
+			for _, target := range paladin.Env.Raid.GetLowestHealthUnits(numTargets) {
+				baseHealing := sim.Roll(minHealing, maxHealing)
+
+				spell.DamageMultiplier *= holyPower
+				spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+				spell.DamageMultiplier /= holyPower
+			}
+			paladin.SpendHolyPower(sim, hpMetrics)
+		},
+	}))
+}
@@ -44,9 +44,19 @@ const (
 	SpellMaskShieldOfTheRighteous
 	SpellMaskHolyShield
 	SpellMaskArdentDefender
+	SpellMaskRebuke
 
 	SpellMaskHolyShock
 	SpellMaskWordOfGlory
+	SpellMaskHolyShockHeal
+	SpellMaskHolyLight
+	SpellMaskDivineLight
+	SpellMaskFlashOfLight
+	SpellMaskLightOfDawn
+	SpellMaskBeaconOfLight
+	SpellMaskIlluminatedHealing
+	SpellMaskDivineFavor
+	SpellMaskAuraMastery
 
 	SpellMaskSealOfTruth
 	SpellMaskSealOfInsight
@@ -95,7 +105,23 @@ const SpellMaskCanTriggerDivinePurpose = SpellMaskHammerOfWrath |
 	SpellMaskInquisition
 
 const SpellMaskCanConsumeDivinePurpose = SpellMaskInquisition |
-	SpellMaskTemplarsVerdict
+	SpellMaskTemplarsVerdict |
+	SpellMaskWordOfGlory |
+	SpellMaskLightOfDawn
+
+const SpellMaskDirectHeals = SpellMaskHolyLight |
+	SpellMaskDivineLight |
+	SpellMaskFlashOfLight |
+	SpellMaskHolyShockHeal |
+	SpellMaskWordOfGlory |
+	SpellMaskLightOfDawn
+
+// Single target heals which are copied to the Beacon of Light target.
+const SpellMaskCanTriggerBeaconOfLight = SpellMaskHolyLight |
+	SpellMaskDivineLight |
+	SpellMaskFlashOfLight |
+	SpellMaskHolyShockHeal |
+	SpellMaskWordOfGlory
 
 const SpellMaskModifiedByTwoHandedSpec = SpellMaskJudgement |
 	SpellMaskSealOfTruth |
@@ -141,6 +167,9 @@ type Paladin struct {
 	CurrentJudgement  *core.Spell
 	StartingHolyPower int32
 
+	// Unit which Beacon of Light is placed on, if set.
+	BeaconTarget *proto.UnitReference
+
 	// Pets
 	AncientGuardian *AncientGuardianPet
 
@@ -167,6 +196,16 @@ type Paladin struct {
 	JudgementOfRighteousness *core.Spell
 	JudgementOfJustice       *core.Spell
 	ShieldOfTheRighteous     *core.Spell
+	Rebuke                   *core.Spell
+	HolyShock                *core.Spell
+	WordOfGlory              *core.Spell
+	HolyLight                *core.Spell
+	DivineLight              *core.Spell
+	FlashOfLight             *core.Spell
+	LightOfDawn              *core.Spell
+	BeaconOfLight            *core.Spell
+	DivineFavor              *core.Spell
+	AuraMastery              *core.Spell
 
 	HolyShieldAura          *core.Aura
 	RighteousFuryAura       *core.Aura
@@ -185,6 +224,10 @@ type Paladin struct {
 	SacredDutyAura          *core.Aura
 	GoakAura                *core.Aura
 	AncientPowerAura        *core.Aura
+	InfusionOfLightAura     *core.Aura
+	DivineFavorAura         *core.Aura
+
+	BeaconOfLightAuras core.AuraArray
 
 	// Cached Gurthalak tentacles
 	gurthalakTentacles []*cata.TentacleOfTheOldOnesPet
@@ -267,6 +310,20 @@ func (paladin *Paladin) registerSpells() {
 	paladin.registerHolyWrath()
 	paladin.registerGuardianOfAncientKings()
 	paladin.registerDivineProtectionSpell()
+	paladin.registerRebukeSpell()
+	paladin.registerHandOfReckoningSpell()
+}
+
+// Registers the heals, which are only used by Holy.
+func (paladin *Paladin) RegisterHealingSpells() {
+	paladin.registerHolyLight()
+	paladin.registerDivineLight()
+	paladin.registerFlashOfLight()
+	paladin.registerWordOfGlory()
+	paladin.registerLightOfDawn()
+	paladin.registerBeaconOfLight()
+	paladin.registerDivineFavor()
+	paladin.registerAuraMastery()
 }
 
 func (paladin *Paladin) Reset(sim *core.Simulation) {
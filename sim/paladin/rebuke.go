@@ -0,0 +1,40 @@
+package paladin
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (paladin *Paladin) registerRebukeSpell() {
+	paladin.Rebuke = paladin.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 96231},
+		SpellSchool:    core.SpellSchoolPhysical,
+		ProcMask:       core.ProcMaskEmpty,
+		Flags:          core.SpellFlagMeleeMetrics | core.SpellFlagAPL,
+		ClassSpellMask: SpellMaskRebuke,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 9,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				NonEmpty: true,
+			},
+			CD: core.Cooldown{
+				Timer:    paladin.NewTimer(),
+				Duration: 10 * time.Second,
+			},
+			IgnoreHaste: true,
+		},
+
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			result := spell.CalcAndDealOutcome(sim, target, spell.OutcomeMeleeSpecialHit)
+			if result.Landed() {
+				spell.Interrupt(sim, target, 4*time.Second)
+			}
+		},
+	})
+}
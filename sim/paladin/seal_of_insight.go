@@ -5,9 +5,14 @@ import (
 	"time"
 
 	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
 )
 
 func (paladin *Paladin) registerSealOfInsight() {
+	judgementManaMetrics := paladin.NewManaMetrics(core.ActionID{SpellID: 54158})
+	// Judgement of Insight also returns 15% of base mana.
+	judgementMana := math.Round(0.15 * paladin.BaseMana)
+
 	// Judgement of Insight cast on Judgement
 	paladin.JudgementOfInsight = paladin.RegisterSpell(core.SpellConfig{
 		ActionID:       core.ActionID{SpellID: 54158},
@@ -26,6 +31,7 @@ func (paladin *Paladin) registerSealOfInsight() {
 				0.15999999642*spell.MeleeAttackPower()
 
 			spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMeleeSpecialNoBlockDodgeParry)
+			paladin.AddMana(sim, judgementMana, judgementManaMetrics)
 		},
 	})
 
@@ -54,6 +60,9 @@ func (paladin *Paladin) registerSealOfInsight() {
 		},
 	})
 
+	// Glyph of Seal of Insight increases healing done while the seal is active.
+	healingMultiplier := core.TernaryFloat64(paladin.HasPrimeGlyph(proto.PaladinPrimeGlyph_GlyphOfSealOfInsight), 1.05, 1)
+
 	dpm := paladin.AutoAttacks.NewPPMManager(15, core.ProcMaskMeleeMH)
 	paladin.SealOfInsightAura = paladin.RegisterAura(core.Aura{
 		Label:    "Seal of Insight" + paladin.Label,
@@ -62,6 +71,13 @@ func (paladin *Paladin) registerSealOfInsight() {
 		Duration: time.Minute * 30,
 		Dpm:      dpm,
 
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			paladin.PseudoStats.HealingDealtMultiplier *= healingMultiplier
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			paladin.PseudoStats.HealingDealtMultiplier /= healingMultiplier
+		},
+
 		OnSpellHitDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
 			// Don't proc on misses
 			if !result.Landed() {
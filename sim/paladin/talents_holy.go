@@ -12,6 +12,9 @@ func (paladin *Paladin) applyHolyTalents() {
 	paladin.applyJudgementsOfThePure()
 	paladin.applyBlazingLight()
 	paladin.applyDenounce()
+	paladin.applyClarityOfPurpose()
+	paladin.applyInfusionOfLight()
+	paladin.applyTowerOfRadiance()
 }
 
 func (paladin *Paladin) applyArbiterOfTheLight() {
@@ -99,3 +102,90 @@ func (paladin *Paladin) applyDenounce() {
 		IntValue:  -([]int32{0, 38, 75}[paladin.Talents.Denounce]),
 	})
 }
+
+func (paladin *Paladin) applyClarityOfPurpose() {
+	if paladin.Talents.ClarityOfPurpose == 0 {
+		return
+	}
+
+	paladin.AddStaticMod(core.SpellModConfig{
+		ClassMask: SpellMaskHolyLight | SpellMaskDivineLight,
+		Kind:      core.SpellMod_CastTime_Flat,
+		TimeValue: -time.Millisecond * 150 * time.Duration(paladin.Talents.ClarityOfPurpose),
+	})
+}
+
+func (paladin *Paladin) applyInfusionOfLight() {
+	if paladin.Talents.InfusionOfLight == 0 {
+		return
+	}
+
+	paladin.AddStaticMod(core.SpellModConfig{
+		ClassMask:  SpellMaskHolyShockHeal,
+		Kind:       core.SpellMod_BonusCrit_Percent,
+		FloatValue: 5 * float64(paladin.Talents.InfusionOfLight),
+	})
+
+	castTimeMod := paladin.AddDynamicMod(core.SpellModConfig{
+		ClassMask: SpellMaskDivineLight,
+		Kind:      core.SpellMod_CastTime_Flat,
+		TimeValue: -time.Millisecond * 750 * time.Duration(paladin.Talents.InfusionOfLight),
+	})
+
+	paladin.InfusionOfLightAura = paladin.RegisterAura(core.Aura{
+		Label:    "Infusion of Light" + paladin.Label,
+		ActionID: core.ActionID{SpellID: 54149},
+		Duration: time.Second * 15,
+
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			castTimeMod.Activate()
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			castTimeMod.Deactivate()
+		},
+		OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
+			if spell.Matches(SpellMaskDivineLight) {
+				aura.Deactivate(sim)
+			}
+		},
+	})
+
+	core.MakeProcTriggerAura(&paladin.Unit, core.ProcTrigger{
+		Name:           "Infusion of Light (Proc)" + paladin.Label,
+		Callback:       core.CallbackOnHealDealt,
+		Outcome:        core.OutcomeCrit,
+		ClassSpellMask: SpellMaskHolyShockHeal,
+
+		Handler: func(sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			paladin.InfusionOfLightAura.Activate(sim)
+		},
+	})
+}
+
+func (paladin *Paladin) applyTowerOfRadiance() {
+	if paladin.Talents.TowerOfRadiance == 0 {
+		return
+	}
+
+	actionID := core.ActionID{SpellID: 88852}
+	hpMetrics := paladin.NewHolyPowerMetrics(actionID)
+	procChance := []float64{0, 0.33, 0.66, 1}[paladin.Talents.TowerOfRadiance]
+
+	core.MakeProcTriggerAura(&paladin.Unit, core.ProcTrigger{
+		Name:           "Tower of Radiance" + paladin.Label,
+		ActionID:       actionID,
+		Callback:       core.CallbackOnHealDealt,
+		ClassSpellMask: SpellMaskFlashOfLight | SpellMaskDivineLight,
+
+		Handler: func(sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			// Only heals on the Beacon of Light target generate holy power.
+			if paladin.BeaconOfLightAuras == nil || !paladin.BeaconOfLightAuras.Get(result.Target).IsActive() {
+				return
+			}
+
+			if sim.Proc(procChance, "Tower of Radiance"+paladin.Label) {
+				paladin.GainHolyPower(sim, 1, hpMetrics)
+			}
+		},
+	})
+}
@@ -0,0 +1,46 @@
+package paladin
+
+import (
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func (paladin *Paladin) registerWordOfGlory() {
+	actionID := core.ActionID{SpellID: 85673}
+	hpMetrics := paladin.NewHolyPowerMetrics(actionID)
+	minHealing, maxHealing := core.CalcScalingSpellEffectVarianceMinMax(proto.Class_ClassPaladin, 2.13299989700, 0.10800000280)
+
+	if paladin.HasPrimeGlyph(proto.PaladinPrimeGlyph_GlyphOfWordOfGlory) {
+		paladin.AddStaticMod(core.SpellModConfig{
+			Kind:       core.SpellMod_DamageDone_Flat,
+			ClassMask:  SpellMaskWordOfGlory,
+			FloatValue: 0.1,
+		})
+	}
+
+	paladin.WordOfGlory = paladin.RegisterSpell(paladin.healConfig(core.SpellConfig{
+		ActionID:       actionID,
+		Flags:          core.SpellFlagAPL,
+		ClassSpellMask: SpellMaskWordOfGlory,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+		},
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return paladin.GetHolyPowerValue() > 0
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			holyPower := float64(paladin.GetHolyPowerValue())
+
+			baseHealing := sim.Roll(minHealing, maxHealing) +
+				0.209*spell.HealingPower(target) +
+				0.198*spell.MeleeAttackPower()
+
+			spell.CalcAndDealHealing(sim, target, baseHealing*holyPower, spell.OutcomeHealingCrit)
+			paladin.SpendHolyPower(sim, hpMetrics)
+		},
+	}))
+}
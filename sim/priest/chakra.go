@@ -0,0 +1,232 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+const chakraDuration = time.Second * 30
+
+func (priest *Priest) registerChakraSpells() {
+	if !priest.Talents.Chakra {
+		return
+	}
+
+	// Chakra: Serenity
+	serenityCritMod := priest.AddDynamicMod(core.SpellModConfig{
+		ClassMask:  PriestSpellDirectHeal,
+		FloatValue: 10,
+		Kind:       core.SpellMod_BonusCrit_Percent,
+	})
+	priest.ChakraSerenityAura = priest.RegisterAura(core.Aura{
+		Label:    "Chakra: Serenity",
+		ActionID: core.ActionID{SpellID: 81208},
+		Duration: chakraDuration,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			serenityCritMod.Activate()
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			serenityCritMod.Deactivate()
+		},
+	})
+
+	// Chakra: Sanctuary
+	sanctuaryHealingMod := priest.AddDynamicMod(core.SpellModConfig{
+		ClassMask:  PriestSpellAoeHeal,
+		FloatValue: 0.15,
+		Kind:       core.SpellMod_DamageDone_Pct,
+	})
+	sanctuaryCooldownMod := priest.AddDynamicMod(core.SpellModConfig{
+		ClassMask: PriestSpellCircleOfHealing,
+		TimeValue: time.Second * -2,
+		Kind:      core.SpellMod_Cooldown_Flat,
+	})
+	priest.ChakraSanctuaryAura = priest.RegisterAura(core.Aura{
+		Label:    "Chakra: Sanctuary",
+		ActionID: core.ActionID{SpellID: 81206},
+		Duration: chakraDuration,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			sanctuaryHealingMod.Activate()
+			sanctuaryCooldownMod.Activate()
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			sanctuaryHealingMod.Deactivate()
+			sanctuaryCooldownMod.Deactivate()
+		},
+	})
+
+	// Chakra: Chastise
+	chastiseDamageMod := priest.AddDynamicMod(core.SpellModConfig{
+		School:     core.SpellSchoolHoly | core.SpellSchoolShadow,
+		ProcMask:   core.ProcMaskSpellDamage,
+		FloatValue: 0.15,
+		Kind:       core.SpellMod_DamageDone_Pct,
+	})
+	priest.ChakraChastiseAura = priest.RegisterAura(core.Aura{
+		Label:    "Chakra: Chastise",
+		ActionID: core.ActionID{SpellID: 81209},
+		Duration: chakraDuration,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			chastiseDamageMod.Activate()
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			chastiseDamageMod.Deactivate()
+		},
+	})
+
+	states := []*core.Aura{priest.ChakraSerenityAura, priest.ChakraSanctuaryAura, priest.ChakraChastiseAura}
+	enterState := func(sim *core.Simulation, state *core.Aura) {
+		for _, other := range states {
+			if other != state {
+				other.Deactivate(sim)
+			}
+		}
+		state.Activate(sim)
+	}
+
+	// The next spell cast after using Chakra decides which state is entered.
+	priest.ChakraAura = priest.RegisterAura(core.Aura{
+		Label:    "Chakra",
+		ActionID: core.ActionID{SpellID: 14751},
+		Duration: chakraDuration,
+		OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
+			switch {
+			case spell.Matches(PriestSpellHeal | PriestSpellFlashHeal | PriestSpellGreaterHeal | PriestSpellBindingHeal):
+				enterState(sim, priest.ChakraSerenityAura)
+			case spell.Matches(PriestSpellPrayerOfHealing | PriestSpellPrayerOfMending):
+				enterState(sim, priest.ChakraSanctuaryAura)
+			case spell.Matches(PriestSpellSmite | PriestSpellMindSpike):
+				enterState(sim, priest.ChakraChastiseAura)
+			default:
+				return
+			}
+			aura.Deactivate(sim)
+		},
+	})
+
+	priest.Chakra = priest.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 14751},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskEmpty,
+		Flags:          core.SpellFlagNoOnCastComplete | core.SpellFlagAPL,
+		ClassSpellMask: PriestSpellChakra,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    priest.NewTimer(),
+				Duration: time.Second * 30,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			priest.ChakraAura.Activate(sim)
+		},
+	})
+
+	if priest.Talents.Revelations {
+		priest.registerHolyWordSerenitySpell()
+		priest.registerHolyWordSanctuarySpell()
+	}
+
+	if priest.Talents.TomeOfLight > 0 {
+		priest.AddStaticMod(core.SpellModConfig{
+			ClassMask:  PriestSpellHolyWordChastise | PriestSpellHolyWordSerenity | PriestSpellHolyWordSanctuary,
+			FloatValue: -0.15 * float64(priest.Talents.TomeOfLight),
+			Kind:       core.SpellMod_Cooldown_Multiplier,
+		})
+	}
+}
+
+func (priest *Priest) registerHolyWordSerenitySpell() {
+	actionID := core.ActionID{SpellID: 88684}
+
+	priest.SerenityAuras = priest.NewAllyAuraArray(func(unit *core.Unit) *core.Aura {
+		return unit.GetOrRegisterAura(core.Aura{
+			Label:    "Holy Word: Serenity-" + priest.Label,
+			ActionID: actionID,
+			Duration: time.Second * 6,
+		})
+	})
+
+	priest.HolyWordSerenity = priest.RegisterSpell(priest.directHealConfig(core.SpellConfig{
+		ActionID:       actionID,
+		ClassSpellMask: PriestSpellHolyWordSerenity,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 8,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    priest.NewTimer(),
+				Duration: time.Second * 15,
+			},
+		},
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return priest.ChakraSerenityAura.IsActive()
+		},
+
+		BonusCoefficient: 0.486,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			priest.calcAndDealDirectHeal(sim, spell, target, priest.calcBaseDamage(sim, 5.912, 0.16))
+			priest.SerenityAuras.Get(target).Activate(sim)
+		},
+	}))
+}
+
+func (priest *Priest) registerHolyWordSanctuarySpell() {
+	priest.HolyWordSanctuary = priest.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 88685},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: PriestSpellHolyWordSanctuary,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 44,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    priest.NewTimer(),
+				Duration: time.Second * 40,
+			},
+		},
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return priest.ChakraSanctuaryAura.IsActive()
+		},
+
+		BonusCoefficient:         0.0583,
+		DamageMultiplier:         1,
+		DamageMultiplierAdditive: 1,
+		CritMultiplier:           priest.DefaultHealingCritMultiplier(),
+		ThreatMultiplier:         1,
+
+		// Ground effect which heals up to 6 allies standing in it every 2 seconds.
+		Hot: core.DotConfig{
+			SelfOnly: true,
+			Aura: core.Aura{
+				Label: "Holy Word: Sanctuary",
+			},
+			NumberOfTicks: 15,
+			TickLength:    time.Second * 2,
+
+			OnTick: func(sim *core.Simulation, _ *core.Unit, dot *core.Dot) {
+				spell := dot.Spell
+				for _, raidUnit := range priest.Env.Raid.GetLowestHealthUnits(6) {
+					spell.CalcAndDealHealing(sim, raidUnit, priest.calcBaseDamage(sim, 0.108, 0.2), spell.OutcomeHealingCrit)
+				}
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			spell.SelfHot().Apply(sim)
+		},
+	})
+}
@@ -0,0 +1,57 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func (priest *Priest) registerCircleOfHealingSpell() {
+	if !priest.Talents.CircleOfHealing {
+		return
+	}
+
+	numTargets := int32(5)
+	if priest.HasMajorGlyph(proto.PriestMajorGlyph_GlyphOfCircleOfHealing) {
+		numTargets++
+		priest.AddStaticMod(core.SpellModConfig{
+			ClassMask: PriestSpellCircleOfHealing,
+			IntValue:  20,
+			Kind:      core.SpellMod_PowerCost_Pct,
+		})
+	}
+
+	priest.CircleOfHealing = priest.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 34861},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: PriestSpellCircleOfHealing,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 21,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    priest.NewTimer(),
+				Duration: time.Second * 10,
+			},
+		},
+
+		BonusCoefficient:         0.207,
+		DamageMultiplier:         1,
+		DamageMultiplierAdditive: 1,
+		CritMultiplier:           priest.DefaultHealingCritMultiplier(),
+		ThreatMultiplier:         1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			for _, raidUnit := range priest.Env.Raid.GetLowestHealthUnits(numTargets) {
+				spell.CalcAndDealHealing(sim, raidUnit, priest.calcBaseDamage(sim, 2.571, 0.1), spell.OutcomeHealingCrit)
+			}
+		},
+	})
+}
@@ -55,24 +55,56 @@ func (discPriest *DisciplinePriest) GetPriest() *priest.Priest {
 	return discPriest.Priest
 }
 
-func (discPriest *DisciplinePriest) GetMainTarget() *core.Unit {
-	target := discPriest.Env.Raid.GetFirstTargetDummy()
-	if target == nil {
-		return &discPriest.Unit
-	} else {
-		return &target.Unit
-	}
-}
-
 func (discPriest *DisciplinePriest) Initialize() {
-	discPriest.CurrentTarget = discPriest.GetMainTarget()
 	discPriest.Priest.Initialize()
-	// discPriest.Priest.RegisterHealingSpells()
+	discPriest.RegisterHealingSpells()
+
+	discPriest.RegisterSmiteSpell()
+	discPriest.RegisterHolyFireSpell()
+	discPriest.RegisterPenanceSpell()
+	discPriest.RegisterPenanceHealSpell()
 
 	// // discPriest.ApplyRapture(discPriest.Options.RapturesPerMinute)
 	// discPriest.RegisterHymnOfHopeCD()
 }
 
 func (discPriest *DisciplinePriest) Reset(sim *core.Simulation) {
-	//discPriest.Priest.Reset(sim)
+	discPriest.Priest.Reset(sim)
+}
+
+func (discPriest *DisciplinePriest) ApplyTalents() {
+	discPriest.Priest.ApplyTalents()
+
+	// Meditation
+	discPriest.PseudoStats.SpiritRegenRateCombat = 0.5
+
+	discPriest.applyShieldDiscipline()
+}
+
+func shieldDisciplineBonus(masteryPoints float64) float64 {
+	return (20 + masteryPoints*2.5) / 100
+}
+
+// Mastery: Shield Discipline, increases the potency of absorption effects.
+func (discPriest *DisciplinePriest) applyShieldDiscipline() {
+	absorbMod := discPriest.AddDynamicMod(core.SpellModConfig{
+		ClassMask:  priest.PriestSpellPowerWordShield | priest.PriestSpellDivineAegis,
+		FloatValue: shieldDisciplineBonus(discPriest.GetMasteryPoints()),
+		Kind:       core.SpellMod_DamageDone_Pct,
+	})
+
+	discPriest.AddOnMasteryStatChanged(func(sim *core.Simulation, oldMastery, newMastery float64) {
+		absorbMod.UpdateFloatValue(shieldDisciplineBonus(core.MasteryRatingToMasteryPoints(newMastery)))
+	})
+
+	core.MakePermanent(discPriest.RegisterAura(core.Aura{
+		Label:    "Shield Discipline",
+		ActionID: core.ActionID{SpellID: 77484},
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			absorbMod.Activate()
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			absorbMod.Deactivate()
+		},
+	}))
 }
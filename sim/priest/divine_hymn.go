@@ -0,0 +1,91 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (priest *Priest) registerDivineHymnSpell() {
+	actionID := core.ActionID{SpellID: 64843}
+
+	hymnAuras := priest.NewAllyAuraArray(func(unit *core.Unit) *core.Aura {
+		return unit.GetOrRegisterAura(core.Aura{
+			Label:    "Divine Hymn",
+			ActionID: core.ActionID{SpellID: 64844},
+			Duration: time.Second * 8,
+			OnGain: func(aura *core.Aura, sim *core.Simulation) {
+				aura.Unit.PseudoStats.HealingTakenMultiplier *= 1.1
+			},
+			OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+				aura.Unit.PseudoStats.HealingTakenMultiplier /= 1.1
+			},
+		})
+	})
+
+	if priest.Talents.HeavenlyVoice > 0 {
+		priest.AddStaticMod(core.SpellModConfig{
+			ClassMask:  PriestSpellDivineHymn,
+			FloatValue: 0.1 * float64(priest.Talents.HeavenlyVoice),
+			Kind:       core.SpellMod_DamageDone_Flat,
+		})
+		priest.AddStaticMod(core.SpellModConfig{
+			ClassMask: PriestSpellDivineHymn,
+			TimeValue: time.Second * -150 * time.Duration(priest.Talents.HeavenlyVoice),
+			Kind:      core.SpellMod_Cooldown_Flat,
+		})
+	}
+
+	// Heals 5 targets in raids of more than 10 players, otherwise 3.
+	numTargets := core.TernaryInt32(len(priest.Env.Raid.AllPlayerUnits) > 10, 5, 3)
+
+	priest.DivineHymn = priest.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagChanneled | core.SpellFlagAPL,
+		ClassSpellMask: PriestSpellDivineHymn,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 36,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    priest.NewTimer(),
+				Duration: time.Minute * 8,
+			},
+		},
+
+		BonusCoefficient:         0.429,
+		DamageMultiplier:         1,
+		DamageMultiplierAdditive: 1,
+		CritMultiplier:           priest.DefaultHealingCritMultiplier(),
+		ThreatMultiplier:         1,
+
+		Hot: core.DotConfig{
+			SelfOnly: true,
+			Aura: core.Aura{
+				Label: "Divine Hymn Channel",
+			},
+			NumberOfTicks:        4,
+			TickLength:           time.Second * 2,
+			AffectedByCastSpeed:  true,
+			HasteReducesDuration: true,
+
+			OnTick: func(sim *core.Simulation, _ *core.Unit, dot *core.Dot) {
+				spell := dot.Spell
+				for _, raidUnit := range priest.Env.Raid.GetLowestHealthUnits(numTargets) {
+					spell.CalcAndDealHealing(sim, raidUnit, priest.calcBaseDamage(sim, 4.24, 0), spell.OutcomeHealingCrit)
+					hymnAuras.Get(raidUnit).Activate(sim)
+				}
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			spell.SelfHot().Apply(sim)
+		},
+	})
+}
@@ -0,0 +1,51 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (priest *Priest) registerFadeSpell() {
+	actionID := core.ActionID{SpellID: 586}
+
+	// Threat removed from each target, returned when Fade wears off.
+	fadedThreat := make([]float64, len(priest.Env.Encounter.TargetUnits))
+
+	fadeAura := priest.RegisterAura(core.Aura{
+		Label:    "Fade",
+		ActionID: actionID,
+		Duration: time.Second * 10,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			for i, target := range sim.Encounter.TargetUnits {
+				fadedThreat[i] = priest.ThreatOn(target)
+				priest.AddThreat(sim, target, -fadedThreat[i])
+			}
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			for i, target := range sim.Encounter.TargetUnits {
+				priest.AddThreat(sim, target, fadedThreat[i])
+			}
+		},
+	})
+
+	priest.Fade = priest.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		Flags:          core.SpellFlagAPL,
+		ClassSpellMask: PriestSpellFade,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    priest.NewTimer(),
+				Duration: time.Second * 30,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			fadeAura.Activate(sim)
+		},
+	})
+}
@@ -25,6 +25,30 @@ func (priest *Priest) ApplyGlyphs() {
 		})
 	}
 
+	if priest.HasPrimeGlyph(proto.PriestPrimeGlyph_GlyphOfFlashHeal) {
+		priest.AddStaticMod(core.SpellModConfig{
+			ClassMask:  int64(PriestSpellFlashHeal),
+			FloatValue: 10,
+			Kind:       core.SpellMod_BonusCrit_Percent,
+		})
+	}
+
+	if priest.HasPrimeGlyph(proto.PriestPrimeGlyph_GlyphOfRenew) {
+		priest.AddStaticMod(core.SpellModConfig{
+			ClassMask:  int64(PriestSpellRenew),
+			FloatValue: 0.1,
+			Kind:       core.SpellMod_DamageDone_Flat,
+		})
+	}
+
+	if priest.HasPrimeGlyph(proto.PriestPrimeGlyph_GlyphOfPenance) {
+		priest.AddStaticMod(core.SpellModConfig{
+			ClassMask: int64(PriestSpellPenance),
+			TimeValue: time.Second * -2,
+			Kind:      core.SpellMod_Cooldown_Flat,
+		})
+	}
+
 	if priest.HasPrimeGlyph(proto.PriestPrimeGlyph_GlyphOfDispersion) {
 		priest.AddStaticMod(core.SpellModConfig{
 			Kind:      core.SpellMod_Cooldown_Flat,
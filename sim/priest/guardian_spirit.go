@@ -0,0 +1,93 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func (priest *Priest) registerGuardianSpiritSpell() {
+	if !priest.Talents.GuardianSpirit {
+		return
+	}
+
+	actionID := core.ActionID{SpellID: 47788}
+	hasGlyph := priest.HasPrimeGlyph(proto.PriestPrimeGlyph_GlyphOfGuardianSpirit)
+
+	saveHealAmount := 0.0
+	saveHeal := priest.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 48153},
+		SpellSchool: core.SpellSchoolHoly,
+		ProcMask:    core.ProcMaskSpellHealing,
+		Flags:       core.SpellFlagHelpful | core.SpellFlagPassiveSpell | core.SpellFlagIgnoreModifiers,
+
+		DamageMultiplier: 1,
+		CritMultiplier:   1,
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealHealing(sim, target, saveHealAmount, spell.OutcomeHealing)
+		},
+	})
+
+	priest.GuardianSpiritAuras = priest.NewAllyAuraArray(func(unit *core.Unit) *core.Aura {
+		consumed := false
+		aura := unit.GetOrRegisterAura(core.Aura{
+			Label:    "Guardian Spirit-" + priest.Label,
+			Tag:      core.GuardianSpiritAuraTag,
+			ActionID: actionID,
+			Duration: time.Second * 10,
+			OnGain: func(aura *core.Aura, sim *core.Simulation) {
+				consumed = false
+				aura.Unit.PseudoStats.HealingTakenMultiplier *= 1.6
+			},
+			OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+				aura.Unit.PseudoStats.HealingTakenMultiplier /= 1.6
+
+				// Glyph of Guardian Spirit: shortens the cooldown to 1.5 min if the spirit was never triggered.
+				if hasGlyph && !consumed {
+					priest.GuardianSpirit.CD.Set(min(priest.GuardianSpirit.CD.ReadyAt(), aura.StartedAt()+time.Second*90))
+				}
+			},
+		})
+
+		// Prevents the killing blow and heals the target for 50% of its maximum health instead.
+		unit.AddDynamicDamageTakenModifier(func(sim *core.Simulation, _ *core.Spell, result *core.SpellResult) {
+			if aura.IsActive() && unit.HasHealthBar() && result.Damage >= unit.CurrentHealth() {
+				result.Damage = max(0, unit.CurrentHealth()-1)
+				saveHealAmount = 0.5 * unit.MaxHealth()
+				saveHeal.Cast(sim, unit)
+				consumed = true
+				aura.Deactivate(sim)
+			}
+		})
+
+		return aura
+	})
+
+	priest.GuardianSpirit = priest.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskEmpty,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: PriestSpellGuardianSpirit,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 6,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    priest.NewTimer(),
+				Duration: time.Minute * 3,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			priest.GuardianSpiritAuras.Get(target).Activate(sim)
+		},
+	})
+}
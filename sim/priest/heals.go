@@ -0,0 +1,155 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+// Config shared by the direct single target heals.
+func (priest *Priest) directHealConfig(config core.SpellConfig) core.SpellConfig {
+	config.SpellSchool = core.SpellSchoolHoly
+	config.ProcMask = core.ProcMaskSpellHealing
+	config.Flags |= core.SpellFlagHelpful | core.SpellFlagAPL
+	config.DamageMultiplier = 1
+	config.DamageMultiplierAdditive = 1
+	config.CritMultiplier = priest.DefaultHealingCritMultiplier()
+	config.ThreatMultiplier = 1
+	return config
+}
+
+// Deals a direct heal, applying the effects which depend on the heal's target.
+func (priest *Priest) calcAndDealDirectHeal(sim *core.Simulation, spell *core.Spell, target *core.Unit, baseHealing float64) *core.SpellResult {
+	// Holy Word: Serenity increases the crit chance of heals on its target.
+	bonusCrit := 0.0
+	if priest.SerenityAuras != nil && priest.SerenityAuras.Get(target).IsActive() {
+		bonusCrit += 25
+	}
+
+	// Renewed Hope increases the crit chance of heals on targets with Weakened Soul.
+	weakenedSoul := priest.WeakenedSouls.Get(target)
+	if priest.Talents.RenewedHope > 0 && weakenedSoul.IsActive() && spell.Matches(PriestSpellFlashHeal|PriestSpellGreaterHeal|PriestSpellHeal) {
+		bonusCrit += 5 * float64(priest.Talents.RenewedHope)
+	}
+
+	spell.BonusCritPercent += bonusCrit
+	result := spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+	spell.BonusCritPercent -= bonusCrit
+
+	// Chakra: Serenity refreshes Renew on targets of direct heals.
+	if priest.ChakraSerenityAura.IsActive() && priest.Renew != nil {
+		if renew := priest.Renew.Hot(target); renew.IsActive() {
+			renew.ApplyRollover(sim)
+		}
+	}
+
+	// Strength of Soul shortens Weakened Soul on the target.
+	if priest.Talents.StrengthOfSoul > 0 && weakenedSoul.IsActive() && spell.Matches(PriestSpellFlashHeal|PriestSpellGreaterHeal|PriestSpellHeal) {
+		remaining := weakenedSoul.RemainingDuration(sim) - time.Second*2*time.Duration(priest.Talents.StrengthOfSoul)
+		if remaining <= 0 {
+			weakenedSoul.Deactivate(sim)
+		} else {
+			duration := weakenedSoul.Duration
+			weakenedSoul.Duration = remaining
+			weakenedSoul.Refresh(sim)
+			weakenedSoul.Duration = duration
+		}
+	}
+
+	return result
+}
+
+func (priest *Priest) registerFlashHealSpell() {
+	priest.FlashHeal = priest.RegisterSpell(priest.directHealConfig(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 2061},
+		ClassSpellMask: PriestSpellFlashHeal,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 28,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 1500,
+			},
+		},
+
+		BonusCoefficient: 0.806,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			priest.calcAndDealDirectHeal(sim, spell, target, priest.calcBaseDamage(sim, 7.12, 0.15))
+		},
+	}))
+}
+
+func (priest *Priest) registerGreaterHealSpell() {
+	priest.GreaterHeal = priest.RegisterSpell(priest.directHealConfig(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 2060},
+		ClassSpellMask: PriestSpellGreaterHeal,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 27,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Second * 3,
+			},
+		},
+
+		BonusCoefficient: 0.967,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			priest.calcAndDealDirectHeal(sim, spell, target, priest.calcBaseDamage(sim, 9.564, 0.15))
+		},
+	}))
+}
+
+func (priest *Priest) registerHealSpell() {
+	priest.Heal = priest.RegisterSpell(priest.directHealConfig(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 2050},
+		ClassSpellMask: PriestSpellHeal,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 9,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Second * 3,
+			},
+		},
+
+		BonusCoefficient: 0.318,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			priest.calcAndDealDirectHeal(sim, spell, target, priest.calcBaseDamage(sim, 3.151, 0.15))
+		},
+	}))
+}
+
+func (priest *Priest) registerBindingHealSpell() {
+	priest.BindingHeal = priest.RegisterSpell(priest.directHealConfig(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 32546},
+		ClassSpellMask: PriestSpellBindingHeal,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 28,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 1500,
+			},
+		},
+
+		BonusCoefficient: 0.564,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			priest.calcAndDealDirectHeal(sim, spell, target, priest.calcBaseDamage(sim, 5.34, 0.25))
+			if target != &priest.Unit {
+				priest.calcAndDealDirectHeal(sim, spell, &priest.Unit, priest.calcBaseDamage(sim, 5.34, 0.25))
+			}
+		},
+	}))
+}
@@ -1,6 +1,8 @@
 package holy
 
 import (
+	"time"
+
 	"github.com/wowsims/cata/sim/core"
 	"github.com/wowsims/cata/sim/core/proto"
 	"github.com/wowsims/cata/sim/priest"
@@ -49,13 +51,81 @@ func (holyPriest *HolyPriest) GetPriest() *priest.Priest {
 
 func (holyPriest *HolyPriest) Initialize() {
 	holyPriest.Priest.Initialize()
+	holyPriest.RegisterHealingSpells()
 
-	// holyPriest.RegisterHolyFireSpell()
-	// holyPriest.RegisterSmiteSpell()
+	holyPriest.RegisterHolyFireSpell()
+	holyPriest.RegisterSmiteSpell()
 	// holyPriest.RegisterPenanceSpell()
 	// holyPriest.RegisterHymnOfHopeCD()
 }
 
 func (holyPriest *HolyPriest) Reset(sim *core.Simulation) {
-	//holyPriest.Priest.Reset(sim)
+	holyPriest.Priest.Reset(sim)
+}
+
+func (holyPriest *HolyPriest) ApplyTalents() {
+	holyPriest.Priest.ApplyTalents()
+
+	// Spiritual Healing
+	holyPriest.PseudoStats.HealingDealtMultiplier *= 1.15
+	core.MakePermanent(holyPriest.RegisterAura(core.Aura{
+		Label:    "Spiritual Healing",
+		ActionID: core.ActionID{SpellID: 87336},
+	}))
+
+	// Meditation
+	holyPriest.PseudoStats.SpiritRegenRateCombat = 0.5
+
+	holyPriest.applyEchoOfLight()
+}
+
+func echoOfLightBonus(masteryPoints float64) float64 {
+	return (10 + masteryPoints*1.25) / 100
+}
+
+// Mastery: Echo of Light, direct heals also heal the target over 6 seconds.
+func (holyPriest *HolyPriest) applyEchoOfLight() {
+	echoOfLight := holyPriest.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 77489},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell | core.SpellFlagIgnoreModifiers,
+		ClassSpellMask: priest.PriestSpellEchoOfLight,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+
+		Hot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Echo of Light",
+			},
+			NumberOfTicks: 6,
+			TickLength:    time.Second,
+
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.CalcAndDealPeriodicSnapshotHealing(sim, target, dot.OutcomeTick)
+			},
+		},
+	})
+
+	core.MakePermanent(holyPriest.RegisterAura(core.Aura{
+		Label:    "Echo of Light Mastery",
+		ActionID: core.ActionID{SpellID: 77485},
+		OnHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if spell.ClassSpellMask == 0 || result.Damage <= 0 {
+				return
+			}
+
+			// Any healing left on the target is rolled into the new hot.
+			hot := echoOfLight.Hot(result.Target)
+			totalHealing := echoOfLightBonus(holyPriest.GetMasteryPoints()) * result.Damage
+			if hot.IsActive() {
+				totalHealing += hot.OutstandingDmg()
+			}
+
+			hot.Apply(sim)
+			hot.SnapshotBaseDamage = totalHealing / float64(hot.BaseTickCount)
+			hot.SnapshotAttackerMultiplier = 1
+		},
+	}))
 }
@@ -1,60 +1,73 @@
 package holy
 
 import (
+	"testing"
+
 	_ "github.com/wowsims/cata/sim/common" // imported to get caster sets included.
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
 )
 
 func init() {
 	RegisterHolyPriest()
 }
 
-// func TestSmite(t *testing.T) {
-// 	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator(core.CharacterSuiteConfig{
-// 		Class: proto.Class_ClassPriest,
-// 		Race:  proto.Race_RaceUndead,
-
-// 		GearSet:     core.GetGearSet("../../../ui/smite_priest/gear_sets", "p1"),
-// 		Talents:     DefaultTalents,
-// 		Glyphs:      DefaultGlyphs,
-// 		Consumes:    FullConsumes,
-// 		SpecOptions: core.SpecOptionsCombo{Label: "Basic", SpecOptions: PlayerOptionsBasic},
-// 		Rotation:    core.GetAplRotation("../../../ui/smite_priest/apls", "default"),
-
-// 		ItemFilter: core.ItemFilter{
-// 			WeaponTypes: []proto.WeaponType{
-// 				proto.WeaponType_WeaponTypeDagger,
-// 				proto.WeaponType_WeaponTypeMace,
-// 				proto.WeaponType_WeaponTypeOffHand,
-// 				proto.WeaponType_WeaponTypeStaff,
-// 			},
-// 			ArmorType: proto.ArmorType_ArmorTypeCloth,
-// 			RangedWeaponTypes: []proto.RangedWeaponType{
-// 				proto.RangedWeaponType_RangedWeaponTypeWand,
-// 			},
-// 		},
-// 	}))
-// }
-
-// var DefaultTalents = "05332031013005023310001-005551002020152-00502"
-// var DefaultGlyphs = &proto.Glyphs{
-// 	Major1: int32(proto.PriestMajorGlyph_GlyphOfSmite),
-// 	Major2: int32(proto.PriestMajorGlyph_GlyphOfHolyNova),
-// 	Major3: int32(proto.PriestMajorGlyph_GlyphOfShadowWordDeath),
-// 	// No interesting minor glyphs.
-// }
-
-// var FullConsumes = &proto.Consumes{
-// 	Flask:         proto.Flask_FlaskOfTheFrostWyrm,
-// 	Food:          proto.Food_FoodFishFeast,
-// 	DefaultPotion: proto.Potions_RunicManaInjector,
-// 	PrepopPotion:  proto.Potions_PotionOfWildMagic,
-// }
-
-// var PlayerOptionsBasic = &proto.Player_SmitePriest{
-// 	SmitePriest: &proto.SmitePriest{
-// 		Options: &proto.SmitePriest_Options{
-// 			UseInnerFire:   true,
-// 			UseShadowfiend: true,
-// 		},
-// 	},
-// }
+func TestHoly(t *testing.T) {
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator(core.CharacterSuiteConfig{
+		Class:      proto.Class_ClassPriest,
+		Race:       proto.Race_RaceTroll,
+		OtherRaces: []proto.Race{proto.Race_RaceDraenei},
+		IsHealer:   true,
+
+		// The holy gear sets still hold pre-Cataclysm gems, so share the shadow gear.
+		GearSet:     core.GetGearSet("../../../ui/priest/shadow/gear_sets", "p4"),
+		Talents:     DefaultTalents,
+		Glyphs:      DefaultGlyphs,
+		Consumes:    FullConsumes,
+		SpecOptions: core.SpecOptionsCombo{Label: "Basic", SpecOptions: PlayerOptionsBasic},
+		Rotation:    core.GetAplRotation("../../../ui/priest/holy/apls", "default"),
+
+		ItemFilter: core.ItemFilter{
+			WeaponTypes: []proto.WeaponType{
+				proto.WeaponType_WeaponTypeDagger,
+				proto.WeaponType_WeaponTypeMace,
+				proto.WeaponType_WeaponTypeOffHand,
+				proto.WeaponType_WeaponTypeStaff,
+			},
+			ArmorType: proto.ArmorType_ArmorTypeCloth,
+			RangedWeaponTypes: []proto.RangedWeaponType{
+				proto.RangedWeaponType_RangedWeaponTypeWand,
+			},
+		},
+	}))
+}
+
+var DefaultTalents = "232-233122221211211103211"
+var DefaultGlyphs = &proto.Glyphs{
+	Prime1: int32(proto.PriestPrimeGlyph_GlyphOfPrayerOfHealing),
+	Prime2: int32(proto.PriestPrimeGlyph_GlyphOfRenew),
+	Prime3: int32(proto.PriestPrimeGlyph_GlyphOfFlashHeal),
+	Major1: int32(proto.PriestMajorGlyph_GlyphOfCircleOfHealing),
+	Major2: int32(proto.PriestMajorGlyph_GlyphOfPrayerOfMending),
+	Major3: int32(proto.PriestMajorGlyph_GlyphOfFade),
+	Minor1: int32(proto.PriestMinorGlyph_GlyphOfFortitude),
+}
+
+var FullConsumes = &proto.Consumes{
+	Flask:         proto.Flask_FlaskOfTheDraconicMind,
+	Food:          proto.Food_FoodSeafoodFeast,
+	DefaultPotion: proto.Potions_MythicalManaPotion,
+	PrepopPotion:  proto.Potions_VolcanicPotion,
+	TinkerHands:   proto.TinkerHands_TinkerHandsSynapseSprings,
+}
+
+var PlayerOptionsBasic = &proto.Player_HolyPriest{
+	HolyPriest: &proto.HolyPriest{
+		Options: &proto.HolyPriest_Options{
+			ClassOptions: &proto.PriestOptions{
+				Armor:          proto.PriestOptions_InnerFire,
+				UseShadowfiend: true,
+			},
+		},
+	},
+}
@@ -0,0 +1,100 @@
+package holy
+
+import (
+	"testing"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+var prayerOfMendingID = core.ActionID{SpellID: 33076}
+
+func newHolyPriestPlayer(name string, rotation *proto.APLRotation) *proto.Player {
+	return core.WithSpec(&proto.Player{
+		Name:      name,
+		Class:     proto.Class_ClassPriest,
+		Race:      proto.Race_RaceUndead,
+		Equipment: &proto.EquipmentSpec{},
+		Consumes:  &proto.Consumes{},
+		Buffs:     &proto.IndividualBuffs{},
+		Rotation:  rotation,
+	}, &proto.Player_HolyPriest{HolyPriest: &proto.HolyPriest{Options: &proto.HolyPriest_Options{ClassOptions: &proto.PriestOptions{}}}})
+}
+
+// Runs a priest casting Prayer of Mending on another player, and returns the
+// healing and overhealing done by it.
+func runPrayerOfMending(t *testing.T, incomingDamage *proto.IncomingDamageProfile) (float64, float64) {
+	pomRotation := &proto.APLRotation{
+		Type: proto.APLRotation_TypeAPL,
+		PriorityList: []*proto.APLListItem{{
+			Action: &proto.APLAction{Action: &proto.APLAction_CastFriendlySpell{CastFriendlySpell: &proto.APLActionCastFriendlySpell{
+				SpellId: prayerOfMendingID.ToProto(),
+				Target:  &proto.UnitReference{Type: proto.UnitReference_Player, Index: 1},
+			}}},
+		}},
+	}
+	idleRotation := &proto.APLRotation{Type: proto.APLRotation_TypeAPL}
+
+	result := core.RunRaidSim(&proto.RaidSimRequest{
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{{
+				Players: []*proto.Player{newHolyPriestPlayer("Healer", pomRotation), newHolyPriestPlayer("Ally", idleRotation)},
+				Buffs:   &proto.PartyBuffs{},
+			}},
+			Buffs:   &proto.RaidBuffs{},
+			Debuffs: &proto.Debuffs{},
+		},
+		Encounter: &proto.Encounter{
+			Duration:       60,
+			Targets:        []*proto.Target{{Name: "target", Level: 88, MobType: proto.MobType_MobTypeDemon, TankIndex: -1}},
+			IncomingDamage: incomingDamage,
+		},
+		SimOptions: &proto.SimOptions{Iterations: 5, RandomSeed: 101, IsTest: true},
+	})
+	if result.Error != nil {
+		t.Fatalf("Sim failed: %s", result.Error.Message)
+	}
+
+	healing, overhealing := 0.0, 0.0
+	for _, action := range result.RaidMetrics.Parties[0].Players[0].Actions {
+		if core.ProtoToActionID(action.Id).SameAction(prayerOfMendingID) {
+			for _, target := range action.Targets {
+				healing += target.Healing
+				overhealing += target.Overhealing
+			}
+		}
+	}
+	if healing == 0 {
+		t.Fatalf("Expected Prayer of Mending to heal")
+	}
+	return healing, overhealing
+}
+
+func TestPrayerOfMendingWithoutIncomingDamage(t *testing.T) {
+	// Nobody takes damage, so the charges are consumed on their own and every heal overheals.
+	healing, overhealing := runPrayerOfMending(t, nil)
+	if overhealing != healing {
+		t.Errorf("Expected all %0.0f healing to be overhealing, got %0.0f", healing, overhealing)
+	}
+}
+
+func TestPrayerOfMendingWithIncomingDamage(t *testing.T) {
+	// Charges are only consumed by the bursts, each of which is bigger than a single heal.
+	healing, overhealing := runPrayerOfMending(t, &proto.IncomingDamageProfile{
+		AoeBurstDamage:   15000,
+		AoeBurstInterval: 20,
+	})
+	if overhealing != 0 {
+		t.Errorf("Expected all %0.0f healing to be effective, got %0.0f overhealing", healing, overhealing)
+	}
+}
+
+func TestPrayerOfMendingOverhealing(t *testing.T) {
+	// Constant small hits consume every charge straight away, so most of each heal overheals.
+	healing, overhealing := runPrayerOfMending(t, &proto.IncomingDamageProfile{
+		RaidDps: 100,
+	})
+	if effective := healing - overhealing; effective <= 0 || overhealing <= effective {
+		t.Errorf("Expected mostly overhealing with small hits, got %0.0f effective and %0.0f overhealing", effective, overhealing)
+	}
+}
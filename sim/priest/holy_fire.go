@@ -0,0 +1,61 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (priest *Priest) RegisterHolyFireSpell() {
+	priest.HolyFire = priest.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 14914},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellDamage,
+		Flags:          core.SpellFlagAPL,
+		ClassSpellMask: PriestSpellHolyFire,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 11,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 2000,
+			},
+			CD: core.Cooldown{
+				Timer:    priest.NewTimer(),
+				Duration: time.Second * 10,
+			},
+		},
+
+		BonusCoefficient:         1.11,
+		DamageMultiplier:         1,
+		DamageMultiplierAdditive: 1,
+		CritMultiplier:           priest.DefaultSpellCritMultiplier(),
+		ThreatMultiplier:         1,
+
+		Dot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Holy Fire",
+			},
+			NumberOfTicks:    7,
+			TickLength:       time.Second,
+			BonusCoefficient: 0.0312,
+
+			OnSnapshot: func(sim *core.Simulation, target *core.Unit, dot *core.Dot, _ bool) {
+				dot.Snapshot(target, priest.ClassSpellScaling*0.0312)
+			},
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.CalcAndDealPeriodicSnapshotDamage(sim, target, dot.OutcomeTick)
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			result := spell.CalcDamage(sim, target, priest.calcBaseDamage(sim, 1.11, 0.25), spell.OutcomeMagicHitAndCrit)
+			if result.Landed() {
+				spell.Dot(target).Apply(sim)
+			}
+			spell.DealDamage(sim, result)
+		},
+	})
+}
@@ -0,0 +1,63 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (priest *Priest) registerInnerFocusSpell() {
+	if !priest.Talents.InnerFocus {
+		return
+	}
+
+	actionID := core.ActionID{SpellID: 89485}
+	affectedSpells := PriestSpellFlashHeal | PriestSpellBindingHeal | PriestSpellGreaterHeal | PriestSpellPrayerOfHealing
+
+	costMod := priest.AddDynamicMod(core.SpellModConfig{
+		ClassMask: affectedSpells,
+		IntValue:  -100,
+		Kind:      core.SpellMod_PowerCost_Pct,
+	})
+	critMod := priest.AddDynamicMod(core.SpellModConfig{
+		ClassMask:  affectedSpells,
+		FloatValue: 25,
+		Kind:       core.SpellMod_BonusCrit_Percent,
+	})
+
+	innerFocusAura := priest.RegisterAura(core.Aura{
+		Label:    "Inner Focus",
+		ActionID: actionID,
+		Duration: core.NeverExpires,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			costMod.Activate()
+			critMod.Activate()
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			costMod.Deactivate()
+			critMod.Deactivate()
+		},
+		OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
+			if spell.Matches(affectedSpells) {
+				aura.Deactivate(sim)
+			}
+		},
+	})
+
+	priest.InnerFocus = priest.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		Flags:          core.SpellFlagNoOnCastComplete | core.SpellFlagAPL,
+		ClassSpellMask: PriestSpellInnerFocus,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    priest.NewTimer(),
+				Duration: time.Second * 45,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			innerFocusAura.Activate(sim)
+		},
+	})
+}
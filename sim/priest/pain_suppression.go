@@ -0,0 +1,51 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (priest *Priest) registerPainSuppressionSpell() {
+	if !priest.Talents.PainSuppression {
+		return
+	}
+
+	actionID := core.ActionID{SpellID: 33206}
+	painSuppressionAuras := priest.NewAllyAuraArray(func(unit *core.Unit) *core.Aura {
+		return unit.GetOrRegisterAura(core.Aura{
+			Label:    "Pain Suppression-" + priest.Label,
+			Tag:      core.PainSuppressionAuraTag,
+			ActionID: actionID,
+			Duration: core.PainSuppressionDuration,
+			OnGain: func(aura *core.Aura, sim *core.Simulation) {
+				aura.Unit.PseudoStats.DamageTakenMultiplier *= 0.6
+			},
+			OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+				aura.Unit.PseudoStats.DamageTakenMultiplier /= 0.6
+			},
+		})
+	})
+
+	priest.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskEmpty,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: PriestSpellPainSuppresion,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 8,
+		},
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    priest.NewTimer(),
+				Duration: time.Minute * 3,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, _ *core.Spell) {
+			painSuppressionAuras.Get(target).Activate(sim)
+		},
+	})
+}
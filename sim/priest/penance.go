@@ -4,10 +4,9 @@ import (
 	"time"
 
 	"github.com/wowsims/cata/sim/core"
-	"github.com/wowsims/cata/sim/core/proto"
 )
 
-func (priest *Priest) registerPenanceHealSpell() {
+func (priest *Priest) RegisterPenanceHealSpell() {
 	priest.PenanceHeal = priest.makePenanceSpell(true)
 }
 
@@ -16,8 +15,9 @@ func (priest *Priest) RegisterPenanceSpell() {
 }
 
 func (priest *Priest) makePenanceSpell(isHeal bool) *core.Spell {
-	if !priest.Talents.Penance {
-		return nil
+	if priest.PenanceCooldown == nil {
+		// Both versions of Penance share their cooldown.
+		priest.PenanceCooldown = priest.NewTimer()
 	}
 
 	var procMask core.ProcMask
@@ -30,37 +30,30 @@ func (priest *Priest) makePenanceSpell(isHeal bool) *core.Spell {
 	}
 
 	return priest.RegisterSpell(core.SpellConfig{
-		ActionID:    core.ActionID{SpellID: 53007},
-		SpellSchool: core.SpellSchoolHoly,
-		ProcMask:    procMask,
-		Flags:       flags,
+		ActionID:       core.ActionID{SpellID: 47540}.WithTag(core.TernaryInt32(isHeal, 1, 0)),
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       procMask,
+		Flags:          flags,
+		ClassSpellMask: PriestSpellPenance,
 
 		ManaCost: core.ManaCostOptions{
-			BaseCost: 0.16,
-			Multiplier: 1 *
-				(1 - 0.05*float64(priest.Talents.ImprovedHealing)) *
-				(1 - []float64{0, .04, .07, .10}[priest.Talents.MentalAgility]),
+			BaseCostPercent: 14,
 		},
 		Cast: core.CastConfig{
 			DefaultCast: core.Cast{
 				GCD: core.GCDDefault,
 			},
 			CD: core.Cooldown{
-				Timer:    priest.NewTimer(),
-				Duration: time.Duration(float64(time.Second*12-core.TernaryDuration(priest.HasMajorGlyph(proto.PriestMajorGlyph_GlyphOfPenance), time.Second*2, 0)) * (1 - .1*float64(priest.Talents.Aspiration))),
+				Timer:    priest.PenanceCooldown,
+				Duration: time.Second * 12,
 			},
 		},
 
-		DamageMultiplier: 1 +
-			core.TernaryFloat64(isHeal,
-				1*
-					(1+.02*float64(priest.Talents.SpiritualHealing))*
-					(1+.01*float64(priest.Talents.BlessedResilience))*
-					(1+.02*float64(priest.Talents.FocusedPower)),
-				.05*float64(priest.Talents.SearingLight)) +
-			.01*float64(priest.Talents.TwinDisciplines),
-		CritMultiplier:   core.TernaryFloat64(isHeal, priest.DefaultHealingCritMultiplier(), priest.DefaultSpellCritMultiplier()),
-		ThreatMultiplier: 0,
+		BonusCoefficient:         core.TernaryFloat64(isHeal, 0.321, 0),
+		DamageMultiplier:         1,
+		DamageMultiplierAdditive: 1,
+		CritMultiplier:           core.TernaryFloat64(isHeal, priest.DefaultHealingCritMultiplier(), priest.DefaultSpellCritMultiplier()),
+		ThreatMultiplier:         0,
 
 		Dot: core.Ternary(!isHeal, core.DotConfig{
 			Aura: core.Aura{
@@ -69,9 +62,10 @@ func (priest *Priest) makePenanceSpell(isHeal bool) *core.Spell {
 			NumberOfTicks:       2,
 			TickLength:          time.Second,
 			AffectedByCastSpeed: true,
+			BonusCoefficient:    0.229,
 
 			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
-				baseDamage := 375 + 0.2290*dot.Spell.SpellPower()
+				baseDamage := priest.calcBaseDamage(sim, 0.74, 0.122)
 				dot.Spell.CalcAndDealPeriodicDamage(sim, target, baseDamage, dot.Spell.OutcomeMagicHitAndCrit)
 			},
 		}, core.DotConfig{}),
@@ -84,7 +78,7 @@ func (priest *Priest) makePenanceSpell(isHeal bool) *core.Spell {
 			AffectedByCastSpeed: true,
 
 			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
-				baseHealing := sim.Roll(1484, 1676) + 0.5362*dot.Spell.HealingPower(target)
+				baseHealing := priest.calcBaseDamage(sim, 3.1, 0.122)
 				dot.Spell.CalcAndDealPeriodicHealing(sim, target, baseHealing, dot.Spell.OutcomeHealingCrit)
 			},
 		}, core.DotConfig{}),
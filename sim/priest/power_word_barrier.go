@@ -0,0 +1,59 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func (priest *Priest) registerPowerWordBarrierSpell() {
+	if !priest.Talents.PowerWordBarrier {
+		return
+	}
+
+	actionID := core.ActionID{SpellID: 62618}
+	damageTakenMultiplier := core.TernaryFloat64(priest.HasPrimeGlyph(proto.PriestPrimeGlyph_GlyphOfPowerWordBarrier), 0.7, 0.75)
+
+	barrierAuras := priest.NewAllyAuraArray(func(unit *core.Unit) *core.Aura {
+		return unit.GetOrRegisterAura(core.Aura{
+			Label:    "Power Word: Barrier-" + priest.Label,
+			ActionID: core.ActionID{SpellID: 81782},
+			Duration: time.Second * 10,
+			OnGain: func(aura *core.Aura, sim *core.Simulation) {
+				aura.Unit.PseudoStats.DamageTakenMultiplier *= damageTakenMultiplier
+			},
+			OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+				aura.Unit.PseudoStats.DamageTakenMultiplier /= damageTakenMultiplier
+			},
+		})
+	})
+
+	priest.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskEmpty,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: PriestSpellPowerWordBarrier,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 30,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    priest.NewTimer(),
+				Duration: time.Minute * 3,
+			},
+		},
+
+		// The barrier covers the whole raid, which is assumed to be stacked inside it.
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			for _, unit := range priest.Env.Raid.GetActiveAllyUnits() {
+				barrierAuras.Get(unit).Activate(sim)
+			}
+		},
+	})
+}
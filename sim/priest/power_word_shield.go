@@ -0,0 +1,98 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func (priest *Priest) registerPowerWordShieldSpell() {
+	priest.WeakenedSouls = priest.NewAllyAuraArray(func(target *core.Unit) *core.Aura {
+		return target.GetOrRegisterAura(core.Aura{
+			Label:    "Weakened Soul",
+			ActionID: core.ActionID{SpellID: 6788},
+			Duration: time.Second * 15,
+		})
+	})
+
+	var glyphHeal *core.Spell
+	shieldAmount := 0.0
+
+	var raptureIcd *core.Cooldown
+	var raptureMetrics *core.ResourceMetrics
+	if priest.Talents.Rapture > 0 {
+		raptureIcd = &core.Cooldown{
+			Timer:    priest.NewTimer(),
+			Duration: time.Second * 12,
+		}
+		raptureMetrics = priest.NewManaMetrics(core.ActionID{SpellID: 47755})
+	}
+
+	priest.PowerWordShield = priest.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 17},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: PriestSpellPowerWordShield,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 34,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+		},
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return !priest.WeakenedSouls.Get(target).IsActive()
+		},
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+
+		Shield: core.ShieldConfig{
+			Aura: core.Aura{
+				Label:    "Power Word: Shield",
+				Duration: time.Second * 15,
+				OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+					// Rapture: restores mana when the shield is fully absorbed.
+					shield := priest.PowerWordShield.Shield(aura.Unit)
+					if raptureIcd != nil && shield.ShieldStrength <= 0 && raptureIcd.IsReady(sim) {
+						raptureIcd.Use(sim)
+						priest.AddMana(sim, []float64{0, 0.02, 0.05, 0.07}[priest.Talents.Rapture]*priest.MaxMana(), raptureMetrics)
+					}
+				},
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			shieldAmount = priest.ClassSpellScaling*8.609 + 0.87*spell.HealingPower(target)
+			spell.Shield(target).Apply(sim, shieldAmount)
+			priest.WeakenedSouls.Get(target).Activate(sim)
+
+			if glyphHeal != nil {
+				glyphHeal.Cast(sim, target)
+			}
+		},
+	})
+
+	if priest.HasPrimeGlyph(proto.PriestPrimeGlyph_GlyphOfPowerWordShield) {
+		glyphHeal = priest.RegisterSpell(core.SpellConfig{
+			ActionID:    core.ActionID{SpellID: 56160},
+			SpellSchool: core.SpellSchoolHoly,
+			ProcMask:    core.ProcMaskSpellHealing,
+			Flags:       core.SpellFlagHelpful | core.SpellFlagPassiveSpell | core.SpellFlagNoOnCastComplete,
+
+			DamageMultiplier:         1,
+			DamageMultiplierAdditive: 1,
+			CritMultiplier:           priest.DefaultHealingCritMultiplier(),
+			ThreatMultiplier:         1,
+
+			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+				baseHealing := shieldAmount * priest.PowerWordShield.DamageMultiplier * 0.2
+				spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+			},
+		})
+	}
+}
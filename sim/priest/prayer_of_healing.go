@@ -0,0 +1,54 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (priest *Priest) registerPrayerOfHealingSpell() {
+	priest.PrayerOfHealing = priest.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 596},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: PriestSpellPrayerOfHealing,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 26,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 2500,
+			},
+		},
+
+		BonusCoefficient:         0.34,
+		DamageMultiplier:         1,
+		DamageMultiplierAdditive: 1,
+		CritMultiplier:           priest.DefaultHealingCritMultiplier(),
+		ThreatMultiplier:         1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			for _, partyUnit := range priest.partyUnitsOf(target) {
+				spell.CalcAndDealHealing(sim, partyUnit, priest.calcBaseDamage(sim, 3.192, 0.055), spell.OutcomeHealingCrit)
+			}
+		},
+	})
+}
+
+// Returns the units in the target's party, falling back to the priest's own party
+// for targets outside of the raid.
+func (priest *Priest) partyUnitsOf(target *core.Unit) []*core.Unit {
+	party := priest.Party
+	if agent := priest.Env.Raid.GetPlayerFromUnit(target); agent != nil {
+		party = agent.GetCharacter().Party
+	}
+
+	units := make([]*core.Unit, 0, len(party.PlayersAndPets))
+	for _, partyAgent := range party.PlayersAndPets {
+		units = append(units, &partyAgent.GetCharacter().Unit)
+	}
+	return units
+}
@@ -0,0 +1,123 @@
+package priest
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func (priest *Priest) registerPrayerOfMendingSpell() {
+	actionID := core.ActionID{SpellID: 33076}
+
+	pomAuras := priest.NewAllyAuraArray(func(unit *core.Unit) *core.Aura {
+		return priest.makePrayerOfMendingAura(unit)
+	})
+
+	hasGlyph := priest.HasMajorGlyph(proto.PriestMajorGlyph_GlyphOfPrayerOfMending)
+	const maxJumps = 4
+
+	var curTarget *core.Unit
+	var remainingJumps int
+	priest.ProcPrayerOfMending = func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+		// Glyph of Prayer of Mending increases the first heal by 60%.
+		glyphMultiplier := 1.0
+		if hasGlyph && remainingJumps == maxJumps {
+			glyphMultiplier = 1.6
+		}
+
+		spell.DamageMultiplier *= glyphMultiplier
+		spell.CalcAndDealHealing(sim, target, priest.calcBaseDamage(sim, 3.143, 0), spell.OutcomeHealingCrit)
+		spell.DamageMultiplier /= glyphMultiplier
+
+		pomAuras.Get(target).Deactivate(sim)
+		curTarget = nil
+
+		// Bounce to new ally.
+		if remainingJumps == 0 {
+			return
+		}
+
+		// Find the ally with the lowest % HP which is not the current mending target.
+		var newTarget *core.Unit
+		for _, raidUnit := range core.LowestHealthUnits(priest.Env.Raid.AllPlayerUnits, 2) {
+			if raidUnit != target {
+				newTarget = raidUnit
+				break
+			}
+		}
+
+		if newTarget != nil {
+			remainingJumps--
+			curTarget = newTarget
+			pomAuras.Get(newTarget).Activate(sim)
+		}
+	}
+
+	priest.PrayerOfMending = priest.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: PriestSpellPrayerOfMending,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 18,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    priest.NewTimer(),
+				Duration: time.Second * 10,
+			},
+		},
+
+		BonusCoefficient:         0.318,
+		DamageMultiplier:         1,
+		DamageMultiplierAdditive: 1,
+		CritMultiplier:           priest.DefaultHealingCritMultiplier(),
+		ThreatMultiplier:         1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			if curTarget != nil {
+				pomAuras.Get(curTarget).Deactivate(sim)
+			}
+
+			remainingJumps = maxJumps
+			curTarget = target
+			pomAuras.Get(target).Activate(sim)
+		},
+	})
+}
+
+func (priest *Priest) makePrayerOfMendingAura(target *core.Unit) *core.Aura {
+	return target.RegisterAura(core.Aura{
+		Label:    "PrayerOfMending" + strconv.Itoa(int(priest.Index)),
+		ActionID: core.ActionID{SpellID: 41635},
+		Duration: time.Second * 30,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			// Without incoming damage nothing would consume the charge, so it is
+			// consumed shortly after landing instead.
+			if !priest.Env.Encounter.HasIncomingDamage {
+				gainedAt := sim.CurrentTime
+				core.StartDelayedAction(sim, core.DelayedActionOptions{
+					DoAt: sim.CurrentTime + time.Second*5,
+					OnAction: func(sim *core.Simulation) {
+						// Skip if the charge already moved on and came back since.
+						if aura.IsActive() && aura.StartedAt() == gainedAt {
+							priest.ProcPrayerOfMending(sim, aura.Unit, priest.PrayerOfMending)
+						}
+					},
+				})
+			}
+		},
+		OnSpellHitTaken: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if priest.Env.Encounter.HasIncomingDamage && result.Damage > 0 {
+				priest.ProcPrayerOfMending(sim, aura.Unit, priest.PrayerOfMending)
+			}
+		},
+	})
+}
@@ -35,6 +35,7 @@ type Priest struct {
 	CircleOfHealing *core.Spell
 	FlashHeal       *core.Spell
 	GreaterHeal     *core.Spell
+	Heal            *core.Spell
 	Penance         *core.Spell
 	PenanceHeal     *core.Spell
 	PowerWordShield *core.Spell
@@ -43,6 +44,7 @@ type Priest struct {
 	Renew           *core.Spell
 	EmpoweredRenew  *core.Spell
 	InnerFocus      *core.Spell
+	Fade            *core.Spell
 	HolyFire        *core.Spell
 	Smite           *core.Spell
 	DevouringPlague *core.Spell
@@ -50,9 +52,24 @@ type Priest struct {
 	Shadowfiend     *core.Spell
 	VampiricTouch   *core.Spell
 
-	WeakenedSouls core.AuraArray
+	Chakra            *core.Spell
+	HolyWordSerenity  *core.Spell
+	HolyWordSanctuary *core.Spell
+	DivineHymn        *core.Spell
+	GuardianSpirit    *core.Spell
+
+	ChakraAura          *core.Aura
+	ChakraSerenityAura  *core.Aura
+	ChakraSanctuaryAura *core.Aura
+	ChakraChastiseAura  *core.Aura
+	SerendipityAura     *core.Aura
+
+	WeakenedSouls       core.AuraArray
+	SerenityAuras       core.AuraArray
+	GuardianSpiritAuras core.AuraArray
 
 	ProcPrayerOfMending core.ApplySpellResults
+	PenanceCooldown     *core.Timer
 
 	ClassSpellScaling float64
 }
@@ -108,22 +125,31 @@ func (priest *Priest) Initialize() {
 	priest.registerMindSpike()
 
 	priest.registerPowerInfusionSpell()
+	priest.registerFadeSpell()
 
 	priest.newMindFlaySpell()
 	priest.newMindSearSpell()
 }
 
-// func (priest *Priest) RegisterHealingSpells() {
-// 	priest.registerPenanceHealSpell()
-// 	priest.registerBindingHealSpell()
-// 	priest.registerCircleOfHealingSpell()
-// 	priest.registerFlashHealSpell()
-// 	priest.registerGreaterHealSpell()
-// 	priest.registerPowerWordShieldSpell()
-// 	priest.registerPrayerOfHealingSpell()
-// 	priest.registerPrayerOfMendingSpell()
-// 	priest.registerRenewSpell()
-// }
+// Registers the healing spells shared by the healing specs.
+func (priest *Priest) RegisterHealingSpells() {
+	priest.registerFlashHealSpell()
+	priest.registerGreaterHealSpell()
+	priest.registerHealSpell()
+	priest.registerBindingHealSpell()
+	priest.registerRenewSpell()
+	priest.registerPrayerOfMendingSpell()
+	priest.registerPrayerOfHealingSpell()
+	priest.registerCircleOfHealingSpell()
+	priest.registerDivineHymnSpell()
+	priest.registerGuardianSpiritSpell()
+	priest.registerChakraSpells()
+
+	priest.registerPowerWordShieldSpell()
+	priest.registerInnerFocusSpell()
+	priest.registerPainSuppressionSpell()
+	priest.registerPowerWordBarrierSpell()
+}
 
 func (priest *Priest) AddHolyEvanglismStack(sim *core.Simulation) {
 	if priest.HolyEvangelismProcAura != nil {
@@ -176,17 +202,20 @@ const (
 	PriestSpellArchangel int64 = 1 << iota
 	PriestSpellDarkArchangel
 	PriestSpellBindingHeal
+	PriestSpellChakra
 	PriestSpellCircleOfHealing
 	PriestSpellDevouringPlague
 	PriestSpellDesperatePrayer
 	PriestSpellDispersion
 	PriestSpellDivineAegis
 	PriestSpellDivineHymn
+	PriestSpellEchoOfLight
 	PriestSpellEmpoweredRenew
 	PriestSpellFade
 	PriestSpellFlashHeal
 	PriestSpellGreaterHeal
 	PriestSpellGuardianSpirit
+	PriestSpellHeal
 	PriestSpellHolyFire
 	PriestSpellHolyNova
 	PriestSpellHolyWordChastise
@@ -243,6 +272,16 @@ const (
 		PriestSpellShadowWordDeath |
 		PriestSpellShadowWordPain |
 		PriestSpellVampiricEmbrace
+	PriestSpellDirectHeal = PriestSpellBindingHeal |
+		PriestSpellFlashHeal |
+		PriestSpellGreaterHeal |
+		PriestSpellHeal |
+		PriestSpellHolyWordSerenity
+	PriestSpellAoeHeal = PriestSpellCircleOfHealing |
+		PriestSpellDivineHymn |
+		PriestSpellHolyWordSanctuary |
+		PriestSpellPrayerOfHealing |
+		PriestSpellPrayerOfMending
 	PriestShadowSpells = PriestSpellImprovedDevouringPlague |
 		PriestSpellDevouringPlague |
 		PriestSpellShadowWordDeath |
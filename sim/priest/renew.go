@@ -0,0 +1,82 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (priest *Priest) registerRenewSpell() {
+	actionID := core.ActionID{SpellID: 139}
+
+	var divineTouch *core.Spell
+	if priest.Talents.DivineTouch > 0 {
+		divineTouch = priest.RegisterSpell(core.SpellConfig{
+			ActionID:       core.ActionID{SpellID: 63544},
+			SpellSchool:    core.SpellSchoolHoly,
+			ProcMask:       core.ProcMaskSpellHealing,
+			Flags:          core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+			ClassSpellMask: PriestSpellEmpoweredRenew,
+
+			DamageMultiplier:         1,
+			DamageMultiplierAdditive: 1,
+			CritMultiplier:           priest.DefaultHealingCritMultiplier(),
+			ThreatMultiplier:         1,
+
+			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+				renew := priest.Renew.Hot(target)
+				totalHealing := renew.SnapshotBaseDamage * float64(renew.BaseTickCount)
+				baseHealing := totalHealing * 0.05 * float64(priest.Talents.DivineTouch)
+				spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+			},
+		})
+	}
+
+	priest.Renew = priest.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: PriestSpellRenew,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 17,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+		},
+
+		DamageMultiplier:         1,
+		DamageMultiplierAdditive: 1,
+		CritMultiplier:           priest.DefaultHealingCritMultiplier(),
+		ThreatMultiplier:         1,
+
+		Hot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Renew",
+			},
+			NumberOfTicks:    4,
+			TickLength:       time.Second * 3,
+			BonusCoefficient: 0.131,
+
+			OnSnapshot: func(sim *core.Simulation, target *core.Unit, dot *core.Dot, isRollover bool) {
+				if !isRollover {
+					dot.SnapshotHeal(target, priest.ClassSpellScaling*1.307)
+				}
+			},
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.CalcAndDealPeriodicSnapshotHealing(sim, target, dot.OutcomeSnapshotCrit)
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.Hot(target).Apply(sim)
+
+			if divineTouch != nil {
+				divineTouch.Cast(sim, target)
+			}
+		},
+	})
+}
@@ -0,0 +1,48 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func (priest *Priest) RegisterSmiteSpell() {
+	// Glyph of Smite increases damage done against targets afflicted by Holy Fire.
+	hasGlyph := priest.HasMajorGlyph(proto.PriestMajorGlyph_GlyphOfSmite)
+
+	priest.Smite = priest.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 585},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellDamage,
+		Flags:          core.SpellFlagAPL,
+		ClassSpellMask: PriestSpellSmite,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 15,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 2500,
+			},
+		},
+
+		BonusCoefficient:         0.856,
+		DamageMultiplier:         1,
+		DamageMultiplierAdditive: 1,
+		CritMultiplier:           priest.DefaultSpellCritMultiplier(),
+		ThreatMultiplier:         1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			glyphMultiplier := 1.0
+			if hasGlyph && priest.HolyFire != nil && priest.HolyFire.Dot(target).IsActive() {
+				glyphMultiplier = 1.2
+			}
+
+			spell.DamageMultiplier *= glyphMultiplier
+			spell.CalcAndDealDamage(sim, target, priest.calcBaseDamage(sim, 0.856, 0.115), spell.OutcomeMagicHitAndCrit)
+			spell.DamageMultiplier /= glyphMultiplier
+		},
+	})
+}
@@ -14,19 +14,10 @@ func (priest *Priest) ApplyTalents() {
 	// TODO:
 	// Reflective Shield
 	// Improved Flash Heal
-	// Renewed Hope
-	// Rapture
-	// Pain Suppression
 	// Test of Faith
-	// Guardian Spirit
 
-	// priest.applyDivineAegis()
-	// priest.applyGrace()
-	// priest.applyBorrowedTime()
 	// priest.applyInspiration()
 	// priest.applyHolyConcentration()
-	// priest.applySerendipity()
-	// priest.applySurgeOfLight()
 	// priest.registerInnerFocus()
 
 	// priest.AddStat(stats.SpellCrit, 1*float64(priest.Talents.FocusedWill)*core.CritRatingPerCritChance)
@@ -64,7 +55,6 @@ func (priest *Priest) ApplyTalents() {
 	// }
 
 	// Disciplin Talents
-	// Improved Power Word: Shield - TBD
 	// Twin Disciplines
 	if priest.Talents.TwinDisciplines > 0 {
 		priest.AddStaticMod(core.SpellModConfig{
@@ -86,9 +76,81 @@ func (priest *Priest) ApplyTalents() {
 	// Evangelism
 	priest.applyEvangelism()
 
+	// Improved Power Word: Shield
+	if priest.Talents.ImprovedPowerWordShield > 0 {
+		priest.AddStaticMod(core.SpellModConfig{
+			ClassMask:  PriestSpellPowerWordShield,
+			FloatValue: 0.1 * float64(priest.Talents.ImprovedPowerWordShield),
+			Kind:       core.SpellMod_DamageDone_Pct,
+		})
+	}
+
+	// Atonement
+	priest.applyAtonement()
+
+	// Rapture - power_word_shield.go
+	// Borrowed Time
+	priest.applyBorrowedTime()
+
+	// Renewed Hope, Strength of Soul - heals.go
+	// Divine Aegis
+	priest.applyDivineAegis()
+
+	// Inner Focus, Pain Suppression, Power Word: Barrier - registered with the healing spells
+	// Grace
+	priest.applyGrace()
+
 	// Archangel
 	priest.applyArchangel()
 
+	// Holy Talents
+	// Improved Renew
+	if priest.Talents.ImprovedRenew > 0 {
+		priest.AddStaticMod(core.SpellModConfig{
+			ClassMask:  PriestSpellRenew,
+			FloatValue: 0.05 * float64(priest.Talents.ImprovedRenew),
+			Kind:       core.SpellMod_DamageDone_Flat,
+		})
+	}
+
+	// Empowered Healing
+	if priest.Talents.EmpoweredHealing > 0 {
+		priest.AddStaticMod(core.SpellModConfig{
+			ClassMask:  PriestSpellFlashHeal | PriestSpellGreaterHeal | PriestSpellBindingHeal | PriestSpellHeal,
+			FloatValue: 0.05 * float64(priest.Talents.EmpoweredHealing),
+			Kind:       core.SpellMod_DamageDone_Flat,
+		})
+	}
+
+	// Divine Fury
+	if priest.Talents.DivineFury > 0 {
+		priest.AddStaticMod(core.SpellModConfig{
+			ClassMask: PriestSpellSmite | PriestSpellHolyFire | PriestSpellHeal | PriestSpellGreaterHeal,
+			TimeValue: -[]time.Duration{0, 150, 350, 500}[priest.Talents.DivineFury] * time.Millisecond,
+			Kind:      core.SpellMod_CastTime_Flat,
+		})
+	}
+
+	// Surge of Light
+	priest.applySurgeOfLight()
+
+	// Divine Touch - renew.go
+	// Rapid Renewal
+	if priest.Talents.RapidRenewal {
+		priest.AddStaticMod(core.SpellModConfig{
+			ClassMask: PriestSpellRenew,
+			TimeValue: time.Millisecond * -500,
+			Kind:      core.SpellMod_GlobalCooldown_Flat,
+		})
+	}
+
+	// Serendipity
+	priest.applySerendipity()
+
+	// Chakra, Revelations, Tome of Light - chakra.go
+	// Heavenly Voice - divine_hymn.go
+	// Circle of Healing, Guardian Spirit - circle_of_healing.go, guardian_spirit.go
+
 	// Shadow Talents
 	// Darkness
 	if priest.Talents.Darkness > 0 {
@@ -280,7 +342,8 @@ func (priest *Priest) applyArchangel() {
 				priest.AddMana(sim, 0.01*priest.MaxMana()*float64((newStacks-oldStacks)), archAngelMana)
 			}
 
-			// place holder mod healing done not present yet
+			priest.PseudoStats.HealingDealtMultiplier /= 1 + 0.03*float64(oldStacks)
+			priest.PseudoStats.HealingDealtMultiplier *= 1 + 0.03*float64(newStacks)
 		},
 	})
 
@@ -686,85 +749,159 @@ func (priest *Priest) applyShadowyApparition() {
 	})
 }
 
-// func (priest *Priest) applyDivineAegis() {
-// 	if priest.Talents.DivineAegis == 0 {
-// 		return
-// 	}
+func (priest *Priest) applyDivineAegis() {
+	if priest.Talents.DivineAegis == 0 {
+		return
+	}
 
-// 	divineAegis := priest.RegisterSpell(core.SpellConfig{
-// 		ActionID:    core.ActionID{SpellID: 47515},
-// 		SpellSchool: core.SpellSchoolHoly,
-// 		ProcMask:    core.ProcMaskSpellHealing,
-// 		Flags:       core.SpellFlagNoOnCastComplete | core.SpellFlagHelpful,
-
-// 		DamageMultiplier: 1 *
-// 			(0.1 * float64(priest.Talents.DivineAegis)) *
-// 			core.TernaryFloat64(priest.CouldHaveSetBonus(ItemSetZabrasRaiment, 4), 1.1, 1),
-// 		ThreatMultiplier: 1,
-
-// 		Shield: core.ShieldConfig{
-// 			Aura: core.Aura{
-// 				Label:    "Divine Aegis",
-// 				Duration: time.Second * 12,
-// 			},
-// 		},
-// 	})
+	divineAegis := priest.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 47753},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagNoOnCastComplete | core.SpellFlagHelpful | core.SpellFlagPassiveSpell,
+		ClassSpellMask: PriestSpellDivineAegis,
 
-// 	priest.RegisterAura(core.Aura{
-// 		Label:    "Divine Aegis Talent",
-// 		Duration: core.NeverExpires,
-// 		OnReset: func(aura *core.Aura, sim *core.Simulation) {
-// 			aura.Activate(sim)
-// 		},
-// 		OnHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
-// 			if result.Outcome.Matches(core.OutcomeCrit) {
-// 				divineAegis.Shield(result.Target).Apply(sim, result.Damage)
-// 			}
-// 		},
-// 	})
-// }
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
 
-// func (priest *Priest) applyGrace() {
-// 	if priest.Talents.Grace == 0 {
-// 		return
-// 	}
+		Shield: core.ShieldConfig{
+			Aura: core.Aura{
+				Label:    "Divine Aegis",
+				Duration: time.Second * 15,
+			},
+		},
+	})
 
-// 	procChance := .5 * float64(priest.Talents.Grace)
+	aegisPercent := 0.1 * float64(priest.Talents.DivineAegis)
+	core.MakePermanent(priest.RegisterAura(core.Aura{
+		Label: "Divine Aegis Talent",
+		OnHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if spell.ClassSpellMask == 0 || spell.ClassSpellMask == PriestSpellDivineAegis {
+				return
+			}
 
-// 	auras := make([]*core.Aura, len(priest.Env.AllUnits))
-// 	for _, unit := range priest.Env.AllUnits {
-// 		if !priest.IsOpponent(unit) {
-// 			aura := unit.RegisterAura(core.Aura{
-// 				Label:     "Grace" + strconv.Itoa(int(priest.Index)),
-// 				ActionID:  core.ActionID{SpellID: 47517},
-// 				Duration:  time.Second * 15,
-// 				MaxStacks: 3,
-// 				OnStacksChange: func(aura *core.Aura, sim *core.Simulation, oldStacks, newStacks int32) {
-// 					priest.AttackTables[aura.Unit.UnitIndex].HealingDealtMultiplier /= 1 + .03*float64(oldStacks)
-// 					priest.AttackTables[aura.Unit.UnitIndex].HealingDealtMultiplier *= 1 + .03*float64(newStacks)
-// 				},
-// 			})
-// 			auras[unit.UnitIndex] = aura
-// 		}
-// 	}
+			// Prayer of Healing always triggers Divine Aegis.
+			if !result.Outcome.Matches(core.OutcomeCrit) && spell.ClassSpellMask != PriestSpellPrayerOfHealing {
+				return
+			}
 
-// 	priest.RegisterAura(core.Aura{
-// 		Label:    "Grace Talent",
-// 		Duration: core.NeverExpires,
-// 		OnReset: func(aura *core.Aura, sim *core.Simulation) {
-// 			aura.Activate(sim)
-// 		},
-// 		OnHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
-// 			if spell == priest.FlashHeal || spell == priest.GreaterHeal || spell == priest.PenanceHeal {
-// 				if sim.Proc(procChance, "Grace") {
-// 					aura := auras[result.Target.UnitIndex]
-// 					aura.Activate(sim)
-// 					aura.AddStack(sim)
-// 				}
-// 			}
-// 		},
-// 	})
-// }
+			// Divine Aegis stacks with itself, up to 40% of the target's maximum health.
+			shield := divineAegis.Shield(result.Target)
+			shieldAmount := result.Damage * aegisPercent
+			if shield.IsActive() {
+				shieldAmount += shield.ShieldStrength / divineAegis.DamageMultiplier
+			}
+			if result.Target.HasHealthBar() {
+				shieldAmount = min(shieldAmount, 0.4*result.Target.MaxHealth()/divineAegis.DamageMultiplier)
+			}
+
+			shield.Apply(sim, shieldAmount)
+		},
+	}))
+}
+
+func (priest *Priest) applyGrace() {
+	if priest.Talents.Grace == 0 {
+		return
+	}
+
+	healingBonus := 0.04 * float64(priest.Talents.Grace)
+	graceAuras := priest.NewAllyAuraArray(func(unit *core.Unit) *core.Aura {
+		return unit.RegisterAura(core.Aura{
+			Label:     "Grace-" + priest.Label,
+			ActionID:  core.ActionID{SpellID: 77613},
+			Duration:  time.Second * 15,
+			MaxStacks: 3,
+			OnStacksChange: func(aura *core.Aura, sim *core.Simulation, oldStacks, newStacks int32) {
+				priest.AttackTables[aura.Unit.UnitIndex].HealingDealtMultiplier /= 1 + healingBonus*float64(oldStacks)
+				priest.AttackTables[aura.Unit.UnitIndex].HealingDealtMultiplier *= 1 + healingBonus*float64(newStacks)
+			},
+		})
+	})
+
+	core.MakePermanent(priest.RegisterAura(core.Aura{
+		Label: "Grace Talent",
+		OnHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if spell.Matches(PriestSpellFlashHeal | PriestSpellGreaterHeal | PriestSpellHeal | PriestSpellPenance) {
+				aura := graceAuras.Get(result.Target)
+				aura.Activate(sim)
+				aura.AddStack(sim)
+			}
+		},
+	}))
+}
+
+func (priest *Priest) applyAtonement() {
+	if priest.Talents.Atonement == 0 {
+		return
+	}
+
+	atonementHealing := 0.0
+	atonement := priest.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 81751},
+		SpellSchool: core.SpellSchoolHoly,
+		ProcMask:    core.ProcMaskSpellHealing,
+		Flags:       core.SpellFlagNoOnCastComplete | core.SpellFlagHelpful | core.SpellFlagPassiveSpell,
+
+		DamageMultiplier:         1,
+		DamageMultiplierAdditive: 1,
+		ThreatMultiplier:         1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealHealing(sim, target, atonementHealing, spell.OutcomeHealing)
+		},
+	})
+
+	// Heals the lowest health ally for part of the damage done by Smite and Holy Fire.
+	core.MakeProcTriggerAura(&priest.Unit, core.ProcTrigger{
+		Name:           "Atonement",
+		Callback:       core.CallbackOnSpellHitDealt,
+		Outcome:        core.OutcomeLanded,
+		ClassSpellMask: PriestSpellSmite | PriestSpellHolyFire,
+		Handler: func(sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			targets := priest.Env.Raid.GetLowestHealthUnits(1)
+			if len(targets) == 0 || result.Damage <= 0 {
+				return
+			}
+
+			atonementHealing = result.Damage * 0.5 * float64(priest.Talents.Atonement)
+			atonement.Cast(sim, targets[0])
+		},
+	})
+}
+
+func (priest *Priest) applyBorrowedTime() {
+	if priest.Talents.BorrowedTime == 0 {
+		return
+	}
+
+	hasteMultiplier := 1 + 0.07*float64(priest.Talents.BorrowedTime)
+	borrowedTimeAura := priest.RegisterAura(core.Aura{
+		Label:    "Borrowed Time",
+		ActionID: core.ActionID{SpellID: []int32{0, 59887, 59888}[priest.Talents.BorrowedTime]},
+		Duration: time.Second * 6,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			priest.MultiplyCastSpeed(hasteMultiplier)
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			priest.MultiplyCastSpeed(1 / hasteMultiplier)
+		},
+		OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
+			if spell.ClassSpellMask != 0 && !spell.Matches(PriestSpellPowerWordShield) {
+				aura.Deactivate(sim)
+			}
+		},
+	})
+
+	core.MakePermanent(priest.RegisterAura(core.Aura{
+		Label: "Borrowed Time Talent",
+		OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
+			if spell.Matches(PriestSpellPowerWordShield) {
+				borrowedTimeAura.Activate(sim)
+			}
+		},
+	}))
+}
 
 // // This one is called from healing priest sim initialization because it needs an input.
 // func (priest *Priest) ApplyRapture(ppm float64) {
@@ -891,110 +1028,107 @@ func (priest *Priest) applyShadowyApparition() {
 // 	})
 // }
 
-// func (priest *Priest) applySerendipity() {
-// 	if priest.Talents.Serendipity == 0 {
-// 		return
-// 	}
+func (priest *Priest) applySerendipity() {
+	if priest.Talents.Serendipity == 0 {
+		return
+	}
 
-// 	reductionPerStack := .04 * float64(priest.Talents.Serendipity)
+	castTimeMod := priest.AddDynamicMod(core.SpellModConfig{
+		ClassMask:  PriestSpellGreaterHeal | PriestSpellPrayerOfHealing,
+		FloatValue: 0,
+		Kind:       core.SpellMod_CastTime_Pct,
+	})
+	costMod := priest.AddDynamicMod(core.SpellModConfig{
+		ClassMask: PriestSpellGreaterHeal | PriestSpellPrayerOfHealing,
+		IntValue:  0,
+		Kind:      core.SpellMod_PowerCost_Pct,
+	})
 
-// 	procAura := priest.RegisterAura(core.Aura{
-// 		Label:     "Serendipity",
-// 		ActionID:  core.ActionID{SpellID: 63737},
-// 		Duration:  time.Second * 20,
-// 		MaxStacks: 3,
-// 		OnStacksChange: func(aura *core.Aura, sim *core.Simulation, oldStacks, newStacks int32) {
-// 			priest.PrayerOfHealing.CastTimeMultiplier += reductionPerStack * float64(oldStacks)
-// 			priest.PrayerOfHealing.CastTimeMultiplier -= reductionPerStack * float64(newStacks)
-// 			priest.GreaterHeal.CastTimeMultiplier += reductionPerStack * float64(oldStacks)
-// 			priest.GreaterHeal.CastTimeMultiplier -= reductionPerStack * float64(newStacks)
-// 		},
-// 	})
+	priest.SerendipityAura = priest.RegisterAura(core.Aura{
+		Label:     "Serendipity",
+		ActionID:  core.ActionID{SpellID: []int32{0, 63731, 63735}[priest.Talents.Serendipity]},
+		Duration:  time.Second * 20,
+		MaxStacks: 2,
+		OnStacksChange: func(aura *core.Aura, sim *core.Simulation, oldStacks, newStacks int32) {
+			castTimeMod.UpdateFloatValue(-0.1 * float64(priest.Talents.Serendipity*newStacks))
+			castTimeMod.Activate()
 
-// 	priest.RegisterAura(core.Aura{
-// 		Label:    "Serendipity Talent",
-// 		Duration: core.NeverExpires,
-// 		OnReset: func(aura *core.Aura, sim *core.Simulation) {
-// 			aura.Activate(sim)
-// 		},
-// 		OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
-// 			if spell == priest.FlashHeal || spell == priest.BindingHeal {
-// 				procAura.Activate(sim)
-// 				procAura.AddStack(sim)
-// 			} else if spell == priest.GreaterHeal || spell == priest.PrayerOfHealing {
-// 				procAura.Deactivate(sim)
-// 			}
-// 		},
-// 	})
-// }
+			costMod.UpdateIntValue(-5 * priest.Talents.Serendipity * newStacks)
+			costMod.Activate()
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			castTimeMod.Deactivate()
+			costMod.Deactivate()
+		},
+	})
 
-// func (priest *Priest) applySurgeOfLight() {
-// 	if priest.Talents.SurgeOfLight == 0 {
-// 		return
-// 	}
+	core.MakePermanent(priest.RegisterAura(core.Aura{
+		Label: "Serendipity Talent",
+		OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
+			if spell.Matches(PriestSpellFlashHeal | PriestSpellBindingHeal) {
+				priest.SerendipityAura.Activate(sim)
+				priest.SerendipityAura.AddStack(sim)
+			} else if spell.Matches(PriestSpellGreaterHeal | PriestSpellPrayerOfHealing) {
+				priest.SerendipityAura.Deactivate(sim)
+			}
+		},
+	}))
+}
 
-// 	procHandler := func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
-// 		if spell == priest.Smite || spell == priest.FlashHeal {
-// 			aura.Deactivate(sim)
-// 		}
-// 	}
+func (priest *Priest) applySurgeOfLight() {
+	if priest.Talents.SurgeOfLight == 0 {
+		return
+	}
 
-// 	priest.SurgeOfLightProcAura = priest.RegisterAura(core.Aura{
-// 		Label:    "Surge of Light Proc",
-// 		ActionID: core.ActionID{SpellID: 33154},
-// 		Duration: time.Second * 10,
-// 		OnGain: func(aura *core.Aura, sim *core.Simulation) {
-// 			if priest.Smite != nil {
-// 				priest.Smite.CastTimeMultiplier -= 1
-// 				priest.Smite.Cost.Multiplier -= 100
-// 				priest.Smite.BonusCritRating -= 100 * core.CritRatingPerCritChance
-// 			}
-// 			if priest.FlashHeal != nil {
-// 				priest.FlashHeal.CastTimeMultiplier -= 1
-// 				priest.FlashHeal.Cost.Multiplier -= 100
-// 				priest.FlashHeal.BonusCritRating -= 100 * core.CritRatingPerCritChance
-// 			}
-// 		},
-// 		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
-// 			if priest.Smite != nil {
-// 				priest.Smite.CastTimeMultiplier += 1
-// 				priest.Smite.Cost.Multiplier += 100
-// 				priest.Smite.BonusCritRating += 100 * core.CritRatingPerCritChance
-// 			}
-// 			if priest.FlashHeal != nil {
-// 				priest.FlashHeal.CastTimeMultiplier += 1
-// 				priest.FlashHeal.Cost.Multiplier += 100
-// 				priest.FlashHeal.BonusCritRating += 100 * core.CritRatingPerCritChance
-// 			}
-// 		},
-// 		OnSpellHitDealt: procHandler,
-// 		OnHealDealt:     procHandler,
-// 	})
+	castTimeMod := priest.AddDynamicMod(core.SpellModConfig{
+		ClassMask:  PriestSpellFlashHeal,
+		FloatValue: -1,
+		Kind:       core.SpellMod_CastTime_Pct,
+	})
+	costMod := priest.AddDynamicMod(core.SpellModConfig{
+		ClassMask: PriestSpellFlashHeal,
+		IntValue:  -100,
+		Kind:      core.SpellMod_PowerCost_Pct,
+	})
 
-// 	procChance := 0.25 * float64(priest.Talents.SurgeOfLight)
-// 	icd := core.Cooldown{
-// 		Timer:    priest.NewTimer(),
-// 		Duration: time.Second * 6,
-// 	}
-// 	priest.SurgeOfLightProcAura.Icd = &icd
+	priest.SurgeOfLightProcAura = priest.RegisterAura(core.Aura{
+		Label:    "Surge of Light Proc",
+		ActionID: core.ActionID{SpellID: 88688},
+		Duration: time.Second * 10,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			castTimeMod.Activate()
+			costMod.Activate()
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			castTimeMod.Deactivate()
+			costMod.Deactivate()
+		},
+		OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
+			if spell.Matches(PriestSpellFlashHeal) {
+				aura.Deactivate(sim)
+			}
+		},
+	})
 
-// 	handler := func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
-// 		if icd.IsReady(sim) && result.Outcome.Matches(core.OutcomeCrit) && sim.RandomFloat("SurgeOfLight") < procChance {
-// 			icd.Use(sim)
-// 			priest.SurgeOfLightProcAura.Activate(sim)
-// 		}
-// 	}
+	procChance := 0.03 * float64(priest.Talents.SurgeOfLight)
+	core.MakePermanent(priest.RegisterAura(core.Aura{
+		Label: "Surge of Light",
+		OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
+			if !spell.Matches(PriestSpellSmite | PriestSpellHeal | PriestSpellFlashHeal | PriestSpellBindingHeal | PriestSpellGreaterHeal) {
+				return
+			}
 
-// 	priest.RegisterAura(core.Aura{
-// 		Label:    "Surge of Light",
-// 		Duration: core.NeverExpires,
-// 		OnReset: func(aura *core.Aura, sim *core.Simulation) {
-// 			aura.Activate(sim)
-// 		},
-// 		OnSpellHitDealt: handler,
-// 		OnHealDealt:     handler,
-// 	})
-// }
+			// The free Flash Heal consuming the proc can't refresh it.
+			if spell.Matches(PriestSpellFlashHeal) && priest.SurgeOfLightProcAura.IsActive() {
+				return
+			}
+
+			if sim.Proc(procChance, "Surge of Light") {
+				priest.SurgeOfLightProcAura.Activate(sim)
+			}
+		},
+	}))
+}
 
 // func (priest *Priest) applyMisery() {
 // 	if priest.Talents.Misery == 0 {
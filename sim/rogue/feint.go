@@ -34,7 +34,11 @@ func (rogue *Rogue) registerFeintSpell() {
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			rogue.BreakStealth(sim)
-			spell.CalcAndDealOutcome(sim, target, spell.OutcomeMeleeSpecialHit)
+			result := spell.CalcAndDealOutcome(sim, target, spell.OutcomeMeleeSpecialHit)
+			if result.Landed() {
+				// Modeled as halving the rogue's threat on the target.
+				rogue.ScaleThreat(sim, target, 0.5)
+			}
 		},
 	})
 }
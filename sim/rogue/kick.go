@@ -0,0 +1,40 @@
+package rogue
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (rogue *Rogue) registerKickSpell() {
+	rogue.Kick = rogue.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 1766},
+		SpellSchool:    core.SpellSchoolPhysical,
+		ProcMask:       core.ProcMaskEmpty,
+		Flags:          core.SpellFlagMeleeMetrics | core.SpellFlagAPL,
+		ClassSpellMask: RogueSpellKick,
+
+		EnergyCost: core.EnergyCostOptions{
+			Cost: 25,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				NonEmpty: true,
+			},
+			CD: core.Cooldown{
+				Timer:    rogue.NewTimer(),
+				Duration: time.Second * 10,
+			},
+			IgnoreHaste: true,
+		},
+
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			result := spell.CalcAndDealOutcome(sim, target, spell.OutcomeMeleeSpecialHit)
+			if result.Landed() {
+				spell.Interrupt(sim, target, time.Second*5)
+			}
+		},
+	})
+}
@@ -68,6 +68,7 @@ type Rogue struct {
 	KillingSpree     *core.Spell
 	AdrenalineRush   *core.Spell
 	Gouge            *core.Spell
+	Kick             *core.Spell
 
 	Envenom      *core.Spell
 	Eviscerate   *core.Spell
@@ -201,6 +202,7 @@ func (rogue *Rogue) Initialize() {
 	rogue.registerShivSpell()
 	rogue.registerThistleTeaCD()
 	rogue.registerGougeSpell()
+	rogue.registerKickSpell()
 
 	rogue.T12ToTLastBuff = 3
 
@@ -391,6 +393,7 @@ const (
 	RogueSpellWoundPoison
 	RogueSpellInstantPoison
 	RogueSpellDeadlyPoison
+	RogueSpellKick
 
 	RogueSpellLast
 	RogueSpellsAll = RogueSpellLast<<1 - 1
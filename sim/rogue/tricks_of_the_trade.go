@@ -17,10 +17,17 @@ func (rogue *Rogue) registerTricksOfTheTradeSpell() {
 		tottTarget = rogue.GetUnit(rogue.Options.TricksOfTheTradeTarget)
 	}
 
+	var castTarget *core.Unit
 	tricksOfTheTradeThreatTransferAura := rogue.GetOrRegisterAura(core.Aura{
 		ActionID: core.ActionID{SpellID: 59628},
 		Label:    "TricksOfTheTradeThreatTransfer",
 		Duration: time.Second * 6,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			rogue.ThreatRedirect = castTarget
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			rogue.ThreatRedirect = nil
+		},
 	})
 
 	// Bogus Tricks threat "cast" for hooking T12/T13 set bonuses
@@ -36,7 +43,6 @@ func (rogue *Rogue) registerTricksOfTheTradeSpell() {
 		return core.TricksOfTheTradeAura(unit, rogue.Index, damageMult)
 	})
 
-	var castTarget *core.Unit
 	tricksOfTheTradeApplicationAura := rogue.GetOrRegisterAura(core.Aura{
 		ActionID: core.ActionID{SpellID: 57934},
 		Label:    "TricksOfTheTradeApplication",
@@ -29,6 +29,8 @@ func (rogue *Rogue) registerVanishSpell() {
 			rogue.AutoAttacks.CancelAutoSwing(sim)
 			// Apply stealth
 			rogue.StealthAura.Activate(sim)
+			// Drop all threat
+			rogue.ScaleThreatOnAllTargets(sim, 0)
 		},
 	})
 
@@ -0,0 +1,62 @@
+package shaman
+
+import (
+	"slices"
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func (shaman *Shaman) registerChainHealSpell() {
+	const numBounces = 3
+
+	// Glyph of Chain Heal shifts healing from the first target to the bounces.
+	hasGlyph := shaman.HasMajorGlyph(proto.ShamanMajorGlyph_GlyphOfChainHeal)
+	firstTargetMultiplier := core.TernaryFloat64(hasGlyph, 0.9, 1)
+	bounceMultiplier := core.TernaryFloat64(hasGlyph, 1.15, 1)
+
+	shaman.ChainHeal = shaman.RegisterSpell(shaman.healConfig(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 1064},
+		Flags:          core.SpellFlagAPL,
+		ClassSpellMask: SpellMaskChainHeal,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 17,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 2500,
+			},
+		},
+
+		BonusCoefficient: 0.3226,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			// Riptide on the first target increases the whole chain's healing.
+			riptideMultiplier := 1.0
+			if shaman.Riptide != nil && shaman.Riptide.Hot(target).IsActive() {
+				riptideMultiplier = 1.25
+			}
+
+			// Each jump heals the most injured ally not yet healed, for 30% less than the previous one.
+			others := slices.DeleteFunc(shaman.Env.Raid.GetActiveAllyUnits(), func(unit *core.Unit) bool {
+				return unit == target
+			})
+			targets := append([]*core.Unit{target}, core.LowestHealthUnits(others, numBounces)...)
+
+			jumpMultiplier := riptideMultiplier
+			for i, unit := range targets {
+				multiplier := jumpMultiplier * core.TernaryFloat64(i == 0, firstTargetMultiplier, bounceMultiplier)
+
+				baseHealing := sim.Roll(core.CalcScalingSpellEffectVarianceMinMax(proto.Class_ClassShaman, 3.21, 0.133))
+				spell.DamageMultiplier *= multiplier
+				shaman.calcAndDealHealing(sim, spell, unit, baseHealing)
+				spell.DamageMultiplier /= multiplier
+
+				jumpMultiplier *= 0.7
+			}
+		},
+	}))
+}
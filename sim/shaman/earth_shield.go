@@ -0,0 +1,115 @@
+package shaman
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func (shaman *Shaman) registerEarthShieldSpell() {
+	const maxCharges = 9
+
+	if shaman.HasPrimeGlyph(proto.ShamanPrimeGlyph_GlyphOfEarthShield) {
+		shaman.AddStaticMod(core.SpellModConfig{
+			ClassMask:  SpellMaskEarthShield,
+			FloatValue: 0.2,
+			Kind:       core.SpellMod_DamageDone_Flat,
+		})
+	}
+
+	// Nature's Blessing increases all of the shaman's healing on the Earth Shield target.
+	naturesBlessingMultiplier := 1 + 0.06*float64(shaman.Talents.NaturesBlessing)
+
+	healSpell := shaman.RegisterSpell(shaman.healConfig(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 379},
+		Flags:          core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+		ClassSpellMask: SpellMaskEarthShield,
+
+		BonusCoefficient: 0.286,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			shaman.calcAndDealHealing(sim, spell, target, 1.862*shaman.ClassSpellScaling)
+		},
+	}))
+
+	earthShieldAuras := shaman.NewAllyAuraArray(func(unit *core.Unit) *core.Aura {
+		icd := core.Cooldown{
+			Timer:    shaman.NewTimer(),
+			Duration: time.Second * 3,
+		}
+
+		useCharge := func(aura *core.Aura, sim *core.Simulation) {
+			if !icd.IsReady(sim) {
+				return
+			}
+			icd.Use(sim)
+			healSpell.Cast(sim, aura.Unit)
+			aura.RemoveStack(sim)
+		}
+
+		// Without simulated incoming damage, charges are used at the configured rate instead.
+		var procAction *core.PendingAction
+
+		return unit.RegisterAura(core.Aura{
+			Label:     "Earth Shield-" + shaman.Label,
+			ActionID:  core.ActionID{SpellID: 974},
+			Duration:  time.Minute * 10,
+			MaxStacks: maxCharges,
+			OnGain: func(aura *core.Aura, sim *core.Simulation) {
+				shaman.AttackTables[aura.Unit.UnitIndex].HealingDealtMultiplier *= naturesBlessingMultiplier
+
+				if shaman.EarthShieldPPM > 0 {
+					procAction = core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+						Period: time.Minute / time.Duration(shaman.EarthShieldPPM),
+						OnAction: func(sim *core.Simulation) {
+							useCharge(aura, sim)
+						},
+					})
+				}
+			},
+			OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+				shaman.AttackTables[aura.Unit.UnitIndex].HealingDealtMultiplier /= naturesBlessingMultiplier
+
+				if procAction != nil {
+					procAction.Cancel(sim)
+					procAction = nil
+				}
+			},
+			OnSpellHitTaken: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+				if shaman.EarthShieldPPM == 0 && result.Landed() && result.Damage > 0 {
+					useCharge(aura, sim)
+				}
+			},
+		})
+	})
+
+	shaman.EarthShield = shaman.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 974},
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskEmpty,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: SpellMaskEarthShield,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 19,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+		},
+
+		// Earth Shield can only be active on one target at a time.
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			if shaman.earthShieldTarget != nil && shaman.earthShieldTarget != target {
+				earthShieldAuras.Get(shaman.earthShieldTarget).Deactivate(sim)
+			}
+			shaman.earthShieldTarget = target
+
+			aura := earthShieldAuras.Get(target)
+			aura.Activate(sim)
+			aura.SetStacks(sim, maxCharges)
+		},
+	})
+}
@@ -0,0 +1,56 @@
+package shaman
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (shaman *Shaman) registerHealingRainSpell() {
+	const maxTargets = 6
+
+	shaman.HealingRain = shaman.RegisterSpell(shaman.healConfig(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 73920},
+		Flags:          core.SpellFlagAPL,
+		ClassSpellMask: SpellMaskHealingRain,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 46,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Second * 2,
+			},
+			CD: core.Cooldown{
+				Timer:    shaman.NewTimer(),
+				Duration: time.Second * 10,
+			},
+		},
+
+		Hot: core.DotConfig{
+			SelfOnly: true,
+			Aura: core.Aura{
+				Label: "Healing Rain",
+			},
+			NumberOfTicks:       5,
+			TickLength:          time.Second * 2,
+			AffectedByCastSpeed: true,
+			BonusCoefficient:    0.0951,
+
+			OnSnapshot: func(sim *core.Simulation, target *core.Unit, dot *core.Dot, _ bool) {
+				dot.SnapshotHeal(target, 0.7335*shaman.ClassSpellScaling)
+			},
+			// The raid is assumed to be stacked in the rain, so each tick heals the most injured allies.
+			OnTick: func(sim *core.Simulation, _ *core.Unit, dot *core.Dot) {
+				for _, unit := range shaman.Env.Raid.GetLowestHealthUnits(maxTargets) {
+					shaman.dealPeriodicHealing(sim, dot, unit, dot.OutcomeSnapshotCrit)
+				}
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			spell.SelfHot().Apply(sim)
+		},
+	}))
+}
@@ -0,0 +1,216 @@
+package shaman
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+// Config shared by the shaman heals.
+func (shaman *Shaman) healConfig(config core.SpellConfig) core.SpellConfig {
+	config.SpellSchool = core.SpellSchoolNature
+	config.ProcMask = core.ProcMaskSpellHealing
+	config.Flags |= core.SpellFlagHelpful
+	config.DamageMultiplier = 1
+	config.DamageMultiplierAdditive = 1
+	config.CritMultiplier = shaman.DefaultHealingCritMultiplier()
+	config.ThreatMultiplier = 1
+	return config
+}
+
+// Deep Healing depends on how hurt the target is when the heal lands, so it is
+// kept up to date on the target's attack table right before each heal.
+func (shaman *Shaman) applyDeepHealing(target *core.Unit) {
+	if shaman.DeepHealingBonus == 0 {
+		return
+	}
+
+	missingHealth := 0.0
+	if target.HasHealthBar() && target.MaxHealth() > 0 {
+		missingHealth = 1 - target.CurrentHealthPercent()
+	}
+
+	multiplier := 1 + shaman.DeepHealingBonus*missingHealth
+	shaman.AttackTables[target.UnitIndex].HealingDealtMultiplier *= multiplier / shaman.deepHealingMultipliers[target.UnitIndex]
+	shaman.deepHealingMultipliers[target.UnitIndex] = multiplier
+}
+
+// Deals a direct heal, applying the effects which depend on the heal's target and outcome.
+func (shaman *Shaman) calcAndDealHealing(sim *core.Simulation, spell *core.Spell, target *core.Unit, baseHealing float64) *core.SpellResult {
+	shaman.applyDeepHealing(target)
+	result := spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+
+	// Ancestral Awakening only triggers from single target heals.
+	if result.DidCrit() && shaman.AncestralAwakening != nil && spell.Matches(SpellMaskDirectHeals&^SpellMaskChainHeal) {
+		shaman.procAncestralAwakening(sim, result.Damage)
+	}
+
+	return result
+}
+
+// Deals a periodic heal from a snapshotted HoT.
+func (shaman *Shaman) dealPeriodicHealing(sim *core.Simulation, dot *core.Dot, target *core.Unit, outcomeApplier core.OutcomeApplier) *core.SpellResult {
+	shaman.applyDeepHealing(target)
+	return dot.CalcAndDealPeriodicSnapshotHealing(sim, target, outcomeApplier)
+}
+
+func (shaman *Shaman) registerAncestralAwakeningSpell() {
+	if shaman.Talents.AncestralAwakening == 0 {
+		return
+	}
+
+	shaman.AncestralAwakening = shaman.RegisterSpell(shaman.healConfig(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 52752},
+		Flags:          core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell | core.SpellFlagIgnoreAttackerModifiers,
+		ClassSpellMask: SpellMaskAncestralAwakening,
+	}))
+}
+
+// Ancestral Awakening heals the most injured ally for a portion of a critical heal.
+func (shaman *Shaman) procAncestralAwakening(sim *core.Simulation, healed float64) {
+	spell := shaman.AncestralAwakening
+	amount := healed * 0.1 * float64(shaman.Talents.AncestralAwakening)
+
+	for _, target := range shaman.Env.Raid.GetLowestHealthUnits(1) {
+		shaman.applyDeepHealing(target)
+		spell.CalcAndDealHealing(sim, target, amount, spell.OutcomeHealing)
+	}
+}
+
+func (shaman *Shaman) registerHealingWaveSpell() {
+	// Glyph of Healing Wave also heals the shaman when healing someone else.
+	var glyphHeal *core.Spell
+	if shaman.HasMajorGlyph(proto.ShamanMajorGlyph_GlyphOfHealingWave) {
+		glyphHeal = shaman.RegisterSpell(shaman.healConfig(core.SpellConfig{
+			ActionID: core.ActionID{SpellID: 55533},
+			Flags:    core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell | core.SpellFlagIgnoreModifiers,
+		}))
+	}
+
+	shaman.HealingWave = shaman.RegisterSpell(shaman.healConfig(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 331},
+		Flags:          core.SpellFlagAPL,
+		ClassSpellMask: SpellMaskHealingWave,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 9,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Second * 3,
+			},
+		},
+
+		BonusCoefficient: 0.302,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseHealing := sim.Roll(core.CalcScalingSpellEffectVarianceMinMax(proto.Class_ClassShaman, 3.012, 0.133))
+			healed := shaman.calcAndDealHealing(sim, spell, target, baseHealing).Damage
+
+			if glyphHeal != nil && target != &shaman.Unit {
+				glyphHeal.CalcAndDealHealing(sim, &shaman.Unit, healed*0.2, glyphHeal.OutcomeHealing)
+			}
+		},
+	}))
+}
+
+func (shaman *Shaman) registerGreaterHealingWaveSpell() {
+	shaman.GreaterHealingWave = shaman.RegisterSpell(shaman.healConfig(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 77472},
+		Flags:          core.SpellFlagAPL,
+		ClassSpellMask: SpellMaskGreaterHealingWave,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 30,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Second * 3,
+			},
+		},
+
+		BonusCoefficient: 0.967,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseHealing := sim.Roll(core.CalcScalingSpellEffectVarianceMinMax(proto.Class_ClassShaman, 7.474, 0.133))
+			shaman.calcAndDealHealing(sim, spell, target, baseHealing)
+		},
+	}))
+}
+
+func (shaman *Shaman) registerHealingSurgeSpell() {
+	shaman.HealingSurge = shaman.RegisterSpell(shaman.healConfig(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 8004},
+		Flags:          core.SpellFlagAPL,
+		ClassSpellMask: SpellMaskHealingSurge,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 27,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 1500,
+			},
+		},
+
+		BonusCoefficient: 0.483,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseHealing := sim.Roll(core.CalcScalingSpellEffectVarianceMinMax(proto.Class_ClassShaman, 6.04, 0.133))
+			shaman.calcAndDealHealing(sim, spell, target, baseHealing)
+		},
+	}))
+}
+
+// Tidal Waves, casting Chain Heal or Riptide speeds up the next two Healing Waves
+// or Greater Healing Waves, or improves the crit chance of the next two Healing Surges.
+func (shaman *Shaman) registerTidalWaves() {
+	if shaman.Talents.TidalWaves == 0 {
+		return
+	}
+
+	castTimeMod := shaman.AddDynamicMod(core.SpellModConfig{
+		ClassMask:  SpellMaskHealingWave | SpellMaskGreaterHealingWave,
+		FloatValue: -0.1 * float64(shaman.Talents.TidalWaves),
+		Kind:       core.SpellMod_CastTime_Pct,
+	})
+	critMod := shaman.AddDynamicMod(core.SpellModConfig{
+		ClassMask:  SpellMaskHealingSurge,
+		FloatValue: 10 * float64(shaman.Talents.TidalWaves),
+		Kind:       core.SpellMod_BonusCrit_Percent,
+	})
+
+	shaman.TidalWavesAura = shaman.RegisterAura(core.Aura{
+		Label:     "Tidal Waves",
+		ActionID:  core.ActionID{SpellID: 53390},
+		Duration:  time.Second * 15,
+		MaxStacks: 2,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			castTimeMod.Activate()
+			critMod.Activate()
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			castTimeMod.Deactivate()
+			critMod.Deactivate()
+		},
+		OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
+			if spell.Matches(SpellMaskHealingWave | SpellMaskGreaterHealingWave | SpellMaskHealingSurge) {
+				aura.RemoveStack(sim)
+			}
+		},
+	})
+
+	core.MakePermanent(shaman.RegisterAura(core.Aura{
+		Label: "Tidal Waves Trigger",
+		OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
+			if spell.Matches(SpellMaskChainHeal | SpellMaskRiptide) {
+				shaman.TidalWavesAura.Activate(sim)
+				shaman.TidalWavesAura.SetStacks(sim, 2)
+			}
+		},
+	}))
+}
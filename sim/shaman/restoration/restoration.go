@@ -1,6 +1,8 @@
 package restoration
 
 import (
+	"time"
+
 	"github.com/wowsims/cata/sim/core"
 	"github.com/wowsims/cata/sim/core/proto"
 	"github.com/wowsims/cata/sim/core/stats"
@@ -28,7 +30,8 @@ func NewRestorationShaman(character *core.Character, options *proto.Player) *Res
 	restoOptions := options.GetRestorationShaman().Options
 
 	selfBuffs := shaman.SelfBuffs{
-		Shield: restoOptions.ClassOptions.Shield,
+		Shield:  restoOptions.ClassOptions.Shield,
+		ImbueMH: proto.ShamanImbue_EarthlivingWeapon,
 	}
 
 	totems := &proto.ShamanTotems{}
@@ -39,6 +42,7 @@ func NewRestorationShaman(character *core.Character, options *proto.Player) *Res
 	resto := &RestorationShaman{
 		Shaman: shaman.NewShaman(character, options.TalentsString, totems, selfBuffs, false, false),
 	}
+	resto.EarthShieldPPM = restoOptions.EarthShieldPPM
 
 	if resto.HasMHWeapon() {
 		resto.ApplyEarthlivingImbueToItem(resto.GetMHWeapon())
@@ -58,34 +62,53 @@ func (resto *RestorationShaman) GetShaman() *shaman.Shaman {
 func (resto *RestorationShaman) Reset(sim *core.Simulation) {
 	resto.Shaman.Reset(sim)
 }
-func (resto *RestorationShaman) GetMainTarget() *core.Unit {
-	// TODO: make this just grab first player that isn't self.
-	target := resto.Env.Raid.GetFirstTargetDummy()
-	if target == nil {
-		return &resto.Unit
-	} else {
-		return &target.Unit
-	}
-}
-
 func (resto *RestorationShaman) Initialize() {
-	// resto.CurrentTarget = resto.GetMainTarget()
-
-	// // Has to be here because earthliving can cast hots and needs Env to be set to create the hots.
-	// procMask := core.ProcMaskUnknown
-	// if resto.HasMHWeapon() {
-	// 	procMask |= core.ProcMaskMeleeMH
-	// }
-	// if resto.HasOHWeapon() {
-	// 	procMask |= core.ProcMaskMeleeOH
-	// }
-	// resto.RegisterEarthlivingImbue(procMask)
-
 	resto.Shaman.Initialize()
 	resto.Shaman.RegisterHealingSpells()
+
+	// Has to be here because earthliving can cast hots and needs Env to be set to create the hots.
+	procMask := core.ProcMaskUnknown
+	if resto.HasMHWeapon() {
+		procMask |= core.ProcMaskMeleeMH
+	}
+	resto.RegisterEarthlivingImbue(procMask)
 }
 
 func (resto *RestorationShaman) ApplyTalents() {
 	resto.Shaman.ApplyTalents()
 	resto.ApplyArmorSpecializationEffect(stats.Intellect, proto.ArmorType_ArmorTypeMail, 86529)
+
+	// Purification
+	resto.PseudoStats.HealingDealtMultiplier *= 1.25
+	resto.AddStaticMod(core.SpellModConfig{
+		ClassMask: shaman.SpellMaskHealingWave | shaman.SpellMaskGreaterHealingWave,
+		TimeValue: -time.Millisecond * 500,
+		Kind:      core.SpellMod_CastTime_Flat,
+	})
+	core.MakePermanent(resto.RegisterAura(core.Aura{
+		Label:    "Purification",
+		ActionID: core.ActionID{SpellID: 16213},
+	}))
+
+	// Meditation
+	resto.PseudoStats.SpiritRegenRateCombat = 0.5
+
+	resto.applyDeepHealing()
+}
+
+func deepHealingBonus(masteryPoints float64) float64 {
+	return (24 + masteryPoints*3) / 100
+}
+
+// Mastery: Deep Healing, heals are stronger the lower the target's health is.
+func (resto *RestorationShaman) applyDeepHealing() {
+	resto.DeepHealingBonus = deepHealingBonus(resto.GetMasteryPoints())
+	resto.AddOnMasteryStatChanged(func(sim *core.Simulation, oldMastery, newMastery float64) {
+		resto.DeepHealingBonus = deepHealingBonus(core.MasteryRatingToMasteryPoints(newMastery))
+	})
+
+	core.MakePermanent(resto.RegisterAura(core.Aura{
+		Label:    "Deep Healing",
+		ActionID: core.ActionID{SpellID: 77226},
+	}))
 }
@@ -0,0 +1,59 @@
+package shaman
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func (shaman *Shaman) registerRiptideSpell() {
+	if !shaman.Talents.Riptide {
+		return
+	}
+
+	numTicks := core.TernaryInt32(shaman.HasPrimeGlyph(proto.ShamanPrimeGlyph_GlyphOfRiptide), 7, 5)
+
+	shaman.Riptide = shaman.RegisterSpell(shaman.healConfig(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 61295},
+		Flags:          core.SpellFlagAPL,
+		ClassSpellMask: SpellMaskRiptide,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 10,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    shaman.NewTimer(),
+				Duration: time.Second * 6,
+			},
+		},
+
+		BonusCoefficient: 0.2,
+
+		Hot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Riptide",
+			},
+			NumberOfTicks:       numTicks,
+			TickLength:          time.Second * 3,
+			AffectedByCastSpeed: true,
+			BonusCoefficient:    0.0755,
+
+			OnSnapshot: func(sim *core.Simulation, target *core.Unit, dot *core.Dot, _ bool) {
+				dot.SnapshotHeal(target, 0.3776*shaman.ClassSpellScaling)
+			},
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				shaman.dealPeriodicHealing(sim, dot, target, dot.OutcomeSnapshotCrit)
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			shaman.calcAndDealHealing(sim, spell, target, 2.563*shaman.ClassSpellScaling)
+			spell.Hot(target).Apply(sim)
+		},
+	}))
+}
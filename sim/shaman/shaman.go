@@ -127,6 +127,7 @@ type Shaman struct {
 	EarthShock *core.Spell
 	FlameShock *core.Spell
 	FrostShock *core.Spell
+	WindShear  *core.Spell
 
 	FeralSpirit  *core.Spell
 	SpiritWolves *SpiritWolves
@@ -160,16 +161,26 @@ type Shaman struct {
 	SearingFlamesMultiplier float64
 
 	// Healing Spells
-	tidalWaveProc          *core.Aura
-	ancestralHealingAmount float64
-	AncestralAwakening     *core.Spell
-	HealingSurge           *core.Spell
-
+	AncestralAwakening *core.Spell
+	HealingSurge       *core.Spell
 	GreaterHealingWave *core.Spell
 	HealingWave        *core.Spell
 	ChainHeal          *core.Spell
 	Riptide            *core.Spell
 	EarthShield        *core.Spell
+	HealingRain        *core.Spell
+	SpiritLinkTotem    *core.Spell
+
+	TidalWavesAura *core.Aura
+
+	// Earth Shield procs per minute when incoming damage isn't simulated, or 0 to proc on damage taken.
+	EarthShieldPPM int32
+
+	// Maximum bonus from Deep Healing on a target with no health left, set by the Restoration spec.
+	DeepHealingBonus       float64
+	deepHealingMultipliers []float64
+
+	earthShieldTarget *core.Unit
 
 	waterShieldManaMetrics *core.ResourceMetrics
 
@@ -220,10 +231,6 @@ func (shaman *Shaman) AddRaidBuffs(raidBuffs *proto.RaidBuffs) {
 		raidBuffs.ManaSpringTotem = true
 	}
 
-	if shaman.Talents.ManaTideTotem {
-		raidBuffs.ManaTideTotemCount++
-	}
-
 	switch shaman.Totems.Air {
 	case proto.AirTotem_WrathOfAirTotem:
 		raidBuffs.WrathOfAirTotem = true
@@ -259,6 +266,7 @@ func (shaman *Shaman) Initialize() {
 	shaman.registerMagmaTotemSpell()
 	shaman.registerSearingTotemSpell()
 	shaman.registerShocks()
+	shaman.registerWindShearSpell()
 	shaman.registerUnleashElements()
 
 	shaman.registerStrengthOfEarthTotemSpell()
@@ -279,36 +287,25 @@ func (shaman *Shaman) Initialize() {
 }
 
 func (shaman *Shaman) RegisterHealingSpells() {
-	// shaman.registerAncestralHealingSpell()
-	// shaman.registerHealingSurgeSpell()
-	// shaman.registerHealingWaveSpell()
-	// shaman.registerRiptideSpell()
-	// shaman.registerEarthShieldSpell()
-	// shaman.registerChainHealSpell()
-
-	// if shaman.Talents.TidalWaves > 0 {
-	// 	shaman.tidalWaveProc = shaman.GetOrRegisterAura(core.Aura{
-	// 		Label:    "Tidal Wave Proc",
-	// 		ActionID: core.ActionID{SpellID: 53390},
-	// 		Duration: core.NeverExpires,
-	// 		OnReset: func(aura *core.Aura, sim *core.Simulation) {
-	// 			aura.Deactivate(sim)
-	// 		},
-	// 		OnGain: func(aura *core.Aura, sim *core.Simulation) {
-	// 			shaman.HealingWave.CastTimeMultiplier *= 0.7
-	// 			shaman.HealingSurge.BonusCritRating += core.CritRatingPerCritChance * 25
-	// 		},
-	// 		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
-	// 			shaman.HealingWave.CastTimeMultiplier /= 0.7
-	// 			shaman.HealingSurge.BonusCritRating -= core.CritRatingPerCritChance * 25
-	// 		},
-	// 		MaxStacks: 2,
-	// 	})
-	// }
+	shaman.deepHealingMultipliers = make([]float64, len(shaman.Env.AllUnits))
+	for i := range shaman.deepHealingMultipliers {
+		shaman.deepHealingMultipliers[i] = 1
+	}
+
+	shaman.registerAncestralAwakeningSpell()
+	shaman.registerHealingWaveSpell()
+	shaman.registerGreaterHealingWaveSpell()
+	shaman.registerHealingSurgeSpell()
+	shaman.registerRiptideSpell()
+	shaman.registerChainHealSpell()
+	shaman.registerEarthShieldSpell()
+	shaman.registerHealingRainSpell()
+	shaman.registerSpiritLinkTotemSpell()
+	shaman.registerTidalWaves()
 }
 
 func (shaman *Shaman) Reset(sim *core.Simulation) {
-
+	shaman.earthShieldTarget = nil
 }
 
 func (shaman *Shaman) GetOverloadChance() float64 {
@@ -365,6 +362,18 @@ const (
 	SpellMaskElementalMastery
 	SpellMaskSpiritwalkersGrace
 	SpellMaskShamanisticRage
+	SpellMaskWindShear
+	SpellMaskHealingWave
+	SpellMaskGreaterHealingWave
+	SpellMaskHealingSurge
+	SpellMaskChainHeal
+	SpellMaskRiptide
+	SpellMaskHealingRain
+	SpellMaskUnleashLife
+	SpellMaskEarthliving
+	SpellMaskAncestralAwakening
+	SpellMaskHealingStreamTotem
+	SpellMaskSpiritLinkTotem
 
 	SpellMaskStormstrike = SpellMaskStormstrikeCast | SpellMaskStormstrikeDamage
 	SpellMaskFlameShock  = SpellMaskFlameShockDirect | SpellMaskFlameShockDot
@@ -372,4 +381,7 @@ const (
 	SpellMaskNature      = SpellMaskLightningBolt | SpellMaskLightningBoltOverload | SpellMaskChainLightning | SpellMaskChainLightningOverload | SpellMaskEarthShock | SpellMaskThunderstorm | SpellMaskFulmination
 	SpellMaskFrost       = SpellMaskUnleashFrost | SpellMaskFrostShock
 	SpellMaskOverload    = SpellMaskLavaBurstOverload | SpellMaskLightningBoltOverload | SpellMaskChainLightningOverload
+
+	SpellMaskDirectHeals   = SpellMaskHealingWave | SpellMaskGreaterHealingWave | SpellMaskHealingSurge | SpellMaskChainHeal | SpellMaskRiptide | SpellMaskUnleashLife
+	SpellMaskHealingSpells = SpellMaskDirectHeals | SpellMaskHealingRain | SpellMaskEarthliving | SpellMaskEarthShield | SpellMaskAncestralAwakening | SpellMaskHealingStreamTotem
 )
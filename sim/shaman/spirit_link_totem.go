@@ -0,0 +1,93 @@
+package shaman
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (shaman *Shaman) registerSpiritLinkTotemSpell() {
+	if !shaman.Talents.SpiritLinkTotem {
+		return
+	}
+
+	actionID := core.ActionID{SpellID: 98008}
+	healthMetrics := shaman.NewHealthMetrics(actionID)
+
+	spiritLinkAuras := shaman.NewAllyAuraArray(func(unit *core.Unit) *core.Aura {
+		return unit.GetOrRegisterAura(core.Aura{
+			Label:    "Spirit Link Totem-" + shaman.Label,
+			ActionID: core.ActionID{SpellID: 98007},
+			Duration: time.Second * 6,
+			OnGain: func(aura *core.Aura, sim *core.Simulation) {
+				aura.Unit.PseudoStats.DamageTakenMultiplier *= 0.9
+			},
+			OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+				aura.Unit.PseudoStats.DamageTakenMultiplier /= 0.9
+			},
+		})
+	})
+
+	shaman.SpiritLinkTotem = shaman.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskEmpty,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: SpellMaskSpiritLinkTotem,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 11,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: time.Second,
+			},
+			IgnoreHaste: true,
+			CD: core.Cooldown{
+				Timer:    shaman.NewTimer(),
+				Duration: time.Minute * 3,
+			},
+		},
+
+		// The totem covers the whole raid, which is assumed to be stacked around it.
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			var linkedUnits []*core.Unit
+			for _, unit := range shaman.Env.Raid.GetActiveAllyUnits() {
+				spiritLinkAuras.Get(unit).Activate(sim)
+				if unit.HasHealthBar() && unit.MaxHealth() > 0 {
+					linkedUnits = append(linkedUnits, unit)
+				}
+			}
+
+			core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:   time.Second,
+				NumTicks: 6,
+				OnAction: func(sim *core.Simulation) {
+					shaman.redistributeHealth(sim, linkedUnits, healthMetrics)
+				},
+			})
+		},
+	})
+}
+
+// Spirit Link evens out the health percent of all linked units.
+func (shaman *Shaman) redistributeHealth(sim *core.Simulation, units []*core.Unit, healthMetrics *core.ResourceMetrics) {
+	if len(units) < 2 {
+		return
+	}
+
+	totalHealth, totalMaxHealth := 0.0, 0.0
+	for _, unit := range units {
+		totalHealth += unit.CurrentHealth()
+		totalMaxHealth += unit.MaxHealth()
+	}
+
+	healthPercent := totalHealth / totalMaxHealth
+	for _, unit := range units {
+		if delta := healthPercent*unit.MaxHealth() - unit.CurrentHealth(); delta > 0 {
+			unit.GainHealth(sim, delta, healthMetrics)
+		} else if delta < 0 {
+			unit.RemoveHealth(sim, -delta)
+		}
+	}
+}
@@ -113,6 +113,31 @@ func (shaman *Shaman) ApplyTalents() {
 		shaman.SearingFlamesMultiplier += 0.1 * float64(shaman.Talents.ImprovedLavaLash)
 	}
 
+	if shaman.Talents.TidalFocus > 0 {
+		shaman.AddStaticMod(core.SpellModConfig{
+			ClassMask: SpellMaskHealingSpells,
+			IntValue:  -2 * shaman.Talents.TidalFocus,
+			Kind:      core.SpellMod_PowerCost_Pct,
+		})
+	}
+
+	if shaman.Talents.SparkOfLife > 0 {
+		shaman.PseudoStats.HealingDealtMultiplier *= 1 + 0.02*float64(shaman.Talents.SparkOfLife)
+		shaman.PseudoStats.HealingTakenMultiplier *= 1 + 0.02*float64(shaman.Talents.SparkOfLife)
+	}
+
+	// Healing Stream Totem's share of Soothing Rains is built into the totem.
+	if shaman.Talents.SoothingRains > 0 {
+		shaman.AddStaticMod(core.SpellModConfig{
+			ClassMask:  SpellMaskHealingRain,
+			FloatValue: 0.25 * float64(shaman.Talents.SoothingRains),
+			Kind:       core.SpellMod_DamageDone_Flat,
+		})
+	}
+
+	// The Earthliving proc chance bonus is handled in weapon_imbues.go.
+	shaman.AddStat(stats.SpellCritPercent, 2*float64(shaman.Talents.BlessingOfTheEternals))
+
 	shaman.registerElementalMasteryCD()
 	shaman.registerNaturesSwiftnessCD()
 	shaman.registerShamanisticRageCD()
@@ -479,22 +504,27 @@ func (shaman *Shaman) registerNaturesSwiftnessCD() {
 	cdTimer := shaman.NewTimer()
 	cd := time.Minute * 2
 
+	// Healing Rain is left out since a cast time mod would also shorten its hasted tick period.
+	nsSpellMask := SpellMaskChainLightning | SpellMaskLavaBurst | SpellMaskLightningBolt |
+		SpellMaskHealingWave | SpellMaskGreaterHealingWave | SpellMaskHealingSurge | SpellMaskChainHeal
+	castTimeMod := shaman.AddDynamicMod(core.SpellModConfig{
+		ClassMask:  nsSpellMask,
+		FloatValue: -1,
+		Kind:       core.SpellMod_CastTime_Pct,
+	})
+
 	nsAura := shaman.RegisterAura(core.Aura{
 		Label:    "Natures Swiftness",
 		ActionID: actionID,
 		Duration: core.NeverExpires,
 		OnGain: func(aura *core.Aura, sim *core.Simulation) {
-			shaman.ChainLightning.CastTimeMultiplier -= 1
-			shaman.LavaBurst.CastTimeMultiplier -= 1
-			shaman.LightningBolt.CastTimeMultiplier -= 1
+			castTimeMod.Activate()
 		},
 		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
-			shaman.ChainLightning.CastTimeMultiplier += 1
-			shaman.LavaBurst.CastTimeMultiplier += 1
-			shaman.LightningBolt.CastTimeMultiplier += 1
+			castTimeMod.Deactivate()
 		},
 		OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
-			if spell != shaman.LightningBolt && spell != shaman.ChainLightning && spell != shaman.LavaBurst {
+			if !spell.Matches(nsSpellMask) {
 				return
 			}
 
@@ -696,7 +726,11 @@ func (shaman *Shaman) registerManaTideTotemCD() {
 		return
 	}
 
-	mttAura := core.ManaTideTotemAura(shaman.GetCharacter(), shaman.Index)
+	var mttAuras []*core.Aura
+	for _, agent := range shaman.Party.Players {
+		mttAuras = append(mttAuras, core.ManaTideTotemAura(agent.GetCharacter(), shaman.Index))
+	}
+
 	mttSpell := shaman.RegisterSpell(core.SpellConfig{
 		ActionID: core.ManaTideTotemActionID,
 		Flags:    core.SpellFlagNoOnCastComplete,
@@ -711,7 +745,9 @@ func (shaman *Shaman) registerManaTideTotemCD() {
 			},
 		},
 		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
-			mttAura.Activate(sim)
+			for _, mttAura := range mttAuras {
+				mttAura.Activate(sim)
+			}
 
 			// If healing stream is active, cancel it while mana tide is up.
 			if shaman.HealingStreamTotem.Hot(&shaman.Unit).IsActive() {
@@ -729,7 +765,7 @@ func (shaman *Shaman) registerManaTideTotemCD() {
 
 	shaman.AddMajorCooldown(core.MajorCooldown{
 		Spell: mttSpell,
-		Type:  core.CooldownTypeDPS,
+		Type:  core.CooldownTypeMana,
 		ShouldActivate: func(sim *core.Simulation, character *core.Character) bool {
 			return sim.CurrentTime > time.Second*30
 		},
@@ -55,6 +55,7 @@ func (shaman *Shaman) registerHealingStreamTotemSpell() {
 		SpellSchool:      core.SpellSchoolNature,
 		ProcMask:         core.ProcMaskEmpty,
 		Flags:            core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete,
+		ClassSpellMask:   SpellMaskHealingStreamTotem,
 		DamageMultiplier: 1 + (0.25 * float64(shaman.Talents.SoothingRains)),
 		CritMultiplier:   1,
 		ThreatMultiplier: 1,
@@ -120,53 +120,46 @@ func (shaman *Shaman) registerUnleashWind() {
 }
 
 func (shaman *Shaman) registerUnleashLife() {
-	var affectedSpells []*core.Spell
+	unleashLifeMod := shaman.AddDynamicMod(core.SpellModConfig{
+		ClassMask:  SpellMaskDirectHeals &^ SpellMaskUnleashLife,
+		FloatValue: 0.2,
+		Kind:       core.SpellMod_DamageDone_Flat,
+	})
 
+	// Unleash Life empowers the next direct heal.
 	unleashLifeAura := shaman.RegisterAura(core.Aura{
 		Label:    "Unleash Life",
 		ActionID: core.ActionID{SpellID: 73685},
 		Duration: time.Second * 8,
-		OnInit: func(aura *core.Aura, sim *core.Simulation) {
-			affectedSpells = core.FilterSlice([]*core.Spell{
-				shaman.ChainHeal,
-				shaman.HealingWave,
-				shaman.GreaterHealingWave,
-				shaman.HealingSurge,
-				shaman.Riptide,
-			}, func(spell *core.Spell) bool { return spell != nil })
-		},
 		OnGain: func(aura *core.Aura, sim *core.Simulation) {
-			for _, spell := range affectedSpells {
-				spell.DamageMultiplierAdditive += 0.2
-			}
+			unleashLifeMod.Activate()
 		},
 		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
-			for _, spell := range affectedSpells {
-				spell.DamageMultiplierAdditive -= 0.2
+			unleashLifeMod.Deactivate()
+		},
+		OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
+			if spell.Matches(SpellMaskDirectHeals &^ SpellMaskUnleashLife) {
+				aura.Deactivate(sim)
 			}
 		},
 	})
 
-	shaman.UnleashLife = shaman.RegisterSpell(core.SpellConfig{
-		ActionID:         core.ActionID{SpellID: 73685},
-		SpellSchool:      core.SpellSchoolNature,
-		ProcMask:         core.ProcMaskSpellHealing,
-		Flags:            core.SpellFlagHelpful | core.SpellFlagPassiveSpell,
-		CritMultiplier:   shaman.DefaultHealingCritMultiplier(),
+	shaman.UnleashLife = shaman.RegisterSpell(shaman.healConfig(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 73685},
+		Flags:          core.SpellFlagPassiveSpell,
+		ClassSpellMask: SpellMaskUnleashLife,
+
 		BonusCoefficient: 0.201,
-		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			baseHeal := shaman.ClassSpellScaling * 1.98699998856
-			result := spell.CalcAndDealHealing(sim, target, baseHeal, spell.OutcomeHealingCrit)
 
-			if result.Outcome.Matches(core.OutcomeCrit) {
-				if shaman.Talents.AncestralAwakening > 0 {
-					shaman.ancestralHealingAmount = result.Damage * 0.3
-					shaman.AncestralAwakening.Cast(sim, target)
-				}
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			// Unleash Elements is usually cast at an enemy, in which case the shaman gets the heal.
+			if shaman.IsOpponent(target) {
+				target = &shaman.Unit
 			}
+			shaman.calcAndDealHealing(sim, spell, target, shaman.ClassSpellScaling*1.98699998856)
 			unleashLifeAura.Activate(sim)
 		},
-	})
+	}))
 }
 
 func (shaman *Shaman) registerUnleashElements() {
@@ -328,10 +328,11 @@ func (shaman *Shaman) newEarthlivingImbueSpell() *core.Spell {
 	glyphBonus := core.Ternary(shaman.HasPrimeGlyph(proto.ShamanPrimeGlyph_GlyphOfEarthlivingWeapon), 1.2, 1.0)
 
 	return shaman.RegisterSpell(core.SpellConfig{
-		ActionID:    core.ActionID{SpellID: 51730},
-		SpellSchool: core.SpellSchoolNature,
-		ProcMask:    core.ProcMaskEmpty,
-		Flags:       core.SpellFlagPassiveSpell,
+		ActionID:       core.ActionID{SpellID: 51730},
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskEmpty,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagPassiveSpell,
+		ClassSpellMask: SpellMaskEarthliving,
 
 		DamageMultiplier: 1,
 		ThreatMultiplier: 1,
@@ -348,7 +349,7 @@ func (shaman *Shaman) newEarthlivingImbueSpell() *core.Spell {
 				dot.SnapshotAttackerMultiplier = dot.Spell.CasterHealingMultiplier()
 			},
 			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
-				dot.CalcAndDealPeriodicSnapshotHealing(sim, target, dot.OutcomeTick)
+				shaman.dealPeriodicHealing(sim, dot, target, dot.OutcomeTick)
 			},
 		},
 
@@ -393,15 +394,21 @@ func (shaman *Shaman) RegisterEarthlivingImbue(procMask core.ProcMask) {
 			aura.Activate(sim)
 		},
 		OnHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
-			if spell != shaman.ChainHeal && spell != shaman.HealingSurge && spell != shaman.HealingWave && spell != shaman.Riptide {
+			if !spell.Matches(SpellMaskDirectHeals) {
 				return
 			}
 
-			if procMask.Matches(core.ProcMaskMeleeMH) && sim.RandomFloat("earthliving") < 0.2 {
+			// Blessing of the Eternals makes Earthliving more likely to proc on low health targets.
+			procChance := 0.2
+			if target := result.Target; target.HasHealthBar() && target.CurrentHealthPercent() <= 0.35 {
+				procChance += 0.4 * float64(shaman.Talents.BlessingOfTheEternals)
+			}
+
+			if procMask.Matches(core.ProcMaskMeleeMH) && sim.RandomFloat("earthliving") < procChance {
 				imbueSpell.Cast(sim, result.Target)
 			}
 
-			if procMask.Matches(core.ProcMaskMeleeOH) && sim.RandomFloat("earthliving") < 0.2 {
+			if procMask.Matches(core.ProcMaskMeleeOH) && sim.RandomFloat("earthliving") < procChance {
 				imbueSpell.Cast(sim, result.Target)
 			}
 		},
@@ -0,0 +1,40 @@
+package shaman
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (shaman *Shaman) registerWindShearSpell() {
+	shaman.WindShear = shaman.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 57994},
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskEmpty,
+		Flags:          core.SpellFlagAPL,
+		ClassSpellMask: SpellMaskWindShear,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 9,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				NonEmpty: true,
+			},
+			CD: core.Cooldown{
+				Timer:    shaman.NewTimer(),
+				Duration: time.Second * 15,
+			},
+			IgnoreHaste: true,
+		},
+
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			result := spell.CalcAndDealOutcome(sim, target, spell.OutcomeMagicHit)
+			if result.Landed() {
+				spell.Interrupt(sim, target, time.Second*2)
+			}
+		},
+	})
+}
@@ -42,6 +42,7 @@ const (
 	SpellMaskShieldBlock
 	SpellMaskDeathWish
 	SpellMaskSweepingStrikes
+	SpellMaskPummel
 
 	// Special attacks
 	SpellMaskCleave
@@ -111,6 +112,7 @@ type Warrior struct {
 	DeepWounds        *core.Spell
 	Charge            *core.Spell
 	ChargeAura        *core.Aura
+	Pummel            *core.Spell
 
 	shoutsCD                 *core.Timer
 	recklessnessDeadlyCalmCD *core.Timer
@@ -189,6 +191,8 @@ func (warrior *Warrior) Initialize() {
 	warrior.RegisterThunderClapSpell()
 	warrior.RegisterWhirlwindSpell()
 	warrior.RegisterCharge()
+	warrior.RegisterPummel()
+	warrior.RegisterTaunt()
 }
 
 func (warrior *Warrior) Reset(_ *core.Simulation) {
@@ -103,6 +103,15 @@ var handlers = map[string]apiHandler{
 	"/statWeightCompute": {msg: func() googleProto.Message { return &proto.StatWeightsCalcRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
 		return core.StatWeightCompute(msg.(*proto.StatWeightsCalcRequest))
 	}},
+	"/itemAttribution": {msg: func() googleProto.Message { return &proto.ItemAttributionRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.ItemAttribution(msg.(*proto.ItemAttributionRequest))
+	}},
+	"/buffValues": {msg: func() googleProto.Message { return &proto.BuffValueRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.BuffValues(msg.(*proto.BuffValueRequest))
+	}},
+	"/encounterSweep": {msg: func() googleProto.Message { return &proto.EncounterSweepRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.EncounterSweep(msg.(*proto.EncounterSweepRequest))
+	}},
 	"/computeStats": {msg: func() googleProto.Message { return &proto.ComputeStatsRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
 		return core.ComputeStats(msg.(*proto.ComputeStatsRequest))
 	}},
@@ -123,6 +132,15 @@ var asyncAPIHandlers = map[string]asyncAPIHandler{
 	"/statWeightsAsync": {msg: func() googleProto.Message { return &proto.StatWeightsRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics, requestId string) {
 		core.StatWeightsAsync(msg.(*proto.StatWeightsRequest), reporter, requestId)
 	}},
+	"/itemAttributionAsync": {msg: func() googleProto.Message { return &proto.ItemAttributionRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics, requestId string) {
+		core.ItemAttributionAsync(msg.(*proto.ItemAttributionRequest), reporter, requestId)
+	}},
+	"/buffValuesAsync": {msg: func() googleProto.Message { return &proto.BuffValueRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics, requestId string) {
+		core.BuffValuesAsync(msg.(*proto.BuffValueRequest), reporter, requestId)
+	}},
+	"/encounterSweepAsync": {msg: func() googleProto.Message { return &proto.EncounterSweepRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics, requestId string) {
+		core.EncounterSweepAsync(msg.(*proto.EncounterSweepRequest), reporter, requestId)
+	}},
 	"/bulkSimAsync": {msg: func() googleProto.Message { return &proto.BulkSimRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics, requestId string) {
 		core.RunBulkSimAsync(msg.(*proto.BulkSimRequest), reporter, requestId)
 	}},
@@ -206,7 +224,7 @@ func (s *server) handleAsyncAPI(w http.ResponseWriter, r *http.Request) {
 					return
 				}
 				simProgress.latestProgress.Store(progMetric)
-				if progMetric.FinalRaidResult != nil || progMetric.FinalWeightResult != nil || progMetric.FinalBulkResult != nil {
+				if progMetric.FinalRaidResult != nil || progMetric.FinalWeightResult != nil || progMetric.FinalBulkResult != nil || progMetric.FinalAttributionResult != nil || progMetric.FinalBuffValueResult != nil || progMetric.FinalSweepResult != nil {
 					return
 				}
 			}
@@ -264,7 +282,7 @@ func (s *server) setupAsyncServer() {
 		}
 
 		// If this was the last result, delete the cache for this simulation.
-		if latest.FinalRaidResult != nil || latest.FinalWeightResult != nil || latest.FinalBulkResult != nil {
+		if latest.FinalRaidResult != nil || latest.FinalWeightResult != nil || latest.FinalBulkResult != nil || latest.FinalAttributionResult != nil || latest.FinalBuffValueResult != nil || latest.FinalSweepResult != nil {
 			s.progMut.Lock()
 			delete(s.asyncProgresses, msg.ProgressId)
 			s.progMut.Unlock()
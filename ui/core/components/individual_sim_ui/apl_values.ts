@@ -16,6 +16,7 @@ import {
 	APLValueAuraRemainingTime,
 	APLValueAuraShouldRefresh,
 	APLValueAutoTimeToNext,
+	APLValueBossIsCastingInterruptible,
 	APLValueBossSpellIsCasting,
 	APLValueBossSpellTimeToReady,
 	APLValueCatExcessEnergy,
@@ -56,6 +57,7 @@ import {
 	APLValueInputDelay,
 	APLValueIsExecutePhase,
 	APLValueIsExecutePhase_ExecutePhaseThreshold as ExecutePhaseThreshold,
+	APLValueLowestAllyHealthPercent,
 	APLValueMageCurrentCombustionDotEstimate,
 	APLValueMath,
 	APLValueMath_MathOperator as MathOperator,
@@ -67,6 +69,7 @@ import {
 	APLValueNextRuneCooldown,
 	APLValueNot,
 	APLValueNumberTargets,
+	APLValueNumInjuredAllies,
 	APLValueNumEquippedStatProcTrinkets,
 	APLValueNumStatBuffCooldowns,
 	APLValueOr,
@@ -635,6 +638,13 @@ const valueKindFactories: { [f in NonNullable<APLValueKind>]: ValueKindConfig<AP
 			AplHelpers.actionIdFieldConfig('spellId', 'non_instant_spells', 'targetUnit', 'currentTarget'),
 		],
 	}),
+	bossIsCastingInterruptible: inputBuilder({
+		label: 'Is Casting Interruptible',
+		submenu: ['Boss'],
+		shortDescription: '<b>True</b> if the boss is currently casting or channeling a spell that can be interrupted, otherwise <b>False</b>.',
+		newValue: APLValueBossIsCastingInterruptible.create,
+		fields: [AplHelpers.unitFieldConfig('targetUnit', 'targets')],
+	}),
 	bossSpellTimeToReady: inputBuilder({
 		label: 'Spell Time to Ready',
 		submenu: ['Boss'],
@@ -667,6 +677,35 @@ const valueKindFactories: { [f in NonNullable<APLValueKind>]: ValueKindConfig<AP
 		newValue: APLValueCurrentHealthPercent.create,
 		fields: [AplHelpers.unitFieldConfig('sourceUnit', 'aura_sources')],
 	}),
+	lowestAllyHealthPercent: inputBuilder({
+		label: 'Lowest Ally Health (%)',
+		submenu: ['Resources', 'Health'],
+		shortDescription: 'Health of the most injured ally, as a percentage.',
+		newValue: APLValueLowestAllyHealthPercent.create,
+		fields: [],
+	}),
+	numInjuredAllies: inputBuilder({
+		label: 'Number of Injured Allies',
+		submenu: ['Resources', 'Health'],
+		shortDescription: 'Number of allies whose health is below the given percentage.',
+		newValue: () =>
+			APLValueNumInjuredAllies.create({
+				healthThreshold: {
+					value: {
+						oneofKind: 'const',
+						const: {
+							val: '100%',
+						},
+					},
+				},
+			}),
+		fields: [
+			valueFieldConfig('healthThreshold', {
+				label: 'Health Threshold',
+				labelTooltip: 'Allies below this health percentage are counted.',
+			}),
+		],
+	}),
 	currentMana: inputBuilder({
 		label: 'Current Mana',
 		submenu: ['Resources', 'Mana'],
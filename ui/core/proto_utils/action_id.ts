@@ -138,6 +138,18 @@ export class ActionId {
 				baseName = 'Prepull';
 				iconUrl = 'https://wow.zamimg.com/images/wow/icons/medium/inv_misc_pocketwatch_02.jpg';
 				break;
+			case OtherAction.OtherActionStun:
+				baseName = 'Stunned';
+				iconUrl = 'https://wow.zamimg.com/images/wow/icons/medium/spell_frost_stun.jpg';
+				break;
+			case OtherAction.OtherActionSilence:
+				baseName = 'Silenced';
+				iconUrl = 'https://wow.zamimg.com/images/wow/icons/medium/spell_holy_silence.jpg';
+				break;
+			case OtherAction.OtherActionHealthGain:
+				baseName = 'Health Gain';
+				iconUrl = 'https://wow.zamimg.com/images/wow/icons/large/spell_holy_renew.jpg';
+				break;
 		}
 		this.baseName = baseName;
 		this.name = name || baseName;
@@ -1336,6 +1336,27 @@ export class TargetedActionMetrics {
 		return this.data.shielding;
 	}
 
+	get overhealing() {
+		return this.data.overhealing;
+	}
+
+	get effectiveHealing() {
+		return this.data.healing - this.data.overhealing;
+	}
+
+	get avgOverhealing() {
+		return this.data.overhealing / this.iterations;
+	}
+
+	get overhealingPercent() {
+		const totalHealing = this.data.healing + this.data.overhealing;
+		return totalHealing ? (this.data.overhealing / totalHealing) * 100 : 0;
+	}
+
+	get shieldsApplied() {
+		return this.data.shieldsApplied;
+	}
+
 	get hps() {
 		return (this.data.healing + this.data.shielding) / this.iterations / this.duration;
 	}
@@ -1540,6 +1561,8 @@ export class TargetedActionMetrics {
 				healing: sum(actions.map(a => a.data.healing)),
 				critHealing: sum(actions.map(a => a.data.critHealing)),
 				shielding: sum(actions.map(a => a.data.shielding)),
+				overhealing: sum(actions.map(a => a.data.overhealing)),
+				shieldsApplied: sum(actions.map(a => a.data.shieldsApplied)),
 				castTimeMs: sum(actions.map(a => a.data.castTimeMs)),
 			}),
 			{
@@ -15,6 +15,7 @@ import {
 } from '../../core/proto/common';
 import { DruidMajorGlyph, DruidMinorGlyph, RestorationDruid_Options as RestorationDruidOptions } from '../../core/proto/druid';
 import { SavedTalents } from '../../core/proto/ui';
+import DefaultApl from './apls/default.apl.json';
 // Preset options for this spec.
 // Eventually we will import these values for the raid sim too, so its good to
 // keep them in a separate file.
@@ -30,6 +31,8 @@ import { Stats } from '../../core/proto_utils/stats';
 import P4Gear from './gear_sets/p4.gear.json';
 export const P4_PRESET = PresetUtils.makePresetGear('P4 Preset', P4Gear);
 
+export const ROTATION_PRESET_DEFAULT = PresetUtils.makePresetAPLRotation('Default', DefaultApl);
+
 export const P1_EP_PRESET = PresetUtils.makePresetEpWeights(
 	'P1',
 	Stats.fromMap({
@@ -1,5 +1,5 @@
 import * as PresetUtils from '../../core/preset_utils.js';
-import { Consumes, Debuffs, Flask, Food, Glyphs, Potions, Profession, RaidBuffs, Spec, Stat } from '../../core/proto/common.js';
+import { Consumes, Debuffs, Flask, Food, Glyphs, Potions, Profession, RaidBuffs, Spec, Stat, UnitReference } from '../../core/proto/common.js';
 import {
 	HolyPaladin_Options as Paladin_Options,
 	PaladinAura,
@@ -10,6 +10,7 @@ import {
 } from '../../core/proto/paladin.js';
 import { SavedTalents } from '../../core/proto/ui.js';
 import { Stats } from '../../core/proto_utils/stats';
+import DefaultApl from './apls/default.apl.json';
 import P1Gear from './gear_sets/p1.gear.json';
 import PreraidGear from './gear_sets/preraid.gear.json';
 
@@ -23,6 +24,8 @@ export const P1_PRESET = PresetUtils.makePresetGear('P1 Preset', P1Gear);
 // export const P3_PRESET = PresetUtils.makePresetGear('P3 Preset', P3Gear);
 // export const P4_PRESET = PresetUtils.makePresetGear('P4 Preset', P4Gear);
 
+export const ROTATION_PRESET_DEFAULT = PresetUtils.makePresetAPLRotation('Default', DefaultApl);
+
 // Preset options for EP weights
 export const P1_EP_PRESET = PresetUtils.makePresetEpWeights(
 	'P1',
@@ -62,6 +65,7 @@ export const DefaultOptions = Paladin_Options.create({
 		aura: PaladinAura.Devotion,
 		seal: PaladinSeal.Insight,
 	},
+	beaconTarget: UnitReference.create(),
 });
 
 export const DefaultRaidBuffs = RaidBuffs.create({
@@ -4,7 +4,7 @@ import { Player } from '../core/player';
 import { Class, Spec, UnitReference } from '../core/proto/common';
 import { DeathKnightTalents } from '../core/proto/death_knight';
 import { PriestTalents } from '../core/proto/priest';
-import { emptyUnitReference, RogueSpecs } from '../core/proto_utils/utils';
+import { emptyUnitReference, HunterSpecs, RogueSpecs } from '../core/proto_utils/utils';
 import { EventID, TypedEvent } from '../core/typed_event';
 import { RaidSimUI } from './raid_sim_ui';
 
@@ -15,6 +15,7 @@ export class AssignmentsPicker extends Component {
 	private readonly innervatesPicker: InnervatesPicker;
 	private readonly powerInfusionsPicker: PowerInfusionsPicker;
 	private readonly tricksOfTheTradesPicker: TricksOfTheTradesPicker;
+	private readonly misdirectionsPicker: MisdirectionsPicker;
 	private readonly unholyFrenzyPicker: UnholyFrenzyPicker;
 	private readonly focusMagicsPicker: FocusMagicsPicker;
 
@@ -25,6 +26,7 @@ export class AssignmentsPicker extends Component {
 		this.innervatesPicker = new InnervatesPicker(this.rootElem, raidSimUI);
 		this.powerInfusionsPicker = new PowerInfusionsPicker(this.rootElem, raidSimUI);
 		this.tricksOfTheTradesPicker = new TricksOfTheTradesPicker(this.rootElem, raidSimUI);
+		this.misdirectionsPicker = new MisdirectionsPicker(this.rootElem, raidSimUI);
 		this.unholyFrenzyPicker = new UnholyFrenzyPicker(this.rootElem, raidSimUI);
 		this.focusMagicsPicker = new FocusMagicsPicker(this.rootElem, raidSimUI);
 	}
@@ -173,6 +175,26 @@ class TricksOfTheTradesPicker extends AssignedBuffPicker {
 	}
 }
 
+class MisdirectionsPicker extends AssignedBuffPicker {
+	getTitle(): string {
+		return 'Misdirection';
+	}
+
+	getSourcePlayers(): Array<Player<any>> {
+		return this.raidSimUI.getActivePlayers().filter(player => player.isClass(Class.ClassHunter));
+	}
+
+	getPlayerValue(player: Player<any>): UnitReference {
+		return (player as Player<HunterSpecs>).getSpecOptions().classOptions!.misdirectionTarget || emptyUnitReference();
+	}
+
+	setPlayerValue(eventID: EventID, player: Player<any>, newValue: UnitReference) {
+		const newOptions = (player as Player<HunterSpecs>).getSpecOptions();
+		newOptions.classOptions!.misdirectionTarget = newValue;
+		player.setSpecOptions(eventID, newOptions);
+	}
+}
+
 class UnholyFrenzyPicker extends AssignedBuffPicker {
 	getTitle(): string {
 		return 'Unholy Frenzy';
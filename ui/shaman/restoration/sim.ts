@@ -106,13 +106,13 @@ const SPEC_CONFIG = registerSpecConfig(Spec.SpecRestorationShaman, {
 		epWeights: [Presets.P1_EP_PRESET],
 		// Preset talents that the user can quickly select.
 		talents: [Presets.RaidHealingTalents, Presets.TankHealingTalents],
-		rotations: [],
+		rotations: [Presets.ROTATION_PRESET_DEFAULT],
 		// Preset gear configurations that the user can quickly select.
 		gear: [Presets.PRERAID_PRESET, Presets.P1_PRESET, Presets.P2_PRESET, Presets.P3_PRESET, Presets.P4_PRESET],
 	},
 
 	autoRotation: (_player: Player<Spec.SpecRestorationShaman>): APLRotation => {
-		return APLRotation.create();
+		return Presets.ROTATION_PRESET_DEFAULT.rotation.rotation!;
 	},
 
 	raidSimPresets: [